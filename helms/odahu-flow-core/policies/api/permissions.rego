@@ -13,6 +13,7 @@ permissions := {
     	["GET", "^/api/v1/packaging/integration.*"],
     	["GET", "^/api/v1/user.*"],
     	["GET", "^/api/v1/toolchain/integration.*"],
+    	["GET", "^/api/v1/predictor.*"],
     ],
   roles.admin : [
       [".*", ".*"]
@@ -27,5 +28,6 @@ permissions := {
     	["GET", "^/api/v1/packaging/integration.*"],
     	["GET", "^/api/v1/user.*"],
     	["GET", "^/api/v1/toolchain/integration.*"],
+    	["GET", "^/api/v1/predictor.*"],
   ]
 }
\ No newline at end of file
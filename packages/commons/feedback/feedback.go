@@ -1,23 +1,23 @@
 package feedback
 
 type RequestResponse struct {
-	RequestID           string            `msg:"request_id"`
-	RequestHttpHeaders  map[string]string `msg:"request_http_headers"`
-	RequestContent      string            `msg:"request_content"`
-	RequestUri          string            `msg:"request_uri"`
-	ResponseStatus      string            `msg:"response_status"`
-	ResponseHttpHeaders map[string]string `msg:"response_http_headers"`
-	RequestHost         string            `msg:"request_host"`
-	ModelVersion        string            `msg:"model_version"`
-	ModelName           string            `msg:"model_name"`
-	RequestHttpMethod   string            `msg:"request_http_method"`
+	RequestID           string            `json:"request_id" msg:"request_id"`
+	RequestHttpHeaders  map[string]string `json:"request_http_headers" msg:"request_http_headers"`
+	RequestContent      string            `json:"request_content" msg:"request_content"`
+	RequestUri          string            `json:"request_uri" msg:"request_uri"`
+	ResponseStatus      string            `json:"response_status" msg:"response_status"`
+	ResponseHttpHeaders map[string]string `json:"response_http_headers" msg:"response_http_headers"`
+	RequestHost         string            `json:"request_host" msg:"request_host"`
+	ModelVersion        string            `json:"model_version" msg:"model_version"`
+	ModelName           string            `json:"model_name" msg:"model_name"`
+	RequestHttpMethod   string            `json:"request_http_method" msg:"request_http_method"`
 }
 
 type ResponseBody struct {
-	RequestID       string `msg:"request_id"`
-	ModelVersion    string `msg:"model_version"`
-	ModelName       string `msg:"model_name"`
-	ResponseContent string `msg:"response_content"`
+	RequestID       string `json:"request_id" msg:"request_id"`
+	ModelVersion    string `json:"model_version" msg:"model_version"`
+	ModelName       string `json:"model_name" msg:"model_name"`
+	ResponseContent string `json:"response_content" msg:"response_content"`
 }
 
 type ModelFeedback struct {
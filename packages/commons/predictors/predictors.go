@@ -18,6 +18,31 @@ package predictors
 
 import corev1 "k8s.io/api/core/v1"
 
+type InspectorType string
+
+const (
+	// Inspector of ODAHU ML Server. Metadata is fetched from the /api/model/info endpoint
+	OdahuMLServerInspector InspectorType = "odahu-ml-server"
+	// Inspector of Triton Inference Server. Metadata is fetched from the KFServing V2 protocol endpoints
+	TritonInspector InspectorType = "triton"
+	// Generic inspector. Metadata is fetched from an OpenAPI document served by the model server
+	OpenAPIInspector InspectorType = "openapi"
+	// Generic inspector of servers implementing the KServe (Open Inference Protocol) V2.
+	// Metadata and the OpenAPI document are built from the /v2/models/{name} endpoint
+	KServeV2Inspector InspectorType = "kserve-v2"
+)
+
+// Inspector describes how the metadata of a deployed model is discovered
+type Inspector struct {
+	// Type of the inspector
+	Type InspectorType
+	// Path of the OpenAPI document relative to the model URL prefix. Used only by the OpenAPI inspector
+	OpenAPIPath string
+	// Name of the inspected model. Used only by the KServe V2 inspector.
+	// If empty, the model is discovered through the /v2/repository/index endpoint
+	ModelName string
+}
+
 type Predictor struct {
 	// Predictor ID
 	ID string
@@ -27,10 +52,14 @@ type Predictor struct {
 	LivenessProbe corev1.Probe
 	// Endpoint to check Readiness
 	ReadinessProbe corev1.Probe
-	// OPA policy filename
+	// OPA policy filename. Built-in policies are compiled into the operator
 	OpaPolicyFilename string
+	// OPA policy template. It is used if OpaPolicyFilename is empty
+	OpaPolicy string
 	// Inference endpoint regex
 	InferenceEndpointRegex string
+	// Inspector of deployed models
+	Inspector Inspector
 }
 
 var (
@@ -63,6 +92,7 @@ var (
 			TimeoutSeconds:   1,
 		},
 		InferenceEndpointRegex: ".*/api/model/invoke.*",
+		Inspector:              Inspector{Type: OdahuMLServerInspector},
 	}
 
 	Triton = Predictor{
@@ -108,8 +138,10 @@ var (
 			TimeoutSeconds:   1,
 		},
 		InferenceEndpointRegex: `.*/v2/models/.*/infer/?`,
+		Inspector:              Inspector{Type: TritonInspector},
 	}
 
+	// Built-in predictors. Other predictors are registered at runtime through the API server
 	Predictors = map[string]Predictor{
 		OdahuMLServer.ID: OdahuMLServer,
 		Triton.ID:        Triton,
@@ -29,10 +29,11 @@ import (
 )
 
 const (
-	cmdEnvoyHost      = "envoy-host"
-	cmdEnvoyPort      = "envoy-port"
-	cmdEnvoyConfigId  = "config-id"
-	cmdMonitoringPort = "monitoring-port"
+	cmdEnvoyHost       = "envoy-host"
+	cmdEnvoyPort       = "envoy-port"
+	cmdEnvoyConfigId   = "config-id"
+	cmdMonitoringPort  = "monitoring-port"
+	cmdEndpointRegexes = "inference-endpoint-regexes"
 )
 
 var log = logf.Log.WithName("rq-catcher-cmd")
@@ -60,6 +61,7 @@ func entrypoint(cmd *cobra.Command, args []string) {
 		viper.GetString(tapping.CfgEnvoyConfigId),
 		dataLogger,
 		viper.GetStringSlice(feedback.CfgProhibitedHeaders),
+		viper.GetStringSlice(tapping.CfgInferenceEndpointRegexes),
 	)
 	if err != nil {
 		log.Error(err, "Collector creation")
@@ -106,6 +108,12 @@ func init() {
 	feedback.PanicIfError(viper.BindPFlag(tapping.CfgEnvoyPort, mainCmd.Flags().Lookup(cmdEnvoyPort)))
 	feedback.PanicIfError(viper.BindPFlag(tapping.CfgEnvoyConfigId, mainCmd.Flags().Lookup(cmdEnvoyConfigId)))
 
+	mainCmd.Flags().StringSlice(cmdEndpointRegexes, nil,
+		"Comma-separated inference endpoint regexes of custom predictors")
+	feedback.PanicIfError(viper.BindPFlag(
+		tapping.CfgInferenceEndpointRegexes, mainCmd.Flags().Lookup(cmdEndpointRegexes),
+	))
+
 	mainCmd.Flags().Int(cmdMonitoringPort, 7777, "Monitoring webserver port")
 	feedback.PanicIfError(viper.BindPFlag(tapping.CfgMonitoringPort, mainCmd.Flags().Lookup(cmdMonitoringPort)))
 
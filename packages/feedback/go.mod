@@ -8,6 +8,7 @@ require (
 	github.com/onsi/ginkgo v1.14.0 // indirect
 	github.com/philhofer/fwd v1.1.1 // indirect
 	github.com/prometheus/client_golang v1.7.1
+	github.com/segmentio/kafka-go v0.4.16
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/viper v1.7.0
 	github.com/stretchr/testify v1.6.1
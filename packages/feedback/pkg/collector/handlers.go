@@ -17,6 +17,7 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"github.com/odahu/odahu-flow/packages/feedback/pkg/feedback"
 	"net/http"
@@ -71,7 +72,11 @@ func handleFeedbackEndpoint(c *gin.Context) {
 
 	err = logger.Post(loggerTag, message)
 
-	if err != nil {
+	if errors.Is(err, feedback.ErrQueueFull) {
+		logH.Error(err, "Message is rejected")
+
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Feedback queue is full"})
+	} else if err != nil {
 		logH.Error(err, "Cannot deliver message")
 
 		c.JSON(http.StatusBadGateway, gin.H{"error": "Cannot deliver message"})
@@ -111,6 +111,25 @@ func TestSendFeedbackWithOnlyHeader(t *testing.T) {
 	mocked.AssertExpectations(t)
 }
 
+func TestSendFeedbackWithFullQueue(t *testing.T) {
+	router, mocked, tag := buildRouterWithDataMock()
+	modelName, modelVersion, requestID := "test-name", "1.0", "test-request-id"
+
+	expectedMessage := buildMessage(modelName, modelVersion, requestID, map[string]interface{}{})
+	mocked.On("Post", tag, expectedMessage).Return(feedback.ErrQueueFull)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", testFeedbackUrl, nil)
+	req.Header.Set(feedback.OdahuFlowRequestIdHeaderKey, requestID)
+	req.Header.Set(feedback.ModelNameHeaderKey, modelName)
+	req.Header.Set(feedback.ModelVersionHeaderKey, modelVersion)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "{\"error\":\"Feedback queue is full\"}", w.Body.String())
+	mocked.AssertExpectations(t)
+}
+
 func TestIndexRoute(t *testing.T) {
 	router, _, _ := buildRouterWithDataMock()
 
@@ -0,0 +1,192 @@
+package feedback
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+const (
+	bufferBatchSize    = 100
+	bufferRetryWait    = time.Second
+	bufferMaxRetryWait = 30 * time.Second
+)
+
+// BatchDataLogging is a sink which delivers several records at once
+type BatchDataLogging interface {
+	PostBatch(records []Record) error
+}
+
+// TimedDataLogging is a sink which keeps the original time of a message, like fluentd client
+type TimedDataLogging interface {
+	PostWithTime(tag string, tm time.Time, message interface{}) error
+}
+
+// BufferedDataLogger writes messages into DiskQueue and delivers them to the sink in background.
+// Failed delivery is retried with exponential backoff until the sink recovers, so the sink must
+// report delivery errors synchronously. Queued messages are delivered after restart as well
+type BufferedDataLogger struct {
+	name  string
+	sink  DataLogging
+	queue *DiskQueue
+	// If it is set, structs are converted to maps using this field tag before queueing
+	fieldTag  string
+	retryWait time.Duration
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewBufferedDataLogger creates queue for the sink in dir. Name identifies the sink in metrics.
+// Set fieldTag to "msg" for fluentd client, so that it receives the same field names as without queue
+func NewBufferedDataLogger(
+	name string, sink DataLogging, dir string, maxSize int64, policy FullQueuePolicy, fieldTag string,
+) (*BufferedDataLogger, error) {
+	return newBufferedDataLogger(name, sink, dir, maxSize, policy, fieldTag, bufferRetryWait)
+}
+
+func newBufferedDataLogger(
+	name string, sink DataLogging, dir string, maxSize int64, policy FullQueuePolicy, fieldTag string,
+	retryWait time.Duration,
+) (*BufferedDataLogger, error) {
+	queue, err := OpenDiskQueue(dir, maxSize, policy, func(count int) {
+		droppedFeedback.WithLabelValues(name, string(DropOldestPolicy)).Add(float64(count))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l := &BufferedDataLogger{
+		name:      name,
+		sink:      sink,
+		queue:     queue,
+		fieldTag:  fieldTag,
+		retryWait: retryWait,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if count := queue.Len(); count > 0 {
+		logger.Info("Replaying queued feedback", "sink", name, "count", count)
+	}
+	queuedFeedback.WithLabelValues(name).Set(float64(queue.Len()))
+
+	go l.run()
+	return l, nil
+}
+
+// Post queues the message. If the queue is full, the result depends on the queue policy:
+// Post blocks, drops the oldest messages or returns ErrQueueFull
+func (l *BufferedDataLogger) Post(tag string, message interface{}) error {
+	if len(l.fieldTag) > 0 {
+		message = structToMap(message, l.fieldTag)
+	}
+	entry, err := json.Marshal(Record{Tag: tag, Time: time.Now(), Message: message})
+	if err != nil {
+		return err
+	}
+
+	err = l.queue.Push(entry)
+	if err == ErrQueueFull {
+		droppedFeedback.WithLabelValues(l.name, string(RejectPolicy)).Inc()
+	}
+	queuedFeedback.WithLabelValues(l.name).Set(float64(l.queue.Len()))
+	return err
+}
+
+// Close stops the delivery and closes the sink. Not delivered messages are kept in the queue
+func (l *BufferedDataLogger) Close() error {
+	close(l.stop)
+	queueErr := l.queue.Close()
+	<-l.done
+
+	if err := l.sink.Close(); err != nil {
+		return err
+	}
+	return queueErr
+}
+
+func (l *BufferedDataLogger) run() {
+	defer close(l.done)
+
+	wait := l.retryWait
+	for {
+		entries, err := l.queue.Peek(bufferBatchSize)
+		if err != nil {
+			return
+		}
+
+		if err := l.deliver(entries); err != nil {
+			logger.Error(err, "Unable to deliver feedback. Delivery will be retried", "sink", l.name, "wait", wait)
+			select {
+			case <-l.stop:
+				return
+			case <-time.After(wait):
+			}
+			if wait *= 2; wait > bufferMaxRetryWait {
+				wait = bufferMaxRetryWait
+			}
+			continue
+		}
+		wait = l.retryWait
+
+		if err := l.queue.Ack(entries); err != nil {
+			logger.Error(err, "Unable to remove delivered feedback from the queue", "sink", l.name)
+		}
+		queuedFeedback.WithLabelValues(l.name).Set(float64(l.queue.Len()))
+	}
+}
+
+func (l *BufferedDataLogger) deliver(entries [][]byte) error {
+	records := make([]Record, 0, len(entries))
+	for _, entry := range entries {
+		var record Record
+		if err := json.Unmarshal(entry, &record); err != nil {
+			logger.Error(err, "Corrupted feedback entry is skipped", "sink", l.name)
+			continue
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	if sink, ok := l.sink.(BatchDataLogging); ok {
+		return sink.PostBatch(records)
+	}
+
+	// Records are removed from the queue only if all of them are delivered,
+	// so some of them can be delivered twice
+	for _, record := range records {
+		var err error
+		if sink, ok := l.sink.(TimedDataLogging); ok {
+			err = sink.PostWithTime(record.Tag, record.Time, record.Message)
+		} else {
+			err = l.sink.Post(record.Tag, record.Message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structToMap converts struct to map which keys are values of the field tag or field names
+func structToMap(message interface{}, fieldTag string) interface{} {
+	value := reflect.ValueOf(message)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return message
+	}
+
+	result := make(map[string]interface{}, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := field.Name
+		if tagValue := field.Tag.Get(fieldTag); len(tagValue) > 0 {
+			name = tagValue
+		}
+		result[name] = value.Field(i).Interface()
+	}
+	return result
+}
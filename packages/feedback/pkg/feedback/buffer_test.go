@@ -0,0 +1,169 @@
+package feedback
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	commons_feedback "odahu-commons/feedback"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakySink fails until it is switched to available
+type flakySink struct {
+	lock      sync.Mutex
+	available bool
+	attempts  int
+	records   []Record
+}
+
+func (s *flakySink) Post(tag string, message interface{}) error {
+	return s.PostWithTime(tag, time.Now(), message)
+}
+
+func (s *flakySink) PostWithTime(tag string, tm time.Time, message interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.attempts++
+	if !s.available {
+		return errors.New("unavailable")
+	}
+	s.records = append(s.records, Record{Tag: tag, Time: tm, Message: message})
+	return nil
+}
+
+func (s *flakySink) Close() error {
+	return nil
+}
+
+func (s *flakySink) setAvailable() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.available = true
+}
+
+func (s *flakySink) delivered() []Record {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Record(nil), s.records...)
+}
+
+func (s *flakySink) attempted() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.attempts
+}
+
+func TestBufferedDataLogger_Recovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-buffer")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sink := &flakySink{}
+	buffered, err := newBufferedDataLogger("fluentd", sink, dir, 1024*1024, RejectPolicy, "msg", time.Millisecond)
+	assert.NoError(t, err)
+
+	postedAt := time.Now()
+	assert.NoError(t, buffered.Post("response_body", commons_feedback.ResponseBody{
+		RequestID: "request", ModelName: "model", ModelVersion: "1", ResponseContent: "{}",
+	}))
+	assert.NoError(t, buffered.Post("feedback", commons_feedback.ModelFeedback{
+		RequestID: "request", ModelName: "model", ModelVersion: "1",
+	}))
+
+	// Sink is unavailable, messages are kept in the queue
+	assert.Eventually(t, func() bool { return sink.attempted() >= 3 }, time.Second, time.Millisecond)
+	assert.Empty(t, sink.delivered())
+	assert.Equal(t, 2, buffered.queue.Len())
+
+	sink.setAvailable()
+	assert.Eventually(t, func() bool { return len(sink.delivered()) == 2 }, 5*time.Second, time.Millisecond)
+	assert.NoError(t, buffered.Close())
+
+	records := sink.delivered()
+	assert.Equal(t, "response_body", records[0].Tag)
+	assert.WithinDuration(t, postedAt, records[0].Time, time.Second)
+	// Field names are the same as fluentd client sends without queue
+	assert.Equal(t, map[string]interface{}{
+		"request_id": "request", "model_name": "model", "model_version": "1", "payload": nil,
+	}, records[1].Message)
+}
+
+func TestBufferedDataLogger_ReplayAfterRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-buffer")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	buffered, err := newBufferedDataLogger("http", &flakySink{}, dir, 1024*1024, RejectPolicy, "", time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, buffered.Post("feedback", "message"))
+	assert.NoError(t, buffered.Close())
+
+	sink := &flakySink{available: true}
+	buffered, err = newBufferedDataLogger("http", sink, dir, 1024*1024, RejectPolicy, "", time.Hour)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return len(sink.delivered()) == 1 }, time.Second, time.Millisecond)
+	assert.NoError(t, buffered.Close())
+	assert.Equal(t, "message", sink.delivered()[0].Message)
+}
+
+func TestBufferedDataLogger_Reject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-buffer")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	buffered, err := newBufferedDataLogger("kafka", &flakySink{}, dir, 128, RejectPolicy, "", time.Hour)
+	assert.NoError(t, err)
+	defer buffered.Close()
+
+	var lastErr error
+	for i := 0; i < 5 && lastErr == nil; i++ {
+		lastErr = buffered.Post("feedback", "message")
+	}
+	assert.True(t, errors.Is(MultiDataLogger{buffered}.Post("feedback", "message"), ErrQueueFull))
+	assert.Equal(t, ErrQueueFull, lastErr)
+}
+
+func TestBufferedDataLogger_PartialReject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-buffer")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	full, err := newBufferedDataLogger(
+		"kafka", &flakySink{}, filepath.Join(dir, "kafka"), 128, RejectPolicy, "", time.Hour,
+	)
+	assert.NoError(t, err)
+	defer full.Close()
+	for err == nil {
+		err = full.Post("feedback", "message")
+	}
+	assert.Equal(t, ErrQueueFull, err)
+
+	sink := &flakySink{available: true}
+	available, err := newBufferedDataLogger(
+		"http", sink, filepath.Join(dir, "http"), 1024*1024, RejectPolicy, "", time.Millisecond,
+	)
+	assert.NoError(t, err)
+	defer available.Close()
+
+	multi := MultiDataLogger{full, available}
+	// The message is accepted by one of the sinks, so it is not rejected
+	assert.NoError(t, multi.Post("feedback", "accepted"))
+	assert.Eventually(t, func() bool {
+		for _, record := range sink.delivered() {
+			if record.Message == "accepted" {
+				return true
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond)
+
+	// Message is rejected only if all sinks reject it
+	assert.True(t, errors.Is(MultiDataLogger{full, full}.Post("feedback", "message"), ErrQueueFull))
+}
@@ -22,6 +22,34 @@ const (
 	cmdFluentdPort          = "fluentd-port"
 )
 
+// Sink configuration. Sinks lists backends where feedback is sent, every message is sent to each of them
+const (
+	CfgSinks                 = "sinks"
+	CfgFileSinkPath          = "file.path"
+	CfgFileSinkMaxSizeMB     = "file.max_size_mb"
+	CfgFileSinkMaxBackups    = "file.max_backups"
+	CfgHTTPSinkURL           = "http.url"
+	CfgHTTPSinkBatchSize     = "http.batch_size"
+	CfgHTTPSinkFlushInterval = "http.flush_interval"
+	CfgKafkaBrokers          = "kafka.brokers"
+	CfgKafkaTopicPrefix      = "kafka.topic_prefix"
+	cmdSinks                 = "sinks"
+	cmdFileSinkPath          = "file-path"
+	cmdHTTPSinkURL           = "http-url"
+	cmdKafkaBrokers          = "kafka-brokers"
+	cmdKafkaTopicPrefix      = "kafka-topic-prefix"
+)
+
+// Buffer configuration. If the buffer directory is set, messages are queued on disk before delivery to sinks
+const (
+	CfgBufferDir        = "buffer.dir"
+	CfgBufferMaxSizeMB  = "buffer.max_size_mb"
+	CfgBufferFullPolicy = "buffer.full_policy"
+	cmdBufferDir        = "buffer-dir"
+	cmdBufferMaxSizeMB  = "buffer-max-size-mb"
+	cmdBufferFullPolicy = "buffer-full-policy"
+)
+
 var (
 	CfgFile string
 	logC    = log.Log.WithName("config")
@@ -39,15 +67,37 @@ func InitBasicParams(cmd *cobra.Command) {
 		[]string{},
 		"List of prohibited headers which will be skipped from feedback",
 	)
+	cmd.Flags().StringSlice(cmdSinks, []string{FluentdSink}, "List of sinks: fluentd, file, http, kafka")
+	cmd.Flags().String(cmdFileSinkPath, "", "Path of the file sink")
+	cmd.Flags().String(cmdHTTPSinkURL, "", "Bulk endpoint URL of the HTTP sink")
+	cmd.Flags().StringSlice(cmdKafkaBrokers, []string{}, "List of Kafka brokers")
+	cmd.Flags().String(cmdKafkaTopicPrefix, "", "Prefix of Kafka topics. Topic name is the prefix and a message tag")
+	cmd.Flags().String(cmdBufferDir, "", "Directory of disk queues. Messages are not queued if it is empty")
+	cmd.Flags().Int(cmdBufferMaxSizeMB, 256, "Maximum size of a sink queue in megabytes")
+	cmd.Flags().String(
+		cmdBufferFullPolicy, string(RejectPolicy), "What to do if a queue is full: block, drop-oldest or reject",
+	)
 
 	PanicIfError(viper.BindPFlag(CfgFluentdHost, cmd.Flags().Lookup(cmdFluentHost)))
 	PanicIfError(viper.BindPFlag(CfgFluentdPort, cmd.Flags().Lookup(cmdFluentdPort)))
 	PanicIfError(viper.BindPFlag(CfgProhibitedHeaders, cmd.Flags().Lookup(cmdProhibitedHeaders)))
+	PanicIfError(viper.BindPFlag(CfgSinks, cmd.Flags().Lookup(cmdSinks)))
+	PanicIfError(viper.BindPFlag(CfgFileSinkPath, cmd.Flags().Lookup(cmdFileSinkPath)))
+	PanicIfError(viper.BindPFlag(CfgHTTPSinkURL, cmd.Flags().Lookup(cmdHTTPSinkURL)))
+	PanicIfError(viper.BindPFlag(CfgKafkaBrokers, cmd.Flags().Lookup(cmdKafkaBrokers)))
+	PanicIfError(viper.BindPFlag(CfgKafkaTopicPrefix, cmd.Flags().Lookup(cmdKafkaTopicPrefix)))
+	PanicIfError(viper.BindPFlag(CfgBufferDir, cmd.Flags().Lookup(cmdBufferDir)))
+	PanicIfError(viper.BindPFlag(CfgBufferMaxSizeMB, cmd.Flags().Lookup(cmdBufferMaxSizeMB)))
+	PanicIfError(viper.BindPFlag(CfgBufferFullPolicy, cmd.Flags().Lookup(cmdBufferFullPolicy)))
 
 	viper.SetDefault(CfgProhibitedHeaders, []string{"authorization", "x-jwt", "x-user", "x-email"})
 	viper.SetDefault(CfgRequestResponseTag, "request_response")
 	viper.SetDefault(CfgResponseBodyTag, "response_body")
 	viper.SetDefault(CfgFeedbackTag, "feedback")
+	viper.SetDefault(CfgFileSinkMaxSizeMB, 100)
+	viper.SetDefault(CfgFileSinkMaxBackups, 5)
+	viper.SetDefault(CfgHTTPSinkBatchSize, 100)
+	viper.SetDefault(CfgHTTPSinkFlushInterval, "5s")
 }
 
 func PanicIfError(err error) {
@@ -0,0 +1,126 @@
+package feedback
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileDataLogger writes records into a file as newline-delimited JSON.
+// When the file exceeds maxSize bytes, it is rotated: path is renamed to path.1, path.1 to path.2
+// and so on. Only maxBackups rotated files are kept
+type FileDataLogger struct {
+	lock       sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func NewFileDataLogger(path string, maxSize int64, maxBackups int) (*FileDataLogger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	l := &FileDataLogger{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *FileDataLogger) Post(tag string, message interface{}) error {
+	line, err := json.Marshal(Record{Tag: tag, Time: time.Now(), Message: message})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.file == nil {
+		return fmt.Errorf("file sink %s is closed", l.path)
+	}
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	return err
+}
+
+func (l *FileDataLogger) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *FileDataLogger) open() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// rotate renames files and opens a new one. If renaming fails, the current file is reopened,
+// so records are still written and the rotation is retried on the next record
+func (l *FileDataLogger) rotate() error {
+	closeErr := l.file.Close()
+	l.file = nil
+
+	rotateErr := closeErr
+	if rotateErr == nil {
+		rotateErr = l.shiftBackups()
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+	if rotateErr != nil {
+		logger.Error(rotateErr, "Unable to rotate file sink. Records are written to the current file", "path", l.path)
+	}
+	return nil
+}
+
+func (l *FileDataLogger) shiftBackups() error {
+	if l.maxBackups < 1 {
+		if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	for i := l.maxBackups - 1; i >= 1; i-- {
+		err := os.Rename(l.backupPath(i), l.backupPath(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(l.path, l.backupPath(1))
+}
+
+func (l *FileDataLogger) backupPath(number int) string {
+	return fmt.Sprintf("%s.%d", l.path, number)
+}
@@ -0,0 +1,208 @@
+package feedback
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	httpSinkTimeout              = 30 * time.Second
+	defaultHTTPSinkFlushInterval = 5 * time.Second
+	// Limit of not sent records in bytes. The same as the default buffer limit of fluentd client
+	httpSinkBufferLimit = 8 * 1024 * 1024
+	httpSinkName        = "http"
+)
+
+// HTTPBulkClient synchronously sends records to a bulk endpoint as newline-delimited JSON
+type HTTPBulkClient struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewHTTPBulkClient(url string) *HTTPBulkClient {
+	return &HTTPBulkClient{URL: url, Client: &http.Client{Timeout: httpSinkTimeout}}
+}
+
+func (c *HTTPBulkClient) Post(tag string, message interface{}) error {
+	return c.PostBatch([]Record{{Tag: tag, Time: time.Now(), Message: message}})
+}
+
+func (c *HTTPBulkClient) PostBatch(records []Record) error {
+	lines := make([][]byte, 0, len(records))
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line)
+	}
+	return c.send(lines)
+}
+
+func (c *HTTPBulkClient) Close() error {
+	return nil
+}
+
+func (c *HTTPBulkClient) send(lines [][]byte) error {
+	body := bytes.Join(lines, []byte{'\n'})
+	body = append(body, '\n')
+
+	response, err := c.Client.Post(c.URL, "application/x-ndjson", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("HTTP sink responded with status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// HTTPDataLogger sends records in batches to a bulk endpoint as newline-delimited JSON.
+// Batch is sent when it has batchSize records or every flushInterval. Like fluentd client in
+// asynchronous mode, it retries to deliver a batch maxRetryToDeliver times and then drops it.
+// Records are rejected while not sent records exceed bufferLimit bytes
+type HTTPDataLogger struct {
+	client      *HTTPBulkClient
+	batchSize   int
+	bufferLimit int
+	lock        sync.Mutex
+	batch       [][]byte
+	batchBytes  int
+	closed      bool
+	flushCh     chan struct{}
+	done        chan struct{}
+	retryWait   time.Duration
+}
+
+func NewHTTPDataLogger(url string, batchSize int, flushInterval time.Duration) *HTTPDataLogger {
+	return newHTTPDataLogger(url, batchSize, flushInterval, maxRetryWait*time.Millisecond, httpSinkBufferLimit)
+}
+
+func newHTTPDataLogger(
+	url string, batchSize int, flushInterval time.Duration, retryWait time.Duration, bufferLimit int,
+) *HTTPDataLogger {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultHTTPSinkFlushInterval
+	}
+	l := &HTTPDataLogger{
+		client:      NewHTTPBulkClient(url),
+		batchSize:   batchSize,
+		bufferLimit: bufferLimit,
+		flushCh:     make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		retryWait:   retryWait,
+	}
+	go l.run(flushInterval)
+	return l
+}
+
+func (l *HTTPDataLogger) Post(tag string, message interface{}) error {
+	line, err := json.Marshal(Record{Tag: tag, Time: time.Now(), Message: message})
+	if err != nil {
+		return err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed {
+		return errors.New("HTTP sink is closed")
+	}
+	if l.batchBytes+len(line) > l.bufferLimit {
+		droppedFeedback.WithLabelValues(httpSinkName, string(RejectPolicy)).Inc()
+		return fmt.Errorf("buffer of HTTP sink exceeds %d bytes: %w", l.bufferLimit, ErrQueueFull)
+	}
+	l.batch = append(l.batch, line)
+	l.batchBytes += len(line)
+	if len(l.batch) >= l.batchSize {
+		select {
+		case l.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close sends buffered records and stops the sink
+func (l *HTTPDataLogger) Close() error {
+	l.lock.Lock()
+	if l.closed {
+		l.lock.Unlock()
+		return nil
+	}
+	l.closed = true
+	l.lock.Unlock()
+
+	close(l.flushCh)
+	<-l.done
+	return nil
+}
+
+func (l *HTTPDataLogger) run(flushInterval time.Duration) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case _, ok := <-l.flushCh:
+			// Sink is closed, all records are sent
+			if !ok {
+				l.flush(false)
+				return
+			}
+			l.flush(true)
+		case <-ticker.C:
+			l.flush(false)
+		}
+	}
+}
+
+// flush sends buffered records in batches. If fullOnly is true, the rest that is smaller than batchSize is kept
+func (l *HTTPDataLogger) flush(fullOnly bool) {
+	for {
+		l.lock.Lock()
+		size := len(l.batch)
+		if size > l.batchSize {
+			size = l.batchSize
+		}
+		if fullOnly && size < l.batchSize {
+			size = 0
+		}
+		batch := l.batch[:size]
+		l.batch = l.batch[size:]
+		for _, line := range batch {
+			l.batchBytes -= len(line)
+		}
+		l.lock.Unlock()
+
+		if len(batch) == 0 {
+			return
+		}
+		l.send(batch)
+	}
+}
+
+func (l *HTTPDataLogger) send(batch [][]byte) {
+	var err error
+	for attempt := 1; attempt <= maxRetryToDeliver; attempt++ {
+		if err = l.client.send(batch); err == nil {
+			return
+		}
+		if attempt < maxRetryToDeliver {
+			time.Sleep(l.retryWait)
+		}
+	}
+	logger.Error(err, "Unable to deliver batch to HTTP sink. Batch is dropped", "url", l.client.URL, "size", len(batch))
+}
@@ -0,0 +1,60 @@
+package feedback
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/segmentio/kafka-go"
+	"time"
+)
+
+// Batches of synchronous producer are sent without waiting for more messages
+const kafkaSyncBatchTimeout = 10 * time.Millisecond
+
+// KafkaDataLogger produces records into Kafka. Topic of a record is topicPrefix followed by the record tag.
+// If async is true, messages are produced in background and delivery errors are only logged
+type KafkaDataLogger struct {
+	writer      *kafka.Writer
+	topicPrefix string
+}
+
+func NewKafkaDataLogger(brokers []string, topicPrefix string, async bool) *KafkaDataLogger {
+	writer := &kafka.Writer{
+		Addr:        kafka.TCP(brokers...),
+		Balancer:    &kafka.LeastBytes{},
+		MaxAttempts: maxRetryToDeliver,
+		Async:       async,
+	}
+	if async {
+		writer.Completion = func(messages []kafka.Message, err error) {
+			if err != nil {
+				logger.Error(err, "Unable to deliver messages to Kafka", "count", len(messages))
+			}
+		}
+	} else {
+		writer.BatchTimeout = kafkaSyncBatchTimeout
+	}
+
+	return &KafkaDataLogger{writer: writer, topicPrefix: topicPrefix}
+}
+
+func (l *KafkaDataLogger) Post(tag string, message interface{}) error {
+	return l.PostBatch([]Record{{Tag: tag, Time: time.Now(), Message: message}})
+}
+
+func (l *KafkaDataLogger) PostBatch(records []Record) error {
+	messages := make([]kafka.Message, 0, len(records))
+	for _, record := range records {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, kafka.Message{Topic: l.topicPrefix + record.Tag, Value: value})
+	}
+
+	return l.writer.WriteMessages(context.Background(), messages...)
+}
+
+// Close flushes pending messages and closes the producer
+func (l *KafkaDataLogger) Close() error {
+	return l.writer.Close()
+}
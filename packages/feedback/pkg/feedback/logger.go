@@ -1,9 +1,13 @@
 package feedback
 
 import (
+	"errors"
+	"fmt"
 	"github.com/fluent/fluent-logger-golang/fluent"
 	"github.com/spf13/viper"
+	"path/filepath"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"time"
 )
 
 const (
@@ -11,9 +15,107 @@ const (
 	maxRetryWait      = 1000
 )
 
+// Sink types
+const (
+	FluentdSink = "fluentd"
+	FileSink    = "file"
+	HTTPSink    = "http"
+	KafkaSink   = "kafka"
+)
+
 var logger = log.Log.WithName("config")
 
+// Record is a message with its tag and time. File, HTTP and Kafka sinks write records as JSON
+type Record struct {
+	Tag     string      `json:"tag"`
+	Time    time.Time   `json:"time"`
+	Message interface{} `json:"message"`
+}
+
+// NewDataLogger creates sinks listed in the configuration. If there are several sinks,
+// messages are sent to each of them. If the buffer directory is configured, every sink except the file one
+// gets own disk queue in the sub-directory named after the sink type
 func NewDataLogger() (DataLogging, error) {
+	sinkTypes := viper.GetStringSlice(CfgSinks)
+	if len(sinkTypes) == 0 {
+		sinkTypes = []string{FluentdSink}
+	}
+
+	bufferDir := viper.GetString(CfgBufferDir)
+	policy, err := ParseFullQueuePolicy(viper.GetString(CfgBufferFullPolicy))
+	if len(bufferDir) > 0 && err != nil {
+		return nil, err
+	}
+
+	sinks := make(MultiDataLogger, 0, len(sinkTypes))
+	for _, sinkType := range sinkTypes {
+		buffered := len(bufferDir) > 0 && sinkType != FileSink
+		// Sink behind a queue must report delivery errors, so it works synchronously
+		sink, err := newSink(sinkType, !buffered)
+		if err == nil && buffered {
+			fieldTag := ""
+			if sinkType == FluentdSink {
+				fieldTag = "msg"
+			}
+			logger.Info("Buffering feedback on disk", "sink", sinkType, "dir", bufferDir, "policy", policy)
+			sink, err = NewBufferedDataLogger(
+				sinkType, sink, filepath.Join(bufferDir, sinkType),
+				viper.GetInt64(CfgBufferMaxSizeMB)*1024*1024, policy, fieldTag,
+			)
+		}
+		if err != nil {
+			_ = sinks.Close()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if len(sinks) == 1 {
+		return sinks[0], nil
+	}
+	return sinks, nil
+}
+
+func newSink(sinkType string, async bool) (DataLogging, error) {
+	switch sinkType {
+	case FluentdSink:
+		return NewFluentdDataLogger(async)
+	case FileSink:
+		path := viper.GetString(CfgFileSinkPath)
+		if len(path) == 0 {
+			return nil, errors.New("path of the file sink is not configured")
+		}
+		logger.Info("Writing to file", "path", path)
+		return NewFileDataLogger(
+			path, viper.GetInt64(CfgFileSinkMaxSizeMB)*1024*1024, viper.GetInt(CfgFileSinkMaxBackups),
+		)
+	case HTTPSink:
+		url := viper.GetString(CfgHTTPSinkURL)
+		if len(url) == 0 {
+			return nil, errors.New("URL of the HTTP sink is not configured")
+		}
+		logger.Info("Sending to HTTP bulk endpoint", "url", url)
+		if !async {
+			return NewHTTPBulkClient(url), nil
+		}
+		return NewHTTPDataLogger(
+			url, viper.GetInt(CfgHTTPSinkBatchSize), viper.GetDuration(CfgHTTPSinkFlushInterval),
+		), nil
+	case KafkaSink:
+		brokers := viper.GetStringSlice(CfgKafkaBrokers)
+		if len(brokers) == 0 {
+			return nil, errors.New("brokers of the Kafka sink are not configured")
+		}
+		logger.Info("Producing to Kafka", "brokers", brokers)
+		return NewKafkaDataLogger(brokers, viper.GetString(CfgKafkaTopicPrefix), async), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type: %s", sinkType)
+	}
+}
+
+// NewFluentdDataLogger creates fluentd client. Asynchronous client drops messages
+// if it is unable to deliver them after maxRetryToDeliver attempts
+func NewFluentdDataLogger(async bool) (DataLogging, error) {
 	host := viper.GetString(CfgFluentdHost)
 	port := viper.GetInt(CfgFluentdPort)
 
@@ -23,7 +125,7 @@ func NewDataLogger() (DataLogging, error) {
 		FluentPort:   port,
 		FluentHost:   host,
 		MaxRetry:     maxRetryToDeliver,
-		Async:        true,
+		Async:        async,
 		MaxRetryWait: maxRetryWait,
 	})
 }
@@ -0,0 +1,21 @@
+package feedback
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	queuedFeedback = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "queued_feedback",
+		Help: "The number of messages in the queue of a sink which are not delivered yet",
+	}, []string{"sink"})
+	droppedFeedback = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "total_dropped_feedback",
+		Help: "The total number of messages dropped because the queue of a sink was full",
+	}, []string{"sink", "policy"})
+	partiallyDroppedFeedback = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "total_partially_dropped_feedback",
+		Help: "The total number of messages rejected by some sinks and accepted by others",
+	})
+)
@@ -0,0 +1,87 @@
+package feedback
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiDataLogger sends every message to each of the sinks
+type MultiDataLogger []DataLogging
+
+// Post sends the message to all sinks even if some of them fail.
+// If some sinks reject the message because their queues are full but another sink accepts it,
+// the rejections are only logged and counted so the message is not reported as rejected
+func (m MultiDataLogger) Post(tag string, message interface{}) error {
+	var rejected, failed []error
+	accepted := 0
+	for _, sink := range m {
+		switch err := sink.Post(tag, message); {
+		case err == nil:
+			accepted++
+		case errors.Is(err, ErrQueueFull):
+			rejected = append(rejected, err)
+		default:
+			failed = append(failed, err)
+		}
+	}
+
+	if accepted == 0 {
+		if errs := append(rejected, failed...); len(errs) > 0 {
+			return sinksError{errs: errs, total: len(m)}
+		}
+		return nil
+	}
+
+	if len(rejected) > 0 {
+		logger.Error(
+			sinksError{errs: rejected, total: len(m)},
+			"Message is rejected by a part of sinks", "tag", tag,
+		)
+		partiallyDroppedFeedback.Inc()
+	}
+	if len(failed) > 0 {
+		return sinksError{errs: failed, total: len(m)}
+	}
+	return nil
+}
+
+func (m MultiDataLogger) Close() error {
+	return joinErrors(func(sink DataLogging) error { return sink.Close() }, m)
+}
+
+// sinksError keeps errors of failed sinks. errors.Is matches it if any of the errors matches
+type sinksError struct {
+	errs  []error
+	total int
+}
+
+func (e sinksError) Error() string {
+	messages := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d of %d sinks failed: %s", len(e.errs), e.total, strings.Join(messages, "; "))
+}
+
+func (e sinksError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinErrors(call func(sink DataLogging) error, sinks []DataLogging) error {
+	var errs []error
+	for _, sink := range sinks {
+		if err := call(sink); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return sinksError{errs: errs, total: len(sinks)}
+	}
+	return nil
+}
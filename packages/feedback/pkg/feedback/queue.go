@@ -0,0 +1,542 @@
+package feedback
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	segmentExtension = ".log"
+	headFileName     = "head"
+	// Count of segments which the maximum size of the queue is split into
+	segmentsPerQueue = 8
+)
+
+// ErrQueueFull is returned by DiskQueue.Push if the queue is full and the policy is RejectPolicy
+var ErrQueueFull = errors.New("feedback queue is full")
+
+var errQueueClosed = errors.New("feedback queue is closed")
+
+// FullQueuePolicy defines what happens with a new entry when the queue is full
+type FullQueuePolicy string
+
+const (
+	// BlockPolicy waits until delivered entries free space in the queue
+	BlockPolicy FullQueuePolicy = "block"
+	// DropOldestPolicy removes the oldest entries to free space for the new one
+	DropOldestPolicy FullQueuePolicy = "drop-oldest"
+	// RejectPolicy returns ErrQueueFull
+	RejectPolicy FullQueuePolicy = "reject"
+)
+
+func ParseFullQueuePolicy(policy string) (FullQueuePolicy, error) {
+	switch p := FullQueuePolicy(policy); p {
+	case BlockPolicy, DropOldestPolicy, RejectPolicy:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown full queue policy: %s", policy)
+	}
+}
+
+type segment struct {
+	path string
+	size int64
+}
+
+type queueHead struct {
+	Segment string `json:"segment"`
+	Offset  int64  `json:"offset"`
+}
+
+// DiskQueue is a write-ahead FIFO queue of newline-terminated entries. Entries are appended into
+// segment files in the queue directory and synced to disk before Push returns. Concurrent pushes share
+// one fsync (group commit): entries written while a sync is in progress are synced together by the next one.
+// If the sync fails, Push returns the error but the entry stays in the queue and can still be delivered.
+// Position of the oldest
+// entry is saved into the head file when entries are acknowledged, so entries which were not acknowledged
+// are replayed after restart. Fully acknowledged segments are removed. Total size of not acknowledged
+// entries is limited by maxSize.
+// Queue supports only one consumer which calls Peek and Ack in turn
+type DiskQueue struct {
+	lock     sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+
+	dir         string
+	maxSize     int64
+	segmentSize int64
+	policy      FullQueuePolicy
+	onDrop      func(count int)
+
+	segments []segment
+	// Offset of the oldest entry in the first segment
+	head   int64
+	size   int64
+	count  int
+	tail   *os.File
+	closed bool
+	// Count of entries returned by the last Peek and count of them which were dropped before Ack
+	peeked  int
+	skipped int
+	// Count of entries written to segments, count of them which are synced to disk and whether
+	// a sync is in progress. syncErr is the error of the last failed sync of entries up to syncErrUntil
+	written      uint64
+	synced       uint64
+	syncing      bool
+	syncDone     *sync.Cond
+	syncErr      error
+	syncErrUntil uint64
+}
+
+// OpenDiskQueue opens the queue in dir or creates a new one.
+// onDrop is called with count of entries removed by DropOldestPolicy
+func OpenDiskQueue(
+	dir string, maxSize int64, policy FullQueuePolicy, onDrop func(count int),
+) (*DiskQueue, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	q := &DiskQueue{
+		dir:         dir,
+		maxSize:     maxSize,
+		segmentSize: maxSize / segmentsPerQueue,
+		policy:      policy,
+		onDrop:      onDrop,
+	}
+	q.notEmpty = sync.NewCond(&q.lock)
+	q.notFull = sync.NewCond(&q.lock)
+	q.syncDone = sync.NewCond(&q.lock)
+
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Push appends the entry to the queue. Entry must not contain newlines
+func (q *DiskQueue) Push(entry []byte) error {
+	line := append(append(make([]byte, 0, len(entry)+1), entry...), '\n')
+	if int64(len(line)) > q.maxSize {
+		return fmt.Errorf("entry of %d bytes exceeds the queue size", len(line))
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for !q.closed && q.size+int64(len(line)) > q.maxSize {
+		switch q.policy {
+		case RejectPolicy:
+			return ErrQueueFull
+		case DropOldestPolicy:
+			entries, err := q.peek(1)
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				return ErrQueueFull
+			}
+			if err := q.ack(entries); err != nil {
+				return err
+			}
+			if q.peeked > 0 {
+				q.peeked--
+				q.skipped++
+			}
+			if q.onDrop != nil {
+				q.onDrop(len(entries))
+			}
+		default:
+			q.notFull.Wait()
+		}
+	}
+	if q.closed {
+		return errQueueClosed
+	}
+
+	if q.tail == nil || q.segments[len(q.segments)-1].size+int64(len(line)) > q.segmentSize {
+		if err := q.addSegment(); err != nil {
+			return err
+		}
+	}
+	if err := q.appendTail(line); err != nil {
+		return err
+	}
+
+	q.written++
+	q.count++
+	q.notEmpty.Signal()
+	return q.waitSynced(q.written)
+}
+
+// Peek waits until the queue has entries and returns up to n oldest of them.
+// Entries stay in the queue until they are acknowledged. Error is returned if the queue is closed
+func (q *DiskQueue) Peek(n int) ([][]byte, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for !q.closed && q.count == 0 {
+		q.notEmpty.Wait()
+	}
+	if q.closed {
+		return nil, errQueueClosed
+	}
+
+	entries, err := q.peek(n)
+	q.peeked, q.skipped = len(entries), 0
+	return entries, err
+}
+
+// Ack removes entries returned by the last Peek from the queue. Entries which were already dropped
+// by DropOldestPolicy are skipped
+func (q *DiskQueue) Ack(entries [][]byte) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	skip := q.skipped
+	if skip > len(entries) {
+		skip = len(entries)
+	}
+	q.peeked, q.skipped = 0, 0
+	return q.ack(entries[skip:])
+}
+
+// Len returns count of entries in the queue
+func (q *DiskQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.count
+}
+
+// Close unblocks Push and Peek calls. Entries are kept on disk
+func (q *DiskQueue) Close() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+
+	if q.tail != nil {
+		return q.closeTail()
+	}
+	return nil
+}
+
+func (q *DiskQueue) peek(n int) ([][]byte, error) {
+	entries := make([][]byte, 0, n)
+	offset := q.head
+	for _, seg := range q.segments {
+		if len(entries) == n || len(entries) == q.count {
+			break
+		}
+
+		read, err := readEntries(seg.path, offset, n-len(entries))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, read...)
+		offset = 0
+	}
+	return entries, nil
+}
+
+func (q *DiskQueue) ack(entries [][]byte) error {
+	var size int64
+	for _, entry := range entries {
+		size += int64(len(entry)) + 1
+	}
+	q.size -= size
+	q.count -= len(entries)
+
+	q.head += size
+	// Remove fully acknowledged segments except the one which is written
+	for len(q.segments) > 1 && q.head >= q.segments[0].size {
+		q.head -= q.segments[0].size
+		if err := os.Remove(q.segments[0].path); err != nil {
+			return err
+		}
+		q.segments = q.segments[1:]
+	}
+
+	q.notFull.Broadcast()
+	return q.saveHead()
+}
+
+// appendTail writes the line into the last segment. If the write fails, the segment is truncated
+// to its previous size, so a partially written entry does not corrupt the next ones
+func (q *DiskQueue) appendTail(line []byte) error {
+	last := &q.segments[len(q.segments)-1]
+	if _, err := q.tail.Write(line); err != nil {
+		if truncErr := os.Truncate(last.path, last.size); truncErr != nil {
+			return fmt.Errorf("%v (unable to truncate the segment: %v)", err, truncErr)
+		}
+		return err
+	}
+
+	last.size += int64(len(line))
+	q.size += int64(len(line))
+	return nil
+}
+
+// waitSynced returns when the entry with the written number is synced to disk. If no sync is in progress,
+// the caller syncs the tail segment without the lock, so entries pushed meanwhile are synced by the next caller
+func (q *DiskQueue) waitSynced(number uint64) error {
+	for q.synced < number {
+		if q.syncErr != nil && number <= q.syncErrUntil {
+			return q.syncErr
+		}
+		if q.syncing {
+			q.syncDone.Wait()
+			continue
+		}
+
+		q.syncing = true
+		tail, written := q.tail, q.written
+		q.lock.Unlock()
+		err := tail.Sync()
+		q.lock.Lock()
+		q.syncing = false
+		q.syncDone.Broadcast()
+
+		// The tail could be synced and closed meanwhile by a segment rotation or Close
+		switch {
+		case err == nil:
+			q.setSynced(written)
+		case q.synced < number:
+			q.syncErr, q.syncErrUntil = err, written
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *DiskQueue) setSynced(written uint64) {
+	if written > q.synced {
+		q.synced = written
+	}
+}
+
+// closeTail syncs all written entries and closes the tail segment
+func (q *DiskQueue) closeTail() error {
+	if err := q.tail.Sync(); err != nil {
+		return err
+	}
+	q.setSynced(q.written)
+	q.syncDone.Broadcast()
+	return q.tail.Close()
+}
+
+func (q *DiskQueue) addSegment() error {
+	name := fmt.Sprintf("%020d%s", q.nextSegmentNumber(), segmentExtension)
+	path := filepath.Join(q.dir, name)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if err := syncDir(q.dir); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if q.tail != nil {
+		if err := q.closeTail(); err != nil {
+			_ = file.Close()
+			return err
+		}
+	}
+	q.tail = file
+	q.segments = append(q.segments, segment{path: path})
+	return nil
+}
+
+func (q *DiskQueue) nextSegmentNumber() int64 {
+	if len(q.segments) == 0 {
+		return 1
+	}
+	var last int64
+	_, _ = fmt.Sscanf(filepath.Base(q.segments[len(q.segments)-1].path), "%d", &last)
+	return last + 1
+}
+
+func (q *DiskQueue) saveHead() error {
+	head := queueHead{}
+	if len(q.segments) > 0 {
+		head = queueHead{Segment: filepath.Base(q.segments[0].path), Offset: q.head}
+	}
+	data, err := json.Marshal(head)
+	if err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(q.dir, headFileName+".tmp")
+	if err := writeFileSync(tmp, data); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(q.dir, headFileName)); err != nil {
+		return err
+	}
+	return syncDir(q.dir)
+}
+
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir makes renames and removals of files in the directory durable
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return file.Sync()
+}
+
+// load restores segments and the head position. Not terminated entry at the end of the last segment
+// (the process was stopped during writing) is truncated
+func (q *DiskQueue) load() error {
+	files, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), segmentExtension) {
+			q.segments = append(q.segments, segment{path: filepath.Join(q.dir, file.Name()), size: file.Size()})
+		}
+	}
+	sort.Slice(q.segments, func(i, j int) bool {
+		return q.segments[i].path < q.segments[j].path
+	})
+
+	head := queueHead{}
+	data, err := ioutil.ReadFile(filepath.Join(q.dir, headFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &head); err != nil {
+			return err
+		}
+	}
+	// Segments before the head segment are acknowledged but were not removed
+	for len(q.segments) > 0 && filepath.Base(q.segments[0].path) < head.Segment {
+		if err := os.Remove(q.segments[0].path); err != nil {
+			return err
+		}
+		q.segments = q.segments[1:]
+	}
+	if len(q.segments) > 0 && filepath.Base(q.segments[0].path) == head.Segment {
+		q.head = head.Offset
+	}
+
+	if len(q.segments) == 0 {
+		return nil
+	}
+	if err := q.truncateTail(); err != nil {
+		return err
+	}
+
+	offset := q.head
+	for _, seg := range q.segments {
+		count, err := countEntries(seg.path, offset)
+		if err != nil {
+			return err
+		}
+		q.count += count
+		q.size += seg.size - offset
+		offset = 0
+	}
+
+	q.tail, err = os.OpenFile(q.segments[len(q.segments)-1].path, os.O_WRONLY|os.O_APPEND, 0644)
+	return err
+}
+
+func (q *DiskQueue) truncateTail() error {
+	last := &q.segments[len(q.segments)-1]
+	data, err := ioutil.ReadFile(last.path)
+	if err != nil {
+		return err
+	}
+	size := int64(bytes.LastIndexByte(data, '\n') + 1)
+	if size == last.size {
+		return nil
+	}
+	last.size = size
+	return os.Truncate(last.path, size)
+}
+
+func readEntries(path string, offset int64, n int) ([][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var entries [][]byte
+	reader := bufio.NewReader(file)
+	for len(entries) < n {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, line[:len(line)-1])
+	}
+	return entries, nil
+}
+
+func countEntries(path string, offset int64) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	reader := bufio.NewReader(file)
+	for {
+		_, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		count++
+	}
+}
@@ -0,0 +1,204 @@
+package feedback
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openTestQueue(t *testing.T, dir string, maxSize int64, policy FullQueuePolicy) (*DiskQueue, *int) {
+	dropped := 0
+	queue, err := OpenDiskQueue(dir, maxSize, policy, func(count int) { dropped += count })
+	assert.NoError(t, err)
+	return queue, &dropped
+}
+
+func pushEntries(t *testing.T, queue *DiskQueue, from, to int) {
+	for i := from; i < to; i++ {
+		assert.NoError(t, queue.Push([]byte(fmt.Sprintf("entry-%02d", i))))
+	}
+}
+
+func entryNames(entries [][]byte) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, string(entry))
+	}
+	return names
+}
+
+func TestDiskQueue_Replay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-queue")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Every entry is 9 bytes, every segment keeps two of them
+	queue, _ := openTestQueue(t, dir, 160, RejectPolicy)
+	pushEntries(t, queue, 0, 5)
+
+	entries, err := queue.Peek(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"entry-00", "entry-01", "entry-02"}, entryNames(entries))
+	assert.NoError(t, queue.Ack(entries))
+	assert.NoError(t, queue.Close())
+
+	// Acknowledged segments are removed
+	segments, err := filepath.Glob(filepath.Join(dir, "*"+segmentExtension))
+	assert.NoError(t, err)
+	assert.Len(t, segments, 2)
+
+	// Not acknowledged entries are replayed after restart. Not terminated entry is truncated
+	file, err := os.OpenFile(segments[1], os.O_WRONLY|os.O_APPEND, 0644)
+	assert.NoError(t, err)
+	_, err = file.WriteString("entry-")
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	queue, _ = openTestQueue(t, dir, 160, RejectPolicy)
+	assert.Equal(t, 2, queue.Len())
+	pushEntries(t, queue, 5, 6)
+
+	entries, err = queue.Peek(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"entry-03", "entry-04", "entry-05"}, entryNames(entries))
+	assert.NoError(t, queue.Close())
+}
+
+func TestDiskQueue_RejectPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-queue")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	queue, _ := openTestQueue(t, dir, 27, RejectPolicy)
+	defer queue.Close()
+	pushEntries(t, queue, 0, 3)
+
+	assert.Equal(t, ErrQueueFull, queue.Push([]byte("entry-03")))
+	assert.Equal(t, 3, queue.Len())
+}
+
+func TestDiskQueue_DropOldestPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-queue")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	queue, dropped := openTestQueue(t, dir, 27, DropOldestPolicy)
+	defer queue.Close()
+	pushEntries(t, queue, 0, 5)
+
+	assert.Equal(t, 2, *dropped)
+	entries, err := queue.Peek(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"entry-02", "entry-03", "entry-04"}, entryNames(entries))
+}
+
+func TestDiskQueue_DropPeekedEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-queue")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	queue, dropped := openTestQueue(t, dir, 27, DropOldestPolicy)
+	defer queue.Close()
+	pushEntries(t, queue, 0, 3)
+
+	// The oldest entry is dropped while it is delivered
+	entries, err := queue.Peek(2)
+	assert.NoError(t, err)
+	pushEntries(t, queue, 3, 4)
+	assert.NoError(t, queue.Ack(entries))
+
+	assert.Equal(t, 1, *dropped)
+	entries, err = queue.Peek(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"entry-02", "entry-03"}, entryNames(entries))
+}
+
+func TestDiskQueue_BlockPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-queue")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	queue, _ := openTestQueue(t, dir, 27, BlockPolicy)
+	defer queue.Close()
+	pushEntries(t, queue, 0, 3)
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- queue.Push([]byte("entry-03"))
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("Push must block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	entries, err := queue.Peek(1)
+	assert.NoError(t, err)
+	assert.NoError(t, queue.Ack(entries))
+	assert.NoError(t, <-pushed)
+	assert.Equal(t, 3, queue.Len())
+}
+
+func TestDiskQueue_FailedWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-queue")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	queue, _ := openTestQueue(t, dir, 160, RejectPolicy)
+	defer queue.Close()
+	pushEntries(t, queue, 0, 1)
+
+	// Simulate a partially written entry and a failed write
+	tail := queue.tail
+	segment := queue.segments[len(queue.segments)-1].path
+	_, err = tail.WriteString("entry-")
+	assert.NoError(t, err)
+	queue.tail, err = os.Open(segment)
+	assert.NoError(t, err)
+	assert.Error(t, queue.Push([]byte("entry-01")))
+	assert.NoError(t, queue.tail.Close())
+	queue.tail = tail
+
+	// Partially written entry is truncated and is not accounted
+	assert.Equal(t, 1, queue.Len())
+	assert.Equal(t, int64(9), queue.size)
+	info, err := os.Stat(segment)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), info.Size())
+
+	pushEntries(t, queue, 2, 3)
+	entries, err := queue.Peek(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"entry-00", "entry-02"}, entryNames(entries))
+}
+
+func TestDiskQueue_ConcurrentPush(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback-queue")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Segments are rotated while other entries are synced
+	queue, _ := openTestQueue(t, dir, 800, RejectPolicy)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, queue.Push([]byte(fmt.Sprintf("entry-%02d", i))))
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(50), queue.synced)
+	assert.NoError(t, queue.Close())
+
+	queue, _ = openTestQueue(t, dir, 800, RejectPolicy)
+	defer queue.Close()
+	assert.Equal(t, 50, queue.Len())
+}
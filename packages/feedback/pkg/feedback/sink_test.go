@@ -0,0 +1,179 @@
+package feedback
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readRecords(t *testing.T, path string) []Record {
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestFileDataLogger_Rotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "nested", "feedback.json")
+
+	// Every record is about 80 bytes, so each file keeps two of them
+	sink, err := NewFileDataLogger(path, 200, 2)
+	assert.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		assert.NoError(t, sink.Post("feedback", map[string]int{"number": i}))
+	}
+	assert.NoError(t, sink.Close())
+	assert.Error(t, sink.Post("feedback", "closed"))
+
+	for file, numbers := range map[string][]float64{path: {6}, path + ".1": {4, 5}, path + ".2": {2, 3}} {
+		var actual []float64
+		for _, record := range readRecords(t, file) {
+			assert.Equal(t, "feedback", record.Tag)
+			actual = append(actual, record.Message.(map[string]interface{})["number"].(float64))
+		}
+		assert.Equal(t, numbers, actual, file)
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileDataLogger_FailedRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "feedback.json")
+
+	// The backup can not be replaced by the rotated file, because it is a non-empty directory
+	assert.NoError(t, os.MkdirAll(filepath.Join(path+".1", "occupied"), 0755))
+
+	sink, err := NewFileDataLogger(path, 100, 1)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, sink.Post("feedback", map[string]int{"number": i}))
+	}
+	assert.NoError(t, sink.Close())
+
+	assert.Len(t, readRecords(t, path), 3)
+}
+
+type bulkEndpoint struct {
+	lock      sync.Mutex
+	batches   [][]string
+	failFirst int
+}
+
+func (e *bulkEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.failFirst > 0 {
+		e.failFirst--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+	e.batches = append(e.batches, strings.Split(strings.TrimSpace(string(body)), "\n"))
+}
+
+func (e *bulkEndpoint) batchSizes() []int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	sizes := make([]int, 0, len(e.batches))
+	for _, batch := range e.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func TestHTTPDataLogger(t *testing.T) {
+	endpoint := &bulkEndpoint{failFirst: 2}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	sink := newHTTPDataLogger(server.URL, 3, time.Hour, time.Millisecond, httpSinkBufferLimit)
+
+	// Full batch is sent immediately, failed requests are retried
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, sink.Post("request_response", fmt.Sprintf("message %d", i)))
+	}
+	assert.Eventually(t, func() bool { return len(endpoint.batchSizes()) == 1 }, time.Second, 10*time.Millisecond)
+
+	// Remaining records are sent on close
+	assert.NoError(t, sink.Close())
+	assert.Equal(t, []int{3, 1}, endpoint.batchSizes())
+	assert.Error(t, sink.Post("request_response", "closed"))
+
+	var record Record
+	assert.NoError(t, json.Unmarshal([]byte(endpoint.batches[1][0]), &record))
+	assert.Equal(t, Record{Tag: "request_response", Time: record.Time, Message: "message 3"}, record)
+}
+
+func TestHTTPDataLoggerBufferLimit(t *testing.T) {
+	endpoint := &bulkEndpoint{}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	// Records are not sent until close, the limit is enough for two records
+	sink := newHTTPDataLogger(server.URL, 100, time.Hour, time.Millisecond, 400)
+	message := strings.Repeat("x", 100)
+
+	assert.NoError(t, sink.Post("request_response", message))
+	assert.NoError(t, sink.Post("request_response", message))
+	err := sink.Post("request_response", message)
+	assert.True(t, errors.Is(err, ErrQueueFull))
+
+	assert.NoError(t, sink.Close())
+	assert.Equal(t, []int{2}, endpoint.batchSizes())
+}
+
+type recordingSink struct {
+	messages []interface{}
+	err      error
+}
+
+func (s *recordingSink) Post(tag string, message interface{}) error {
+	s.messages = append(s.messages, message)
+	return s.err
+}
+
+func (s *recordingSink) Close() error {
+	return s.err
+}
+
+func TestMultiDataLogger(t *testing.T) {
+	first := &recordingSink{err: errors.New("unavailable")}
+	second := &recordingSink{}
+	sinks := MultiDataLogger{first, second}
+
+	err := sinks.Post("feedback", "message")
+	assert.EqualError(t, err, "1 of 2 sinks failed: unavailable")
+	// Message is sent to the second sink even though the first one failed
+	assert.Equal(t, []interface{}{"message"}, first.messages)
+	assert.Equal(t, []interface{}{"message"}, second.messages)
+	assert.Error(t, sinks.Close())
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/odahu/odahu-flow/packages/feedback/pkg/feedback"
 	"github.com/spf13/viper"
@@ -38,18 +39,25 @@ func NewRequestCollector(
 	configId string,
 	logger feedback.DataLogging,
 	prohibitedHeaders []string,
+	inferenceEndpointRegexes []string,
 ) (*RequestCollector, error) {
 	feedbackRequest := TapRequest{
 		ConfigID: configId,
 	}
 
-	predictorRules := make([]MatchPredicate, 0, len(predictors.Predictors))
+	regexes := make([]string, 0, len(predictors.Predictors)+len(inferenceEndpointRegexes))
 	for _, predictor := range predictors.Predictors {
+		regexes = append(regexes, predictor.InferenceEndpointRegex)
+	}
+	regexes = append(regexes, inferenceEndpointRegexes...)
+
+	predictorRules := make([]MatchPredicate, 0, len(regexes))
+	for _, regex := range regexes {
 		predictorRules = append(predictorRules, MatchPredicate{
 			HttpRequestHeadersMatch: HttpHeadersMatch{
 				Headers: []HeaderMatcher{{
 					Name:       filterHeaderKey,
-					RegexMatch: predictor.InferenceEndpointRegex,
+					RegexMatch: regex,
 				}},
 			},
 		})
@@ -238,15 +246,25 @@ func (rc *RequestCollector) tapTraffic() error {
 			"request_id", responseBody.RequestID)
 
 		err = rc.logger.Post(viper.GetString(feedback.CfgRequestResponseTag), *requestResponse)
-		if err != nil {
+		if err != nil && !isRejected(err) {
 			return err
 		}
 
 		err = rc.logger.Post(viper.GetString(feedback.CfgResponseBodyTag), *responseBody)
-		if err != nil {
+		if err != nil && !isRejected(err) {
 			return err
 		}
 	}
 
 	return err
 }
+
+// isRejected returns true if the message was rejected because the feedback queue is full.
+// Tapping continues in this case, the message is counted by the dropped feedback metric
+func isRejected(err error) bool {
+	if errors.Is(err, feedback.ErrQueueFull) {
+		log.Error(err, "Message is rejected")
+		return true
+	}
+	return false
+}
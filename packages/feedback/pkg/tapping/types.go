@@ -6,6 +6,10 @@ const (
 	CfgEnvoyConfigId = "envoy.config_id"
 )
 
+// CfgInferenceEndpointRegexes lists inference endpoint regexes of custom predictors
+// in addition to the built-in ones
+const CfgInferenceEndpointRegexes = "envoy.inference_endpoint_regexes"
+
 // https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/tap/v3/common.proto#config-tap-v3-matchpredicate
 type MatchPredicate struct {
 	OrMatch                  MatchSet         `yaml:"or_match,omitempty"`
@@ -48,6 +48,94 @@ type ModelDeploymentSpec struct {
 	ImagePullConnectionID *string `json:"imagePullConnID,omitempty"`
 	// Node selector for specifying a node pool
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Autoscaling policy of model pods. By default the Knative Pod Autoscaler
+	// scales pods by the number of concurrent requests.
+	Autoscaling *Autoscaling `json:"autoscaling,omitempty"`
+	// Environment variables for the model container
+	Envs []DeploymentEnvironmentVariable `json:"envs,omitempty"`
+	// ConfigMaps and secrets mounted to the model container
+	Volumes []DeploymentVolume `json:"volumes,omitempty"`
+}
+
+type DeploymentEnvironmentVariable struct {
+	// Name of an environment variable
+	Name string `json:"name"`
+	// Value of an environment variable
+	Value string `json:"value,omitempty"`
+	// Source of the value. Cannot be used together with the value
+	ValueFrom *EnvironmentVariableSource `json:"valueFrom,omitempty"`
+}
+
+// EnvironmentVariableSource must contain exactly one of the references
+type EnvironmentVariableSource struct {
+	// Field of an ODAHU connection, for example "password". The name is a connection ID.
+	// The value is copied to a Kubernetes secret of the model deployment
+	ConnectionRef *KeySelector `json:"connectionRef,omitempty"`
+	// Key of a secret in the deployment namespace
+	SecretKeyRef *KeySelector `json:"secretKeyRef,omitempty"`
+	// Key of a ConfigMap in the deployment namespace
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+type KeySelector struct {
+	// Name of the referenced object
+	Name string `json:"name"`
+	// Key of the value in the referenced object
+	Key string `json:"key"`
+}
+
+// DeploymentVolume must contain exactly one of the ConfigMap and the secret
+type DeploymentVolume struct {
+	// Name of a volume
+	Name string `json:"name"`
+	// Path within the model container at which the volume is mounted read-only
+	MountPath string `json:"mountPath"`
+	// Name of a ConfigMap in the deployment namespace
+	ConfigMap string `json:"configMap,omitempty"`
+	// Name of a secret in the deployment namespace
+	Secret string `json:"secret,omitempty"`
+}
+
+type AutoscalingClass string
+
+const (
+	// Knative Pod Autoscaler. Supports scale to zero
+	KPAAutoscalingClass AutoscalingClass = "kpa"
+	// Kubernetes Horizontal Pod Autoscaler. Requires at least one replica
+	HPAAutoscalingClass AutoscalingClass = "hpa"
+)
+
+type AutoscalingMetric string
+
+const (
+	// Number of simultaneous requests per pod. Supported by kpa
+	ConcurrencyAutoscalingMetric AutoscalingMetric = "concurrency"
+	// Number of requests per second per pod. Supported by kpa
+	RPSAutoscalingMetric AutoscalingMetric = "rps"
+	// Percentage of requested CPU. Supported by hpa
+	CPUAutoscalingMetric AutoscalingMetric = "cpu"
+	// Memory usage in megabytes. Supported by hpa
+	MemoryAutoscalingMetric AutoscalingMetric = "memory"
+)
+
+// Autoscaling describes how the number of model pods is changed between
+// the minimum and maximum number of replicas
+type Autoscaling struct {
+	// Autoscaler implementation: "kpa" (default) or "hpa"
+	Class AutoscalingClass `json:"class,omitempty"`
+	// Metric to scale on: "concurrency" (default) or "rps" for kpa, "cpu" (default) or "memory" for hpa
+	Metric AutoscalingMetric `json:"metric,omitempty"`
+	// Target value of the metric per pod
+	Target *int32 `json:"target,omitempty"`
+	// Time window over which the metric is averaged. Only for kpa
+	StableWindow *metav1.Duration `json:"stableWindow,omitempty"`
+	// Panic window as a percentage of the stable window. Only for kpa
+	PanicWindowPercentage *int32 `json:"panicWindowPercentage,omitempty"`
+	// Minimum time the last pod is kept after the autoscaler decided to scale to zero.
+	// Only for kpa with zero minimum replicas
+	ScaleToZeroGracePeriod *metav1.Duration `json:"scaleToZeroGracePeriod,omitempty"`
+	// Time during which the load must stay low before pods are removed. Only for kpa
+	ScaleDownDelay *metav1.Duration `json:"scaleDownDelay,omitempty"`
 }
 
 type ModelDeploymentState string
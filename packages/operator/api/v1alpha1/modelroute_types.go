@@ -40,6 +40,63 @@ type ModelRouteSpec struct {
 	Mirror *string `json:"mirror,omitempty"`
 	// A http rule can forward traffic to Model Deployments.
 	ModelDeploymentTargets []ModelDeploymentTarget `json:"modelDeployments"`
+	// Progressive rollout of a canary Model Deployment. If it is set then weights of targets
+	// are managed by the rollout
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
+	// Rules forward matched requests to their own Model Deployments instead of modelDeployments.
+	// Rules must not overlap, so every request matches at most one rule
+	Rules []RouteRule `json:"rules,omitempty"`
+}
+
+type RouteMatchType string
+
+const (
+	HeaderRouteMatch     = RouteMatchType("header")
+	QueryParamRouteMatch = RouteMatchType("queryParam")
+	CookieRouteMatch     = RouteMatchType("cookie")
+)
+
+// RouteMatch is a condition on a request header, query parameter or cookie.
+// Exactly one of exact, prefix and regex must be set
+type RouteMatch struct {
+	// Possible values: header, queryParam, cookie
+	Type RouteMatchType `json:"type"`
+	// Name of the header, query parameter or cookie
+	Name string `json:"name"`
+	// Value must be equal to this string
+	Exact string `json:"exact,omitempty"`
+	// Value must start with this string. It is not supported for query parameters
+	Prefix string `json:"prefix,omitempty"`
+	// Value must match this RE2 regular expression
+	Regex string `json:"regex,omitempty"`
+}
+
+// RouteRule pins requests which match all conditions to specific Model Deployments,
+// for example to run A/B experiments or to let testers hit a shadow model
+type RouteRule struct {
+	// Name of the rule. It must be unique within the route
+	Name string `json:"name"`
+	// Conditions of the rule. A request matches the rule if it matches all of them
+	Match []RouteMatch `json:"match"`
+	// Model Deployments which receive matched requests. Weights follow the same rules as for modelDeployments
+	ModelDeploymentTargets []ModelDeploymentTarget `json:"modelDeployments"`
+}
+
+// RolloutPolicy shifts traffic to the canary Model Deployment step by step.
+// Every step lasts stepInterval, then metrics of the canary are analyzed. If they exceed limits,
+// then the rollout is aborted and all traffic is returned to other targets
+type RolloutPolicy struct {
+	// Model Deployment which receives growing traffic. It must be one of the model deployment targets
+	Canary string `json:"canary"`
+	// Canary weights of consecutive steps, for example [10, 25, 50, 100].
+	// Other targets share the rest of the traffic equally
+	StepWeights []int32 `json:"stepWeights"`
+	// Duration of every step, for example 5m. Default value is 5m
+	StepInterval string `json:"stepInterval,omitempty"`
+	// Maximum share of failed (5xx) canary responses, for example 0.05
+	MaxErrorRate *float64 `json:"maxErrorRate,omitempty"`
+	// Maximum 99th percentile of canary latency in milliseconds
+	MaxLatencyMs *float64 `json:"maxLatencyMs,omitempty"`
 }
 
 type ModelRouteState string
@@ -55,6 +112,39 @@ type ModelRouteStatus struct {
 	EdgeURL string `json:"edgeUrl"`
 	// State of Model Route
 	State ModelRouteState `json:"state"`
+	// Progress of the rollout. It is managed by the rollout controller
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+type RolloutPhase string
+
+const (
+	RolloutProgressing = RolloutPhase("Progressing")
+	RolloutSucceeded   = RolloutPhase("Succeeded")
+	RolloutAborted     = RolloutPhase("Aborted")
+)
+
+// RolloutStep is a record of a finished or current step of the rollout
+type RolloutStep struct {
+	// Canary weight of the step
+	Weight int32 `json:"weight"`
+	// When the step was started
+	StartedAt metav1.Time `json:"startedAt"`
+	// Share of failed canary responses measured at the end of the step
+	ErrorRate *float64 `json:"errorRate,omitempty"`
+	// 99th percentile of canary latency in milliseconds measured at the end of the step
+	LatencyMs *float64 `json:"latencyMs,omitempty"`
+}
+
+type RolloutStatus struct {
+	// Possible values: Progressing, Succeeded, Aborted
+	Phase RolloutPhase `json:"phase"`
+	// Index of the current step in stepWeights
+	CurrentStep int `json:"currentStep"`
+	// Steps which were started, in execution order
+	Steps []RolloutStep `json:"steps,omitempty"`
+	// Reason of the abort or the last error of the metrics analysis
+	Message string `json:"message,omitempty"`
 }
 
 func (in ModelRouteSpec) Value() (driver.Value, error) {
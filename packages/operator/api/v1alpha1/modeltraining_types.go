@@ -117,6 +117,9 @@ type TrainingResult struct {
 	ArtifactName string `json:"artifactName"`
 	// VCS commit
 	CommitID string `json:"commitID"`
+	// Scalar metrics of the trained model, for example accuracy. They are used by training sweeps
+	// to choose the best training
+	Metrics map[string]float64 `json:"metrics,omitempty"`
 }
 
 // ModelTrainingStatus defines the observed state of ModelTraining
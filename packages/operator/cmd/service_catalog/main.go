@@ -22,6 +22,7 @@ import (
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiclient/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiclient/event"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	md_deployment "github.com/odahu/odahu-flow/packages/operator/pkg/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/inspectors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/http"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/servicecatalog"
@@ -38,34 +39,53 @@ import (
 )
 
 func initReflector(cfg config.ServiceCatalog, logger *zap.SugaredLogger,
-	catalog servicecatalog.Catalog) (servicecatalog.Reflector, error) {
+	handler servicecatalog.UpdateHandler, cursorStore servicecatalog.CursorStore) servicecatalog.Reflector {
 	aCfg := cfg.Auth
 	httpClient := http.NewBaseAPIClient(
 		aCfg.APIURL, aCfg.APIToken, aCfg.ClientID, aCfg.ClientSecret, aCfg.OAuthOIDCTokenEndpoint, "api/v1",
 	)
 
-	inspectorsMap, err := inspectors.NewInspectorsMap(cfg.EdgeURL, &httpClient)
-	if err != nil {
-		return servicecatalog.Reflector{}, err
+	eventClient := event.ModelRouteEventClient{
+		HTTPClient: &httpClient,
+		Log:        logger,
+	}
+	var fetcher servicecatalog.EventFetcher = servicecatalog.RouteEventFetcher{APIClient: eventClient}
+	if cfg.StreamEvents {
+		fetcher = servicecatalog.NewStreamingRouteEventFetcher(eventClient)
 	}
 
-	deploymentClient := deployment.NewClient(aCfg)
-
-	return servicecatalog.NewReflector(logger, servicecatalog.UpdateHandler{
-		Inspectors:       inspectorsMap,
-		Catalog:          catalog,
-		DeploymentClient: deploymentClient,
-	}, servicecatalog.RouteEventFetcher{
-		APIClient: event.ModelRouteEventClient{
-			HTTPClient: &httpClient,
-			Log:        logger,
-		},
-	},
+	return servicecatalog.NewReflector(logger, handler, fetcher,
 		servicecatalog.ReflectorOpts{
 			WorkersCount: cfg.WorkersCount,
 			FetchTimeout: time.Duration(cfg.FetchTimeout) * time.Second,
-		}), nil
+			CursorStore:  cursorStore,
+		})
+
+}
+
+// initUpdateHandler creates handler which inspects models behind routes and updates the catalog
+func initUpdateHandler(
+	cfg config.ServiceCatalog, catalog servicecatalog.Catalog,
+) (servicecatalog.UpdateHandler, error) {
+	aCfg := cfg.Auth
+	httpClient := http.NewBaseAPIClient(
+		aCfg.APIURL, aCfg.APIToken, aCfg.ClientID, aCfg.ClientSecret, aCfg.OAuthOIDCTokenEndpoint, "api/v1",
+	)
+
+	deploymentClient := deployment.NewClient(aCfg)
+
+	inspectorRegistry, err := inspectors.NewRegistry(
+		cfg.EdgeURL, &httpClient, md_deployment.PredictorResolver{Client: deploymentClient},
+	)
+	if err != nil {
+		return servicecatalog.UpdateHandler{}, err
+	}
 
+	return servicecatalog.UpdateHandler{
+		Inspectors:       inspectorRegistry,
+		Catalog:          catalog,
+		DeploymentClient: deploymentClient,
+	}, nil
 }
 
 var mainCmd = &cobra.Command{
@@ -94,13 +114,25 @@ var mainCmd = &cobra.Command{
 		}
 
 		routeCatalog := servicecatalog.NewModelRouteCatalog(sLogger)
+		// Catalog restored from the state file reflects only events which happened after the saved cursor
+		var cursorStore servicecatalog.CursorStore
+		if stateFile := odahuConfig.ServiceCatalog.StateFile; len(stateFile) > 0 {
+			routeCatalog, err = servicecatalog.NewPersistentModelRouteCatalog(
+				sLogger, servicecatalog.FileStateStore{Path: stateFile},
+			)
+			if err != nil {
+				sLogger.Fatalf("Unable to restore service-catalog state. Error %v", err)
+			}
+			cursorStore = routeCatalog
+		}
 
 		// Run reflector (keep state of service catalog up to date with ODAHU API Server)
 
-		reflector, err := initReflector(odahuConfig.ServiceCatalog, sLogger, routeCatalog)
+		updateHandler, err := initUpdateHandler(odahuConfig.ServiceCatalog, routeCatalog)
 		if err != nil {
 			sLogger.Fatalf("Unable set up service-catalog reflector. Error %v", err)
 		}
+		reflector := initReflector(odahuConfig.ServiceCatalog, sLogger, updateHandler, cursorStore)
 
 		ctx, cancel := context.WithCancel(context.Background())
 		wg := sync.WaitGroup{}
@@ -116,9 +148,25 @@ var mainCmd = &cobra.Command{
 			cancel()
 		}()
 
+		// Run re-inspection of models (refresh swaggers and health of routes in the catalog)
+
+		if period := odahuConfig.ServiceCatalog.ReinspectionPeriod; period > 0 {
+			reinspector := servicecatalog.NewReinspector(
+				sLogger, updateHandler, routeCatalog,
+				time.Duration(period)*time.Second, odahuConfig.ServiceCatalog.UnhealthyThreshold,
+			)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sLogger.Info("Starting the reinspector.")
+				reinspector.Run(ctx)
+				sLogger.Info("Reinspector was stopped")
+			}()
+		}
+
 		// Run webserver. API for getting information about deployed models, swaggers, metadata, etc
 
-		mainServer, err := servicecatalog.SetUPMainServer(routeCatalog, odahuConfig.ServiceCatalog)
+		mainServer, err := servicecatalog.SetUPMainServer(routeCatalog, reflector.Ready, odahuConfig.ServiceCatalog)
 		if err != nil {
 			sLogger.Fatalf("Unable set up service-catalog server. Error %v", err)
 		}
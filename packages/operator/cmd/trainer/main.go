@@ -35,6 +35,9 @@ const (
 	mtIDCLIParam               = "mt-id"
 	apiURLCLIParam             = "api-url"
 	outputTrainingDirCLIParam  = "output-dir"
+	metricCLIParam             = "metric"
+	paramCLIParam              = "param"
+	stepCLIParam               = "step"
 	MTFileConfigKey            = "trainer.mtFile"
 	OutputTrainingDirConfigKey = "trainer.outputDir"
 	APIURLConfigKey            = "trainer.auth.apiUrl"
@@ -69,6 +72,28 @@ var saveCmd = &cobra.Command{
 	},
 }
 
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Save metrics and params of a training",
+	Example: "trainer metrics --mt-id wine-training --metric loss=0.3 --metric accuracy=0.9 --step 2 " +
+		"--param batch_size=32",
+	Run: func(cmd *cobra.Command, args []string) {
+		metrics, err := cmd.Flags().GetStringToString(metricCLIParam)
+		config.PanicIfError(err)
+		params, err := cmd.Flags().GetStringToString(paramCLIParam)
+		config.PanicIfError(err)
+		step, err := cmd.Flags().GetInt64(stepCLIParam)
+		config.PanicIfError(err)
+
+		if err := newTrainerWithHTTPRepositories(config.MustLoadConfig().Trainer).PushMetrics(
+			metrics, params, step,
+		); err != nil {
+			log.Error(err, "Metrics saving failed")
+			os.Exit(1)
+		}
+	},
+}
+
 func init() {
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -95,7 +120,11 @@ func init() {
 		OutputTrainingDirConfigKey, mainCmd.PersistentFlags().Lookup(outputTrainingDirCLIParam),
 	))
 
-	mainCmd.AddCommand(trainerSetupCmd, saveCmd)
+	metricsCmd.Flags().StringToString(metricCLIParam, nil, "Metric value in the key=value format")
+	metricsCmd.Flags().StringToString(paramCLIParam, nil, "Param value in the key=value format")
+	metricsCmd.Flags().Int64(stepCLIParam, 0, "Step of the training, for example epoch number")
+
+	mainCmd.AddCommand(trainerSetupCmd, saveCmd, metricsCmd)
 }
 
 func newTrainerWithHTTPRepositories(config config.TrainerConfig) *trainer.ModelTrainer {
@@ -0,0 +1,193 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	odahuflowv1alpha1 "github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/connection"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/odahuflow"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/hash"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"strconv"
+)
+
+const (
+	// Annotation to restart model pods if values of the referenced connections are changed
+	AppliedEnvSecretHashKey = "odahu.org/applied-env-secret-hash"
+)
+
+// Key of the env secret which contains the value of the connection field
+func envSecretKey(ref odahuflowv1alpha1.KeySelector) string {
+	return fmt.Sprintf("%s.%s", ref.Name, ref.Key)
+}
+
+// Environment variables of the model container. Connection references are resolved from the env secret
+func deploymentEnvs(md *odahuflowv1alpha1.ModelDeployment) []corev1.EnvVar {
+	if len(md.Spec.Envs) == 0 {
+		return nil
+	}
+
+	envs := make([]corev1.EnvVar, 0, len(md.Spec.Envs))
+	for _, env := range md.Spec.Envs {
+		envVar := corev1.EnvVar{Name: env.Name, Value: env.Value}
+
+		if source := env.ValueFrom; source != nil {
+			switch {
+			case source.ConnectionRef != nil:
+				envVar.ValueFrom = &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: odahuflow.GenerateDeploymentEnvSecretName(md.Name),
+					},
+					Key: envSecretKey(*source.ConnectionRef),
+				}}
+			case source.SecretKeyRef != nil:
+				envVar.ValueFrom = &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: source.SecretKeyRef.Name},
+					Key:                  source.SecretKeyRef.Key,
+				}}
+			case source.ConfigMapKeyRef != nil:
+				envVar.ValueFrom = &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: source.ConfigMapKeyRef.Name},
+					Key:                  source.ConfigMapKeyRef.Key,
+				}}
+			}
+		}
+
+		envs = append(envs, envVar)
+	}
+
+	return envs
+}
+
+// Volumes of the model pod and their read-only mounts to the model container
+func deploymentVolumes(md *odahuflowv1alpha1.ModelDeployment) ([]corev1.Volume, []corev1.VolumeMount) {
+	if len(md.Spec.Volumes) == 0 {
+		return nil, nil
+	}
+
+	volumes := make([]corev1.Volume, 0, len(md.Spec.Volumes))
+	mounts := make([]corev1.VolumeMount, 0, len(md.Spec.Volumes))
+	for _, volume := range md.Spec.Volumes {
+		source := corev1.VolumeSource{}
+		if len(volume.ConfigMap) > 0 {
+			source.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: volume.ConfigMap},
+			}
+		} else {
+			source.Secret = &corev1.SecretVolumeSource{SecretName: volume.Secret}
+		}
+
+		volumes = append(volumes, corev1.Volume{Name: volume.Name, VolumeSource: source})
+		mounts = append(mounts, corev1.VolumeMount{Name: volume.Name, MountPath: volume.MountPath, ReadOnly: true})
+	}
+
+	return volumes, mounts
+}
+
+// Values of the connections referenced by environment variables are copied to a Kubernetes secret.
+// So, sensitive data does not appear in the Knative Service
+func (r *ModelDeploymentReconciler) reconcileEnvSecret(
+	log *zap.SugaredLogger,
+	md *odahuflowv1alpha1.ModelDeployment,
+) error {
+	data := make(map[string][]byte)
+	conns := make(map[string]*connection.Connection)
+	for _, env := range md.Spec.Envs {
+		if env.ValueFrom == nil || env.ValueFrom.ConnectionRef == nil {
+			continue
+		}
+		ref := env.ValueFrom.ConnectionRef
+
+		conn, ok := conns[ref.Name]
+		if !ok {
+			var err error
+			if conn, err = r.connAPIClient.GetConnection(ref.Name); err != nil {
+				log.Errorw("Cannot retrieve connection", odahuflow.ConnectionIDLogPrefix, ref.Name, "error", err)
+				return err
+			}
+			// Since connAPIClient here is actually an HTTP client, it returns connection with base64-encoded secrets
+			if err := conn.DecodeBase64Fields(); err != nil {
+				return err
+			}
+			conns[ref.Name] = conn
+		}
+
+		value, ok := conn.Field(ref.Key)
+		if !ok {
+			return fmt.Errorf("unknown field %q of the connection %q", ref.Key, ref.Name)
+		}
+		data[envSecretKey(*ref)] = []byte(value)
+	}
+
+	expectedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      odahuflow.GenerateDeploymentEnvSecretName(md.Name),
+			Namespace: r.deploymentConfig.Namespace,
+		},
+		Data: data,
+		Type: corev1.SecretTypeOpaque,
+	}
+
+	if len(data) == 0 {
+		delete(md.Annotations, AppliedEnvSecretHashKey)
+
+		if err := r.Delete(context.TODO(), expectedSecret); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	secretHash, err := hash.Hash(data)
+	if err != nil {
+		log.Error(err, "Unable to produce env secret hash")
+		return err
+	}
+	md.Annotations[AppliedEnvSecretHashKey] = strconv.FormatUint(secretHash, 10)
+
+	if err := controllerutil.SetControllerReference(md, expectedSecret, r.scheme); err != nil {
+		return err
+	}
+
+	foundSecret := &corev1.Secret{}
+	err = r.Get(context.TODO(), types.NamespacedName{
+		Name: expectedSecret.Name, Namespace: expectedSecret.Namespace,
+	}, foundSecret)
+	switch {
+	case err != nil && k8serrors.IsNotFound(err):
+		log.Info(fmt.Sprintf("Creating %s env secret", expectedSecret.Name))
+		return r.Create(context.TODO(), expectedSecret)
+	case err != nil:
+		log.Error(err, "Fetching Secret")
+		return err
+	case !reflect.DeepEqual(foundSecret.Data, expectedSecret.Data):
+		foundSecret.Data = expectedSecret.Data
+
+		log.Info("Updating env secret")
+		return r.Update(context.TODO(), foundSecret)
+	}
+
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	conn_api_client "github.com/odahu/odahu-flow/packages/operator/pkg/apiclient/connection"
+	deployment_api_client "github.com/odahu/odahu-flow/packages/operator/pkg/apiclient/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/inspectors"
@@ -68,6 +69,10 @@ const (
 	DefaultKnativeAutoscalingMetric      = "concurrency"
 	DefaultKnativeAutoscalingClass       = "kpa.autoscaling.knative.dev"
 	ModelNameAnnotationKey               = "modelName"
+	KnativeWindowKey                     = "autoscaling.knative.dev/window"
+	KnativePanicWindowPercentageKey      = "autoscaling.knative.dev/panicWindowPercentage"
+	KnativeScaleToZeroRetentionKey       = "autoscaling.knative.dev/scaleToZeroPodRetentionPeriod"
+	KnativeScaleDownDelayKey             = "autoscaling.knative.dev/scaleDownDelay"
 	AppliedModelDeploymentSpecKey        = "odahu.org/applied-model-deployment-spec"
 	AppliedPolicyHashKey                 = "odahu.org/applied-policy-hash"
 
@@ -95,7 +100,11 @@ func NewModelDeploymentReconciler(
 		authCfg.ClientSecret, authCfg.OAuthOIDCTokenEndpoint, "",
 	)
 
-	inspectorsMap, err := inspectors.NewInspectorsMap(cfg.ServiceCatalog.EdgeURL, &noPrefixHTTPClient)
+	predictorResolver := deployment.PredictorResolver{Client: deployment_api_client.NewClient(authCfg)}
+
+	inspectorRegistry, err := inspectors.NewRegistry(
+		cfg.ServiceCatalog.EdgeURL, &noPrefixHTTPClient, predictorResolver,
+	)
 	if err != nil {
 		panic(err)
 	}
@@ -114,7 +123,8 @@ func NewModelDeploymentReconciler(
 		deploymentConfig: cfg.Deployment,
 		operatorConfig:   cfg.Operator,
 		gpuResourceName:  cfg.Common.ResourceGPUName,
-		inspectors:       inspectorsMap,
+		predictors:       predictorResolver,
+		inspectors:       inspectorRegistry,
 	}
 }
 
@@ -127,13 +137,61 @@ type ModelDeploymentReconciler struct {
 	deploymentConfig config.ModelDeploymentConfig
 	operatorConfig   config.OperatorConfig
 	gpuResourceName  string
-	inspectors       map[string]inspectors.ModelServerInspector
+	predictors       deployment.PredictorResolver
+	inspectors       *inspectors.Registry
 }
 
 func KnativeServiceName(md *odahuflowv1alpha1.ModelDeployment) string {
 	return md.Name
 }
 
+// Optional autoscaling annotations are removed from the revision template if they are not set in MD
+var knativeOptionalAutoscalingKeys = []string{
+	KnativeAutoscalingTargetKey, KnativeWindowKey, KnativePanicWindowPercentageKey,
+	KnativeScaleToZeroRetentionKey, KnativeScaleDownDelayKey,
+}
+
+// knativeAutoscalingAnnotations maps the autoscaling policy of MD onto Knative annotations.
+// MDs without the policy are scaled by the concurrency as before
+func knativeAutoscalingAnnotations(md *odahuflowv1alpha1.ModelDeployment) map[string]string {
+	annotations := map[string]string{
+		KnativeMinReplicasKey:    strconv.Itoa(int(*md.Spec.MinReplicas)),
+		KnativeMaxReplicasKey:    strconv.Itoa(int(*md.Spec.MaxReplicas)),
+		KnativeAutoscalingClass:  DefaultKnativeAutoscalingClass,
+		KnativeAutoscalingMetric: DefaultKnativeAutoscalingMetric,
+	}
+
+	as := md.Spec.Autoscaling
+	if as == nil {
+		annotations[KnativeAutoscalingTargetKey] = KnativeAutoscalingTargetDefaultValue
+		return annotations
+	}
+
+	if len(as.Class) != 0 {
+		annotations[KnativeAutoscalingClass] = fmt.Sprintf("%s.autoscaling.knative.dev", as.Class)
+	}
+	if len(as.Metric) != 0 {
+		annotations[KnativeAutoscalingMetric] = string(as.Metric)
+	}
+	if as.Target != nil {
+		annotations[KnativeAutoscalingTargetKey] = strconv.Itoa(int(*as.Target))
+	}
+	if as.StableWindow != nil {
+		annotations[KnativeWindowKey] = as.StableWindow.Duration.String()
+	}
+	if as.PanicWindowPercentage != nil {
+		annotations[KnativePanicWindowPercentageKey] = strconv.Itoa(int(*as.PanicWindowPercentage))
+	}
+	if as.ScaleToZeroGracePeriod != nil {
+		annotations[KnativeScaleToZeroRetentionKey] = as.ScaleToZeroGracePeriod.Duration.String()
+	}
+	if as.ScaleDownDelay != nil {
+		annotations[KnativeScaleDownDelayKey] = as.ScaleDownDelay.Duration.String()
+	}
+
+	return annotations
+}
+
 func knativeDeploymentName(revisionName string) string {
 	return fmt.Sprintf("%s-deployment", revisionName)
 }
@@ -159,6 +217,8 @@ func (r *ModelDeploymentReconciler) ReconcileKnativeService(
 		affinity = utils.BuildNodeAffinity(r.deploymentConfig.NodePools)
 	}
 
+	volumes, _ := deploymentVolumes(modelDeploymentCR)
+
 	fulfilKnativeService := func(kService *knservingv1.Service) error {
 		templateLabelsToAdd := map[string]string{
 			ModelNameAnnotationKey:  modelDeploymentCR.Name,
@@ -168,15 +228,13 @@ func (r *ModelDeploymentReconciler) ReconcileKnativeService(
 		if modelDeploymentCR.Spec.RoleName != nil { // Otherwise default ConfigMap will be mounted to container
 			templateLabelsToAdd[podPolicyLabel] = GetCMPolicyName(modelDeploymentCR)
 		}
-		templateAnnotationsToAdd := map[string]string{
-			KnativeAutoscalingClass:          DefaultKnativeAutoscalingClass,
-			KnativeAutoscalingMetric:         DefaultKnativeAutoscalingMetric,
-			KnativeMinReplicasKey:            strconv.Itoa(int(*modelDeploymentCR.Spec.MinReplicas)),
-			KnativeMaxReplicasKey:            strconv.Itoa(int(*modelDeploymentCR.Spec.MaxReplicas)),
-			KnativeAutoscalingTargetKey:      KnativeAutoscalingTargetDefaultValue,
-			IstioRewriteHTTPProbesAnnotation: "true",
-			// Annotation to trigger pod restart if policy is changed
-			AppliedPolicyHashKey: modelDeploymentCR.Annotations[AppliedPolicyHashKey],
+		templateAnnotationsToAdd := knativeAutoscalingAnnotations(modelDeploymentCR)
+		templateAnnotationsToAdd[IstioRewriteHTTPProbesAnnotation] = "true"
+		// Annotation to trigger pod restart if policy is changed
+		templateAnnotationsToAdd[AppliedPolicyHashKey] = modelDeploymentCR.Annotations[AppliedPolicyHashKey]
+		// Annotation to trigger pod restart if referenced connections are changed
+		if envSecretHash, ok := modelDeploymentCR.Annotations[AppliedEnvSecretHashKey]; ok {
+			templateAnnotationsToAdd[AppliedEnvSecretHashKey] = envSecretHash
 		}
 		revisionSpec := knservingv1.RevisionSpec{
 			TimeoutSeconds: &DefaultTerminationPeriod,
@@ -188,6 +246,7 @@ func (r *ModelDeploymentReconciler) ReconcileKnativeService(
 				NodeSelector: modelDeploymentCR.Spec.NodeSelector,
 				Tolerations:  r.deploymentConfig.Tolerations,
 				Affinity:     affinity,
+				Volumes:      volumes,
 			},
 		}
 
@@ -212,6 +271,10 @@ func (r *ModelDeploymentReconciler) ReconcileKnativeService(
 		if revisionTemplate.Annotations == nil {
 			revisionTemplate.Annotations = make(map[string]string, len(templateAnnotationsToAdd))
 		}
+		for _, k := range knativeOptionalAutoscalingKeys {
+			delete(revisionTemplate.Annotations, k)
+		}
+		delete(revisionTemplate.Annotations, AppliedEnvSecretHashKey)
 		for k, v := range templateAnnotationsToAdd {
 			revisionTemplate.Annotations[k] = v
 		}
@@ -270,7 +333,11 @@ func (r *ModelDeploymentReconciler) ReconcileKnativeService(
 	newPolicyHash := modelDeploymentCR.Annotations[AppliedPolicyHashKey]
 	policyIsChanged := newPolicyHash != oldPolicyHash
 
-	if depSpecChanged || policyIsChanged {
+	oldEnvSecretHash := found.Spec.ConfigurationSpec.Template.Annotations[AppliedEnvSecretHashKey]
+	newEnvSecretHash := modelDeploymentCR.Annotations[AppliedEnvSecretHashKey]
+	envSecretIsChanged := newEnvSecretHash != oldEnvSecretHash
+
+	if depSpecChanged || policyIsChanged || envSecretIsChanged {
 		if policyIsChanged {
 			log.Info("Policy hash was changed",
 				"old", oldPolicyHash,
@@ -281,6 +348,9 @@ func (r *ModelDeploymentReconciler) ReconcileKnativeService(
 			log.Info("ModelDeployment spec was changed", "old", lastAppliedMDSpec,
 				"new", modelDeploymentCR.Spec)
 		}
+		if envSecretIsChanged {
+			log.Info("Referenced connections were changed")
+		}
 
 		err = fulfilKnativeService(found)
 		if err != nil {
@@ -316,10 +386,14 @@ func (r *ModelDeploymentReconciler) createModelContainer(
 	readinessProbe := predictor.ReadinessProbe
 	readinessProbe.InitialDelaySeconds = *modelDeploymentCR.Spec.ReadinessProbeInitialDelay
 
+	_, volumeMounts := deploymentVolumes(modelDeploymentCR)
+
 	return &corev1.Container{
 		Image:          modelDeploymentCR.Spec.Image,
 		Resources:      depResources,
 		Ports:          predictor.Ports,
+		Env:            deploymentEnvs(modelDeploymentCR),
+		VolumeMounts:   volumeMounts,
 		LivenessProbe:  &livenessProbe,
 		ReadinessProbe: &readinessProbe,
 	}, nil
@@ -402,7 +476,7 @@ func (r *ModelDeploymentReconciler) reconcilePolicyCM(log *zap.SugaredLogger,
 
 	// Handle case when roleName is set
 
-	policies, err := deployment.ReadDefaultPoliciesAndRender(*rn, predictor.OpaPolicyFilename)
+	policies, err := deployment.RenderPredictorPolicies(*rn, predictor)
 	if err != nil {
 		return err
 	}
@@ -447,7 +521,10 @@ func (r *ModelDeploymentReconciler) reconcilePolicyCM(log *zap.SugaredLogger,
 func (r *ModelDeploymentReconciler) reconcileModelMeta(log *zap.SugaredLogger,
 	modelDeploymentCR *odahuflowv1alpha1.ModelDeployment) error {
 
-	inspector := r.inspectors[modelDeploymentCR.Spec.Predictor]
+	inspector, err := r.inspectors.GetInspector(modelDeploymentCR.Spec.Predictor)
+	if err != nil {
+		return err
+	}
 	servedModel, err := inspector.Inspect("", modelDeploymentCR.Status.HostHeader, log)
 	if err != nil {
 		return err
@@ -522,9 +599,10 @@ func (r *ModelDeploymentReconciler) Reconcile(request ctrl.Request) (ctrl.Result
 		modelDeploymentCR.Annotations = make(map[string]string)
 	}
 
-	predictor, ok := predictors.Predictors[modelDeploymentCR.Spec.Predictor]
-	if !ok {
-		return reconcile.Result{}, fmt.Errorf("unknown predictor %s", modelDeploymentCR.Spec.Predictor)
+	predictor, err := r.predictors.GetPredictor(modelDeploymentCR.Spec.Predictor)
+	if err != nil {
+		log.Error(err, "Resolve predictor")
+		return reconcile.Result{}, err
 	}
 
 	if err := r.reconcilePolicyCM(log, modelDeploymentCR, predictor); err != nil {
@@ -538,6 +616,11 @@ func (r *ModelDeploymentReconciler) Reconcile(request ctrl.Request) (ctrl.Result
 		return reconcile.Result{}, nil
 	}
 
+	if err := r.reconcileEnvSecret(log, modelDeploymentCR); err != nil {
+		log.Error(err, "Reconcile deployment env secret")
+		return reconcile.Result{}, err
+	}
+
 	if err := r.ReconcileKnativeService(log, modelDeploymentCR, predictor); err != nil {
 		log.Error(err, "Reconcile Knative Service")
 		return reconcile.Result{}, err
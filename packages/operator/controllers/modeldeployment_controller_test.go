@@ -190,6 +190,75 @@ func (s *ModelDeploymentControllerSuite) TestReconcile() {
 	s.Assertions.Equal(mdReadinessDelay, containerSpec.ReadinessProbe.InitialDelaySeconds)
 }
 
+func (s *ModelDeploymentControllerSuite) TestReconcileAutoscaling() {
+	s.initReconciler(config.NewDefaultModelDeploymentConfig())
+
+	target := int32(100)
+	panicWindow := int32(20)
+	md := newValidDeployment()
+	md.Spec.Autoscaling = &odahuflowv1alpha1.Autoscaling{
+		Class:                  odahuflowv1alpha1.KPAAutoscalingClass,
+		Metric:                 odahuflowv1alpha1.RPSAutoscalingMetric,
+		Target:                 &target,
+		StableWindow:           &metav1.Duration{Duration: 2 * time.Minute},
+		PanicWindowPercentage:  &panicWindow,
+		ScaleToZeroGracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+		ScaleDownDelay:         &metav1.Duration{Duration: 5 * time.Minute},
+	}
+
+	cleanF := s.createDeployment(md)
+	defer cleanF()
+	knativeService := s.getKnativeService(md)
+
+	podAnnonations := knativeService.Spec.Template.ObjectMeta.Annotations
+	s.Assertions.Equal(DefaultKnativeAutoscalingClass, podAnnonations[KnativeAutoscalingClass])
+	s.Assertions.Equal("rps", podAnnonations[KnativeAutoscalingMetric])
+	s.Assertions.Equal("100", podAnnonations[KnativeAutoscalingTargetKey])
+	s.Assertions.Equal("2m0s", podAnnonations[KnativeWindowKey])
+	s.Assertions.Equal("20", podAnnonations[KnativePanicWindowPercentageKey])
+	s.Assertions.Equal("30s", podAnnonations[KnativeScaleToZeroRetentionKey])
+	s.Assertions.Equal("5m0s", podAnnonations[KnativeScaleDownDelayKey])
+}
+
+func (s *ModelDeploymentControllerSuite) TestReconcileEnvsAndVolumes() {
+	s.initReconciler(config.NewDefaultModelDeploymentConfig())
+
+	md := newValidDeployment()
+	md.Spec.Envs = []odahuflowv1alpha1.DeploymentEnvironmentVariable{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "TOKEN", ValueFrom: &odahuflowv1alpha1.EnvironmentVariableSource{
+			SecretKeyRef: &odahuflowv1alpha1.KeySelector{Name: "model-secrets", Key: "token"},
+		}},
+	}
+	md.Spec.Volumes = []odahuflowv1alpha1.DeploymentVolume{
+		{Name: "config", MountPath: "/etc/model", ConfigMap: "model-config"},
+	}
+
+	cleanF := s.createDeployment(md)
+	defer cleanF()
+	knativeService := s.getKnativeService(md)
+
+	podSpec := knativeService.Spec.Template.Spec
+	s.Assertions.NotContains(knativeService.Spec.Template.ObjectMeta.Annotations, AppliedEnvSecretHashKey)
+	s.Assertions.Equal([]v1.Volume{{
+		Name: "config",
+		VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+			LocalObjectReference: v1.LocalObjectReference{Name: "model-config"},
+		}},
+	}}, podSpec.Volumes)
+
+	containerSpec := podSpec.Containers[0]
+	s.Assertions.Equal([]v1.VolumeMount{{Name: "config", MountPath: "/etc/model", ReadOnly: true}},
+		containerSpec.VolumeMounts)
+	s.Assertions.Equal([]v1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "TOKEN", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "model-secrets"},
+			Key:                  "token",
+		}}},
+	}, containerSpec.Env)
+}
+
 // Node pool provided in packaging request, use it for knative configuration
 func (s *ModelDeploymentControllerSuite) TestDeploymentReconcile_NodePoolProvided() {
 	deploymentConfig := config.NewDefaultModelDeploymentConfig()
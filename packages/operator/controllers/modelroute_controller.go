@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/networking/pkg/apis/networking"
 	"reflect"
+	"regexp"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -53,6 +54,7 @@ const (
 	defaultListOfRetryCauses = "5xx,connect-failure,refused-stream"
 	routeForLabelPrefix      = "odahu-route-for-"
 	ModelRouteVersionKey     = "modelRouteVersion"
+	cookieHeader             = "cookie"
 )
 
 var (
@@ -85,11 +87,14 @@ func VirtualServiceName(mr *odahuflowv1alpha1.ModelRoute) string {
 	return mr.Name
 }
 
-func (r *ModelRouteReconciler) reconcileVirtualService(modelRouteCR *odahuflowv1alpha1.ModelRoute) (bool, error) {
-	httpTargets := make([]*v1alpha3_istio.HTTPRouteDestination, 0, len(modelRouteCR.Spec.ModelDeploymentTargets))
-	reconcileAgain := false
+// httpDestinations returns destinations of the ready targets. ready is false if any of the existing targets
+// is not ready yet. reconcileAgain is true if some targets are not found
+func (r *ModelRouteReconciler) httpDestinations(
+	modelRouteCR *odahuflowv1alpha1.ModelRoute, targets []odahuflowv1alpha1.ModelDeploymentTarget,
+) (httpTargets []*v1alpha3_istio.HTTPRouteDestination, reconcileAgain bool, ready bool, err error) {
+	httpTargets = make([]*v1alpha3_istio.HTTPRouteDestination, 0, len(targets))
 
-	for _, md := range modelRouteCR.Spec.ModelDeploymentTargets {
+	for _, md := range targets {
 		modelDeployment := &odahuflowv1alpha1.ModelDeployment{}
 		if err := r.Get(context.TODO(), types.NamespacedName{
 			Name: md.Name, Namespace: modelRouteCR.Namespace,
@@ -109,13 +114,13 @@ func (r *ModelRouteReconciler) reconcileVirtualService(modelRouteCR *odahuflowv1
 				"Model Route Name", modelRouteCR.Name,
 			)
 
-			return reconcileAgain, err
+			return nil, reconcileAgain, false, err
 		}
 
 		if modelDeployment.Status.State != odahuflowv1alpha1.ModelDeploymentStateReady {
 			log.Info("Model Deployment is not ready, re-schedule...", "Model Deployment Name", md.Name,
 				"Model Route Name", modelRouteCR.Name)
-			return true, nil
+			return nil, true, false, nil
 		}
 
 		requestHeaders := &v1alpha3_istio.Headers_HeaderOperations{
@@ -142,11 +147,146 @@ func (r *ModelRouteReconciler) reconcileVirtualService(modelRouteCR *odahuflowv1
 		})
 	}
 
+	return httpTargets, reconcileAgain, true, nil
+}
+
+// httpMatch converts conditions of the rule to the Istio match request. Cookies are matched
+// by a single regex on the cookie header
+func httpMatch(uriPrefix string, match []odahuflowv1alpha1.RouteMatch) *v1alpha3_istio.HTTPMatchRequest {
+	matchRequest := &v1alpha3_istio.HTTPMatchRequest{
+		Uri: &v1alpha3_istio.StringMatch{
+			MatchType: &v1alpha3_istio.StringMatch_Prefix{Prefix: uriPrefix},
+		},
+	}
+
+	var cookies []odahuflowv1alpha1.RouteMatch
+	for _, m := range match {
+		switch m.Type {
+		case odahuflowv1alpha1.HeaderRouteMatch:
+			if matchRequest.Headers == nil {
+				matchRequest.Headers = map[string]*v1alpha3_istio.StringMatch{}
+			}
+			matchRequest.Headers[strings.ToLower(m.Name)] = stringMatch(m)
+		case odahuflowv1alpha1.QueryParamRouteMatch:
+			if matchRequest.QueryParams == nil {
+				matchRequest.QueryParams = map[string]*v1alpha3_istio.StringMatch{}
+			}
+			matchRequest.QueryParams[m.Name] = stringMatch(m)
+		case odahuflowv1alpha1.CookieRouteMatch:
+			cookies = append(cookies, m)
+		}
+	}
+
+	if len(cookies) > 0 {
+		if matchRequest.Headers == nil {
+			matchRequest.Headers = map[string]*v1alpha3_istio.StringMatch{}
+		}
+		matchRequest.Headers[cookieHeader] = &v1alpha3_istio.StringMatch{
+			MatchType: &v1alpha3_istio.StringMatch_Regex{Regex: cookiesRegex(cookies)},
+		}
+	}
+
+	return matchRequest
+}
+
+func stringMatch(m odahuflowv1alpha1.RouteMatch) *v1alpha3_istio.StringMatch {
+	switch {
+	case len(m.Prefix) > 0:
+		return &v1alpha3_istio.StringMatch{MatchType: &v1alpha3_istio.StringMatch_Prefix{Prefix: m.Prefix}}
+	case len(m.Regex) > 0:
+		return &v1alpha3_istio.StringMatch{MatchType: &v1alpha3_istio.StringMatch_Regex{Regex: m.Regex}}
+	default:
+		return &v1alpha3_istio.StringMatch{MatchType: &v1alpha3_istio.StringMatch_Exact{Exact: m.Exact}}
+	}
+}
+
+// cookiesRegex matches the whole cookie header which contains all cookies with the expected values.
+// Cookies can be sent in any order and RE2 has no lookaheads, so every order is a separate alternative.
+// The number of cookies in a rule is limited by the API validation
+func cookiesRegex(cookies []odahuflowv1alpha1.RouteMatch) string {
+	var alternatives []string
+	for _, order := range permutations(len(cookies)) {
+		pairs := make([]string, 0, len(order))
+		for _, i := range order {
+			pairs = append(pairs, cookiePairRegex(cookies[i]))
+		}
+		alternatives = append(alternatives, `(.*?;\s*)?`+strings.Join(pairs, `;\s*(.*?;\s*)?`)+`(;.*)?`)
+	}
+
+	if len(alternatives) == 1 {
+		return "^" + alternatives[0] + "$"
+	}
+	return "^(?:" + strings.Join(alternatives, "|") + ")$"
+}
+
+func cookiePairRegex(m odahuflowv1alpha1.RouteMatch) string {
+	value := regexp.QuoteMeta(m.Exact)
+	switch {
+	case len(m.Prefix) > 0:
+		value = regexp.QuoteMeta(m.Prefix) + "[^;]*"
+	case len(m.Regex) > 0:
+		value = "(?:" + m.Regex + ")"
+	}
+	return regexp.QuoteMeta(m.Name) + "=" + value
+}
+
+// permutations returns all orders of indexes from 0 to n-1
+func permutations(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+	var res [][]int
+	for _, order := range permutations(n - 1) {
+		for pos := 0; pos <= len(order); pos++ {
+			perm := make([]int, 0, n)
+			perm = append(perm, order[:pos]...)
+			perm = append(perm, n-1)
+			perm = append(perm, order[pos:]...)
+			res = append(res, perm)
+		}
+	}
+	return res
+}
+
+func (r *ModelRouteReconciler) reconcileVirtualService(modelRouteCR *odahuflowv1alpha1.ModelRoute) (bool, error) {
+	httpTargets, reconcileAgain, ready, err := r.httpDestinations(modelRouteCR, modelRouteCR.Spec.ModelDeploymentTargets)
+	if err != nil || !ready {
+		return reconcileAgain, err
+	}
+
 	if len(httpTargets) == 0 {
 		log.Info("Number of http targets is zero", "Model Route Name", modelRouteCR.Name)
 		return reconcileAgain, nil
 	}
 
+	uriPrefix := modelRouteCR.Spec.URLPrefix + "/"
+	// Istio evaluates routes in order, so rules go before the default weighted route.
+	// Rules do not overlap, so their order does not matter
+	httpRoutes := make([]*v1alpha3_istio.HTTPRoute, 0, len(modelRouteCR.Spec.Rules)+1)
+	for _, rule := range modelRouteCR.Spec.Rules {
+		ruleTargets, ruleReconcileAgain, ruleReady, err := r.httpDestinations(modelRouteCR, rule.ModelDeploymentTargets)
+		if err != nil || !ruleReady {
+			return ruleReconcileAgain, err
+		}
+		reconcileAgain = reconcileAgain || ruleReconcileAgain
+
+		if len(ruleTargets) == 0 {
+			log.Info("Number of http targets of the rule is zero, the rule is skipped",
+				"Model Route Name", modelRouteCR.Name, "Rule", rule.Name)
+			continue
+		}
+
+		httpRoutes = append(httpRoutes, &v1alpha3_istio.HTTPRoute{
+			Name:  rule.Name,
+			Match: []*v1alpha3_istio.HTTPMatchRequest{httpMatch(uriPrefix, rule.Match)},
+			Route: ruleTargets,
+		})
+	}
+	httpRoutes = append(httpRoutes, &v1alpha3_istio.HTTPRoute{
+		Match: []*v1alpha3_istio.HTTPMatchRequest{httpMatch(uriPrefix, nil)},
+		Route: httpTargets,
+	})
+
 	var mirror *v1alpha3_istio.Destination
 	if modelRouteCR.Spec.Mirror != nil && len(*modelRouteCR.Spec.Mirror) != 0 {
 		modelDeployment := &odahuflowv1alpha1.ModelDeployment{}
@@ -175,6 +315,18 @@ func (r *ModelRouteReconciler) reconcileVirtualService(modelRouteCR *odahuflowv1
 		}
 	}
 
+	for _, httpRoute := range httpRoutes {
+		httpRoute.Retries = &v1alpha3_istio.HTTPRetry{
+			Attempts:      defaultRetryAttempts,
+			PerTryTimeout: defaultTimeoutPerTry,
+			RetryOn:       defaultListOfRetryCauses,
+		}
+		httpRoute.Rewrite = &v1alpha3_istio.HTTPRewrite{
+			Uri: "/",
+		}
+		httpRoute.Mirror = mirror
+	}
+
 	vservice := &v1alpha3_istio_api.VirtualService{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      VirtualServiceName(modelRouteCR),
@@ -187,27 +339,7 @@ func (r *ModelRouteReconciler) reconcileVirtualService(modelRouteCR *odahuflowv1
 			VirtualService: v1alpha3_istio.VirtualService{
 				Hosts:    []string{"*"},
 				Gateways: []string{fmt.Sprintf("knative-serving/%s", networking.KnativeIngressGateway)},
-				Http: []*v1alpha3_istio.HTTPRoute{
-					{
-						Retries: &v1alpha3_istio.HTTPRetry{
-							Attempts:      defaultRetryAttempts,
-							PerTryTimeout: defaultTimeoutPerTry,
-							RetryOn:       defaultListOfRetryCauses,
-						},
-						Match: []*v1alpha3_istio.HTTPMatchRequest{{
-							Uri: &v1alpha3_istio.StringMatch{
-								MatchType: &v1alpha3_istio.StringMatch_Prefix{
-									Prefix: modelRouteCR.Spec.URLPrefix + "/",
-								},
-							},
-						}},
-						Rewrite: &v1alpha3_istio.HTTPRewrite{
-							Uri: "/",
-						},
-						Route:  httpTargets,
-						Mirror: mirror,
-					},
-				},
+				Http:     httpRoutes,
 			},
 		},
 	}
@@ -217,7 +349,7 @@ func (r *ModelRouteReconciler) reconcileVirtualService(modelRouteCR *odahuflowv1
 	}
 
 	found := &v1alpha3_istio_api.VirtualService{}
-	err := r.Get(context.TODO(), types.NamespacedName{
+	err = r.Get(context.TODO(), types.NamespacedName{
 		Name: vservice.Name, Namespace: vservice.Namespace,
 	}, found)
 	if err != nil && errors.IsNotFound(err) {
@@ -320,8 +452,17 @@ func (r *ModelRouteReconciler) reconcileRouteForLabels(route *odahuflowv1alpha1.
 	}
 
 	var newRouteForLabels []string //nolint
+	targetNames := map[string]bool{}
 	for _, md := range route.Spec.ModelDeploymentTargets {
-		newRouteForLabels = append(newRouteForLabels, routeForLabelKey(md.Name))
+		targetNames[md.Name] = true
+	}
+	for _, rule := range route.Spec.Rules {
+		for _, md := range rule.ModelDeploymentTargets {
+			targetNames[md.Name] = true
+		}
+	}
+	for name := range targetNames {
+		newRouteForLabels = append(newRouteForLabels, routeForLabelKey(name))
 	}
 	sort.Strings(oldRouteForLabels)
 	sort.Strings(newRouteForLabels)
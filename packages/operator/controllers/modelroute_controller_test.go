@@ -25,6 +25,7 @@ import (
 	"golang.org/x/net/context"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"regexp"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -316,3 +317,135 @@ func TestMultipleTargets(t *testing.T) {
 		g.Expect(host.Route[1].Headers.Request.Set["Host"]).To(Equal(md2.Status.HostHeader))
 	}
 }
+
+func TestRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+	stopMgr, mgrStopped, requests := setUp(g)
+	defer teardown(stopMgr, mgrStopped)
+
+	weight := int32(100)
+	mr := &odahuflowv1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mrName,
+			Namespace: testNamespace,
+		},
+		Spec: odahuflowv1alpha1.ModelRouteSpec{
+			URLPrefix: mrURL,
+			ModelDeploymentTargets: []odahuflowv1alpha1.ModelDeploymentTarget{
+				{
+					Name:   md1.ObjectMeta.Name,
+					Weight: &weight,
+				},
+			},
+			Rules: []odahuflowv1alpha1.RouteRule{
+				{
+					Name: "testers",
+					Match: []odahuflowv1alpha1.RouteMatch{
+						{Type: odahuflowv1alpha1.HeaderRouteMatch, Name: "X-Tester", Exact: "true"},
+						{Type: odahuflowv1alpha1.CookieRouteMatch, Name: "group", Prefix: "beta"},
+						{Type: odahuflowv1alpha1.QueryParamRouteMatch, Name: "shadow", Regex: "yes|true"},
+					},
+					ModelDeploymentTargets: []odahuflowv1alpha1.ModelDeploymentTarget{
+						{
+							Name:   md2.ObjectMeta.Name,
+							Weight: &weight,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := c.Create(context.TODO(), mr)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer c.Delete(context.TODO(), mr)
+
+	g.Eventually(requests, timeout).Should(Receive(Equal(routeExpectedRequest)))
+	g.Eventually(requests, timeout).Should(Receive(Equal(routeExpectedRequest)))
+
+	g.Expect(c.Get(context.TODO(), mrKey, mr)).ToNot(HaveOccurred())
+	g.Expect(mr.Status.State).To(Equal(odahuflowv1alpha1.ModelRouteStateReady))
+
+	vs := &v1alpha3_istio_api.VirtualService{}
+	vsKey := types.NamespacedName{Name: VirtualServiceName(mr), Namespace: testNamespace}
+	g.Expect(c.Get(context.TODO(), vsKey, vs)).ToNot(HaveOccurred())
+
+	g.Expect(vs.Spec.Http).To(HaveLen(2))
+
+	rule := vs.Spec.Http[0]
+	g.Expect(rule.Name).To(Equal("testers"))
+	g.Expect(rule.Retries).ToNot(BeNil())
+	g.Expect(rule.Match).To(HaveLen(1))
+	g.Expect(rule.Match[0].Uri.GetPrefix()).To(Equal(mrURL + "/"))
+	g.Expect(rule.Match[0].Headers["x-tester"].GetExact()).To(Equal("true"))
+	g.Expect(rule.Match[0].Headers["cookie"].GetRegex()).To(Equal(`^(.*?;\s*)?group=beta[^;]*(;.*)?$`))
+	g.Expect(rule.Match[0].QueryParams["shadow"].GetRegex()).To(Equal("yes|true"))
+	g.Expect(rule.Route).To(HaveLen(1))
+	g.Expect(rule.Route[0].Headers.Request.Set["Host"]).To(Equal(md2.Status.HostHeader))
+
+	defaultRoute := vs.Spec.Http[1]
+	g.Expect(defaultRoute.Match[0].Headers).To(BeEmpty())
+	g.Expect(defaultRoute.Route).To(HaveLen(1))
+	g.Expect(defaultRoute.Route[0].Headers.Request.Set["Host"]).To(Equal(md1.Status.HostHeader))
+}
+
+func TestRuleWithSeveralCookies(t *testing.T) {
+	g := NewGomegaWithT(t)
+	stopMgr, mgrStopped, requests := setUp(g)
+	defer teardown(stopMgr, mgrStopped)
+
+	weight := int32(100)
+	mr := &odahuflowv1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mrName,
+			Namespace: testNamespace,
+		},
+		Spec: odahuflowv1alpha1.ModelRouteSpec{
+			URLPrefix: mrURL,
+			ModelDeploymentTargets: []odahuflowv1alpha1.ModelDeploymentTarget{
+				{
+					Name:   md1.ObjectMeta.Name,
+					Weight: &weight,
+				},
+			},
+			Rules: []odahuflowv1alpha1.RouteRule{
+				{
+					Name: "beta-testers",
+					Match: []odahuflowv1alpha1.RouteMatch{
+						{Type: odahuflowv1alpha1.CookieRouteMatch, Name: "group", Prefix: "beta"},
+						{Type: odahuflowv1alpha1.CookieRouteMatch, Name: "tester", Exact: "yes"},
+					},
+					ModelDeploymentTargets: []odahuflowv1alpha1.ModelDeploymentTarget{
+						{
+							Name:   md2.ObjectMeta.Name,
+							Weight: &weight,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := c.Create(context.TODO(), mr)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer c.Delete(context.TODO(), mr)
+
+	g.Eventually(requests, timeout).Should(Receive(Equal(routeExpectedRequest)))
+	g.Eventually(requests, timeout).Should(Receive(Equal(routeExpectedRequest)))
+
+	vs := &v1alpha3_istio_api.VirtualService{}
+	vsKey := types.NamespacedName{Name: VirtualServiceName(mr), Namespace: testNamespace}
+	g.Expect(c.Get(context.TODO(), vsKey, vs)).ToNot(HaveOccurred())
+
+	g.Expect(vs.Spec.Http).To(HaveLen(2))
+	g.Expect(vs.Spec.Http[0].Match).To(HaveLen(1))
+
+	// Both cookies are checked by the only matcher of the cookie header
+	cookieRegex := regexp.MustCompile(vs.Spec.Http[0].Match[0].Headers["cookie"].GetRegex())
+	g.Expect(cookieRegex.MatchString("group=beta1; tester=yes")).To(BeTrue())
+	g.Expect(cookieRegex.MatchString("a=b; tester=yes; c=d; group=beta2")).To(BeTrue())
+	g.Expect(cookieRegex.MatchString("group=beta1")).To(BeFalse())
+	g.Expect(cookieRegex.MatchString("tester=yes")).To(BeFalse())
+	g.Expect(cookieRegex.MatchString("group=alpha; tester=yes")).To(BeFalse())
+	g.Expect(cookieRegex.MatchString("group=beta; tester=no")).To(BeFalse())
+}
@@ -13,6 +13,7 @@ require (
 	github.com/banzaicloud/bank-vaults/pkg/sdk v0.3.1
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/emicklei/go-restful v2.9.5+incompatible
+	github.com/evanphx/json-patch v4.5.0+incompatible
 	github.com/fluent/fluent-logger-golang v1.4.0
 	github.com/gin-gonic/gin v1.6.2
 	github.com/go-logr/logr v0.1.0
@@ -36,6 +37,7 @@ require (
 	github.com/prometheus/common v0.10.0
 	github.com/rakyll/statik v0.1.6
 	github.com/rclone/rclone v1.53.0
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/satori/go.uuid v1.2.0
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/viper v1.7.0
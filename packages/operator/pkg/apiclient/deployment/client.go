@@ -82,3 +82,42 @@ func (c *apiClient) GetModelDeployment(id string) (*deployment.ModelDeployment,
 
 	return md, nil
 }
+
+func (c *apiClient) GetPredictor(id string) (*deployment.Predictor, error) {
+	pLogger := log.WithValues("predictor_id", id)
+
+	response, err := c.DoRequest(
+		http.MethodGet,
+		"/predictor/"+id,
+		nil,
+	)
+	if err != nil {
+		pLogger.Error(err, "Retrieving of the predictor from API failed")
+		return nil, err
+	}
+
+	p := &deployment.Predictor{}
+	pBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		pLogger.Error(err, "Read all data from API response")
+		return nil, err
+	}
+	defer func() {
+		bodyCloseError := response.Body.Close()
+		if bodyCloseError != nil {
+			pLogger.Error(err, "Closing predictor response body")
+		}
+	}()
+
+	if response.StatusCode != 200 {
+		return nil, fmt.Errorf("error occures: %s", string(pBytes))
+	}
+
+	err = json.Unmarshal(pBytes, p)
+	if err != nil {
+		pLogger.Error(err, "Unmarshal the predictor")
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -38,6 +38,13 @@ var (
 			Predictor: predictors.OdahuMLServer.ID,
 		},
 	}
+	predictor = apis.Predictor{
+		ID: "torchserve",
+		Spec: apis.PredictorSpec{
+			InferenceEndpointRegex: ".*/predictions/.*",
+			Inspector:              apis.PredictorInspector{Type: predictors.OpenAPIInspector, OpenAPIPath: "/"},
+		},
+	}
 )
 
 type mdSuite struct {
@@ -66,6 +73,19 @@ func (s *mdSuite) SetupSuite() {
 				// Must not be occurred
 				panic(err)
 			}
+		case "/api/v1/predictor/torchserve":
+			w.WriteHeader(http.StatusOK)
+			pBytes, err := json.Marshal(predictor)
+			if err != nil {
+				// Must not be occurred
+				panic(err)
+			}
+
+			_, err = w.Write(pBytes)
+			if err != nil {
+				// Must not be occurred
+				panic(err)
+			}
 		// Mock endpoint that returns some HTML response (e.g. simulate Nginx error)
 		case "/api/v1/model/deployment/get-html-response":
 			w.WriteHeader(http.StatusOK)
@@ -132,3 +152,18 @@ func (s *mdSuite) TestGetDeployment_NoResponse() {
 	s.Assertions.Error(err)
 	s.Assertions.Contains(err.Error(), "EOF")
 }
+
+func (s *mdSuite) TestGetPredictor() {
+	pFromClient, err := s.client.GetPredictor(predictor.ID)
+
+	s.Assertions.NoError(err)
+	s.Assertions.Equal(predictor, *pFromClient)
+}
+
+func (s *mdSuite) TestGetPredictor_NotFound() {
+	pFromClient, err := s.client.GetPredictor("nonexistent-predictor")
+
+	s.Assertions.Nil(pFromClient)
+	s.Assertions.Error(err)
+	s.Assertions.Contains(err.Error(), "not found")
+}
@@ -20,4 +20,5 @@ import "github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
 
 type Client interface {
 	GetModelDeployment(id string) (*deployment.ModelDeployment, error)
+	GetPredictor(id string) (*deployment.Predictor, error)
 }
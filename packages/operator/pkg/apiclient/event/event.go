@@ -1,57 +1,207 @@
 package event
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
 	"go.uber.org/zap"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultStreamIdleTimeout = time.Minute
+	maxStreamMessageSize     = 16 * 1024 * 1024
+	eventsStreamMessage      = "events"
+	errorStreamMessage       = "error"
 )
 
 type httpClient interface {
 	Do(req *http.Request) (*http.Response, error)
+	DoStream(req *http.Request) (*http.Response, error)
 }
 
 type ModelRouteEventClient struct {
 	HTTPClient httpClient
 	Log        *zap.SugaredLogger
+	// Event stream is considered broken if nothing, including keep-alive comments, is received
+	// during this time. Default: 1 minute
+	StreamIdleTimeout time.Duration
+}
+
+// streamError is an error of an event stream. It is temporary, because the stream can be resumed
+type streamError struct {
+	error
+}
+
+func (e streamError) Temporary() bool {
+	return true
 }
 
 
+// GetLastEvents returns ModelRoute events after the cursor.
+// CursorCompactedError is returned if the events after the cursor were compacted, use GetSnapshot to resume
 func (m ModelRouteEventClient) GetLastEvents(cursor int) (events event.LatestRouteEvents, err error) {
-	
+	q := url.Values{}
+	q.Add("cursor", strconv.Itoa(cursor))
+
+	err = m.getEvents(q, &events)
+	if odahu_errors.IsCursorCompactedError(err) {
+		return events, odahu_errors.CursorCompactedError{Cursor: cursor}
+	}
+	return events, err
+}
+
+// GetSnapshot returns the last event of each ModelRoute and the cursor to resume from
+func (m ModelRouteEventClient) GetSnapshot() (events event.LatestRouteEvents, err error) {
+	q := url.Values{}
+	q.Add("snapshot", "true")
 
-	var buf []byte
+	err = m.getEvents(q, &events)
+	return events, err
+}
+
+func (m ModelRouteEventClient) getEvents(q url.Values, events *event.LatestRouteEvents) error {
 	req := &http.Request{
 		Method: http.MethodGet,
-		URL:    &url.URL{
+		URL: &url.URL{
 			Path:     "/model/route-events",
+			RawQuery: q.Encode(),
 		},
 	}
-	q := req.URL.Query()
-	q.Add("cursor", strconv.Itoa(cursor))
-	req.URL.RawQuery = q.Encode()
 	response, err := m.HTTPClient.Do(req)
 	if err != nil {
-		return events, err
+		return err
 	}
 	defer response.Body.Close()
-	buf, err = ioutil.ReadAll(response.Body)
+	buf, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return events, err
+		return err
+	}
+
+	if response.StatusCode == http.StatusGone {
+		return odahu_errors.CursorCompactedError{}
 	}
 
-	err = json.Unmarshal(buf, &events)
+	err = json.Unmarshal(buf, events)
 	if err != nil {
 		m.Log.Error("Unable to unmarshall ModelRoute events", zap.Error(err))
 	}
 
-	return events, err
-
+	return err
 }
 
+// StreamEvents calls handle for each batch of ModelRoute events after the cursor pushed by the server.
+// It blocks until ctx is done, the server closes the stream or handle returns an error.
+// CursorCompactedError is returned if the events after the cursor were compacted, use GetSnapshot to resume.
+// Errors of an established stream are temporary, the stream can be resumed from the last handled cursor
+func (m ModelRouteEventClient) StreamEvents(
+	ctx context.Context, cursor int, handle func(events event.LatestRouteEvents) error) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
+	q := url.Values{}
+	q.Add("cursor", strconv.Itoa(cursor))
+	req := (&http.Request{
+		Method: http.MethodGet,
+		URL: &url.URL{
+			Path:     "/model/route-events/stream",
+			RawQuery: q.Encode(),
+		},
+		Header: http.Header{"Accept": []string{"text/event-stream"}},
+	}).WithContext(streamCtx)
 
+	response, err := m.HTTPClient.DoStream(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return m.streamStatusError(response, cursor)
+	}
+
+	idleTimeout := m.StreamIdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = defaultStreamIdleTimeout
+	}
+	// Server sends keep-alive comments, so silence means that the connection is broken
+	idle := time.AfterFunc(idleTimeout, cancel)
+	defer idle.Stop()
+
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxStreamMessageSize)
+	var messageType, data string
+	for scanner.Scan() {
+		idle.Reset(idleTimeout)
+
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// Empty line completes a message
+			if err := m.handleStreamMessage(messageType, data, handle); err != nil {
+				return err
+			}
+			messageType, data = "", ""
+		case strings.HasPrefix(line, "event:"):
+			messageType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+		// "id" field repeats the cursor of the batch and comments are keep-alives, so they are skipped
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if streamCtx.Err() != nil {
+		return streamError{fmt.Errorf("no data was received from the event stream for %s", idleTimeout)}
+	}
+	if err := scanner.Err(); err != nil {
+		return streamError{err}
+	}
+	return nil
+}
 
+func (m ModelRouteEventClient) handleStreamMessage(
+	messageType string, data string, handle func(events event.LatestRouteEvents) error) error {
+	switch messageType {
+	case eventsStreamMessage:
+		var events event.LatestRouteEvents
+		if err := json.Unmarshal([]byte(data), &events); err != nil {
+			m.Log.Error("Unable to unmarshall ModelRoute events", zap.Error(err))
+			return err
+		}
+		return handle(events)
+	case errorStreamMessage:
+		var result httputil.HTTPResult
+		if err := json.Unmarshal([]byte(data), &result); err != nil {
+			return err
+		}
+		return streamError{errors.New(result.Message)}
+	}
+	return nil
+}
+
+func (m ModelRouteEventClient) streamStatusError(response *http.Response, cursor int) error {
+	if response.StatusCode == http.StatusGone {
+		return odahu_errors.CursorCompactedError{Cursor: cursor}
+	}
+
+	buf, _ := ioutil.ReadAll(response.Body)
+	err := fmt.Errorf("unable to open ModelRoute event stream. Status code: %d. Response body: %s",
+		response.StatusCode, buf)
+	if response.StatusCode >= http.StatusInternalServerError {
+		return streamError{err}
+	}
+	return err
+}
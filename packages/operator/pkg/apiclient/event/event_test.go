@@ -0,0 +1,76 @@
+package event_test
+
+import (
+	"context"
+	"fmt"
+	api_event "github.com/odahu/odahu-flow/packages/operator/pkg/apiclient/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// serverClient sends requests with relative URLs to the test server
+type serverClient struct {
+	serverURL *url.URL
+}
+
+func (c serverClient) Do(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = c.serverURL.Scheme
+	req.URL.Host = c.serverURL.Host
+	return http.DefaultClient.Do(req)
+}
+
+func (c serverClient) DoStream(req *http.Request) (*http.Response, error) {
+	return c.Do(req)
+}
+
+func newClient(server *httptest.Server) api_event.ModelRouteEventClient {
+	serverURL, _ := url.Parse(server.URL)
+	return api_event.ModelRouteEventClient{
+		HTTPClient: serverClient{serverURL: serverURL},
+		Log:        zap.NewNop().Sugar(),
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/model/route-events/stream", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("cursor"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "id: 3\nevent: events\ndata: {\"events\":[{\"entityID\":\"mr\"}],\"cursor\":3}\n\n")
+		_, _ = fmt.Fprint(w, ": keep-alive\n\n")
+		_, _ = fmt.Fprint(w, "event: error\ndata: {\"message\":\"database is unavailable\"}\n\n")
+	}))
+	defer server.Close()
+
+	var batches []event.LatestRouteEvents
+	err := newClient(server).StreamEvents(context.Background(), 2, func(events event.LatestRouteEvents) error {
+		batches = append(batches, events)
+		return nil
+	})
+
+	assert.EqualError(t, err, "database is unavailable")
+	assert.True(t, err.(interface{ Temporary() bool }).Temporary())
+	assert.Len(t, batches, 1)
+	assert.Equal(t, 3, batches[0].Cursor)
+	assert.Len(t, batches[0].Events, 1)
+}
+
+func TestStreamEventsCompactedCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	err := newClient(server).StreamEvents(context.Background(), 2, func(event.LatestRouteEvents) error {
+		return nil
+	})
+
+	assert.True(t, odahu_errors.IsCursorCompactedError(err))
+}
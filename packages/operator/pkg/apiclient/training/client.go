@@ -137,6 +137,42 @@ func (c *trainingAPIClient) SaveModelTrainingResult(
 	return nil
 }
 
+func (c *trainingAPIClient) SaveModelTrainingMetrics(
+	id string, metrics *training.ModelTrainingMetrics,
+) error {
+	mtLogger := wrapMtLogger(id)
+
+	response, err := c.DoRequest(
+		http.MethodPut,
+		strings.Replace("/model/training/:id/metrics", ":id", id, 1),
+		metrics,
+	)
+	if err != nil {
+		mtLogger.Error(err, "Saving of the model training metrics in API failed")
+
+		return err
+	}
+
+	mtBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		mtLogger.Error(err, "Read all data from API response")
+
+		return err
+	}
+	defer func() {
+		bodyCloseError := response.Body.Close()
+		if bodyCloseError != nil {
+			mtLogger.Error(err, "Closing model training metrics response body")
+		}
+	}()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("error occures: %s", string(mtBytes))
+	}
+
+	return nil
+}
+
 func (c *trainingAPIClient) GetToolchainIntegration(id string) (ti *training.ToolchainIntegration, err error) {
 	tiLogger := wrapMtLogger(id)
 
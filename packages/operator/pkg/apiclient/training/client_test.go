@@ -92,6 +92,18 @@ func (s *mtSuite) SetupSuite() {
 				// Must not be occurred
 				panic(err)
 			}
+		case "/api/v1/model/training/test-mt-id/metrics":
+			if r.Method != http.MethodPut {
+				NotFound(w, r)
+				return
+			}
+
+			metrics := &training.ModelTrainingMetrics{}
+			if err := json.NewDecoder(r.Body).Decode(metrics); err != nil || len(metrics.Metrics) == 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
 		case "/api/v1/toolchain/integration/test-ti-id":
 			if r.Method == http.MethodGet {
 				w.WriteHeader(http.StatusOK)
@@ -141,6 +153,19 @@ func (s *mtSuite) TestModelTrainingNotFound() {
 	s.g.Expect(err.Error()).Should(ContainSubstring("not found"))
 }
 
+func (s *mtSuite) TestModelTrainingMetricsSave() {
+	err := s.mtHTTPClient.SaveModelTrainingMetrics(mtID, &training.ModelTrainingMetrics{
+		Metrics: []training.MetricValue{{Key: "loss", Value: 0.3}},
+	})
+	s.g.Expect(err).ShouldNot(HaveOccurred())
+}
+
+func (s *mtSuite) TestModelTrainingMetricsNotFound() {
+	err := s.mtHTTPClient.SaveModelTrainingMetrics("mt-not-found", &training.ModelTrainingMetrics{})
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).Should(ContainSubstring("not found"))
+}
+
 func (s *mtSuite) TestToolchainIntegrationGet() {
 	tiResult, err := s.mtHTTPClient.GetToolchainIntegration(tiID)
 	s.g.Expect(err).ShouldNot(HaveOccurred())
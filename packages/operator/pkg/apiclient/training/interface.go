@@ -8,5 +8,6 @@ import (
 type Client interface {
 	GetModelTraining(id string) (*training.ModelTraining, error)
 	SaveModelTrainingResult(id string, result *v1alpha1.TrainingResult) error
+	SaveModelTrainingMetrics(id string, metrics *training.ModelTrainingMetrics) error
 	GetToolchainIntegration(name string) (*training.ToolchainIntegration, error)
 }
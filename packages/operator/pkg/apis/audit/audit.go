@@ -0,0 +1,92 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/user"
+	"time"
+)
+
+type Operation string
+type EntityKind string
+
+const (
+	CreateOperation Operation = "create"
+	UpdateOperation Operation = "update"
+	DeleteOperation Operation = "delete"
+)
+
+const (
+	ModelTrainingKind         EntityKind = "ModelTraining"
+	ModelTrainingScheduleKind EntityKind = "ModelTrainingSchedule"
+	ModelTrainingSweepKind    EntityKind = "ModelTrainingSweep"
+	ModelPackagingKind        EntityKind = "ModelPackaging"
+	ModelDeploymentKind       EntityKind = "ModelDeployment"
+	ModelRouteKind            EntityKind = "ModelRoute"
+	InferenceServiceKind      EntityKind = "InferenceService"
+	InferenceJobKind          EntityKind = "InferenceJob"
+	SubscriptionKind          EntityKind = "Subscription"
+	ModelPipelineKind         EntityKind = "ModelPipeline"
+	ModelVersionKind          EntityKind = "ModelVersion"
+	PredictorKind             EntityKind = "Predictor"
+)
+
+// This change is used for recording. oldSpec must be nil for create operation
+// and newSpec must be nil for delete operation
+type Change struct {
+	EntityKind EntityKind
+	EntityID   string
+	Operation  Operation
+	OldSpec    interface{}
+	NewSpec    interface{}
+}
+
+// Record describes a single change of an entity made by a user
+type Record struct {
+	// Record ID. Records are ordered by ID
+	ID int `json:"id"`
+	// Kind of the changed entity, for example ModelDeployment
+	EntityKind EntityKind `json:"entityKind"`
+	// ID of the changed entity
+	EntityID string `json:"entityId"`
+	// Possible values: create, update, delete
+	Operation Operation `json:"operation"`
+	// Author of the change
+	User user.UserInfo `json:"user"`
+	// When the change was made
+	Datetime time.Time `json:"datetime"`
+	// Spec of the entity before the change. Empty for create operation
+	OldSpec json.RawMessage `json:"oldSpec,omitempty" swaggertype:"object"`
+	// Spec of the entity after the change. Empty for delete operation
+	NewSpec json.RawMessage `json:"newSpec,omitempty" swaggertype:"object"`
+	// JSON merge patch (RFC 7386) which transforms the old spec into the new one
+	Diff json.RawMessage `json:"diff,omitempty" swaggertype:"object"`
+}
+
+const TagKey = "name"
+
+type RecordFilter struct {
+	EntityKind []string `name:"kind" postgres:"entity_kind"`
+	EntityID   []string `name:"entityId" postgres:"entity_id"`
+	Username   []string `name:"user" postgres:"username"`
+	// Lower bound of the record datetime in RFC 3339 format
+	From []string `name:"from" postgres:"-"`
+	// Upper bound of the record datetime in RFC 3339 format
+	To []string `name:"to" postgres:"-"`
+}
@@ -88,6 +88,21 @@ type InferenceJob struct {
 	Status InferenceJobStatus `json:"status,omitempty"`
 }
 
+func (in InferenceJob) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *InferenceJob) Scan(value interface{}) error {
+	switch b := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(b, &in)
+	default:
+		return errors.New("type assertion to []byte or nil is failed")
+	}
+}
+
 
 func (spec InferenceJobSpec) Value() (driver.Value, error) {
 	return json.Marshal(spec)
@@ -108,6 +108,21 @@ type InferenceService struct {
 	Status    InferenceServiceStatus `json:"status"`
 }
 
+func (in InferenceService) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *InferenceService) Scan(value interface{}) error {
+	switch b := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(b, &in)
+	default:
+		return errors.New("type assertion to []byte or nil is failed")
+	}
+}
+
 
 func (spec InferenceServiceSpec) Value() (driver.Value, error) {
 	return json.Marshal(spec)
@@ -23,6 +23,11 @@ import (
 	"time"
 )
 
+// Keys of the connection fields which can be referenced by environment variables of model deployments
+var FieldKeys = []string{
+	"uri", "region", "username", "password", "role", "keyID", "keySecret", "publicKey", "reference",
+}
+
 const (
 	S3Type            = v1alpha1.ConnectionType("s3")
 	GcsType           = v1alpha1.ConnectionType("gcs")
@@ -127,3 +132,28 @@ func (c *Connection) EncodeBase64Fields() {
 		c.Spec.PublicKey = base64.StdEncoding.EncodeToString([]byte(c.Spec.PublicKey))
 	}
 }
+
+// Field returns the value of the connection field by its JSON key
+func (c *Connection) Field(key string) (string, bool) {
+	switch key {
+	case "uri":
+		return c.Spec.URI, true
+	case "region":
+		return c.Spec.Region, true
+	case "username":
+		return c.Spec.Username, true
+	case "password":
+		return c.Spec.Password, true
+	case "role":
+		return c.Spec.Role, true
+	case "keyID":
+		return c.Spec.KeyID, true
+	case "keySecret":
+		return c.Spec.KeySecret, true
+	case "publicKey":
+		return c.Spec.PublicKey, true
+	case "reference":
+		return c.Spec.Reference, true
+	}
+	return "", false
+}
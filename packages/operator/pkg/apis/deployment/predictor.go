@@ -0,0 +1,123 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package deployment
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	corev1 "k8s.io/api/core/v1"
+	"odahu-commons/predictors"
+	"time"
+)
+
+type PredictorInspector struct {
+	// Type of the inspector which discovers metadata of deployed models.
+	// Possible values: odahu-ml-server, triton, openapi, kserve-v2
+	Type predictors.InspectorType `json:"type"`
+	// Path of the OpenAPI document relative to the model URL prefix, for example "/openapi.json".
+	// Required by the openapi inspector
+	OpenAPIPath string `json:"openAPIPath,omitempty"`
+	// Name of the model which is inspected by the kserve-v2 inspector.
+	// If empty, the first model of the /v2/repository/index endpoint is inspected
+	ModelName string `json:"modelName,omitempty"`
+}
+
+type PredictorSpec struct {
+	// Ports of the model server container. Knative supports exactly one port
+	Ports []corev1.ContainerPort `json:"ports"`
+	// Liveness probe of the model server container. Initial delay is taken from a model deployment
+	LivenessProbe corev1.Probe `json:"livenessProbe"`
+	// Readiness probe of the model server container. Initial delay is taken from a model deployment
+	ReadinessProbe corev1.Probe `json:"readinessProbe"`
+	// Regex of paths of inference requests. Matched requests are collected by the feedback aggregator
+	InferenceEndpointRegex string `json:"inferenceEndpointRegex"`
+	// OPA policy in the Rego language which authorizes requests to deployed models.
+	// It is a Go template, {{.Role}} is replaced by the role name of a model deployment
+	OpaPolicy string `json:"opaPolicy,omitempty"`
+	// Inspector of deployed models
+	Inspector PredictorInspector `json:"inspector"`
+}
+
+// Predictor describes a model server which can be used by model deployments
+type Predictor struct {
+	// Predictor ID
+	ID string `json:"id"`
+	// When resource was created. Managed by system. Cannot be overridden by User
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// When resource was updated. Managed by system. Cannot be overridden by User
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	// Built-in predictors are compiled into ODAHU and can not be changed. Managed by system
+	Builtin bool          `json:"builtin,omitempty"`
+	Spec    PredictorSpec `json:"spec"`
+}
+
+// NewBuiltinPredictor converts a predictor compiled into ODAHU to the API entity
+func NewBuiltinPredictor(p predictors.Predictor) Predictor {
+	return Predictor{
+		ID:      p.ID,
+		Builtin: true,
+		Spec: PredictorSpec{
+			Ports:                  p.Ports,
+			LivenessProbe:          p.LivenessProbe,
+			ReadinessProbe:         p.ReadinessProbe,
+			InferenceEndpointRegex: p.InferenceEndpointRegex,
+			OpaPolicy:              p.OpaPolicy,
+			Inspector: PredictorInspector{
+				Type:        p.Inspector.Type,
+				OpenAPIPath: p.Inspector.OpenAPIPath,
+				ModelName:   p.Inspector.ModelName,
+			},
+		},
+	}
+}
+
+// ToPredictor converts the API entity to the predictor which is used by the operator and the service catalog
+func (in Predictor) ToPredictor() predictors.Predictor {
+	if in.Builtin {
+		if p, ok := predictors.Predictors[in.ID]; ok {
+			return p
+		}
+	}
+
+	return predictors.Predictor{
+		ID:                     in.ID,
+		Ports:                  in.Spec.Ports,
+		LivenessProbe:          in.Spec.LivenessProbe,
+		ReadinessProbe:         in.Spec.ReadinessProbe,
+		OpaPolicy:              in.Spec.OpaPolicy,
+		InferenceEndpointRegex: in.Spec.InferenceEndpointRegex,
+		Inspector: predictors.Inspector{
+			Type:        in.Spec.Inspector.Type,
+			OpenAPIPath: in.Spec.Inspector.OpenAPIPath,
+			ModelName:   in.Spec.Inspector.ModelName,
+		},
+	}
+}
+
+func (in PredictorSpec) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *PredictorSpec) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &in)
+}
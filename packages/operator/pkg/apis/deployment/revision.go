@@ -0,0 +1,49 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"time"
+)
+
+// ModelDeploymentRevision is a snapshot of the model deployment spec.
+// A new revision is saved every time the spec is changed, including rollbacks
+type ModelDeploymentRevision struct {
+	// Model deployment ID
+	DeploymentID string `json:"deploymentId"`
+	// Revision number. Revisions of a deployment are numbered from 1
+	Revision int `json:"revision"`
+	// When the revision was saved
+	CreatedAt time.Time `json:"createdAt"`
+	// Model deployment specification of the revision
+	Spec v1alpha1.ModelDeploymentSpec `json:"spec"`
+}
+
+// ModelDeploymentRevisionDiff describes changes of the spec between two revisions
+type ModelDeploymentRevisionDiff struct {
+	// Model deployment ID
+	DeploymentID string `json:"deploymentId"`
+	// Revision which is compared
+	From int `json:"from"`
+	// Revision which it is compared with
+	To int `json:"to"`
+	// JSON merge patch (RFC 7386) which transforms the spec of the "from" revision into the spec of the "to" one
+	Diff json.RawMessage `json:"diff" swaggertype:"object"`
+}
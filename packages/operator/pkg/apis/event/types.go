@@ -1,7 +1,13 @@
 package event
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/batch"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
 	"time"
 )
 
@@ -32,6 +38,50 @@ const ModelDeploymentDeletionMarkIsSetEventType Type = "ModelDeploymentDeletionM
 
 const ModelDeploymentEventGroup Group = "ModelDeployment"
 
+const ModelTrainingCreatedEventType Type = "ModelTrainingCreated"
+
+const ModelTrainingDeletedEventType Type = "ModelTrainingDeleted"
+
+const ModelTrainingUpdatedEventType Type = "ModelTrainingUpdated"
+
+const ModelTrainingStatusUpdatedEventType Type = "ModelTrainingStatusUpdated"
+
+const ModelTrainingEventGroup Group = "ModelTraining"
+
+const ModelPackagingCreatedEventType Type = "ModelPackagingCreated"
+
+const ModelPackagingDeletedEventType Type = "ModelPackagingDeleted"
+
+const ModelPackagingUpdatedEventType Type = "ModelPackagingUpdated"
+
+const ModelPackagingStatusUpdatedEventType Type = "ModelPackagingStatusUpdated"
+
+const ModelPackagingEventGroup Group = "ModelPackaging"
+
+const InferenceJobCreatedEventType Type = "InferenceJobCreated"
+
+const InferenceJobDeletedEventType Type = "InferenceJobDeleted"
+
+const InferenceJobStatusUpdatedEventType Type = "InferenceJobStatusUpdated"
+
+const InferenceJobEventGroup Group = "InferenceJob"
+
+const InferenceServiceCreatedEventType Type = "InferenceServiceCreated"
+
+const InferenceServiceDeletedEventType Type = "InferenceServiceDeleted"
+
+const InferenceServiceUpdatedEventType Type = "InferenceServiceUpdated"
+
+const InferenceServiceEventGroup Group = "InferenceService"
+
+const ModelPipelineCreatedEventType Type = "ModelPipelineCreated"
+
+const ModelPipelineDeletedEventType Type = "ModelPipelineDeleted"
+
+const ModelPipelineStatusUpdatedEventType Type = "ModelPipelineStatusUpdated"
+
+const ModelPipelineEventGroup Group = "ModelPipeline"
+
 // This event is used for publishing
 type Event struct {
 	EntityID   string
@@ -41,6 +91,34 @@ type Event struct {
 	Payload    interface{}
 }
 
+// Record is an event of any group read from the event log. Payload is not parsed
+type Record struct {
+	// ID of the event in the event log. Events are ordered by ID
+	ID int `json:"id"`
+	// EntityID contains ID of the entity which the event is related to
+	EntityID string `json:"entityID"`
+	// Type of the event, for example ModelTrainingStatusUpdated
+	EventType Type `json:"type"`
+	// Group of the event, for example ModelTraining
+	EventGroup Group `json:"group"`
+	// When event is raised
+	Datetime time.Time `json:"datetime"`
+	// Payload contains the entity in the same format as the group-specific events endpoint returns
+	Payload json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+}
+
+func (r Record) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *Record) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &r)
+}
+
 type RouteEvent struct {
 	// EntityID contains ID of ModelRoute for ModelRouteDeleted and ModelRouteDeletionMarkIsSet
 	// event types
@@ -73,6 +151,58 @@ type DeploymentEvent struct {
 	Datetime time.Time `json:"datetime"`
 }
 
+type TrainingEvent struct {
+	// EntityID contains ID of ModelTraining
+	EntityID string `json:"entityID"`
+	// Payload contains ModelTraining for ModelTrainingCreated, ModelTrainingUpdated,
+	// ModelTrainingStatusUpdated events.
+	// Does not make sense in case of ModelTrainingDeleted event
+	Payload training.ModelTraining `json:"payload"`
+	// Possible values: ModelTrainingCreated, ModelTrainingUpdated, ModelTrainingStatusUpdated,
+	// ModelTrainingDeleted
+	EventType Type `json:"type"`
+	// When event is raised
+	Datetime time.Time `json:"datetime"`
+}
+
+type PackagingEvent struct {
+	// EntityID contains ID of ModelPackaging
+	EntityID string `json:"entityID"`
+	// Payload contains ModelPackaging for ModelPackagingCreated, ModelPackagingUpdated,
+	// ModelPackagingStatusUpdated events.
+	// Does not make sense in case of ModelPackagingDeleted event
+	Payload packaging.ModelPackaging `json:"payload"`
+	// Possible values: ModelPackagingCreated, ModelPackagingUpdated, ModelPackagingStatusUpdated,
+	// ModelPackagingDeleted
+	EventType Type `json:"type"`
+	// When event is raised
+	Datetime time.Time `json:"datetime"`
+}
+
+type InferenceJobEvent struct {
+	// EntityID contains ID of InferenceJob
+	EntityID string `json:"entityID"`
+	// Payload contains InferenceJob for InferenceJobCreated, InferenceJobStatusUpdated events.
+	// Does not make sense in case of InferenceJobDeleted event
+	Payload batch.InferenceJob `json:"payload"`
+	// Possible values: InferenceJobCreated, InferenceJobStatusUpdated, InferenceJobDeleted
+	EventType Type `json:"type"`
+	// When event is raised
+	Datetime time.Time `json:"datetime"`
+}
+
+type InferenceServiceEvent struct {
+	// EntityID contains ID of InferenceService
+	EntityID string `json:"entityID"`
+	// Payload contains InferenceService for InferenceServiceCreated, InferenceServiceUpdated events.
+	// Does not make sense in case of InferenceServiceDeleted event
+	Payload batch.InferenceService `json:"payload"`
+	// Possible values: InferenceServiceCreated, InferenceServiceUpdated, InferenceServiceDeleted
+	EventType Type `json:"type"`
+	// When event is raised
+	Datetime time.Time `json:"datetime"`
+}
+
 type LatestRouteEvents struct {
 	Events []RouteEvent `json:"events"`
 	Cursor int          `json:"cursor"`
@@ -82,3 +212,23 @@ type LatestDeploymentEvents struct {
 	Events []DeploymentEvent `json:"events"`
 	Cursor int               `json:"cursor"`
 }
+
+type LatestTrainingEvents struct {
+	Events []TrainingEvent `json:"events"`
+	Cursor int             `json:"cursor"`
+}
+
+type LatestPackagingEvents struct {
+	Events []PackagingEvent `json:"events"`
+	Cursor int              `json:"cursor"`
+}
+
+type LatestInferenceJobEvents struct {
+	Events []InferenceJobEvent `json:"events"`
+	Cursor int                 `json:"cursor"`
+}
+
+type LatestInferenceServiceEvents struct {
+	Events []InferenceServiceEvent `json:"events"`
+	Cursor int                     `json:"cursor"`
+}
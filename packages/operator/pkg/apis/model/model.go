@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // Metadata of a model
 type Metadata struct {
 	ModelName    string `json:"modelName"`
@@ -23,6 +25,55 @@ type ServedModel struct {
 // DeployedModel contains information about deployed model
 type DeployedModel struct {
 	// deploymentID is ModelDeployment that deploys this model
-	DeploymentID string      `json:"deploymentID"`
-	ServedModel  ServedModel `json:"servedModel"`
+	DeploymentID string `json:"deploymentID"`
+	// servedModel is the first of servedModels. Kept for clients which support only one model per deployment
+	ServedModel ServedModel `json:"servedModel"`
+	// servedModels are all models served behind the deployment
+	ServedModels []ServedModel `json:"servedModels"`
+	// predictor of the deployment
+	Predictor string `json:"predictor,omitempty"`
+	// health is the result of the last inspections of the model server
+	Health ModelHealth `json:"health"`
+}
+
+// ModelHealth is the state of the periodic re-inspection of models behind a ModelRoute
+type ModelHealth struct {
+	// healthy is false if the last inspections failed consecutiveFailures times in a row
+	Healthy bool `json:"healthy"`
+	// lastInspected is the time of the last inspection
+	LastInspected time.Time `json:"lastInspected" swaggertype:"string" format:"date-time"`
+	// lastError is the error of the last inspection if it failed
+	LastError string `json:"lastError,omitempty"`
+	// consecutiveFailures is the number of failed inspections since the last successful one
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+}
+
+// CatalogModel is a model of the service catalog listing
+type CatalogModel struct {
+	// routeID is ModelRoute that exposes the model
+	RouteID string `json:"routeID"`
+	// prefix is URL prefix of the ModelRoute
+	Prefix string `json:"prefix"`
+	// deploymentID is ModelDeployment that deploys the model
+	DeploymentID string `json:"deploymentID"`
+	// predictor of the deployment
+	Predictor string      `json:"predictor,omitempty"`
+	Metadata  Metadata    `json:"metadata"`
+	Health    ModelHealth `json:"health"`
+}
+
+// CatalogModelFilter selects models of the catalog. Model matches the filter if it matches every non-empty field.
+// Field matches if it matches any of its values
+type CatalogModelFilter struct {
+	// Model names
+	Name []string `name:"name"`
+	// Model versions
+	Version []string `name:"version"`
+	// Predictors of deployments
+	Predictor []string `name:"predictor"`
+	// Prefixes of route URL prefixes
+	Prefix []string `name:"prefix"`
+	// Case-insensitive text which is searched in the title, description
+	// and operation summaries and descriptions of the model swagger
+	Search []string `name:"search"`
 }
@@ -39,6 +39,21 @@ type ModelPackaging struct {
 	Status v1alpha1.ModelPackagingStatus `json:"status,omitempty"`
 }
 
+func (in ModelPackaging) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *ModelPackaging) Scan(value interface{}) error {
+	switch b := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(b, &in)
+	default:
+		return errors.New("type assertion to []byte or nil is failed")
+	}
+}
+
 // ModelPackagingSpec defines the desired state of ModelPackaging
 type ModelPackagingSpec struct {
 	// Training output artifact name
@@ -0,0 +1,161 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	"time"
+)
+
+type State string
+
+const (
+	Running   State = "running"
+	Succeeded State = "succeeded"
+	Failed    State = "failed"
+)
+
+type Stage string
+
+const (
+	TrainingStage   Stage = "training"
+	PackagingStage  Stage = "packaging"
+	DeploymentStage Stage = "deployment"
+)
+
+const (
+	// Default template of packaging.artifactName
+	DefaultArtifactNameTemplate = "{{ .Training.ArtifactName }}"
+	// Default template of deployment.image
+	DefaultImageTemplate = "{{ .Packaging.Image }}"
+	// Name of the packaging result which contains the model Docker image
+	ImagePackagingResult = "image"
+)
+
+// ModelPipelineSpec declares stages of the pipeline. Every stage creates an entity with the pipeline ID.
+// packaging.artifactName, string values of packaging.arguments and deployment.image are Go templates,
+// which are rendered with results of previous stages before a stage is started.
+// Available values: .Training.ArtifactName, .Training.RunID, .Training.CommitID, .Packaging.Image and
+// .Packaging.Results.<result name>
+type ModelPipelineSpec struct {
+	// Specification of the ModelTraining stage
+	Training v1alpha1.ModelTrainingSpec `json:"training"`
+	// Specification of the ModelPackaging stage
+	Packaging packaging.ModelPackagingSpec `json:"packaging"`
+	// Specification of the ModelDeployment stage. Pipeline finishes after packaging if it is missed
+	Deployment *v1alpha1.ModelDeploymentSpec `json:"deployment,omitempty"`
+}
+
+type StageStatus struct {
+	// Possible values: training, packaging, deployment
+	Stage Stage `json:"stage"`
+	// ID of the entity created by the stage
+	EntityID string `json:"entityId"`
+	// State of the entity, for example running
+	State string `json:"state,omitempty"`
+	// When the stage was started
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// When the stage reached a final state
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Results of the finished stage, for example artifactName of the training
+	Results map[string]string `json:"results,omitempty"`
+	// Reason of the stage failure
+	Message string `json:"message,omitempty"`
+}
+
+type ModelPipelineStatus struct {
+	// Possible values: running, succeeded, failed
+	State State `json:"state,omitempty"`
+	// Stage which is running now or the last stage
+	CurrentStage Stage `json:"currentStage,omitempty"`
+	// Statuses of started stages in execution order
+	Stages []StageStatus `json:"stages,omitempty"`
+	// Reason of the pipeline failure
+	Message string `json:"message,omitempty"`
+	// Error of the last attempt to advance the pipeline. The attempt is repeated
+	LastError string `json:"lastError,omitempty"`
+}
+
+type ModelPipeline struct {
+	// Model pipeline ID
+	ID string `json:"id"`
+	// When resource was created. Managed by system. Cannot be overridden by User
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// When resource was updated. Managed by system. Cannot be overridden by User
+	UpdatedAt time.Time           `json:"updatedAt,omitempty"`
+	Spec      ModelPipelineSpec   `json:"spec"`
+	Status    ModelPipelineStatus `json:"status,omitempty"`
+}
+
+// Stages returns stages declared by the pipeline in execution order
+func (spec ModelPipelineSpec) Stages() []Stage {
+	stages := []Stage{TrainingStage, PackagingStage}
+	if spec.Deployment != nil {
+		stages = append(stages, DeploymentStage)
+	}
+	return stages
+}
+
+// StageStatus returns status of the stage or nil if the stage was not started
+func (in *ModelPipelineStatus) StageStatus(stage Stage) *StageStatus {
+	for i := range in.Stages {
+		if in.Stages[i].Stage == stage {
+			return &in.Stages[i]
+		}
+	}
+	return nil
+}
+
+// Finished returns true if the pipeline reached a final state
+func (in ModelPipelineStatus) Finished() bool {
+	return in.State == Succeeded || in.State == Failed
+}
+
+const TagKey = "name"
+
+type Filter struct {
+	State []string `name:"state" postgres:"status->>'state'"`
+}
+
+func (spec ModelPipelineSpec) Value() (driver.Value, error) {
+	return json.Marshal(spec)
+}
+
+func (spec *ModelPipelineSpec) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &spec)
+}
+
+func (in ModelPipelineStatus) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *ModelPipelineStatus) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &in)
+}
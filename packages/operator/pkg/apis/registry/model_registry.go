@@ -0,0 +1,114 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package registry
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type Stage string
+
+const (
+	// Stage of a newly registered version
+	NoneStage       Stage = "none"
+	StagingStage    Stage = "staging"
+	ProductionStage Stage = "production"
+	ArchivedStage   Stage = "archived"
+)
+
+// Stages returns all stages which a model version can be transitioned to
+func Stages() []Stage {
+	return []Stage{NoneStage, StagingStage, ProductionStage, ArchivedStage}
+}
+
+type Model struct {
+	// Name of the model. It is taken from model.name of trainings
+	Name string `json:"name"`
+	// When the first version of the model was registered
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// When the last version of the model was registered or changed
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	// Version which was registered last
+	LatestVersion string `json:"latestVersion,omitempty"`
+}
+
+// Lineage links a model version to entities which produced and use it
+type Lineage struct {
+	// The last succeeded training of the version
+	TrainingID string `json:"trainingId,omitempty"`
+	// Artifact which was produced by the training
+	ArtifactName string `json:"artifactName,omitempty"`
+	// Run ID of the training in the ML tracking server
+	RunID string `json:"runId,omitempty"`
+	// Commit of the training source code
+	CommitID string `json:"commitId,omitempty"`
+	// Scalar metrics which were reported in the training result
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+	// Succeeded packagings of the training artifact
+	PackagingIDs []string `json:"packagingIds,omitempty"`
+	// Images which were built by the packagings
+	Images []string `json:"images,omitempty"`
+	// Deployments of the images
+	DeploymentIDs []string `json:"deploymentIds,omitempty"`
+}
+
+type ModelVersion struct {
+	// Name of the model
+	Model string `json:"model"`
+	// Version of the model. It is taken from model.version of trainings
+	Version string `json:"version"`
+	// Possible values: none, staging, production, archived
+	Stage Stage `json:"stage"`
+	// When the stage was changed last time
+	StageUpdatedAt *time.Time `json:"stageUpdatedAt,omitempty"`
+	// When the version was registered. Managed by system
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// When the version was changed. Managed by system
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	Lineage   Lineage   `json:"lineage"`
+}
+
+// StageTransition moves a model version to another stage
+type StageTransition struct {
+	// Possible values: none, staging, production, archived
+	Stage Stage `json:"stage"`
+	// Move other versions of the model which are in the same stage to the archived stage.
+	// It is used to keep a single production version
+	ArchiveExisting bool `json:"archiveExisting,omitempty"`
+}
+
+const TagKey = "name"
+
+type VersionFilter struct {
+	Stage []string `name:"stage" postgres:"stage"`
+}
+
+func (in Lineage) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *Lineage) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &in)
+}
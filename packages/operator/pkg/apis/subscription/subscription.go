@@ -0,0 +1,180 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package subscription
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"time"
+)
+
+const (
+	// Mask of the secret in API responses
+	SecretMask = "*****"
+	// HTTP header which contains HMAC-SHA256 signature of the request body in "sha256=<hex>" format
+	SignatureHeader = "X-Odahu-Signature"
+	// HTTP header which contains the event type
+	EventTypeHeader = "X-Odahu-Event"
+	// HTTP header which contains the delivery ID. Retries of a delivery have the same ID
+	DeliveryHeader = "X-Odahu-Delivery"
+)
+
+type DeliveryState string
+
+const (
+	// Delivery waits for the next attempt
+	DeliveryPending DeliveryState = "pending"
+	// All attempts of the delivery failed. The delivery is not retried until it is requeued by user
+	DeliveryDeadLetter DeliveryState = "deadLetter"
+)
+
+type SubscriptionSpec struct {
+	// URL of the webhook endpoint. Events are delivered by HTTP POST requests with event.Record JSON body
+	URL string `json:"url"`
+	// Secret is used to sign request bodies with HMAC-SHA256. Signature is passed in X-Odahu-Signature header.
+	// Requests are not signed if the secret is empty
+	Secret string `json:"secret,omitempty"`
+	// Only events of these groups are delivered, for example ModelTraining. Empty list matches all groups
+	EventGroups []event.Group `json:"eventGroups,omitempty"`
+	// Only events of these types are delivered, for example ModelTrainingStatusUpdated.
+	// Empty list matches all types
+	EventTypes []event.Type `json:"eventTypes,omitempty"`
+}
+
+type SubscriptionStatus struct {
+	// ID of the last event of the event log which was processed for the subscription
+	Cursor int `json:"cursor"`
+	// When an event was successfully delivered last time
+	LastDeliveryTime *time.Time `json:"lastDeliveryTime,omitempty"`
+	// Error of the last failed delivery attempt
+	LastError string `json:"lastError,omitempty"`
+}
+
+type Subscription struct {
+	// Subscription ID
+	ID string `json:"id"`
+	// When resource was created. Managed by system. Cannot be overridden by User
+	CreatedAt time.Time `json:"createdAt"`
+	// When resource was updated. Managed by system. Cannot be overridden by User
+	UpdatedAt time.Time          `json:"updatedAt"`
+	Spec      SubscriptionSpec   `json:"spec"`
+	Status    SubscriptionStatus `json:"status"`
+}
+
+// Matches returns true if the event must be delivered to the subscription
+func (spec SubscriptionSpec) Matches(e event.Record) bool {
+	if len(spec.EventGroups) > 0 && !containsGroup(spec.EventGroups, e.EventGroup) {
+		return false
+	}
+	if len(spec.EventTypes) > 0 && !containsType(spec.EventTypes, e.EventType) {
+		return false
+	}
+	return true
+}
+
+// Replace sensitive data with mask in the subscription
+func (s *Subscription) DeleteSensitiveData() *Subscription {
+	if len(s.Spec.Secret) != 0 {
+		s.Spec.Secret = SecretMask
+	}
+
+	return s
+}
+
+// Sign returns the value of X-Odahu-Signature header for the request body.
+// Receivers should compare it with the expected value using hmac.Equal
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Delivery is an attempt to deliver an event to the subscription endpoint
+type Delivery struct {
+	// Delivery ID
+	ID int `json:"id"`
+	// ID of the subscription
+	SubscriptionID string `json:"subscriptionId"`
+	// Possible values: pending, deadLetter
+	State DeliveryState `json:"state"`
+	// Number of failed attempts
+	Attempts int `json:"attempts"`
+	// When the next attempt is scheduled
+	NextAttemptTime time.Time `json:"nextAttemptTime"`
+	// Error of the last failed attempt
+	LastError string `json:"lastError,omitempty"`
+	// When the delivery was created
+	CreatedAt time.Time `json:"createdAt"`
+	// Event which is sent as a request body
+	Event event.Record `json:"event"`
+}
+
+const TagKey = "name"
+
+type DeliveryFilter struct {
+	State []string `name:"state" postgres:"state"`
+}
+
+func (spec SubscriptionSpec) Value() (driver.Value, error) {
+	return json.Marshal(spec)
+}
+
+func (spec *SubscriptionSpec) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	res := json.Unmarshal(b, &spec)
+	return res
+}
+
+func (in SubscriptionStatus) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *SubscriptionStatus) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	res := json.Unmarshal(b, &in)
+	return res
+}
+
+func containsGroup(groups []event.Group, group event.Group) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+func containsType(types []event.Type, t event.Type) bool {
+	for _, et := range types {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
@@ -17,6 +17,9 @@
 package training
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
 	"time"
 )
@@ -35,3 +38,18 @@ type ModelTraining struct {
 	// Model training status
 	Status v1alpha1.ModelTrainingStatus `json:"status,omitempty"`
 }
+
+func (in ModelTraining) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *ModelTraining) Scan(value interface{}) error {
+	switch b := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(b, &in)
+	default:
+		return errors.New("type assertion to []byte or nil is failed")
+	}
+}
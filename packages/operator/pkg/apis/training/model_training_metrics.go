@@ -0,0 +1,47 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training
+
+import "time"
+
+const (
+	// Maximum length of metric and param keys
+	MaxMetricKeyLength = 250
+	// Maximum number of metric values and params in one request
+	MaxMetricsPerRequest = 1000
+)
+
+// MetricValue is a value of the scalar metric at a training step
+type MetricValue struct {
+	// Name of the metric, for example loss
+	Key string `json:"key"`
+	// Value of the metric
+	Value float64 `json:"value"`
+	// Step of the training, for example epoch number. Value of the same key and step is overwritten
+	Step int64 `json:"step"`
+	// When the value was measured. Time of the request is used if it is missed
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// ModelTrainingMetrics are metrics and params which are reported by a trainer during a training
+type ModelTrainingMetrics struct {
+	// Time series of scalar metrics ordered by key and step
+	Metrics []MetricValue `json:"metrics,omitempty"`
+	// Parameters of the training, for example batch size. Value of the same key is overwritten
+	Params map[string]string `json:"params,omitempty"`
+}
@@ -0,0 +1,115 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package training
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"time"
+)
+
+type ConcurrencyPolicy string
+
+const (
+	// A new training is started even if trainings of previous runs are still active
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// A run is skipped if a training of a previous run is still active
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// Active trainings of previous runs are deleted before a new training is started
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+const (
+	// Layout of the time suffix of IDs of scheduled trainings. Time is in UTC
+	ScheduledTrainingIDTimeLayout = "200601021504"
+	// Maximum length of a schedule ID. The rest of 63 characters is reserved for the time suffix
+	MaxScheduleIDLength = 63 - len(ScheduledTrainingIDTimeLayout) - 1
+)
+
+type ModelTrainingScheduleSpec struct {
+	// Cron expression in the standard five fields format, for example "0 3 * * *".
+	// Descriptors like "@daily" are supported as well
+	Schedule string `json:"schedule"`
+	// IANA time zone of the schedule, for example "Europe/Berlin". UTC is used by default
+	TimeZone string `json:"timeZone,omitempty"`
+	// Suspended schedule does not start new trainings. Runs which fall into suspension are skipped
+	Suspend bool `json:"suspend,omitempty"`
+	// What to do if a training of a previous run is still active. Possible values: Allow, Forbid, Replace.
+	// Allow is used by default
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// Number of succeeded trainings to keep. Older ones are deleted. 3 by default
+	SuccessfulHistoryLimit *int `json:"successfulHistoryLimit,omitempty"`
+	// Number of failed trainings to keep. Older ones are deleted. 1 by default
+	FailedHistoryLimit *int `json:"failedHistoryLimit,omitempty"`
+	// Specification of trainings which are started by the schedule
+	Template v1alpha1.ModelTrainingSpec `json:"template"`
+}
+
+type ModelTrainingScheduleStatus struct {
+	// Time of the last processed run. It is set even if the run was skipped
+	LastScheduleTime *time.Time `json:"lastScheduleTime,omitempty"`
+	// Time of the next run
+	NextScheduleTime *time.Time `json:"nextScheduleTime,omitempty"`
+	// IDs of trainings started by the schedule which were not deleted yet, from the oldest to the newest
+	Trainings []string `json:"trainings,omitempty"`
+	// IDs of trainings started by the schedule which are not finished yet
+	Active []string `json:"active,omitempty"`
+	// Error of the last run. Empty if the last run was successful
+	LastError string `json:"lastError,omitempty"`
+}
+
+type ModelTrainingSchedule struct {
+	// Model training schedule ID
+	ID string `json:"id"`
+	// When resource was created. Managed by system. Cannot be overridden by User
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// When resource was updated. Managed by system. Cannot be overridden by User
+	UpdatedAt time.Time                   `json:"updatedAt,omitempty"`
+	Spec      ModelTrainingScheduleSpec   `json:"spec"`
+	Status    ModelTrainingScheduleStatus `json:"status,omitempty"`
+}
+
+// TrainingID returns ID of the training which is started by the schedule at the time
+func (in ModelTrainingSchedule) TrainingID(scheduledTime time.Time) string {
+	return in.ID + "-" + scheduledTime.UTC().Format(ScheduledTrainingIDTimeLayout)
+}
+
+func (in ModelTrainingScheduleSpec) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *ModelTrainingScheduleSpec) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &in)
+}
+
+func (in ModelTrainingScheduleStatus) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *ModelTrainingScheduleStatus) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &in)
+}
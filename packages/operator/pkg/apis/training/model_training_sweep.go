@@ -0,0 +1,185 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"time"
+)
+
+type SweepStrategy string
+
+const (
+	// Every combination of parameter values is trained
+	GridSweep SweepStrategy = "grid"
+	// Parameter values of every trial are sampled from distributions
+	RandomSweep SweepStrategy = "random"
+)
+
+type Distribution string
+
+const (
+	// One of the values is chosen with equal probability
+	ChoiceDistribution Distribution = "choice"
+	// Float between min and max
+	UniformDistribution Distribution = "uniform"
+	// Float between min and max whose logarithm is uniformly distributed. min must be positive
+	LogUniformDistribution Distribution = "logUniform"
+	// Integer between min and max inclusive
+	IntUniformDistribution Distribution = "intUniform"
+)
+
+type SweepGoal string
+
+const (
+	MaximizeGoal SweepGoal = "maximize"
+	MinimizeGoal SweepGoal = "minimize"
+)
+
+type SweepState string
+
+const (
+	SweepRunning   SweepState = "running"
+	SweepSucceeded SweepState = "succeeded"
+	SweepFailed    SweepState = "failed"
+)
+
+const (
+	// Maximum number of trials of a sweep
+	MaxSweepTrials = 1000
+	// Maximum length of a sweep ID. The rest of 63 characters is reserved for the trial number suffix
+	MaxSweepIDLength = 63 - len("-999")
+)
+
+type SweepParameter struct {
+	// Name of the hyperparameter
+	Name string `json:"name"`
+	// Values of the parameter. Required for the grid strategy and for the choice distribution
+	Values []string `json:"values,omitempty"`
+	// Distribution of the parameter for the random strategy. Possible values: choice, uniform,
+	// logUniform, intUniform. choice is used by default
+	Distribution Distribution `json:"distribution,omitempty"`
+	// Lower bound of uniform, logUniform and intUniform distributions
+	Min *float64 `json:"min,omitempty"`
+	// Upper bound of uniform, logUniform and intUniform distributions
+	Max *float64 `json:"max,omitempty"`
+}
+
+type SweepObjective struct {
+	// Name of the metric which the trainer reports in the training result
+	Metric string `json:"metric"`
+	// Possible values: maximize, minimize
+	Goal SweepGoal `json:"goal"`
+}
+
+type ModelTrainingSweepSpec struct {
+	// Possible values: grid, random
+	Strategy SweepStrategy `json:"strategy"`
+	// Swept hyperparameters. They override hyperparameters of the template
+	Parameters []SweepParameter `json:"parameters"`
+	// Number of trials of the random strategy. Grid is truncated to this number of trials if it is set
+	MaxTrials int `json:"maxTrials,omitempty"`
+	// Maximum number of trainings which run at the same time. 1 by default
+	Parallelism int `json:"parallelism,omitempty"`
+	// Seed of the random strategy. It is generated if it is missed
+	Seed *int64 `json:"seed,omitempty"`
+	// Metric which is used to choose the best trial
+	Objective SweepObjective `json:"objective"`
+	// Specification of trainings which are started by the sweep
+	Template v1alpha1.ModelTrainingSpec `json:"template"`
+}
+
+type SweepTrial struct {
+	// ID of the training of the trial
+	TrainingID string `json:"trainingId"`
+	// Swept hyperparameters of the trial
+	HyperParameters map[string]string `json:"hyperParameters"`
+	// When the training was started. Empty for pending trials
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// State of the training
+	State string `json:"state,omitempty"`
+	// Value of the objective metric reported by the succeeded training
+	Metric *float64 `json:"metric,omitempty"`
+	// Why the trial has no metric
+	Message string `json:"message,omitempty"`
+}
+
+type ModelTrainingSweepStatus struct {
+	// Possible values: running, succeeded, failed
+	State SweepState `json:"state,omitempty"`
+	// All trials of the sweep. They are generated when the sweep is created
+	Trials []SweepTrial `json:"trials,omitempty"`
+	// Training of the trial with the best objective metric
+	BestTrainingID string `json:"bestTrainingId,omitempty"`
+	// Objective metric of the best trial
+	BestMetric *float64 `json:"bestMetric,omitempty"`
+	// Reason of the sweep failure
+	Message string `json:"message,omitempty"`
+	// Error of the last attempt to process the sweep. The attempt is repeated
+	LastError string `json:"lastError,omitempty"`
+}
+
+type ModelTrainingSweep struct {
+	// Model training sweep ID
+	ID string `json:"id"`
+	// When resource was created. Managed by system. Cannot be overridden by User
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// When resource was updated. Managed by system. Cannot be overridden by User
+	UpdatedAt time.Time                `json:"updatedAt,omitempty"`
+	Spec      ModelTrainingSweepSpec   `json:"spec"`
+	Status    ModelTrainingSweepStatus `json:"status,omitempty"`
+}
+
+// TrialTrainingID returns ID of the training of the trial with the index
+func (in ModelTrainingSweep) TrialTrainingID(index int) string {
+	return fmt.Sprintf("%s-%d", in.ID, index)
+}
+
+const TagKey = "name"
+
+type SweepFilter struct {
+	State []string `name:"state" postgres:"status->>'state'"`
+}
+
+func (in ModelTrainingSweepSpec) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *ModelTrainingSweepSpec) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &in)
+}
+
+func (in ModelTrainingSweepStatus) Value() (driver.Value, error) {
+	return json.Marshal(in)
+}
+
+func (in *ModelTrainingSweepStatus) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &in)
+}
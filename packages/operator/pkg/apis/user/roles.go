@@ -0,0 +1,64 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package user
+
+import "context"
+
+// Role is an ODAHU platform role. Values must be in sync with roles.rego policies
+type Role string
+
+const (
+	AdminRole         Role = "admin"
+	DataScientistRole Role = "data_scientist"
+	ViewerRole        Role = "viewer"
+)
+
+// The key type is unexported to prevent collisions with context keys defined in
+// other package
+type key int
+
+const (
+	userInfoKey key = 0
+	rolesKey    key = 1
+)
+
+// NewContext returns a new Context that carries information about authenticated user
+func NewContext(ctx context.Context, info UserInfo) context.Context {
+	return context.WithValue(ctx, userInfoKey, info)
+}
+
+// FromContext returns user information stored in ctx by NewContext.
+// If there is no user information then AnonymousUser is returned
+func FromContext(ctx context.Context) (UserInfo, bool) {
+	info, ok := ctx.Value(userInfoKey).(UserInfo)
+	if !ok {
+		return AnonymousUser, false
+	}
+	return info, true
+}
+
+// NewRolesContext returns a new Context that carries ODAHU roles of authenticated user
+func NewRolesContext(ctx context.Context, roles []Role) context.Context {
+	return context.WithValue(ctx, rolesKey, roles)
+}
+
+// RolesFromContext returns roles stored in ctx by NewRolesContext.
+// If roles are unknown, for example the request was not authenticated, then false is returned
+func RolesFromContext(ctx context.Context) ([]Role, bool) {
+	roles, ok := ctx.Value(rolesKey).([]Role)
+	return roles, ok
+}
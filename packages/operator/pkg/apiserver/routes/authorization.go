@@ -0,0 +1,145 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package routes
+
+import (
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	request_jwt "github.com/dgrijalva/jwt-go/request"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/user"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils"
+	httputil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	logutils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/log"
+	"net/http"
+	"strings"
+)
+
+const (
+	UnauthorizedErrorMessage = "Authorization is required"
+	ForbiddenErrorMessage    = "Access forbidden"
+)
+
+// AccessPolicy describes which roles are allowed to call a route.
+// The first key is an HTTP method, the second key is a route path template
+// relative to the router group, for example "/model/training/:id".
+// Routes which are absent in the policy are forbidden for everybody
+type AccessPolicy map[string]map[string][]user.Role
+
+// Allowed returns true if any of roles is allowed to call the route with the method
+func (p AccessPolicy) Allowed(method, route string, roles []user.Role) bool {
+	for _, allowedRole := range p[method][route] {
+		for _, role := range roles {
+			if allowedRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AuthorizationMiddleware validates a bearer JWT of a request and checks that
+// the user has a role allowed by the policy. basePath is a path of the router group
+// where routes of the policy are registered. Information about authorized user
+// is stored to the request context, see user.FromContext.
+func AuthorizationMiddleware(
+	policy AccessPolicy, basePath string, keyFunc jwt.Keyfunc,
+	securityConfig config.APISecurityConfig, claims config.Claims,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logutils.FromContext(c.Request.Context())
+
+		token, err := request_jwt.AuthorizationHeaderExtractor.ExtractToken(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, httputil.HTTPResult{Message: UnauthorizedErrorMessage})
+			return
+		}
+
+		if err := utils.ValidateToken(token, keyFunc, securityConfig.JWKS.Issuer); err != nil {
+			log.Info("JWT validation is failed", "error", err.Error())
+			c.AbortWithStatusJSON(http.StatusUnauthorized, httputil.HTTPResult{Message: err.Error()})
+			return
+		}
+
+		userInfo, err := utils.ExtractUserInfoFromToken(token, claims)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, httputil.HTTPResult{
+				Message: fmt.Sprintf("Malformed JWT: %s", err.Error()),
+			})
+			return
+		}
+
+		roles := userRoles(c, token, userInfo.Username, securityConfig)
+
+		route := strings.TrimPrefix(c.FullPath(), basePath)
+		if !policy.Allowed(c.Request.Method, route, roles) {
+			log.Info("Access is denied", "user", userInfo.Username, "roles", roles)
+			c.AbortWithStatusJSON(http.StatusForbidden, httputil.HTTPResult{Message: ForbiddenErrorMessage})
+			return
+		}
+
+		ctx := user.NewContext(c.Request.Context(), *userInfo)
+		c.Request = c.Request.WithContext(user.NewRolesContext(ctx, roles))
+		c.Next()
+	}
+}
+
+// userRoles maps roles from the JWT claim to ODAHU roles. Unknown roles are skipped
+func userRoles(c *gin.Context, token, username string, securityConfig config.APISecurityConfig) []user.Role {
+	rawRoles, err := utils.ExtractRolesFromToken(token, securityConfig.RolesClaim)
+	if err != nil {
+		logutils.FromContext(c.Request.Context()).Info(
+			"User roles extraction is failed", "user", username, "error", err.Error(),
+		)
+	}
+
+	roles := make([]user.Role, 0, len(rawRoles))
+	for _, rawRole := range rawRoles {
+		if role, ok := securityConfig.RolesMapping[rawRole]; ok {
+			roles = append(roles, user.Role(role))
+		}
+	}
+	return roles
+}
+
+// UserInfoMiddleware stores information about the request user and user roles to the request context
+// without a JWT validation. It is used when the token was already validated by
+// the API ingress. Requests without a valid token are processed as the anonymous user
+func UserInfoMiddleware(claims config.Claims, securityConfig config.APISecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := request_jwt.AuthorizationHeaderExtractor.ExtractToken(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		// Roles are stored even if user info is malformed, because the token was accepted by the API ingress
+		ctx := c.Request.Context()
+		userInfo, err := utils.ExtractUserInfoFromToken(token, claims)
+		if err != nil {
+			logutils.FromContext(ctx).Info("Unable to extract user info", "error", err.Error())
+			ctx = user.NewRolesContext(ctx, userRoles(c, token, user.AnonymousUser.Username, securityConfig))
+		} else {
+			ctx = user.NewContext(ctx, *userInfo)
+			ctx = user.NewRolesContext(ctx, userRoles(c, token, userInfo.Username, securityConfig))
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
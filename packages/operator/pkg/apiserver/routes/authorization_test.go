@@ -0,0 +1,199 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package routes_test
+
+import (
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/user"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	authTestBasePath = "/api/v1"
+	authTestURL      = "/test/:id"
+)
+
+var authTestSecret = []byte("test-secret")
+
+type AuthorizationSuite struct {
+	suite.Suite
+	g      *GomegaWithT
+	server *gin.Engine
+	// Username and roles of the last user passed through the middleware
+	username string
+	roles    []user.Role
+}
+
+func (s *AuthorizationSuite) SetupSuite() {
+	securityConfig := config.NewDefaultAPIConfig().Security
+	policy := routes.AccessPolicy{
+		http.MethodGet:    {authTestURL: {user.AdminRole, user.ViewerRole}},
+		http.MethodDelete: {authTestURL: {user.AdminRole}},
+	}
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		return authTestSecret, nil
+	}
+
+	s.server = gin.New()
+	group := s.server.Group(authTestBasePath)
+	group.Use(routes.AuthorizationMiddleware(
+		policy, group.BasePath(), keyFunc, securityConfig, config.NewDefaultUserConfig().Claims,
+	))
+
+	handler := func(c *gin.Context) {
+		userInfo, _ := user.FromContext(c.Request.Context())
+		s.username = userInfo.Username
+		s.roles, _ = user.RolesFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	}
+	group.GET(authTestURL, handler)
+	group.DELETE(authTestURL, handler)
+	group.PUT(authTestURL, handler)
+}
+
+func (s *AuthorizationSuite) SetupTest() {
+	s.g = NewGomegaWithT(s.T())
+	s.username = ""
+	s.roles = nil
+}
+
+func TestAuthorizationSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizationSuite))
+}
+
+func (s *AuthorizationSuite) newToken(roles ...string) string {
+	rawRoles := make([]interface{}, 0, len(roles))
+	for _, role := range roles {
+		rawRoles = append(rawRoles, role)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"name":         "John Doe",
+		"email":        "test@email.org",
+		"realm_access": map[string]interface{}{"roles": rawRoles},
+	}).SignedString(authTestSecret)
+	s.g.Expect(err).NotTo(HaveOccurred())
+
+	return token
+}
+
+func (s *AuthorizationSuite) request(method, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(method, authTestBasePath+"/test/some-id", nil)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	if len(token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	s.server.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *AuthorizationSuite) TestMissingToken() {
+	w := s.request(http.MethodGet, "")
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusUnauthorized))
+	s.g.Expect(s.username).Should(BeEmpty())
+}
+
+func (s *AuthorizationSuite) TestInvalidSignature() {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"name":  "John Doe",
+		"email": "test@email.org",
+	}).SignedString([]byte("another-secret"))
+	s.g.Expect(err).NotTo(HaveOccurred())
+
+	w := s.request(http.MethodGet, token)
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusUnauthorized))
+	s.g.Expect(s.username).Should(BeEmpty())
+}
+
+func (s *AuthorizationSuite) TestViewerRead() {
+	w := s.request(http.MethodGet, s.newToken("odahu_viewer"))
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusOK))
+	s.g.Expect(s.username).Should(Equal("John Doe"))
+}
+
+func (s *AuthorizationSuite) TestViewerDelete() {
+	w := s.request(http.MethodDelete, s.newToken("odahu_viewer"))
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusForbidden))
+	s.g.Expect(s.username).Should(BeEmpty())
+}
+
+func (s *AuthorizationSuite) TestAdminDelete() {
+	w := s.request(http.MethodDelete, s.newToken("some_role", "odahu_admin"))
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusOK))
+	s.g.Expect(s.roles).Should(Equal([]user.Role{user.AdminRole}))
+}
+
+func (s *AuthorizationSuite) TestRouteMissingInPolicy() {
+	w := s.request(http.MethodPut, s.newToken("odahu_admin"))
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusForbidden))
+}
+
+func (s *AuthorizationSuite) TestUnmappedRole() {
+	w := s.request(http.MethodGet, s.newToken("admin"))
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusForbidden))
+}
+
+func TestUserInfoMiddlewareStoresRoles(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var roles []user.Role
+	var rolesKnown bool
+	server := gin.New()
+	server.Use(routes.UserInfoMiddleware(config.NewDefaultUserConfig().Claims, config.NewDefaultAPIConfig().Security))
+	server.GET("/test", func(c *gin.Context) {
+		roles, rolesKnown = user.RolesFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	// Roles are unknown without a token
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	server.ServeHTTP(w, req)
+	g.Expect(w.Code).Should(Equal(http.StatusOK))
+	g.Expect(rolesKnown).Should(BeFalse())
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"name":         "John Doe",
+		"email":        "test@email.org",
+		"realm_access": map[string]interface{}{"roles": []interface{}{"odahu_data_scientist", "unknown"}},
+	}).SignedString(authTestSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	w = httptest.NewRecorder()
+	req.Header.Set("Authorization", "Bearer "+token)
+	server.ServeHTTP(w, req)
+	g.Expect(w.Code).Should(Equal(http.StatusOK))
+	g.Expect(rolesKnown).Should(BeTrue())
+	g.Expect(roles).Should(Equal([]user.Role{user.DataScientistRole}))
+}
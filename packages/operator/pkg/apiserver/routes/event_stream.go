@@ -0,0 +1,161 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	httputil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	logutils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// Clients resume an event stream by passing the ID of the last received message in this header.
+	// Message ID is the event log cursor
+	LastEventIDHeader = "Last-Event-ID"
+	// Type of stream messages which contain a batch of new events
+	EventsStreamMessage = "events"
+	// Type of the stream message which is sent before the stream is closed because of an error.
+	// Data of the message is httputil.HTTPResult
+	ErrorStreamMessage  = "error"
+	EventStreamMIMEType = "text/event-stream"
+)
+
+// EventNotifier signals that new events of a group were committed
+type EventNotifier interface {
+	Subscribe(group event.Group) (notifications <-chan struct{}, unsubscribe func())
+}
+
+// EventBatchFetcher returns a batch of events after the cursor, which is sent to a client as JSON,
+// and the cursor of the last event in the batch. Batch can be limited in size, next batches are fetched
+// until the cursor stops moving
+type EventBatchFetcher func(ctx context.Context, cursor int) (batch interface{}, newCursor int, err error)
+
+// EventStreamer pushes new events to clients of event endpoints as server-sent events
+type EventStreamer struct {
+	Notifier EventNotifier
+	// How often keep-alive comments are sent and new events are looked up without a notification
+	KeepAlivePeriod time.Duration
+}
+
+// Stream sends batches of events of the group after the cursor until the client disconnects.
+// Cursor is taken from Last-Event-ID header or from "cursor" query parameter.
+// If the first batch cannot be fetched, request is aborted with an HTTP error,
+// for example with 410 status code if the cursor was compacted
+func (s EventStreamer) Stream(c *gin.Context, group event.Group, fetch EventBatchFetcher) {
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	var cursor int
+	if lastEventID := c.GetHeader(LastEventIDHeader); lastEventID != "" {
+		var err error
+		if cursor, err = strconv.Atoi(lastEventID); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{
+				Message: fmt.Sprintf("Incorrect %s header value: %v. Integer expected", LastEventIDHeader, lastEventID),
+			})
+			return
+		}
+	} else if err := ValidateAndParseCursor(c, &cursor); err != nil {
+		return
+	}
+
+	// Subscription must precede the first fetch, otherwise events committed in between could be missed
+	notifications, unsubscribe := s.Notifier.Subscribe(group)
+	defer unsubscribe()
+
+	batch, newCursor, err := fetch(ctx, cursor)
+	if err != nil {
+		log.Error(err, "Retrieving events for the stream", "group", group)
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", EventStreamMIMEType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Disables response buffering of nginx based ingress controllers
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	// Headers are flushed immediately, so the client does not wait for the first events
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(s.KeepAlivePeriod)
+	defer keepAlive.Stop()
+
+	for {
+		if newCursor > cursor {
+			if err := writeStreamMessage(c, strconv.Itoa(newCursor), EventsStreamMessage, batch); err != nil {
+				log.Info("Unable to write to the event stream", "error", err.Error())
+				return
+			}
+			cursor = newCursor
+		} else {
+			// New events are awaited only when a fetch returns nothing, because batches are limited in size
+			select {
+			case <-ctx.Done():
+				return
+			case <-notifications:
+			case <-keepAlive.C:
+				// Comments are ignored by clients, but allow both sides to detect broken connections
+				if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+					return
+				}
+				c.Writer.Flush()
+			}
+		}
+
+		batch, newCursor, err = fetch(ctx, cursor)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// Client is expected to reconnect with Last-Event-ID and get the HTTP error if it persists
+			log.Error(err, "Retrieving events for the stream", "group", group)
+			_ = writeStreamMessage(c, "", ErrorStreamMessage, httputil.HTTPResult{Message: err.Error()})
+			return
+		}
+	}
+}
+
+func writeStreamMessage(c *gin.Context, id string, messageType string, data interface{}) error {
+	// JSON is marshaled into a single line, so it fits into one "data" field
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		if _, err = fmt.Fprintf(c.Writer, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	if _, err = fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", messageType, body); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+
+	return nil
+}
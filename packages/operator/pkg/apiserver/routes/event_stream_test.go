@@ -0,0 +1,154 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package routes_test
+
+import (
+	"bufio"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const streamURL = "/events/stream"
+
+type stubNotifier struct {
+	ch chan struct{}
+}
+
+func (n stubNotifier) Subscribe(event.Group) (<-chan struct{}, func()) {
+	return n.ch, func() {}
+}
+
+type batch struct {
+	Cursor int `json:"cursor"`
+}
+
+func setupStreamServer(notifier stubNotifier, fetch routes.EventBatchFetcher) *httptest.Server {
+	server := gin.New()
+	streamer := routes.EventStreamer{Notifier: notifier, KeepAlivePeriod: time.Hour}
+	server.GET(streamURL, func(c *gin.Context) {
+		streamer.Stream(c, event.ModelRouteEventGroup, fetch)
+	})
+	return httptest.NewServer(server)
+}
+
+func readLines(t *testing.T, scanner *bufio.Scanner, n int) (lines []string) {
+	for i := 0; i < n; i++ {
+		require.True(t, scanner.Scan())
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+func TestEventStreamPushesNewEvents(t *testing.T) {
+	notifier := stubNotifier{ch: make(chan struct{}, 1)}
+	// The last committed event. Events up to 5 are committed before the notification
+	lastEvent := make(chan int, 1)
+	lastEvent <- 3
+	server := setupStreamServer(notifier, func(ctx context.Context, cursor int) (interface{}, int, error) {
+		last := <-lastEvent
+		lastEvent <- last
+		return batch{Cursor: last}, last, nil
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+streamURL+"?cursor=1", nil)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, routes.EventStreamMIMEType, resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	assert.Equal(t, []string{"id: 3", "event: events", `data: {"cursor":3}`, ""}, readLines(t, scanner, 4))
+
+	<-lastEvent
+	lastEvent <- 5
+	notifier.ch <- struct{}{}
+	assert.Equal(t, []string{"id: 5", "event: events", `data: {"cursor":5}`, ""}, readLines(t, scanner, 4))
+}
+
+func TestEventStreamSendsAllPages(t *testing.T) {
+	// Notification never comes, so all pages must be sent without waiting for it
+	notifier := stubNotifier{ch: make(chan struct{})}
+	server := setupStreamServer(notifier, func(ctx context.Context, cursor int) (interface{}, int, error) {
+		newCursor := cursor
+		if cursor < 5 {
+			newCursor = cursor + 2
+		}
+		return batch{Cursor: newCursor}, newCursor, nil
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+streamURL+"?cursor=1", nil)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	assert.Equal(t, []string{"id: 3", "event: events", `data: {"cursor":3}`, ""}, readLines(t, scanner, 4))
+	assert.Equal(t, []string{"id: 5", "event: events", `data: {"cursor":5}`, ""}, readLines(t, scanner, 4))
+}
+
+func TestEventStreamResumesFromLastEventID(t *testing.T) {
+	notifier := stubNotifier{ch: make(chan struct{})}
+	server := setupStreamServer(notifier, func(ctx context.Context, cursor int) (interface{}, int, error) {
+		if cursor == 7 {
+			return batch{Cursor: 8}, 8, nil
+		}
+		return batch{Cursor: cursor}, cursor, nil
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+streamURL+"?cursor=1", nil)
+	req.Header.Set(routes.LastEventIDHeader, "7")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, []string{"id: 8"}, readLines(t, bufio.NewScanner(resp.Body), 1))
+}
+
+func TestEventStreamCompactedCursor(t *testing.T) {
+	notifier := stubNotifier{ch: make(chan struct{})}
+	server := setupStreamServer(notifier, func(ctx context.Context, cursor int) (interface{}, int, error) {
+		return nil, cursor, odahu_errors.CursorCompactedError{Cursor: cursor}
+	})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + streamURL + "?cursor=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusGone, resp.StatusCode)
+}
@@ -1,4 +1,4 @@
-package deployment
+package routes
 
 import (
 	"fmt"
@@ -9,6 +9,8 @@ import (
 	"strconv"
 )
 
+// ValidateAndParseCursor parses "cursor" query parameter of event endpoints.
+// Request is aborted with an error if the parameter is malformed
 func ValidateAndParseCursor(c *gin.Context, cursor *int) (err error) {
 	cursorParam := c.Query("cursor")
 	if cursorParam != "" {
@@ -24,4 +26,20 @@ func ValidateAndParseCursor(c *gin.Context, cursor *int) (err error) {
 		}
 	}
 	return
-}
\ No newline at end of file
+}
+
+// ValidateAndParseSnapshot parses "snapshot" query parameter of event endpoints.
+// Request is aborted with an error if the parameter is malformed
+func ValidateAndParseSnapshot(c *gin.Context, snapshot *bool) (err error) {
+	snapshotParam := c.Query("snapshot")
+	if snapshotParam != "" {
+		*snapshot, err = strconv.ParseBool(snapshotParam)
+		if err != nil {
+			text := "Incorrect \"snapshot\" query parameter value: %v. Boolean expected"
+			c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{
+				Message: fmt.Sprintf(text, snapshotParam),
+			})
+		}
+	}
+	return
+}
@@ -0,0 +1,115 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	logutils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/log"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+const (
+	ListURL = "/audit"
+)
+
+var (
+	fieldsCache = map[string]int{}
+)
+
+func init() {
+	elem := reflect.TypeOf(&audit.RecordFilter{}).Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		tagName := elem.Field(i).Tag.Get(audit.TagKey)
+
+		fieldsCache[tagName] = i
+	}
+}
+
+type Getter interface {
+	List(ctx context.Context, options ...filter.ListOption) ([]audit.Record, error)
+}
+
+type controller struct {
+	getter Getter
+}
+
+func SetupRoutes(routes gin.IRoutes, getter Getter) {
+	c := controller{getter: getter}
+	routes.GET(ListURL, c.List)
+}
+
+// @Summary List audit records
+// @Description List records about changes of entities
+// @Tags Audit
+// @Accept  json
+// @Produce  json
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Param kind query string false "Entity kind, for example ModelDeployment"
+// @Param entityId query string false "Entity id"
+// @Param user query string false "Username of the change author"
+// @Param from query string false "Lower bound of the change time in RFC 3339 format"
+// @Param to query string false "Upper bound of the change time in RFC 3339 format"
+// @Success 200 {array} audit.Record
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/audit [get]
+func (cr *controller) List(c *gin.Context) {
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	f := &audit.RecordFilter{}
+	size, page, err := routes.URLParamsToFilter(c, f, fieldsCache)
+	if err != nil {
+		log.Error(err, "Malformed url parameters of audit request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	for _, value := range append(f.From, f.To...) {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{
+				Message: fmt.Sprintf("time must be in RFC 3339 format: %s", value),
+			})
+
+			return
+		}
+	}
+
+	res, err := cr.getter.List(ctx, filter.ListFilter(f), filter.Size(size), filter.Page(page))
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, "Listing audit records")
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,75 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package audit_test
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/audit/mocks"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	router := gin.Default()
+	getter := &mocks.Getter{}
+	var listOptions filter.ListOptions
+	getter.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			for _, option := range args[1:] {
+				option.(filter.ListOption)(&listOptions)
+			}
+		}).
+		Return([]api_types.Record{{ID: 1, EntityKind: api_types.ModelDeploymentKind, EntityID: "wine"}}, nil)
+	audit.SetupRoutes(router, getter)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodGet, audit.ListURL+"?kind=ModelDeployment&entityId=wine&from=2021-01-01T00:00:00Z", nil,
+	)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var records []api_types.Record
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &records))
+	assert.Len(t, records, 1)
+	assert.Equal(t, &api_types.RecordFilter{
+		EntityKind: []string{"ModelDeployment"},
+		EntityID:   []string{"wine"},
+		From:       []string{"2021-01-01T00:00:00Z"},
+	}, listOptions.Filter)
+}
+
+func TestListMalformedTime(t *testing.T) {
+	router := gin.Default()
+	getter := &mocks.Getter{}
+	audit.SetupRoutes(router, getter)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, audit.ListURL+"?to=yesterday", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	getter.AssertNotCalled(t, "List")
+}
@@ -0,0 +1,154 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package v1
+
+import (
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/user"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	audit_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/audit"
+	job_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/batch/job"
+	service_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/batch/service"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/configuration"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/connection"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/packaging"
+	pipeline_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/pipeline"
+	predictor_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/predictor"
+	registry_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/registry"
+	subscription_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/training"
+	userinfo "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/user"
+	"net/http"
+)
+
+var (
+	allRoles    = []user.Role{user.AdminRole, user.DataScientistRole, user.ViewerRole}
+	editorRoles = []user.Role{user.AdminRole, user.DataScientistRole}
+	adminRoles  = []user.Role{user.AdminRole}
+)
+
+// NewAccessPolicy returns role-based permissions for v1 API routes.
+// Permissions mirror the OPA policies of the API ingress (helms/odahu-flow-core/policies/api)
+func NewAccessPolicy() routes.AccessPolicy {
+	return routes.AccessPolicy{
+		http.MethodGet: {
+			training.GetModelTrainingURL:                 allRoles,
+			training.GetAllModelTrainingURL:              allRoles,
+			training.GetModelTrainingLogsURL:             allRoles,
+			training.GetModelTrainingMetricsURL:          allRoles,
+			training.EventsModelTrainingURL:              allRoles,
+			training.GetModelTrainingScheduleURL:         allRoles,
+			training.GetAllModelTrainingScheduleURL:      allRoles,
+			training.GetModelTrainingSweepURL:            allRoles,
+			training.GetAllModelTrainingSweepURL:         allRoles,
+			training.GetToolchainIntegrationURL:          allRoles,
+			training.GetAllToolchainIntegrationURL:       allRoles,
+			packaging.GetModelPackagingURL:               allRoles,
+			packaging.GetAllModelPackagingURL:            allRoles,
+			packaging.GetModelPackagingLogsURL:           allRoles,
+			packaging.EventsModelPackagingURL:            allRoles,
+			packaging.GetPackagingIntegrationURL:         allRoles,
+			packaging.GetAllPackagingIntegrationURL:      allRoles,
+			deployment.GetModelDeploymentURL:             allRoles,
+			deployment.GetAllModelDeploymentURL:          allRoles,
+			deployment.GetModelDeploymentDefaultRouteURL: allRoles,
+			deployment.EventsModelDeploymentURL:          allRoles,
+			deployment.StreamModelDeploymentEventsURL:    allRoles,
+			deployment.GetAllModelDeploymentRevisionURL:  allRoles,
+			deployment.GetModelDeploymentRevisionURL:     allRoles,
+			deployment.DiffModelDeploymentRevisionURL:    allRoles,
+			deployment.GetModelRouteURL:                  allRoles,
+			deployment.GetAllModelRouteURL:               allRoles,
+			deployment.EventsModelRouteURL:               allRoles,
+			deployment.StreamModelRouteEventsURL:         allRoles,
+			connection.GetConnectionURL:                  allRoles,
+			connection.GetAllConnectionURL:               allRoles,
+			connection.GetDecryptedConnectionURL:         adminRoles,
+			service_routes.GetURL:                        allRoles,
+			service_routes.ListURL:                       allRoles,
+			service_routes.EventsURL:                     allRoles,
+			job_routes.GetURL:                            allRoles,
+			job_routes.ListURL:                           allRoles,
+			job_routes.EventsURL:                         allRoles,
+			configuration.GetConfigurationURL:            allRoles,
+			userinfo.GetUserInfoURL:                      allRoles,
+			audit_routes.ListURL:                         adminRoles,
+			subscription_routes.GetURL:                   allRoles,
+			subscription_routes.ListURL:                  allRoles,
+			subscription_routes.ListDeliveriesURL:        allRoles,
+			pipeline_routes.GetURL:                       allRoles,
+			pipeline_routes.ListURL:                      allRoles,
+			registry_routes.ListModelsURL:                allRoles,
+			registry_routes.GetModelURL:                  allRoles,
+			registry_routes.ListVersionsURL:              allRoles,
+			registry_routes.GetVersionURL:                allRoles,
+			predictor_routes.GetURL:                      allRoles,
+			predictor_routes.ListURL:                     allRoles,
+		},
+		http.MethodPost: {
+			training.CreateModelTrainingURL:         editorRoles,
+			training.CreateModelTrainingScheduleURL: editorRoles,
+			training.CreateModelTrainingSweepURL:    editorRoles,
+			training.CreateToolchainIntegrationURL:  adminRoles,
+			packaging.CreateModelPackagingURL:       editorRoles,
+			packaging.CreatePackagingIntegrationURL: adminRoles,
+			deployment.CreateModelDeploymentURL:     editorRoles,
+			deployment.RollbackModelDeploymentURL:   editorRoles,
+			deployment.CreateModelRouteURL:          adminRoles,
+			connection.CreateConnectionURL:          adminRoles,
+			service_routes.PostURL:                  editorRoles,
+			job_routes.PostURL:                      editorRoles,
+			subscription_routes.PostURL:             editorRoles,
+			pipeline_routes.PostURL:                 editorRoles,
+			predictor_routes.PostURL:                adminRoles,
+		},
+		http.MethodPut: {
+			training.UpdateModelTrainingURL:         editorRoles,
+			training.UpdateModelTrainingScheduleURL: editorRoles,
+			training.SaveModelTrainingResultURL:     editorRoles,
+			training.SaveModelTrainingMetricsURL:    editorRoles,
+			training.UpdateToolchainIntegrationURL:  adminRoles,
+			packaging.UpdateModelPackagingURL:       editorRoles,
+			packaging.SaveModelPackagingResultURL:   editorRoles,
+			packaging.UpdatePackagingIntegrationURL: adminRoles,
+			deployment.UpdateModelDeploymentURL:     editorRoles,
+			deployment.UpdateModelRouteURL:          adminRoles,
+			connection.UpdateConnectionURL:          adminRoles,
+			service_routes.PutURL:                   editorRoles,
+			subscription_routes.PutURL:              editorRoles,
+			subscription_routes.RequeueDeliveryURL:  editorRoles,
+			registry_routes.TransitionStageURL:      editorRoles,
+			predictor_routes.PutURL:                 adminRoles,
+		},
+		http.MethodDelete: {
+			training.DeleteModelTrainingURL:         editorRoles,
+			training.DeleteModelTrainingScheduleURL: editorRoles,
+			training.DeleteModelTrainingSweepURL:    editorRoles,
+			training.DeleteToolchainIntegrationURL:  adminRoles,
+			packaging.DeleteModelPackagingURL:       editorRoles,
+			packaging.DeletePackagingIntegrationURL: adminRoles,
+			deployment.DeleteModelDeploymentURL:     editorRoles,
+			deployment.DeleteModelRouteURL:          adminRoles,
+			connection.DeleteConnectionURL:          adminRoles,
+			service_routes.DeleteURL:                editorRoles,
+			job_routes.DeleteURL:                    editorRoles,
+			subscription_routes.DeleteURL:           editorRoles,
+			pipeline_routes.DeleteURL:               editorRoles,
+			predictor_routes.DeleteURL:              adminRoles,
+		},
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/batch"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
@@ -36,6 +37,7 @@ const (
 	ListURL   = "/batch/job"
 	PostURL   = "/batch/job"
 	DeleteURL = "/batch/job/:id"
+	EventsURL = "/batch/job-events"
 	idParam   = "id"
 )
 
@@ -60,16 +62,23 @@ type Service interface {
 	Get(ctx context.Context, id string) (batch.InferenceJob, error)
 }
 
+type InferenceJobEventGetter interface {
+	Get(ctx context.Context, cursor int) ([]event.InferenceJobEvent, int, error)
+	Snapshot(ctx context.Context) ([]event.InferenceJobEvent, int, error)
+}
+
 type controller struct {
-	service Service
+	service      Service
+	eventsReader InferenceJobEventGetter
 }
 
-func SetupRoutes(routes gin.IRoutes, service Service) {
-	c := controller{service: service}
+func SetupRoutes(routes gin.IRoutes, service Service, eventsReader InferenceJobEventGetter) {
+	c := controller{service: service, eventsReader: eventsReader}
 	routes.GET(GetURL, c.Get)
 	routes.GET(ListURL, c.List)
 	routes.POST(PostURL, c.Post)
 	routes.DELETE(DeleteURL, c.Delete)
+	routes.GET(EventsURL, c.Events)
 }
 
 // @Summary Get an InferenceJob
@@ -207,3 +216,51 @@ func (cr *controller) List(c *gin.Context) {
 
 	c.JSON(http.StatusOK, res)
 }
+
+// @Summary Get Last Changes for InferenceJob entities
+// @Description Get Last Changes for InferenceJob entity
+// @Tags Batch
+// @Accept  json
+// @Produce  json
+// @Param cursor query int false "Cursor can be passed to get only new changes"
+// @Param snapshot query bool false "Return only the last event of each entity and the cursor to resume from"
+// @Success 200 {object} event.LatestInferenceJobEvents
+// @Failure 400 {object} httputil.HTTPResult
+// @Failure 410 {object} httputil.HTTPResult
+// @Router /api/v1/batch/job-events [get]
+func (cr *controller) Events(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	var cursor int
+	var err error
+	if err = routes.ValidateAndParseCursor(c, &cursor); err != nil {
+		return
+	}
+
+	var snapshot bool
+	if err = routes.ValidateAndParseSnapshot(c, &snapshot); err != nil {
+		return
+	}
+
+	var events []event.InferenceJobEvent
+	var newCursor int
+	if snapshot {
+		events, newCursor, err = cr.eventsReader.Snapshot(ctx)
+	} else {
+		events, newCursor, err = cr.eventsReader.Get(ctx, cursor)
+	}
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, "Retrieving list of InferenceJob events")
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, event.LatestInferenceJobEvents{
+		Events: events,
+		Cursor: newCursor,
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/batch"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
@@ -37,6 +38,7 @@ const (
 	PostURL   = "/batch/service"
 	PutURL    = "/batch/service"
 	DeleteURL = "/batch/service/:id"
+	EventsURL = "/batch/service-events"
 	idParam   = "id"
 )
 
@@ -61,17 +63,24 @@ type Service interface {
 	List(ctx context.Context, options ...filter.ListOption) (res []batch.InferenceService, err error)
 }
 
+type InferenceServiceEventGetter interface {
+	Get(ctx context.Context, cursor int) ([]event.InferenceServiceEvent, int, error)
+	Snapshot(ctx context.Context) ([]event.InferenceServiceEvent, int, error)
+}
+
 type controller struct {
-	service Service
+	service      Service
+	eventsReader InferenceServiceEventGetter
 }
 
-func SetupRoutes(routes gin.IRoutes, service Service) {
-	controller := controller{service: service}
+func SetupRoutes(routes gin.IRoutes, service Service, eventsReader InferenceServiceEventGetter) {
+	controller := controller{service: service, eventsReader: eventsReader}
 	routes.GET(GetURL, controller.Get)
 	routes.GET(ListURL, controller.List)
 	routes.POST(PostURL, controller.Post)
 	routes.PUT(PutURL, controller.Put)
 	routes.DELETE(DeleteURL, controller.Delete)
+	routes.GET(EventsURL, controller.Events)
 }
 
 // @Summary Get an InferenceService
@@ -245,3 +254,51 @@ func (cr *controller) List(c *gin.Context) {
 
 	c.JSON(http.StatusOK, res)
 }
+
+// @Summary Get Last Changes for InferenceService entities
+// @Description Get Last Changes for InferenceService entity
+// @Tags Batch
+// @Accept  json
+// @Produce  json
+// @Param cursor query int false "Cursor can be passed to get only new changes"
+// @Param snapshot query bool false "Return only the last event of each entity and the cursor to resume from"
+// @Success 200 {object} event.LatestInferenceServiceEvents
+// @Failure 400 {object} httputil.HTTPResult
+// @Failure 410 {object} httputil.HTTPResult
+// @Router /api/v1/batch/service-events [get]
+func (cr *controller) Events(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	var cursor int
+	var err error
+	if err = routes.ValidateAndParseCursor(c, &cursor); err != nil {
+		return
+	}
+
+	var snapshot bool
+	if err = routes.ValidateAndParseSnapshot(c, &snapshot); err != nil {
+		return
+	}
+
+	var events []event.InferenceServiceEvent
+	var newCursor int
+	if snapshot {
+		events, newCursor, err = cr.eventsReader.Snapshot(ctx)
+	} else {
+		events, newCursor, err = cr.eventsReader.Get(ctx, cursor)
+	}
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, "Retrieving list of InferenceService events")
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, event.LatestInferenceServiceEvents{
+		Events: events,
+		Cursor: newCursor,
+	})
+}
@@ -20,10 +20,13 @@ package service_test
 import (
 	"github.com/gin-gonic/gin"
 	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/batch"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	batch "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/batch/service"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/batch/mocks"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -42,7 +45,7 @@ func TestGet(t *testing.T) {
 		Spec:         api_types.InferenceServiceSpec{},
 		Status:       api_types.InferenceServiceStatus{},
 	}, nil)
-	batch.SetupRoutes(router, service)
+	batch.SetupRoutes(router, service, &mocks.InferenceServiceEventGetter{})
 
 
 	w := httptest.NewRecorder()
@@ -52,3 +55,51 @@ func TestGet(t *testing.T) {
 	assert.Equal(t, 200, w.Code)
 
 }
+
+func TestEvents(t *testing.T) {
+	router := gin.Default()
+	eventsReader := &mocks.InferenceServiceEventGetter{}
+	eventsReader.On("Get", mock.Anything, 5).Return([]event.InferenceServiceEvent{{
+		EntityID:  "tf-predictor",
+		EventType: event.InferenceServiceUpdatedEventType,
+	}}, 7, nil)
+	batch.SetupRoutes(router, &mocks.Service{}, eventsReader)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, batch.EventsURL+"?cursor=5", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	var result event.LatestInferenceServiceEvents
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, 7, result.Cursor)
+	assert.Len(t, result.Events, 1)
+	assert.Equal(t, "tf-predictor", result.Events[0].EntityID)
+}
+
+func TestEventsSnapshot(t *testing.T) {
+	router := gin.Default()
+	eventsReader := &mocks.InferenceServiceEventGetter{}
+	eventsReader.On("Snapshot", mock.Anything).Return([]event.InferenceServiceEvent{}, 10, nil)
+	batch.SetupRoutes(router, &mocks.Service{}, eventsReader)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, batch.EventsURL+"?snapshot=true", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	eventsReader.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestEventsCompactedCursor(t *testing.T) {
+	router := gin.Default()
+	eventsReader := &mocks.InferenceServiceEventGetter{}
+	eventsReader.On("Get", mock.Anything, 1).Return(nil, 0, odahu_errors.CursorCompactedError{Cursor: 1})
+	batch.SetupRoutes(router, &mocks.Service{}, eventsReader)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, batch.EventsURL+"?cursor=1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+}
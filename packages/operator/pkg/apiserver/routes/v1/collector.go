@@ -21,12 +21,17 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	audit_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/audit"
 	job_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/batch/job"
 	service_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/batch/service"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/configuration"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/connection"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/packaging"
+	pipeline_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/pipeline"
+	predictor_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/predictor"
+	registry_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/registry"
+	subscription_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/subscription"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/training"
 	userinfo "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/user"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
@@ -40,18 +45,29 @@ import (
 	md_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment"
 	mp_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/packaging"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/service/packaging_integration"
+	pipeline_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/pipeline"
+	predictor_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/predictor"
+	registry_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/registry"
 	mr_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
+	subscription_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/subscription"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/service/toolchain"
 	mt_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training"
+	metrics_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_metrics"
+	schedule_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_schedule"
+	sweep_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_sweep"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	audit_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/audit"
 	batch_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/batch/postgres"
 	conn_repo_type "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection"
 	deploy_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/postgres"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
 	pack_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/packaging/postgres"
+	pipeline_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/pipeline/postgres"
+	registry_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/registry/postgres"
 	route_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/route/postgres"
+	subscription_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/subscription/postgres"
 	train_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/training/postgres"
 )
 
@@ -60,6 +76,20 @@ func SetupV1Routes(routeGroup *gin.RouterGroup, kubeMgr manager.Manager, db *sql
 	k8sClient := kubeMgr.GetClient()
 	k8sConfig := kubeMgr.GetConfig()
 
+	// The middleware must be registered before routes, because gin copies handlers of a group
+	// at the time of route registration
+	if cfg.API.Security.JWKS.Enabled {
+		routeGroup.Use(routes.AuthorizationMiddleware(
+			NewAccessPolicy(),
+			routeGroup.BasePath(),
+			utils.NewJWKSKeyGetter(cfg.API.Security.JWKS.URL).Keyfunc,
+			cfg.API.Security,
+			cfg.Users.Claims,
+		))
+	} else {
+		routeGroup.Use(routes.UserInfoMiddleware(cfg.Users.Claims, cfg.API.Security))
+	}
+
 	var connRepository conn_repo_type.Repository
 	switch cfg.Connection.RepositoryType {
 	case config.RepositoryKubernetesType:
@@ -105,23 +135,35 @@ func SetupV1Routes(routeGroup *gin.RouterGroup, kubeMgr manager.Manager, db *sql
 	)
 
 	connService := conn_service.NewService(connRepository)
-	trainService := mt_service.NewService(trainRepo)
-	packService := mp_service.NewService(packRepo)
-	depService := md_service.NewService(deployRepo, routeRepo, outbox.EventPublisher{DB: db})
-	mrService := mr_service.NewService(routeRepo, outbox.EventPublisher{DB: db})
-	batchServiceService := batch_service.NewInferenceServiceService(batchServiceRepo)
-	batchJobService := batch_service.NewJobService(batchJobRepo, batchServiceRepo, connService)
+	eventPublisher := outbox.EventPublisher{DB: db}
+	auditRecorder := audit_repo.Recorder{DB: db}
+
+	trainService := mt_service.NewService(trainRepo, eventPublisher, auditRecorder)
+	packService := mp_service.NewService(packRepo, eventPublisher, auditRecorder)
+	depService := md_service.NewService(deployRepo, routeRepo, eventPublisher, auditRecorder)
+	mrService := mr_service.NewService(routeRepo, eventPublisher, auditRecorder)
+	batchServiceService := batch_service.NewInferenceServiceService(batchServiceRepo, eventPublisher, auditRecorder)
+	batchJobService := batch_service.NewJobService(
+		batchJobRepo, batchServiceRepo, connService, eventPublisher, auditRecorder,
+	)
 
 	connection.ConfigureRoutes(routeGroup, connService, utils.EvaluatePublicKey, cfg.Connection)
 
 	mdEventGetter := outbox.DeploymentEventGetter{DB: db}
 	mrEventGetter := outbox.RouteEventGetter{DB: db}
+	// Notifier lives as long as the API server, so its connection is never closed explicitly
+	eventsStreamer := routes.EventStreamer{
+		Notifier:        outbox.NewNotifier(cfg.Common.DatabaseConnectionString),
+		KeepAlivePeriod: cfg.Outbox.StreamKeepAlivePeriod,
+	}
 
-	deployment.ConfigureRoutes(routeGroup, depService, mdEventGetter, mrService, mrEventGetter,
-		cfg.Deployment, cfg.Common.ResourceGPUName)
+	predictorService := predictor_service.NewService(deploy_repo.PredictorRepo{DB: db}, deployRepo, auditRecorder)
+	deployment.ConfigureRoutes(routeGroup, depService, mdEventGetter, mrService, mrEventGetter, eventsStreamer,
+		connRepository, predictorService, cfg.Deployment, cfg.Common.ResourceGPUName)
+	predictor_routes.SetupRoutes(routeGroup, predictorService)
 	packagingRouteGroup := routeGroup.Group("", routes.DisableAPIMiddleware(cfg.Packaging.Enabled))
 	packaging.ConfigureRoutes(
-		packagingRouteGroup, packKubeClient, packService,
+		packagingRouteGroup, packKubeClient, packService, outbox.PackagingEventGetter{DB: db},
 		piService, connRepository, cfg.Packaging, cfg.Common.ResourceGPUName,
 	)
 	packaging.ConfigurePiRoutes(packagingRouteGroup, piService)
@@ -132,19 +174,67 @@ func SetupV1Routes(routeGroup *gin.RouterGroup, kubeMgr manager.Manager, db *sql
 		trainingRouteGroup,
 		cfg.Training,
 		cfg.Common.ResourceGPUName,
-		trainService, toolchainService, connRepository, trainKubeClient)
+		trainService, outbox.TrainingEventGetter{DB: db}, toolchainService, connRepository, trainKubeClient)
 
 	training.ConfigureToolchainRoutes(
 		trainingRouteGroup, toolchainService,
 	)
 
+	training.ConfigureScheduleRoutes(
+		trainingRouteGroup,
+		cfg.Training,
+		cfg.Common.ResourceGPUName,
+		schedule_service.NewService(train_repo.TrainingScheduleRepo{DB: db}, auditRecorder),
+		toolchainService, connRepository,
+	)
+
+	training.ConfigureSweepRoutes(
+		trainingRouteGroup,
+		cfg.Training,
+		cfg.Common.ResourceGPUName,
+		sweep_service.NewService(train_repo.TrainingSweepRepo{DB: db}, auditRecorder, trainService),
+		toolchainService, connRepository,
+	)
+
+	training.ConfigureMetricsRoutes(
+		trainingRouteGroup,
+		metrics_service.NewService(train_repo.TrainingMetricsRepo{DB: db}, trainService),
+	)
+
+	pipelineService := pipeline_service.NewService(
+		pipeline_repo.PipelineRepo{DB: db}, eventPublisher, auditRecorder, trainService, packService, depService,
+	)
+	pipelineValidator := pipeline_routes.NewValidator(
+		training.NewMtValidator(toolchainService, connRepository, cfg.Training, cfg.Common.ResourceGPUName),
+		packaging.NewMpValidator(piService, connRepository, cfg.Packaging, cfg.Common.ResourceGPUName),
+		deployment.NewModelDeploymentValidator(
+			connRepository, predictorService, cfg.Deployment, cfg.Common.ResourceGPUName,
+		),
+	)
+	pipelineRouteGroup := routeGroup.Group("", routes.DisableAPIMiddleware(cfg.Pipeline.Enabled))
+	pipeline_routes.SetupRoutes(pipelineRouteGroup, pipelineService, pipelineValidator)
+
+	registryRouteGroup := routeGroup.Group("", routes.DisableAPIMiddleware(cfg.Registry.Enabled))
+	registry_routes.SetupRoutes(
+		registryRouteGroup, registry_service.NewService(registry_repo.RegistryRepo{DB: db}, auditRecorder),
+	)
+
 	configuration.ConfigureRoutes(routeGroup, cfg)
 	userinfo.ConfigureRoutes(routeGroup, cfg.Users.Claims)
 
 	batchRouteGroup := routeGroup.Group("", routes.DisableAPIMiddleware(cfg.Batch.Enabled))
-	service_routes.SetupRoutes(batchRouteGroup, batchServiceService)
+	service_routes.SetupRoutes(batchRouteGroup, batchServiceService, outbox.InferenceServiceEventGetter{DB: db})
 	batchJobRouteGroup := routeGroup.Group("", routes.DisableAPIMiddleware(cfg.Batch.Enabled))
-	job_routes.SetupRoutes(batchJobRouteGroup, batchJobService)
+	job_routes.SetupRoutes(batchJobRouteGroup, batchJobService, outbox.InferenceJobEventGetter{DB: db})
+
+	audit_routes.SetupRoutes(routeGroup, audit_repo.Getter{DB: db})
+
+	subscriptionService := subscription_service.NewService(
+		subscription_repo.SubscriptionRepo{DB: db}, subscription_repo.DeliveryRepo{DB: db},
+		outbox.EventLog{DB: db}, auditRecorder, cfg.Subscription.AllowedHosts,
+	)
+	subscriptionRouteGroup := routeGroup.Group("", routes.DisableAPIMiddleware(cfg.Subscription.Enabled))
+	subscription_routes.SetupRoutes(subscriptionRouteGroup, subscriptionService)
 
 	return err
 }
@@ -43,3 +43,33 @@ func (_m *RoutesEventGetter) Get(ctx context.Context, cursor int) ([]event.Route
 
 	return r0, r1, r2
 }
+
+// Snapshot provides a mock function with given fields: ctx
+func (_m *RoutesEventGetter) Snapshot(ctx context.Context) ([]event.RouteEvent, int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []event.RouteEvent
+	if rf, ok := ret.Get(0).(func(context.Context) []event.RouteEvent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]event.RouteEvent)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context) int); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
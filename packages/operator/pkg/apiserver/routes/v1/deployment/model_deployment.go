@@ -43,6 +43,7 @@ const (
 	UpdateModelDeploymentURL          = "/model/deployment"
 	DeleteModelDeploymentURL          = "/model/deployment/:id"
 	EventsModelDeploymentURL 		  = "/model/deployment-events"
+	StreamModelDeploymentEventsURL    = "/model/deployment-events/stream"
 	IDMdURLParam                      = "id"
 )
 
@@ -61,12 +62,14 @@ func init() {
 
 type ModelDeploymentEventGetter interface {
 	Get(ctx context.Context, cursor int) ([]event.DeploymentEvent, int, error)
+	Snapshot(ctx context.Context) ([]event.DeploymentEvent, int, error)
 }
 
 type ModelDeploymentController struct {
 	mdService   md_service.Service
 	mdValidator *ModelDeploymentValidator
 	eventsReader ModelDeploymentEventGetter
+	eventsStreamer routes.EventStreamer
 }
 
 // @Summary Get a Model deployment
@@ -156,6 +159,12 @@ func (mdc *ModelDeploymentController) createMD(c *gin.Context) {
 		return
 	}
 
+	if err := CheckSecretAccess(c.Request.Context(), md.Spec); err != nil {
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
 	if err := mdc.mdService.CreateModelDeployment(c.Request.Context(), &md); err != nil {
 		logMD.Error(err, fmt.Sprintf("Creation of the model deployment: %+v", md))
 		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
@@ -193,6 +202,12 @@ func (mdc *ModelDeploymentController) updateMD(c *gin.Context) {
 		return
 	}
 
+	if err := CheckSecretAccess(c.Request.Context(), md.Spec); err != nil {
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
 	if err := mdc.mdService.UpdateModelDeployment(c.Request.Context(), &md); err != nil {
 		logMD.Error(err, fmt.Sprintf("Update of the model deployment: %+v", md))
 		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
@@ -258,20 +273,35 @@ func (mdc *ModelDeploymentController) getDefaultRoute(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param cursor query int false "Cursor can be passed to get only new changes"
+// @Param snapshot query bool false "Return only the last event of each entity and the cursor to resume from"
 // @Success 200 {object} event.LatestDeploymentEvents
 // @Failure 400 {object} httputil.HTTPResult
+// @Failure 410 {object} httputil.HTTPResult
 // @Router /api/v1/model/deployment-events [get]
 func (mdc *ModelDeploymentController) getDeploymentEvents(c *gin.Context) {
 	var cursor int
 	var err error
-	if err = ValidateAndParseCursor(c, &cursor); err != nil {
+	if err = routes.ValidateAndParseCursor(c, &cursor); err != nil {
 		return
 	}
 
-	events, newCursor, err := mdc.eventsReader.Get(c.Request.Context(), cursor)
+	var snapshot bool
+	if err = routes.ValidateAndParseSnapshot(c, &snapshot); err != nil {
+		return
+	}
+
+	var events []event.DeploymentEvent
+	var newCursor int
+	if snapshot {
+		events, newCursor, err = mdc.eventsReader.Snapshot(c.Request.Context())
+	} else {
+		events, newCursor, err = mdc.eventsReader.Get(c.Request.Context(), cursor)
+	}
 	if err != nil {
 		logMR.Error(err, "Retrieving list of model deployment events")
 		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
 	}
 
 	response := event.LatestDeploymentEvents{
@@ -280,4 +310,24 @@ func (mdc *ModelDeploymentController) getDeploymentEvents(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, response)
 
+}
+
+// @Summary Stream Changes for ModelDeployment entities
+// @Description Stream new ModelDeployment events as server-sent events. Each "events" message contains
+// @Description event.LatestDeploymentEvents and has the cursor as an id. Pass the id in Last-Event-ID header to resume
+// @Tags Route
+// @Produce  text/event-stream
+// @Param cursor query int false "Cursor can be passed to get only new changes"
+// @Param Last-Event-ID header int false "Cursor to resume from. Takes precedence over cursor query parameter"
+// @Success 200 {object} event.LatestDeploymentEvents
+// @Failure 400 {object} httputil.HTTPResult
+// @Failure 410 {object} httputil.HTTPResult
+// @Router /api/v1/model/deployment-events/stream [get]
+func (mdc *ModelDeploymentController) streamDeploymentEvents(c *gin.Context) {
+	mdc.eventsStreamer.Stream(c, event.ModelDeploymentEventGroup,
+		func(ctx context.Context, cursor int) (interface{}, int, error) {
+			events, newCursor, err := mdc.eventsReader.Get(ctx, cursor)
+			return event.LatestDeploymentEvents{Events: events, Cursor: newCursor}, newCursor, err
+		},
+	)
 }
\ No newline at end of file
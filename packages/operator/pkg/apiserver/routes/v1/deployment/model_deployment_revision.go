@@ -0,0 +1,202 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package deployment
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	httputil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	"net/http"
+	"strconv"
+)
+
+const (
+	GetAllModelDeploymentRevisionURL = "/model/deployment/:id/revision"
+	GetModelDeploymentRevisionURL    = "/model/deployment/:id/revision/:revision"
+	DiffModelDeploymentRevisionURL   = "/model/deployment/:id/revision/:revision/diff"
+	RollbackModelDeploymentURL       = "/model/deployment/:id/rollback"
+	RevisionURLParam                 = "revision"
+	ToRevisionURLParam               = "to"
+	InvalidRevisionErrorMessage      = "revision must be a positive integer, got %q"
+)
+
+// parseRevision parses a revision number. Empty value is parsed as 0 if it is allowed
+func parseRevision(value string, allowEmpty bool) (int, error) {
+	if len(value) == 0 && allowEmpty {
+		return 0, nil
+	}
+	revision, err := strconv.Atoi(value)
+	if err != nil || revision < 1 {
+		return 0, fmt.Errorf(InvalidRevisionErrorMessage, value)
+	}
+	return revision, nil
+}
+
+// @Summary Get list of Model deployment revisions
+// @Description Get revisions of a Model deployment starting from the latest one
+// @Tags Deployment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model deployment id"
+// @Param size path int false "Number of entities in a response"
+// @Param page path int false "Number of a page"
+// @Success 200 {array} deployment.ModelDeploymentRevision
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/deployment/{id}/revision [get]
+func (mdc *ModelDeploymentController) getAllRevisions(c *gin.Context) {
+	mdID := c.Param(IDMdURLParam)
+
+	size, page, err := routes.URLParamsToFilter(c, nil, map[string]int{})
+	if err != nil {
+		logMD.Error(err, "Malformed url parameters of model deployment revision request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	revisions, err := mdc.mdService.GetRevisionList(
+		c.Request.Context(), mdID, filter.Size(size), filter.Page(page),
+	)
+	if err != nil {
+		logMD.Error(err, fmt.Sprintf("Retrieving revisions of %s model deployment", mdID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// @Summary Get a Model deployment revision
+// @Description Get a Model deployment revision by number
+// @Tags Deployment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model deployment id"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} deployment.ModelDeploymentRevision
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/deployment/{id}/revision/{revision} [get]
+func (mdc *ModelDeploymentController) getRevision(c *gin.Context) {
+	mdID := c.Param(IDMdURLParam)
+
+	revision, err := parseRevision(c.Param(RevisionURLParam), false)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	rev, err := mdc.mdService.GetRevision(c.Request.Context(), mdID, revision)
+	if err != nil {
+		logMD.Error(err, fmt.Sprintf("Retrieving revision %d of %s model deployment", revision, mdID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rev)
+}
+
+// @Summary Compare Model deployment revisions
+// @Description Get a JSON merge patch which transforms the spec of the revision into the spec of another one
+// @Tags Deployment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model deployment id"
+// @Param revision path int true "Revision number"
+// @Param to query int false "Revision which the revision is compared with. The latest revision by default"
+// @Success 200 {object} deployment.ModelDeploymentRevisionDiff
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/deployment/{id}/revision/{revision}/diff [get]
+func (mdc *ModelDeploymentController) diffRevisions(c *gin.Context) {
+	mdID := c.Param(IDMdURLParam)
+
+	from, err := parseRevision(c.Param(RevisionURLParam), false)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+	to, err := parseRevision(c.Query(ToRevisionURLParam), true)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	diff, err := mdc.mdService.DiffRevisions(c.Request.Context(), mdID, from, to)
+	if err != nil {
+		logMD.Error(err, fmt.Sprintf("Comparing revisions of %s model deployment", mdID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// @Summary Rollback a Model deployment
+// @Description Restore the spec of a Model deployment revision. The restored spec is saved as a new revision
+// @Tags Deployment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model deployment id"
+// @Param revision query int true "Revision number"
+// @Success 200 {object} deployment.ModelDeployment
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Failure 403 {object} httputil.HTTPResult
+// @Router /api/v1/model/deployment/{id}/rollback [post]
+func (mdc *ModelDeploymentController) rollbackMD(c *gin.Context) {
+	mdID := c.Param(IDMdURLParam)
+
+	revision, err := parseRevision(c.Query(RevisionURLParam), false)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	// Restored spec can reference secrets which the user is not allowed to reference
+	rev, err := mdc.mdService.GetRevision(c.Request.Context(), mdID, revision)
+	if err == nil {
+		err = CheckSecretAccess(c.Request.Context(), rev.Spec)
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	md, err := mdc.mdService.RollbackModelDeployment(c.Request.Context(), mdID, revision)
+	if err != nil {
+		logMD.Error(err, fmt.Sprintf("Rollback of %s model deployment to revision %d", mdID, revision))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, md)
+}
@@ -0,0 +1,134 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package deployment_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	dep_route "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func revisionURL(url string, revision int) string {
+	url = strings.Replace(url, ":id", mdID, -1)
+	return strings.Replace(url, ":revision", fmt.Sprint(revision), -1)
+}
+
+// createRevisions creates the stub deployment and updates its image, so it has two revisions
+func (s *ModelDeploymentRouteSuite) createRevisions() {
+	ctx := context.Background()
+	md := newStubMd()
+	s.g.Expect(s.mdService.CreateModelDeployment(ctx, md)).NotTo(HaveOccurred())
+
+	md = newStubMd()
+	md.Spec.Image = "updated-image"
+	s.g.Expect(s.mdService.UpdateModelDeployment(ctx, md)).NotTo(HaveOccurred())
+}
+
+func (s *ModelDeploymentRouteSuite) TestGetAllRevisions() {
+	s.createRevisions()
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, revisionURL(dep_route.GetAllModelDeploymentRevisionURL, 0), nil)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.server.ServeHTTP(w, req)
+
+	var result []deployment.ModelDeploymentRevision
+	s.g.Expect(json.Unmarshal(w.Body.Bytes(), &result)).NotTo(HaveOccurred())
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusOK))
+	s.g.Expect(result).Should(HaveLen(2))
+	s.g.Expect(result[0].Revision).Should(Equal(2))
+	s.g.Expect(result[0].Spec.Image).Should(Equal("updated-image"))
+	s.g.Expect(result[1].Revision).Should(Equal(1))
+	s.g.Expect(result[1].Spec.Image).Should(Equal(mdImage))
+}
+
+func (s *ModelDeploymentRouteSuite) TestGetRevisionNotFound() {
+	s.createRevisions()
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, revisionURL(dep_route.GetModelDeploymentRevisionURL, 3), nil)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.server.ServeHTTP(w, req)
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusNotFound))
+}
+
+func (s *ModelDeploymentRouteSuite) TestDiffRevisions() {
+	s.createRevisions()
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, revisionURL(dep_route.DiffModelDeploymentRevisionURL, 1), nil)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.server.ServeHTTP(w, req)
+
+	var result deployment.ModelDeploymentRevisionDiff
+	s.g.Expect(json.Unmarshal(w.Body.Bytes(), &result)).NotTo(HaveOccurred())
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusOK))
+	s.g.Expect(result.From).Should(Equal(1))
+	s.g.Expect(result.To).Should(Equal(2))
+	s.g.Expect(string(result.Diff)).Should(MatchJSON(`{"image": "updated-image"}`))
+}
+
+func (s *ModelDeploymentRouteSuite) TestRollbackMD() {
+	s.createRevisions()
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		http.MethodPost, revisionURL(dep_route.RollbackModelDeploymentURL, 0)+"?revision=1", nil,
+	)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.server.ServeHTTP(w, req)
+
+	var result deployment.ModelDeployment
+	s.g.Expect(json.Unmarshal(w.Body.Bytes(), &result)).NotTo(HaveOccurred())
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusOK))
+	s.g.Expect(result.Spec.Image).Should(Equal(mdImage))
+
+	md, err := s.mdService.GetModelDeployment(context.Background(), mdID)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.g.Expect(md.Spec.Image).Should(Equal(mdImage))
+
+	rev, err := s.mdService.GetRevision(context.Background(), mdID, 3)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.g.Expect(rev.Spec.Image).Should(Equal(mdImage))
+}
+
+func (s *ModelDeploymentRouteSuite) TestRollbackMDInvalidRevision() {
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		http.MethodPost, revisionURL(dep_route.RollbackModelDeploymentURL, 0)+"?revision=latest", nil,
+	)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.server.ServeHTTP(w, req)
+
+	var result httputil.HTTPResult
+	s.g.Expect(json.Unmarshal(w.Body.Bytes(), &result)).NotTo(HaveOccurred())
+
+	s.g.Expect(w.Code).Should(Equal(http.StatusBadRequest))
+	s.g.Expect(result.Message).Should(Equal(fmt.Sprintf(dep_route.InvalidRevisionErrorMessage, "latest")))
+}
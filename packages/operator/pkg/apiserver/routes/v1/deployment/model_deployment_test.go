@@ -29,10 +29,13 @@ import (
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/deployment/mocks"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/audit"
+	conn_memory "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection/memory"
 	dep_post_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/postgres"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
 	route_post_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/route/postgres"
 	md_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment"
+	predictor_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/predictor"
 	mr_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
 	httputil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
 	. "github.com/onsi/gomega"
@@ -89,15 +92,21 @@ type ModelDeploymentRouteSuite struct {
 func (s *ModelDeploymentRouteSuite) SetupSuite() {
 	s.mdService = md_service.NewService(dep_post_repository.DeploymentRepo{DB: db}, route_post_repository.RouteRepo{
 		DB: db,
-	}, outbox.EventPublisher{DB: db})
-	s.mrService = mr_service.NewService(route_post_repository.RouteRepo{DB: db}, outbox.EventPublisher{DB: db})
+	}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db})
+	s.mrService = mr_service.NewService(
+		route_post_repository.RouteRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+	)
 	s.mdEventsGetter = &mocks.ModelDeploymentEventGetter{}
 }
 
 func (s *ModelDeploymentRouteSuite) registerHTTPHandlers(deploymentConfig config.ModelDeploymentConfig) {
 	s.server = gin.Default()
 	v1Group := s.server.Group("")
-	dep_route.ConfigureRoutes(v1Group, s.mdService, s.mdEventsGetter, s.mrService, nil,
+	dep_route.ConfigureRoutes(v1Group, s.mdService, s.mdEventsGetter, s.mrService, nil, routes.EventStreamer{},
+		conn_memory.NewRepository(),
+		predictor_service.NewService(
+			dep_post_repository.PredictorRepo{DB: db}, dep_post_repository.DeploymentRepo{DB: db}, audit.Recorder{DB: db},
+		),
 		deploymentConfig, config.NvidiaResourceName)
 }
 
@@ -17,21 +17,31 @@
 package deployment
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	odahuflowv1alpha1 "github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/connection"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/user"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	conn_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/kubernetes"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
 	"go.uber.org/multierr"
+	k8s_validation "k8s.io/apimachinery/pkg/util/validation"
+	"path"
 	"reflect"
+	"strings"
+	"time"
 )
 
 const (
 	ValidationMdErrorMessage           = "Validation of model deployment is failed"
 	EmptyImageErrorMessage             = "empty image parameter"
 	EmptyPredictorErrorMessage         = "empty predictor parameter"
+	UnknownPredictorErrorMessage       = "unknown predictor %q"
 	NegativeMinReplicasErrorMessage    = "minimum number of replicas parameter must not be less than 0"
 	NegativeMaxReplicasErrorMessage    = "maximum number of replicas parameter must not be less than 1"
 	MaxMoreThanMinReplicasErrorMessage = "maximum number of replicas parameter must not be less than minimum number " +
@@ -43,24 +53,89 @@ const (
 	MaxIDLengthErrorMessage    = "model deployment ID should not exceed 46 symbols"
 )
 
+const (
+	UnknownAutoscalingClassErrorMessage      = "unknown autoscaling class %q. Possible values: %v"
+	UnsupportedAutoscalingMetricErrorMessage = "autoscaling metric %q is not supported by the %q class. " +
+		"Possible values: %v"
+	EmptyAutoscalingTargetErrorMessage       = "autoscaling target must be set for the %q metric"
+	NonPositiveAutoscalingTargetErrorMessage = "autoscaling target must be greater than 0"
+	CPUAutoscalingTargetErrorMessage         = "cpu autoscaling target is a percentage and must not exceed 100"
+	KPAOnlyAutoscalingParameterErrorMessage  = "autoscaling parameter %s is supported only by the %q class"
+	StableWindowErrorMessage                 = "autoscaling stableWindow must be between %s and %s"
+	PanicWindowPercentageErrorMessage        = "autoscaling panicWindowPercentage must be between 1 and 100"
+	NegativeAutoscalingDurationErrorMessage  = "autoscaling parameter %s must not be negative"
+	ScaleToZeroGracePeriodErrorMessage       = "autoscaling scaleToZeroGracePeriod requires minimum number of replicas " +
+		"to be 0"
+	HPAMinReplicasErrorMessage = "minimum number of replicas must not be less than 1 for the %q autoscaling class"
+)
+
+const (
+	InvalidEnvNameErrorMessage         = "invalid environment variable name %q: %s"
+	DuplicatedEnvErrorMessage          = "environment variable %q is duplicated"
+	EnvValueSourceErrorMessage         = "environment variable %q must have either a value or a valueFrom"
+	EnvValueFromErrorMessage           = "valueFrom of the environment variable %q must contain exactly one reference"
+	InvalidEnvRefErrorMessage          = "invalid reference of the environment variable %q: %s"
+	UnknownConnectionFieldErrorMessage = "unknown field %q of the connection %q. Possible values: %v"
+	InvalidVolumeNameErrorMessage      = "invalid volume name %q: %s"
+	DuplicatedVolumeErrorMessage       = "volume %q is duplicated"
+	VolumeSourceErrorMessage           = "volume %q must contain exactly one of configMap and secret"
+	InvalidMountPathErrorMessage       = "mount path %q of the volume %q must be an absolute clean path"
+	ReservedMountPathErrorMessage      = "mount path %q of the volume %q is reserved"
+	DuplicatedMountPathErrorMessage    = "mount path %q is used by more than one volume"
+	SecretAccessForbiddenErrorMessage  = "only admins can reference connections and secrets in model deployments"
+)
+
 var (
 	MdDefaultMinimumReplicas            = int32(0)
 	MdDefaultMaximumReplicas            = int32(1)
 	MdDefaultLivenessProbeInitialDelay  = int32(2)
 	MdDefaultReadinessProbeInitialDelay = int32(2)
+	// Default autoscaling targets by metric. The memory target depends on a model, so it has no default
+	MdDefaultAutoscalingTargets = map[odahuflowv1alpha1.AutoscalingMetric]int32{
+		odahuflowv1alpha1.ConcurrencyAutoscalingMetric: 10,
+		odahuflowv1alpha1.RPSAutoscalingMetric:         200,
+		odahuflowv1alpha1.CPUAutoscalingMetric:         80,
+	}
+	// Supported metrics by autoscaling class. The first one is the default
+	autoscalingMetrics = map[odahuflowv1alpha1.AutoscalingClass][]odahuflowv1alpha1.AutoscalingMetric{
+		odahuflowv1alpha1.KPAAutoscalingClass: {
+			odahuflowv1alpha1.ConcurrencyAutoscalingMetric, odahuflowv1alpha1.RPSAutoscalingMetric,
+		},
+		odahuflowv1alpha1.HPAAutoscalingClass: {
+			odahuflowv1alpha1.CPUAutoscalingMetric, odahuflowv1alpha1.MemoryAutoscalingMetric,
+		},
+	}
+	// Knative bounds of the stable window
+	minStableWindow = 6 * time.Second
+	maxStableWindow = time.Hour
+	// Knative does not allow to mount volumes to these paths
+	reservedMountPaths = map[string]bool{
+		"/": true, "/dev": true, "/dev/log": true, "/tmp": true, "/var": true, "/var/log": true,
+	}
 )
 
+// PredictorGetter returns built-in and custom predictors
+type PredictorGetter interface {
+	Get(ctx context.Context, id string) (deployment.Predictor, error)
+}
+
 type ModelDeploymentValidator struct {
+	connRepository        conn_repository.Repository
+	predictorGetter       PredictorGetter
 	modelDeploymentConfig config.ModelDeploymentConfig
 	gpuResourceName       string
 	defaultResources      odahuflowv1alpha1.ResourceRequirements
 }
 
 func NewModelDeploymentValidator(
+	connRepository conn_repository.Repository,
+	predictorGetter PredictorGetter,
 	modelDeploymentConfig config.ModelDeploymentConfig,
 	gpuResourceName string,
 ) *ModelDeploymentValidator {
 	return &ModelDeploymentValidator{
+		connRepository:        connRepository,
+		predictorGetter:       predictorGetter,
 		modelDeploymentConfig: modelDeploymentConfig,
 		gpuResourceName:       gpuResourceName,
 		defaultResources:      modelDeploymentConfig.DefaultResources,
@@ -81,6 +156,11 @@ func (mdv *ModelDeploymentValidator) ValidatesMDAndSetDefaults(md *deployment.Mo
 
 	if len(md.Spec.Predictor) == 0 {
 		err = multierr.Append(err, errors.New(EmptyPredictorErrorMessage))
+	} else if _, predictorErr := mdv.predictorGetter.Get(context.Background(), md.Spec.Predictor); predictorErr != nil {
+		if odahuErrors.IsNotFoundError(predictorErr) {
+			predictorErr = fmt.Errorf(UnknownPredictorErrorMessage, md.Spec.Predictor)
+		}
+		err = multierr.Append(err, predictorErr)
 	}
 
 	if md.Spec.RoleName == nil || len(*md.Spec.RoleName) == 0 {
@@ -117,6 +197,8 @@ func (mdv *ModelDeploymentValidator) ValidatesMDAndSetDefaults(md *deployment.Mo
 		err = multierr.Append(errors.New(MaxMoreThanMinReplicasErrorMessage), err)
 	}
 
+	err = multierr.Append(err, validateAutoscaling(md))
+
 	if md.Spec.Resources == nil {
 		logMD.Info("Deployment resources parameter is nil. Set the default value",
 			"id", md.ID, "resources", mdv.defaultResources)
@@ -155,6 +237,10 @@ func (mdv *ModelDeploymentValidator) ValidatesMDAndSetDefaults(md *deployment.Mo
 
 	err = multierr.Append(mdv.validateNodeSelector(md), err)
 
+	err = multierr.Append(err, mdv.validateEnvs(md))
+
+	err = multierr.Append(err, validateVolumes(md))
+
 	err = multierr.Append(err, validation.ValidateResources(md.Spec.Resources, config.NvidiaResourceName))
 
 	if err != nil {
@@ -179,3 +265,212 @@ func (mdv *ModelDeploymentValidator) validateNodeSelector(md *deployment.ModelDe
 
 	return fmt.Errorf(UnknownNodeSelector, md.Spec.NodeSelector)
 }
+
+func validateAutoscaling(md *deployment.ModelDeployment) (err error) {
+	if md.Spec.Autoscaling == nil {
+		md.Spec.Autoscaling = &odahuflowv1alpha1.Autoscaling{}
+	}
+	as := md.Spec.Autoscaling
+
+	if len(as.Class) == 0 {
+		as.Class = odahuflowv1alpha1.KPAAutoscalingClass
+	}
+	metrics, ok := autoscalingMetrics[as.Class]
+	if !ok {
+		return fmt.Errorf(UnknownAutoscalingClassErrorMessage, as.Class, []odahuflowv1alpha1.AutoscalingClass{
+			odahuflowv1alpha1.KPAAutoscalingClass, odahuflowv1alpha1.HPAAutoscalingClass,
+		})
+	}
+
+	if len(as.Metric) == 0 {
+		as.Metric = metrics[0]
+	}
+	supported := false
+	for _, metric := range metrics {
+		supported = supported || metric == as.Metric
+	}
+	if !supported {
+		err = multierr.Append(err, fmt.Errorf(UnsupportedAutoscalingMetricErrorMessage, as.Metric, as.Class, metrics))
+	}
+
+	if as.Target == nil {
+		if target, ok := MdDefaultAutoscalingTargets[as.Metric]; ok {
+			logMD.Info("Autoscaling target parameter is nil. Set the default value",
+				"id", md.ID, "metric", as.Metric, "target", target)
+			as.Target = &target
+		} else if supported {
+			err = multierr.Append(err, fmt.Errorf(EmptyAutoscalingTargetErrorMessage, as.Metric))
+		}
+	} else if *as.Target <= 0 {
+		err = multierr.Append(err, errors.New(NonPositiveAutoscalingTargetErrorMessage))
+	} else if as.Metric == odahuflowv1alpha1.CPUAutoscalingMetric && *as.Target > 100 {
+		err = multierr.Append(err, errors.New(CPUAutoscalingTargetErrorMessage))
+	}
+
+	if as.Class == odahuflowv1alpha1.HPAAutoscalingClass {
+		// HPA can not scale to zero and does not support Knative specific parameters
+		if *md.Spec.MinReplicas < 1 {
+			err = multierr.Append(err, fmt.Errorf(HPAMinReplicasErrorMessage, as.Class))
+		}
+		for _, param := range []struct {
+			name string
+			set  bool
+		}{
+			{"stableWindow", as.StableWindow != nil},
+			{"panicWindowPercentage", as.PanicWindowPercentage != nil},
+			{"scaleToZeroGracePeriod", as.ScaleToZeroGracePeriod != nil},
+			{"scaleDownDelay", as.ScaleDownDelay != nil},
+		} {
+			if param.set {
+				err = multierr.Append(err, fmt.Errorf(
+					KPAOnlyAutoscalingParameterErrorMessage, param.name, odahuflowv1alpha1.KPAAutoscalingClass,
+				))
+			}
+		}
+		return err
+	}
+
+	if as.StableWindow != nil && (as.StableWindow.Duration < minStableWindow ||
+		as.StableWindow.Duration > maxStableWindow) {
+		err = multierr.Append(err, fmt.Errorf(StableWindowErrorMessage, minStableWindow, maxStableWindow))
+	}
+	if as.PanicWindowPercentage != nil && (*as.PanicWindowPercentage < 1 || *as.PanicWindowPercentage > 100) {
+		err = multierr.Append(err, errors.New(PanicWindowPercentageErrorMessage))
+	}
+	if as.ScaleToZeroGracePeriod != nil {
+		if as.ScaleToZeroGracePeriod.Duration < 0 {
+			err = multierr.Append(err, fmt.Errorf(NegativeAutoscalingDurationErrorMessage, "scaleToZeroGracePeriod"))
+		}
+		if *md.Spec.MinReplicas > 0 {
+			err = multierr.Append(err, errors.New(ScaleToZeroGracePeriodErrorMessage))
+		}
+	}
+	if as.ScaleDownDelay != nil && as.ScaleDownDelay.Duration < 0 {
+		err = multierr.Append(err, fmt.Errorf(NegativeAutoscalingDurationErrorMessage, "scaleDownDelay"))
+	}
+
+	return err
+}
+
+func (mdv *ModelDeploymentValidator) validateEnvs(md *deployment.ModelDeployment) (err error) {
+	names := make(map[string]bool, len(md.Spec.Envs))
+	for _, env := range md.Spec.Envs {
+		if errs := k8s_validation.IsEnvVarName(env.Name); len(errs) > 0 {
+			err = multierr.Append(err, fmt.Errorf(InvalidEnvNameErrorMessage, env.Name, strings.Join(errs, "; ")))
+		}
+		if names[env.Name] {
+			err = multierr.Append(err, fmt.Errorf(DuplicatedEnvErrorMessage, env.Name))
+		}
+		names[env.Name] = true
+
+		if env.ValueFrom == nil {
+			continue
+		}
+		if len(env.Value) > 0 {
+			err = multierr.Append(err, fmt.Errorf(EnvValueSourceErrorMessage, env.Name))
+			continue
+		}
+
+		refs := 0
+		for _, ref := range []*odahuflowv1alpha1.KeySelector{
+			env.ValueFrom.ConnectionRef, env.ValueFrom.SecretKeyRef, env.ValueFrom.ConfigMapKeyRef,
+		} {
+			if ref == nil {
+				continue
+			}
+			refs++
+			if len(ref.Name) == 0 {
+				err = multierr.Append(err, fmt.Errorf(InvalidEnvRefErrorMessage, env.Name, "empty name"))
+			}
+			if errs := k8s_validation.IsConfigMapKey(ref.Key); len(errs) > 0 {
+				err = multierr.Append(err, fmt.Errorf(InvalidEnvRefErrorMessage, env.Name, strings.Join(errs, "; ")))
+			}
+		}
+		if refs != 1 {
+			err = multierr.Append(err, fmt.Errorf(EnvValueFromErrorMessage, env.Name))
+			continue
+		}
+
+		if ref := env.ValueFrom.ConnectionRef; ref != nil && len(ref.Name) > 0 {
+			err = multierr.Append(err, mdv.validateConnectionRef(*ref))
+		}
+	}
+
+	return err
+}
+
+func (mdv *ModelDeploymentValidator) validateConnectionRef(ref odahuflowv1alpha1.KeySelector) error {
+	conn, err := mdv.connRepository.GetConnection(ref.Name)
+	if err != nil {
+		return err
+	}
+	if _, ok := conn.Field(ref.Key); !ok {
+		return fmt.Errorf(UnknownConnectionFieldErrorMessage, ref.Key, ref.Name, connection.FieldKeys)
+	}
+	return nil
+}
+
+// CheckSecretAccess forbids references to connections and secrets of the deployment namespace for users
+// who are not admins. A reference exposes secret values to the model, but decrypted connections are available
+// only for admins, and the namespace contains secrets which are filled with decrypted connection values.
+// Requests of users with unknown roles are not authorized by the API, so they are allowed
+func CheckSecretAccess(ctx context.Context, spec odahuflowv1alpha1.ModelDeploymentSpec) error {
+	if !referencesSecrets(spec) {
+		return nil
+	}
+
+	roles, ok := user.RolesFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	for _, role := range roles {
+		if role == user.AdminRole {
+			return nil
+		}
+	}
+	return odahuErrors.ExtendedForbiddenError{Message: SecretAccessForbiddenErrorMessage}
+}
+
+func referencesSecrets(spec odahuflowv1alpha1.ModelDeploymentSpec) bool {
+	for _, env := range spec.Envs {
+		if env.ValueFrom != nil && (env.ValueFrom.ConnectionRef != nil || env.ValueFrom.SecretKeyRef != nil) {
+			return true
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if len(volume.Secret) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func validateVolumes(md *deployment.ModelDeployment) (err error) {
+	names := make(map[string]bool, len(md.Spec.Volumes))
+	mountPaths := make(map[string]bool, len(md.Spec.Volumes))
+	for _, volume := range md.Spec.Volumes {
+		if errs := k8s_validation.IsDNS1123Label(volume.Name); len(errs) > 0 {
+			err = multierr.Append(err, fmt.Errorf(InvalidVolumeNameErrorMessage, volume.Name, strings.Join(errs, "; ")))
+		}
+		if names[volume.Name] {
+			err = multierr.Append(err, fmt.Errorf(DuplicatedVolumeErrorMessage, volume.Name))
+		}
+		names[volume.Name] = true
+
+		if (len(volume.ConfigMap) > 0) == (len(volume.Secret) > 0) {
+			err = multierr.Append(err, fmt.Errorf(VolumeSourceErrorMessage, volume.Name))
+		}
+
+		switch {
+		case !path.IsAbs(volume.MountPath) || path.Clean(volume.MountPath) != volume.MountPath:
+			err = multierr.Append(err, fmt.Errorf(InvalidMountPathErrorMessage, volume.MountPath, volume.Name))
+		case reservedMountPaths[volume.MountPath]:
+			err = multierr.Append(err, fmt.Errorf(ReservedMountPathErrorMessage, volume.MountPath, volume.Name))
+		case mountPaths[volume.MountPath]:
+			err = multierr.Append(err, fmt.Errorf(DuplicatedMountPathErrorMessage, volume.MountPath))
+		}
+		mountPaths[volume.MountPath] = true
+	}
+
+	return err
+}
@@ -17,21 +17,33 @@
 package deployment_test
 
 import (
+	"database/sql"
+	"fmt"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/connection"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
 	md_routes "github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	conn_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection"
+	conn_memory "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection/memory"
+	predictor_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/predictor"
+	predictor_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/predictor/mocks"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/multierr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"odahu-commons/predictors"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 )
 
 var (
 	mdRoleName        = "test-tole"
+	envConnID         = "env-conn"
 	validNodeSelector = map[string]string{"mode": "valid"} // should be injected to config in SetupTest
 	validDeployment   = deployment.ModelDeployment{
 		ID: "valid-id",
@@ -43,9 +55,13 @@ var (
 	}
 )
 
+const unknownPredictorID = "unknown-predictor"
+
 type ModelDeploymentValidationSuite struct {
 	suite.Suite
 	g                     *GomegaWithT
+	connRepository        conn_repository.Repository
+	predictorService      *predictor_service.Service
 	defaultModelValidator *md_routes.ModelDeploymentValidator
 }
 
@@ -53,7 +69,20 @@ func (s *ModelDeploymentValidationSuite) SetupTest() {
 	s.g = NewGomegaWithT(s.T())
 	deployConfig := config.NewDefaultModelDeploymentConfig()
 	deployConfig.NodePools = append(deployConfig.NodePools, config.NodePool{NodeSelector: validNodeSelector})
+	s.connRepository = conn_memory.NewRepository()
+	s.g.Expect(s.connRepository.SaveConnection(&connection.Connection{
+		ID:   envConnID,
+		Spec: v1alpha1.ConnectionSpec{Type: connection.DockerType, Username: "user", Password: "password"},
+	})).Should(Succeed())
+	predictorRepo := &predictor_mocks.Repository{}
+	predictorRepo.On("Get", mock.Anything, (*sql.Tx)(nil), unknownPredictorID).
+		Return(deployment.Predictor{}, odahuErrors.NotFoundError{Entity: unknownPredictorID})
+	s.predictorService = predictor_service.NewService(
+		predictorRepo, &predictor_mocks.DeploymentRepository{}, &predictor_mocks.AuditRecorder{},
+	)
 	s.defaultModelValidator = md_routes.NewModelDeploymentValidator(
+		s.connRepository,
+		s.predictorService,
 		deployConfig,
 		config.NvidiaResourceName,
 	)
@@ -213,6 +242,16 @@ func (s *ModelDeploymentValidationSuite) TestValidateLivenessProbe() {
 	s.g.Expect(err.Error()).To(ContainSubstring(md_routes.LivenessProbeErrorMessage))
 }
 
+func (s *ModelDeploymentValidationSuite) TestMdUnknownPredictor() {
+	md := validDeployment
+	md.Spec.Predictor = unknownPredictorID
+
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).Should(ContainSubstring(
+		fmt.Sprintf(md_routes.UnknownPredictorErrorMessage, unknownPredictorID)))
+}
+
 func (s *ModelDeploymentValidationSuite) TestMdResourcesValidation() {
 	wrongResourceValue := "wrong res"
 	md := &deployment.ModelDeployment{
@@ -257,7 +296,9 @@ func (s *ModelDeploymentValidationSuite) TestValidateDefaultDockerPullConnection
 		Spec: v1alpha1.ModelDeploymentSpec{},
 	}
 
-	_ = md_routes.NewModelDeploymentValidator(mdConfig, config.NvidiaResourceName).ValidatesMDAndSetDefaults(md)
+	_ = md_routes.NewModelDeploymentValidator(
+		s.connRepository, s.predictorService, mdConfig, config.NvidiaResourceName,
+	).ValidatesMDAndSetDefaults(md)
 	s.g.Expect(md.Spec.ImagePullConnectionID).ShouldNot(BeNil())
 	s.g.Expect(*md.Spec.ImagePullConnectionID).Should(Equal(newDefaultDockerPullConnectionName))
 }
@@ -330,3 +371,239 @@ func (s *ModelDeploymentValidationSuite) TestValidateRoleName_Nil() {
 	s.Assertions.NoError(err)
 	s.Assertions.Equal(*mt.Spec.RoleName, md_routes.DefaultRolePrefix+mt.ID)
 }
+
+func (s *ModelDeploymentValidationSuite) TestMDAutoscalingDefaultValues() {
+	md := validDeployment
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).ShouldNot(HaveOccurred())
+
+	s.g.Expect(md.Spec.Autoscaling).ShouldNot(BeNil())
+	s.g.Expect(md.Spec.Autoscaling.Class).To(Equal(v1alpha1.KPAAutoscalingClass))
+	s.g.Expect(md.Spec.Autoscaling.Metric).To(Equal(v1alpha1.ConcurrencyAutoscalingMetric))
+	s.g.Expect(*md.Spec.Autoscaling.Target).To(Equal(
+		md_routes.MdDefaultAutoscalingTargets[v1alpha1.ConcurrencyAutoscalingMetric],
+	))
+}
+
+func (s *ModelDeploymentValidationSuite) TestMDAutoscalingHPADefaultValues() {
+	md := validDeployment
+	minReplicas := int32(1)
+	md.Spec.MinReplicas = &minReplicas
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{Class: v1alpha1.HPAAutoscalingClass}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).ShouldNot(HaveOccurred())
+
+	s.g.Expect(md.Spec.Autoscaling.Metric).To(Equal(v1alpha1.CPUAutoscalingMetric))
+	s.g.Expect(*md.Spec.Autoscaling.Target).To(Equal(
+		md_routes.MdDefaultAutoscalingTargets[v1alpha1.CPUAutoscalingMetric],
+	))
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateAutoscalingKPA() {
+	md := validDeployment
+	target := int32(50)
+	panicWindow := int32(10)
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{
+		Metric:                 v1alpha1.RPSAutoscalingMetric,
+		Target:                 &target,
+		StableWindow:           &metav1.Duration{Duration: time.Minute},
+		PanicWindowPercentage:  &panicWindow,
+		ScaleToZeroGracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+		ScaleDownDelay:         &metav1.Duration{Duration: 5 * time.Minute},
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).ShouldNot(HaveOccurred())
+	s.g.Expect(*md.Spec.Autoscaling.Target).To(Equal(target))
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateAutoscalingUnknownClass() {
+	md := validDeployment
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{Class: "unknown"}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.UnknownAutoscalingClassErrorMessage, "unknown",
+		[]v1alpha1.AutoscalingClass{v1alpha1.KPAAutoscalingClass, v1alpha1.HPAAutoscalingClass},
+	)))
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateAutoscalingUnsupportedMetric() {
+	md := validDeployment
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{Metric: v1alpha1.CPUAutoscalingMetric}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.UnsupportedAutoscalingMetricErrorMessage, v1alpha1.CPUAutoscalingMetric,
+		v1alpha1.KPAAutoscalingClass,
+		[]v1alpha1.AutoscalingMetric{v1alpha1.ConcurrencyAutoscalingMetric, v1alpha1.RPSAutoscalingMetric},
+	)))
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateAutoscalingTarget() {
+	md := validDeployment
+	minReplicas := int32(1)
+	md.Spec.MinReplicas = &minReplicas
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{
+		Class: v1alpha1.HPAAutoscalingClass, Metric: v1alpha1.MemoryAutoscalingMetric,
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.EmptyAutoscalingTargetErrorMessage, v1alpha1.MemoryAutoscalingMetric,
+	)))
+
+	target := int32(120)
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{Class: v1alpha1.HPAAutoscalingClass, Target: &target}
+	err = s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(md_routes.CPUAutoscalingTargetErrorMessage))
+
+	target = 0
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{Target: &target}
+	err = s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(md_routes.NonPositiveAutoscalingTargetErrorMessage))
+}
+
+// HPA does not support scale to zero and Knative specific parameters
+func (s *ModelDeploymentValidationSuite) TestValidateAutoscalingHPA() {
+	md := validDeployment
+	panicWindow := int32(10)
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{
+		Class:                 v1alpha1.HPAAutoscalingClass,
+		PanicWindowPercentage: &panicWindow,
+		ScaleDownDelay:        &metav1.Duration{Duration: time.Minute},
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.HPAMinReplicasErrorMessage, v1alpha1.HPAAutoscalingClass,
+	)))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.KPAOnlyAutoscalingParameterErrorMessage, "panicWindowPercentage", v1alpha1.KPAAutoscalingClass,
+	)))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.KPAOnlyAutoscalingParameterErrorMessage, "scaleDownDelay", v1alpha1.KPAAutoscalingClass,
+	)))
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateAutoscalingWindows() {
+	md := validDeployment
+	panicWindow := int32(0)
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{
+		StableWindow:          &metav1.Duration{Duration: time.Second},
+		PanicWindowPercentage: &panicWindow,
+		ScaleDownDelay:        &metav1.Duration{Duration: -time.Second},
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.StableWindowErrorMessage, 6*time.Second, time.Hour,
+	)))
+	s.g.Expect(err.Error()).To(ContainSubstring(md_routes.PanicWindowPercentageErrorMessage))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.NegativeAutoscalingDurationErrorMessage, "scaleDownDelay",
+	)))
+}
+
+// Grace period of scale to zero is meaningless if the model can not be scaled to zero
+func (s *ModelDeploymentValidationSuite) TestValidateAutoscalingScaleToZero() {
+	md := validDeployment
+	minReplicas := int32(1)
+	md.Spec.MinReplicas = &minReplicas
+	md.Spec.Autoscaling = &v1alpha1.Autoscaling{
+		ScaleToZeroGracePeriod: &metav1.Duration{Duration: time.Minute},
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(md_routes.ScaleToZeroGracePeriodErrorMessage))
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateEnvs() {
+	md := validDeployment
+	md.Spec.Envs = []v1alpha1.DeploymentEnvironmentVariable{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "EMPTY"},
+		{Name: "PASSWORD", ValueFrom: &v1alpha1.EnvironmentVariableSource{
+			ConnectionRef: &v1alpha1.KeySelector{Name: envConnID, Key: "password"},
+		}},
+		{Name: "TOKEN", ValueFrom: &v1alpha1.EnvironmentVariableSource{
+			SecretKeyRef: &v1alpha1.KeySelector{Name: "model-secrets", Key: "token"},
+		}},
+		{Name: "THRESHOLD", ValueFrom: &v1alpha1.EnvironmentVariableSource{
+			ConfigMapKeyRef: &v1alpha1.KeySelector{Name: "model-config", Key: "threshold"},
+		}},
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).ShouldNot(HaveOccurred())
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateEnvsInvalid() {
+	md := validDeployment
+	md.Spec.Envs = []v1alpha1.DeploymentEnvironmentVariable{
+		{Name: "1INVALID", Value: "a"},
+		{Name: "DUPLICATED", Value: "a"},
+		{Name: "DUPLICATED", Value: "b"},
+		{Name: "BOTH", Value: "a", ValueFrom: &v1alpha1.EnvironmentVariableSource{
+			SecretKeyRef: &v1alpha1.KeySelector{Name: "model-secrets", Key: "token"},
+		}},
+		{Name: "NO_REF", ValueFrom: &v1alpha1.EnvironmentVariableSource{}},
+		{Name: "TWO_REFS", ValueFrom: &v1alpha1.EnvironmentVariableSource{
+			SecretKeyRef:    &v1alpha1.KeySelector{Name: "model-secrets", Key: "token"},
+			ConfigMapKeyRef: &v1alpha1.KeySelector{Name: "model-config", Key: "threshold"},
+		}},
+		{Name: "UNKNOWN_FIELD", ValueFrom: &v1alpha1.EnvironmentVariableSource{
+			ConnectionRef: &v1alpha1.KeySelector{Name: envConnID, Key: "secret"},
+		}},
+		{Name: "UNKNOWN_CONN", ValueFrom: &v1alpha1.EnvironmentVariableSource{
+			ConnectionRef: &v1alpha1.KeySelector{Name: "unknown", Key: "password"},
+		}},
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(`invalid environment variable name "1INVALID"`))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(md_routes.DuplicatedEnvErrorMessage, "DUPLICATED")))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(md_routes.EnvValueSourceErrorMessage, "BOTH")))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(md_routes.EnvValueFromErrorMessage, "NO_REF")))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(md_routes.EnvValueFromErrorMessage, "TWO_REFS")))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.UnknownConnectionFieldErrorMessage, "secret", envConnID, connection.FieldKeys,
+	)))
+	s.g.Expect(err.Error()).To(ContainSubstring(`entity "unknown" is not found`))
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateVolumes() {
+	md := validDeployment
+	md.Spec.Volumes = []v1alpha1.DeploymentVolume{
+		{Name: "config", MountPath: "/etc/model", ConfigMap: "model-config"},
+		{Name: "secrets", MountPath: "/etc/secrets", Secret: "model-secrets"},
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).ShouldNot(HaveOccurred())
+}
+
+func (s *ModelDeploymentValidationSuite) TestValidateVolumesInvalid() {
+	md := validDeployment
+	md.Spec.Volumes = []v1alpha1.DeploymentVolume{
+		{Name: "Invalid_Name", MountPath: "/etc/a", ConfigMap: "model-config"},
+		{Name: "both", MountPath: "/etc/b", ConfigMap: "model-config", Secret: "model-secrets"},
+		{Name: "none", MountPath: "/etc/c"},
+		{Name: "relative", MountPath: "etc/d", Secret: "model-secrets"},
+		{Name: "reserved", MountPath: "/tmp", Secret: "model-secrets"},
+		{Name: "same-path", MountPath: "/etc/a", Secret: "model-secrets"},
+		{Name: "same-path", MountPath: "/etc/e", Secret: "model-secrets"},
+	}
+	err := s.defaultModelValidator.ValidatesMDAndSetDefaults(&md)
+	s.g.Expect(err).Should(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(`invalid volume name "Invalid_Name"`))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(md_routes.VolumeSourceErrorMessage, "both")))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(md_routes.VolumeSourceErrorMessage, "none")))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.InvalidMountPathErrorMessage, "etc/d", "relative",
+	)))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(
+		md_routes.ReservedMountPathErrorMessage, "/tmp", "reserved",
+	)))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(md_routes.DuplicatedMountPathErrorMessage, "/etc/a")))
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(md_routes.DuplicatedVolumeErrorMessage, "same-path")))
+}
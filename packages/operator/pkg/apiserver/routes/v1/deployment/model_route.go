@@ -40,6 +40,7 @@ const (
 	UpdateModelRouteURL = "/model/route"
 	DeleteModelRouteURL = "/model/route/:id"
 	EventsModelRouteURL = "/model/route-events"
+	StreamModelRouteEventsURL = "/model/route-events/stream"
 	IDMrURLParam        = "id"
 )
 
@@ -49,12 +50,14 @@ var (
 
 type RoutesEventGetter interface {
 	Get(ctx context.Context, cursor int) ([]event.RouteEvent, int, error)
+	Snapshot(ctx context.Context) ([]event.RouteEvent, int, error)
 }
 
 type ModelRouteController struct {
 	service      service.Service
 	validator    *MrValidator
 	eventsReader RoutesEventGetter
+	eventsStreamer routes.EventStreamer
 }
 
 // @Summary Get a Model route
@@ -219,21 +222,36 @@ func (mrc *ModelRouteController) deleteMR(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param cursor query int false "Cursor can be passed to get only new changes"
+// @Param snapshot query bool false "Return only the last event of each entity and the cursor to resume from"
 // @Success 200 {object} event.LatestRouteEvents
 // @Failure 400 {object} httputil.HTTPResult
+// @Failure 410 {object} httputil.HTTPResult
 // @Router /api/v1/model/route-events [get]
 func (mrc *ModelRouteController) getRouteEvents(c *gin.Context) {
 	var cursor int
 	var err error
 
-	if err = ValidateAndParseCursor(c, &cursor); err != nil {
+	if err = routes.ValidateAndParseCursor(c, &cursor); err != nil {
 		return
 	}
 
-	events, newCursor, err := mrc.eventsReader.Get(c.Request.Context(), cursor)
+	var snapshot bool
+	if err = routes.ValidateAndParseSnapshot(c, &snapshot); err != nil {
+		return
+	}
+
+	var events []event.RouteEvent
+	var newCursor int
+	if snapshot {
+		events, newCursor, err = mrc.eventsReader.Snapshot(c.Request.Context())
+	} else {
+		events, newCursor, err = mrc.eventsReader.Get(c.Request.Context(), cursor)
+	}
 	if err != nil {
 		logMR.Error(err, "Retrieving list of model route events")
 		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
 	}
 
 	response := event.LatestRouteEvents{
@@ -242,4 +260,24 @@ func (mrc *ModelRouteController) getRouteEvents(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, response)
 
-}
\ No newline at end of file
+}
+
+// @Summary Stream Changes for ModelRoute entities
+// @Description Stream new ModelRoute events as server-sent events. Each "events" message contains
+// @Description event.LatestRouteEvents and has the cursor as an id. Pass the id in Last-Event-ID header to resume
+// @Tags Route
+// @Produce  text/event-stream
+// @Param cursor query int false "Cursor can be passed to get only new changes"
+// @Param Last-Event-ID header int false "Cursor to resume from. Takes precedence over cursor query parameter"
+// @Success 200 {object} event.LatestRouteEvents
+// @Failure 400 {object} httputil.HTTPResult
+// @Failure 410 {object} httputil.HTTPResult
+// @Router /api/v1/model/route-events/stream [get]
+func (mrc *ModelRouteController) streamRouteEvents(c *gin.Context) {
+	mrc.eventsStreamer.Stream(c, event.ModelRouteEventGroup,
+		func(ctx context.Context, cursor int) (interface{}, int, error) {
+			events, newCursor, err := mrc.eventsReader.Get(ctx, cursor)
+			return event.LatestRouteEvents{Events: events, Cursor: newCursor}, newCursor, err
+		},
+	)
+}
@@ -30,10 +30,13 @@ import (
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/deployment/mocks"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/audit"
+	conn_memory "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection/memory"
 	dep_repository_db "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/postgres"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
 	route_repository_db "github.com/odahu/odahu-flow/packages/operator/pkg/repository/route/postgres"
 	md_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment"
+	predictor_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/predictor"
 	mr_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
 	httputil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
 	. "github.com/onsi/gomega"
@@ -69,8 +72,9 @@ func (s *ModelRouteSuite) SetupSuite() {
 	s.mdService = md_service.NewService(
 		dep_repository_db.DeploymentRepo{DB: db},
 		route_repository_db.RouteRepo{DB: db},
-		outbox.EventPublisher{DB: db})
-	s.mrService = mr_service.NewService(s.mrRepo, outbox.EventPublisher{DB: db})
+		outbox.EventPublisher{DB: db},
+		audit.Recorder{DB: db})
+	s.mrService = mr_service.NewService(s.mrRepo, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db})
 	s.mrEventsGetter = &mocks.RoutesEventGetter{}
 
 	err := s.mdService.CreateModelDeployment(context.Background(), &deployment.ModelDeployment{
@@ -125,7 +129,11 @@ func (s *ModelRouteSuite) SetupTest() {
 func (s *ModelRouteSuite) registerHTTPHandlers(deploymentConfig config.ModelDeploymentConfig) {
 	s.server = gin.Default()
 	v1Group := s.server.Group("")
-	dep_route.ConfigureRoutes(v1Group, s.mdService, nil, s.mrService, s.mrEventsGetter,
+	dep_route.ConfigureRoutes(v1Group, s.mdService, nil, s.mrService, s.mrEventsGetter, routes.EventStreamer{},
+		conn_memory.NewRepository(),
+		predictor_service.NewService(
+			dep_repository_db.PredictorRepo{DB: db}, dep_repository_db.DeploymentRepo{DB: db}, audit.Recorder{DB: db},
+		),
 		deploymentConfig, config.NvidiaResourceName)
 }
 
@@ -756,3 +764,61 @@ func (s *ModelRouteSuite) TestGetRouteEventsWithCursor() {
 	s.g.Expect(result.Events).Should(Equal(events))
 	s.g.Expect(err).NotTo(HaveOccurred())
 }
+
+func (s *ModelRouteSuite) TestGetRouteEventsSnapshot() {
+
+	events := []event.RouteEvent{
+		{
+			EntityID:  "route-1",
+			Payload:   deployment.ModelRoute{ID: "route-1"},
+			EventType: event.ModelRouteUpdatedEventType,
+			Datetime:  time.Time{},
+		},
+	}
+
+	s.mrEventsGetter.On("Snapshot", mock.Anything).Return(events, 12, nil)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		http.MethodGet,
+		dep_route.EventsModelRouteURL+"?snapshot=true",
+		nil,
+	)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.server.ServeHTTP(w, req)
+	s.g.Expect(w.Code).Should(Equal(200))
+
+	var result event.LatestRouteEvents
+	err = json.Unmarshal(w.Body.Bytes(), &result)
+	s.g.Expect(result.Cursor).Should(Equal(12))
+	s.g.Expect(result.Events).Should(Equal(events))
+	s.g.Expect(err).NotTo(HaveOccurred())
+}
+
+func (s *ModelRouteSuite) TestGetRouteEventsCompactedCursor() {
+	s.mrEventsGetter.On("Get", mock.Anything, 3).Return(
+		nil, 3, errors.CursorCompactedError{Cursor: 3},
+	)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		http.MethodGet,
+		dep_route.EventsModelRouteURL+"?cursor=3",
+		nil,
+	)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.server.ServeHTTP(w, req)
+	s.g.Expect(w.Code).Should(Equal(http.StatusGone))
+}
+
+func (s *ModelRouteSuite) TestGetRouteEventsWrongSnapshotParam() {
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		http.MethodGet,
+		dep_route.EventsModelRouteURL+"?snapshot=yes-please",
+		nil,
+	)
+	s.g.Expect(err).NotTo(HaveOccurred())
+	s.server.ServeHTTP(w, req)
+	s.g.Expect(w.Code).Should(Equal(http.StatusBadRequest))
+}
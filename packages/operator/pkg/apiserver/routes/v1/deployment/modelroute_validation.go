@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
 	md_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment"
+	mr_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
 	"go.uber.org/multierr"
 	"strings"
@@ -39,6 +41,7 @@ const (
 	InvalidCustomPrefix        = "custom URL prefix must start with %s"
 	ErrorMessageTemplate       = "%s: %s"
 	ValidationMrErrorMessage   = "Validation of model route is failed"
+	RuleErrorMessageTemplate   = "rule %q: %s"
 )
 
 var (
@@ -64,6 +67,11 @@ func (mrv *MrValidator) ValidatesAndSetDefaults(mr *deployment.ModelRoute) (err
 
 	err = multierr.Append(err, mrv.validateMainParameters(mr))
 
+	// Rollout manages weights of targets, so it is validated first
+	err = multierr.Append(err, validateRollout(mr))
+
+	err = multierr.Append(err, validateRules(mr))
+
 	err = multierr.Append(err, mrv.validateModelDeploymentTargets(mr))
 
 	return
@@ -102,26 +110,42 @@ func (mrv *MrValidator) validateMainParameters(mr *deployment.ModelRoute) (err e
 }
 
 func (mrv *MrValidator) validateModelDeploymentTargets(mr *deployment.ModelRoute) (err error) {
-	switch len(mr.Spec.ModelDeploymentTargets) {
+	err = multierr.Append(err, mrv.validateTargets(mr.ID, mr.Spec.ModelDeploymentTargets))
+
+	for _, rule := range mr.Spec.Rules {
+		if len(rule.ModelDeploymentTargets) == 0 {
+			// Reported by validateRules
+			continue
+		}
+		if targetsErr := mrv.validateTargets(mr.ID, rule.ModelDeploymentTargets); targetsErr != nil {
+			err = multierr.Append(err, fmt.Errorf(RuleErrorMessageTemplate, rule.Name, targetsErr.Error()))
+		}
+	}
+
+	return err
+}
+
+func (mrv *MrValidator) validateTargets(mrID string, targets []v1alpha1.ModelDeploymentTarget) (err error) {
+	switch len(targets) {
 	case 0:
 		err = multierr.Append(err, errors.New(EmptyTargetErrorMessage))
 	case 1:
-		mdt := mr.Spec.ModelDeploymentTargets[0]
+		mdt := targets[0]
 
 		if _, k8sError := mrv.mdService.GetModelDeployment(context.TODO(), mdt.Name); k8sError != nil {
 			err = multierr.Append(err, k8sError)
 		}
 		if mdt.Weight == nil {
 			logMR.Info("Weight parameter is nil. Set the default value",
-				"Model Route name", mr.ID, "weight", MaxWeight)
-			mr.Spec.ModelDeploymentTargets[0].Weight = &MaxWeight
+				"Model Route name", mrID, "weight", MaxWeight)
+			targets[0].Weight = &MaxWeight
 		} else if *mdt.Weight != 100 {
 			err = multierr.Append(err, errors.New(OneTargetErrorMessage))
 		}
 	default:
 		weightSum := int32(0)
 
-		for _, mdt := range mr.Spec.ModelDeploymentTargets {
+		for _, mdt := range targets {
 			if _, k8sError := mrv.mdService.GetModelDeployment(context.TODO(), mdt.Name); k8sError != nil {
 				err = multierr.Append(err, k8sError)
 			}
@@ -141,3 +165,18 @@ func (mrv *MrValidator) validateModelDeploymentTargets(mr *deployment.ModelRoute
 
 	return err
 }
+
+func validateRollout(mr *deployment.ModelRoute) (err error) {
+	mr_service.SetRolloutDefaults(&mr.Spec)
+	for _, rolloutErr := range mr_service.ValidateRollout(mr.Spec) {
+		err = multierr.Append(err, rolloutErr)
+	}
+	return err
+}
+
+func validateRules(mr *deployment.ModelRoute) (err error) {
+	for _, ruleErr := range mr_service.ValidateRules(mr.Spec) {
+		err = multierr.Append(err, ruleErr)
+	}
+	return err
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
 	repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/postgres"
 	md_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment"
+	mr_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
 	"github.com/stretchr/testify/suite"
 	"testing"
@@ -43,7 +44,7 @@ func (s *ModelRouteValidationSuite) SetupTest() {
 
 func (s *ModelRouteValidationSuite) SetupSuite() {
 
-	s.validator = dep_route.NewMrValidator(md_service.NewService(repo.DeploymentRepo{DB: db}, nil, nil))
+	s.validator = dep_route.NewMrValidator(md_service.NewService(repo.DeploymentRepo{DB: db}, nil, nil, nil))
 }
 
 func TestModelPackagingValidationSuite(t *testing.T) {
@@ -200,6 +201,91 @@ func (s *ModelRouteValidationSuite) TestMultipleTargetsNotExist() {
 	s.g.Expect(err.Error()).To(ContainSubstring("entity \"not-exists\" is not found"))
 }
 
+func (s *ModelRouteValidationSuite) TestRolloutSetsWeights() {
+	mr := &deployment.ModelRoute{
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{
+				{Name: mdID1},
+				{Name: mdID2},
+			},
+			Rollout: &v1alpha1.RolloutPolicy{Canary: mdID2, StepWeights: []int32{10, 100}},
+		},
+	}
+
+	err := s.validator.ValidatesAndSetDefaults(mr)
+	s.g.Expect(err).To(HaveOccurred())
+	s.g.Expect(err.Error()).NotTo(ContainSubstring(dep_route.MissedWeightErrorMessage))
+	s.g.Expect(*mr.Spec.ModelDeploymentTargets[0].Weight).To(Equal(int32(100)))
+	s.g.Expect(*mr.Spec.ModelDeploymentTargets[1].Weight).To(Equal(int32(0)))
+	s.g.Expect(mr.Spec.Rollout.StepInterval).To(Equal(mr_service.DefaultStepInterval))
+}
+
+func (s *ModelRouteValidationSuite) TestRolloutUnknownCanary() {
+	mr := &deployment.ModelRoute{
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{
+				{Name: mdID1},
+				{Name: mdID2},
+			},
+			Rollout: &v1alpha1.RolloutPolicy{Canary: "not-exists", StepWeights: []int32{10, 100}},
+		},
+	}
+
+	err := s.validator.ValidatesAndSetDefaults(mr)
+	s.g.Expect(err).To(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(fmt.Sprintf(mr_service.UnknownCanaryErrorMessage, "not-exists")))
+}
+
+func (s *ModelRouteValidationSuite) TestRuleTargets() {
+	mr := &deployment.ModelRoute{
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{
+				{Name: mdID1},
+			},
+			Rules: []v1alpha1.RouteRule{
+				{
+					Name:                   "testers",
+					Match:                  []v1alpha1.RouteMatch{{Type: v1alpha1.HeaderRouteMatch, Name: "X-Tester", Exact: "1"}},
+					ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{{Name: mdID2}},
+				},
+				{
+					Name:                   "shadow",
+					Match:                  []v1alpha1.RouteMatch{{Type: v1alpha1.HeaderRouteMatch, Name: "X-Tester", Exact: "2"}},
+					ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{{Name: "not-exists"}},
+				},
+			},
+		},
+	}
+
+	err := s.validator.ValidatesAndSetDefaults(mr)
+	s.g.Expect(err).To(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring("rule \"shadow\": entity \"not-exists\" is not found"))
+	s.g.Expect(err.Error()).NotTo(ContainSubstring("rule \"testers\""))
+	s.g.Expect(*mr.Spec.Rules[0].ModelDeploymentTargets[0].Weight).To(Equal(int32(100)))
+}
+
+func (s *ModelRouteValidationSuite) TestOverlappingRules() {
+	rule := v1alpha1.RouteRule{
+		Name:                   "testers",
+		Match:                  []v1alpha1.RouteMatch{{Type: v1alpha1.CookieRouteMatch, Name: "tester", Exact: "1"}},
+		ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{{Name: mdID2}},
+	}
+	otherRule := *rule.DeepCopy()
+	otherRule.Name = "shadow"
+	mr := &deployment.ModelRoute{
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{{Name: mdID1}},
+			Rules:                  []v1alpha1.RouteRule{rule, otherRule},
+		},
+	}
+
+	err := s.validator.ValidatesAndSetDefaults(mr)
+	s.g.Expect(err).To(HaveOccurred())
+	s.g.Expect(err.Error()).To(ContainSubstring(
+		fmt.Sprintf(mr_service.OverlappingRulesErrorMessage, "testers", "shadow"),
+	))
+}
+
 func (s *ModelRouteValidationSuite) TestURLStartWithSlash() {
 	mr := &deployment.ModelRoute{
 		Spec: v1alpha1.ModelRouteSpec{
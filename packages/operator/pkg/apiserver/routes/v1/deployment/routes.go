@@ -20,6 +20,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	conn_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection"
 	md_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment"
 	mr_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
 )
@@ -27,12 +28,15 @@ import (
 func ConfigureRoutes(routeGroup *gin.RouterGroup, mdService md_service.Service,
 	mdEventsReader ModelDeploymentEventGetter,
 	mrService mr_service.Service,
-	mrEventsReader RoutesEventGetter, deploymentConfig config.ModelDeploymentConfig, gpuResourceName string, ) {
+	mrEventsReader RoutesEventGetter, eventsStreamer routes.EventStreamer,
+	connRepository conn_repository.Repository, predictorGetter PredictorGetter,
+	deploymentConfig config.ModelDeploymentConfig, gpuResourceName string, ) {
 
 	mdController := ModelDeploymentController{
 		mdService:   mdService,
-		mdValidator: NewModelDeploymentValidator(deploymentConfig, gpuResourceName),
+		mdValidator: NewModelDeploymentValidator(connRepository, predictorGetter, deploymentConfig, gpuResourceName),
 		eventsReader: mdEventsReader,
+		eventsStreamer: eventsStreamer,
 	}
 	routeGroup = routeGroup.Group("", routes.DisableAPIMiddleware(deploymentConfig.Enabled))
 
@@ -43,11 +47,17 @@ func ConfigureRoutes(routeGroup *gin.RouterGroup, mdService md_service.Service,
 	routeGroup.DELETE(DeleteModelDeploymentURL, mdController.deleteMD)
 	routeGroup.GET(GetModelDeploymentDefaultRouteURL, mdController.getDefaultRoute)
 	routeGroup.GET(EventsModelDeploymentURL, mdController.getDeploymentEvents)
+	routeGroup.GET(StreamModelDeploymentEventsURL, mdController.streamDeploymentEvents)
+	routeGroup.GET(GetAllModelDeploymentRevisionURL, mdController.getAllRevisions)
+	routeGroup.GET(GetModelDeploymentRevisionURL, mdController.getRevision)
+	routeGroup.GET(DiffModelDeploymentRevisionURL, mdController.diffRevisions)
+	routeGroup.POST(RollbackModelDeploymentURL, mdController.rollbackMD)
 
 	mrController := ModelRouteController{
 		service: mrService,
 		validator:        NewMrValidator(mdService),
 		eventsReader: mrEventsReader,
+		eventsStreamer: eventsStreamer,
 	}
 	routeGroup.GET(GetModelRouteURL, mrController.getMR)
 	routeGroup.GET(GetAllModelRouteURL, mrController.getAllMRs)
@@ -55,4 +65,5 @@ func ConfigureRoutes(routeGroup *gin.RouterGroup, mdService md_service.Service,
 	routeGroup.PUT(UpdateModelRouteURL, mrController.updateMR)
 	routeGroup.DELETE(DeleteModelRouteURL, mrController.deleteMR)
 	routeGroup.GET(EventsModelRouteURL, mrController.getRouteEvents)
+	routeGroup.GET(StreamModelRouteEventsURL, mrController.streamRouteEvents)
 }
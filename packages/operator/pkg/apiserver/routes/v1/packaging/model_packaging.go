@@ -17,9 +17,11 @@
 package packaging
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
@@ -44,6 +46,7 @@ const (
 	UpdateModelPackagingURL     = "/model/packaging"
 	SaveModelPackagingResultURL = "/model/packaging/:id/result"
 	DeleteModelPackagingURL     = "/model/packaging/:id"
+	EventsModelPackagingURL     = "/model/packaging-events"
 	IDMpURLParam                = "id"
 	FollowURLParam              = "follow"
 )
@@ -61,10 +64,16 @@ func init() {
 	}
 }
 
+type ModelPackagingEventGetter interface {
+	Get(ctx context.Context, cursor int) ([]event.PackagingEvent, int, error)
+	Snapshot(ctx context.Context) ([]event.PackagingEvent, int, error)
+}
+
 type ModelPackagingController struct {
-	kubeClient  kube_client.Client
-	packService mp_service.Service
-	validator   *MpValidator
+	kubeClient   kube_client.Client
+	packService  mp_service.Service
+	validator    *MpValidator
+	eventsReader ModelPackagingEventGetter
 }
 
 // @Summary Get a Model Packaging
@@ -295,3 +304,46 @@ func (mpc *ModelPackagingController) getModelPackagingLog(c *gin.Context) {
 		return
 	}
 }
+
+// @Summary Get Last Changes for ModelPackaging entities
+// @Description Get Last Changes for ModelPackaging entity
+// @Tags Packaging
+// @Accept  json
+// @Produce  json
+// @Param cursor query int false "Cursor can be passed to get only new changes"
+// @Param snapshot query bool false "Return only the last event of each entity and the cursor to resume from"
+// @Success 200 {object} event.LatestPackagingEvents
+// @Failure 400 {object} httputil.HTTPResult
+// @Failure 410 {object} httputil.HTTPResult
+// @Router /api/v1/model/packaging-events [get]
+func (mpc *ModelPackagingController) getPackagingEvents(c *gin.Context) {
+	var cursor int
+	var err error
+	if err = routes.ValidateAndParseCursor(c, &cursor); err != nil {
+		return
+	}
+
+	var snapshot bool
+	if err = routes.ValidateAndParseSnapshot(c, &snapshot); err != nil {
+		return
+	}
+
+	var events []event.PackagingEvent
+	var newCursor int
+	if snapshot {
+		events, newCursor, err = mpc.eventsReader.Snapshot(c.Request.Context())
+	} else {
+		events, newCursor, err = mpc.eventsReader.Get(c.Request.Context(), cursor)
+	}
+	if err != nil {
+		logMP.Error(err, "Retrieving list of ModelPackaging events")
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, event.LatestPackagingEvents{
+		Events: events,
+		Cursor: newCursor,
+	})
+}
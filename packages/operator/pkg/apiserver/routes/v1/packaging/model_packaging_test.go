@@ -31,8 +31,10 @@ import (
 	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	kube_client "github.com/odahu/odahu-flow/packages/operator/pkg/kubeclient/packagingclient"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/odahuflow"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/audit"
 	conn_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection"
 	conn_k8s_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection/kubernetes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
 	mp_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/packaging"
 	mp_postgres_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/packaging/postgres"
 	mp_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/packaging"
@@ -90,7 +92,7 @@ func (s *ModelPackagingRouteSuite) SetupSuite() {
 	piRepo := mp_postgres_repository.PackagingIntegrationRepository{DB: db}
 	s.piService = packaging_integration.NewService(&piRepo)
 	s.packRepo = mp_postgres_repository.PackagingRepo{DB: db}
-	s.packService = mp_service.NewService(s.packRepo)
+	s.packService = mp_service.NewService(s.packRepo, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db})
 
 	err := s.piService.CreatePackagingIntegration(&packaging.PackagingIntegration{
 		ID: piIDMpRoute,
@@ -145,7 +147,7 @@ func (s *ModelPackagingRouteSuite) registerHandlers(packagingConfig config.Model
 	packGroup := v1Group.Group("", routes.DisableAPIMiddleware(packagingConfig.Enabled))
 
 	pack_route.ConfigureRoutes(
-		packGroup, s.kubePackClient, s.packService,
+		packGroup, s.kubePackClient, s.packService, outbox.PackagingEventGetter{DB: db},
 		s.piService, s.connStorage, packagingConfig,
 		config.NvidiaResourceName,
 	)
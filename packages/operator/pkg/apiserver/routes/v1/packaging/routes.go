@@ -28,14 +28,16 @@ func ConfigureRoutes(
 	routeGroup *gin.RouterGroup,
 	packKubeClient mp_kube_client.Client,
 	packService mp_service.Service,
+	packEventsReader ModelPackagingEventGetter,
 	piService packagingIntegrationService,
 	connRepo conn_repository.Repository,
 	config config.ModelPackagingConfig,
 	gpuResourceName string) {
 
 	mtController := ModelPackagingController{
-		kubeClient:  packKubeClient,
-		packService: packService,
+		kubeClient:   packKubeClient,
+		packService:  packService,
+		eventsReader: packEventsReader,
 		validator: NewMpValidator(
 			piService,
 			connRepo,
@@ -51,5 +53,6 @@ func ConfigureRoutes(
 	routeGroup.PUT(UpdateModelPackagingURL, mtController.updateMP)
 	routeGroup.PUT(SaveModelPackagingResultURL, mtController.saveMPResults)
 	routeGroup.DELETE(DeleteModelPackagingURL, mtController.deleteMP)
+	routeGroup.GET(EventsModelPackagingURL, mtController.getPackagingEvents)
 
 }
@@ -0,0 +1,227 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	logutils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/log"
+	"net/http"
+	"reflect"
+)
+
+const (
+	GetURL    = "/model/pipeline/:id"
+	ListURL   = "/model/pipeline"
+	PostURL   = "/model/pipeline"
+	DeleteURL = "/model/pipeline/:id"
+	idParam   = "id"
+)
+
+var (
+	fieldsCache = map[string]int{}
+)
+
+func init() {
+	elem := reflect.TypeOf(&pipeline.Filter{}).Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		tagName := elem.Field(i).Tag.Get(pipeline.TagKey)
+
+		fieldsCache[tagName] = i
+	}
+}
+
+type Service interface {
+	Create(ctx context.Context, p *pipeline.ModelPipeline) error
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (pipeline.ModelPipeline, error)
+	List(ctx context.Context, options ...filter.ListOption) ([]pipeline.ModelPipeline, error)
+}
+
+type validator interface {
+	ValidatesAndSetDefaults(p *pipeline.ModelPipeline) error
+}
+
+type controller struct {
+	service   Service
+	validator validator
+}
+
+func SetupRoutes(routes gin.IRoutes, service Service, validator validator) {
+	controller := controller{service: service, validator: validator}
+	routes.GET(GetURL, controller.Get)
+	routes.GET(ListURL, controller.List)
+	routes.POST(PostURL, controller.Post)
+	routes.DELETE(DeleteURL, controller.Delete)
+}
+
+// @Summary Get a Model Pipeline
+// @Description Get a Model Pipeline by id. Status contains state and results of every started stage
+// @Tags Pipeline
+// @Name id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model Pipeline id"
+// @Success 200 {object} pipeline.ModelPipeline
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/pipeline/{id} [get]
+func (cr *controller) Get(c *gin.Context) {
+	id := c.Param(idParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	p, err := cr.service.Get(ctx, id)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Retrieving %s Model Pipeline", id))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// @Summary List Model Pipelines
+// @Description List Model Pipelines
+// @Tags Pipeline
+// @Accept  json
+// @Produce  json
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Param state query string false "Pipeline state: running, succeeded or failed"
+// @Success 200 {array} pipeline.ModelPipeline
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/pipeline [get]
+func (cr *controller) List(c *gin.Context) {
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	f := &pipeline.Filter{}
+	size, page, err := routes.URLParamsToFilter(c, f, fieldsCache)
+	if err != nil {
+		log.Error(err, "Malformed url parameters of model pipeline request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	res, err := cr.service.List(ctx, filter.ListFilter(f), filter.Size(size), filter.Page(page))
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, "Listing Model Pipelines")
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary Create a Model Pipeline
+// @Description Create a Model Pipeline. The pipeline creates a Model Training, then a Model Packaging of
+// @Description the trained artifact and optionally a Model Deployment of the packaged image.
+// @Description Every stage entity gets the pipeline id
+// @Tags Pipeline
+// @Accept  json
+// @Produce  json
+// @Param pipeline body pipeline.ModelPipeline true "Model Pipeline". Only `id` and `spec` are taken into account
+// @Success 201 {object} pipeline.ModelPipeline
+// @Failure 409 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/pipeline [post]
+func (cr *controller) Post(c *gin.Context) {
+
+	var p pipeline.ModelPipeline
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	if err := c.ShouldBindJSON(&p); err != nil {
+		log.Error(err, "JSON binding of the Model Pipeline is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if err := cr.validator.ValidatesAndSetDefaults(&p); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if p.Spec.Deployment != nil {
+		if err := deployment.CheckSecretAccess(ctx, *p.Spec.Deployment); err != nil {
+			c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+			return
+		}
+	}
+
+	if err := cr.service.Create(ctx, &p); err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Creating %s Model Pipeline", p.ID))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, p)
+}
+
+// @Summary Delete a Model Pipeline
+// @Description Delete a Model Pipeline by id. Entities which were created by the pipeline stages are kept
+// @Tags Pipeline
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model Pipeline id"
+// @Success 200 {object} httputil.HTTPResult
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/pipeline/{id} [delete]
+func (cr *controller) Delete(c *gin.Context) {
+
+	id := c.Param(idParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	if err := cr.service.Delete(ctx, id); err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Deleting %s Model Pipeline", id))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, httputil.HTTPResult{Message: fmt.Sprintf("Model pipeline %s was deleted", id)})
+}
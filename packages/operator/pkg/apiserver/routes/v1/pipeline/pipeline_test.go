@@ -0,0 +1,239 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/user"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/pipeline/mocks"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type stubValidator struct {
+	err error
+}
+
+func (v stubValidator) ValidatesAndSetDefaults(p *api_types.ModelPipeline) error {
+	return v.err
+}
+
+type stubStageValidator struct {
+	err error
+}
+
+func (v stubStageValidator) ValidatesAndSetDefaults(mt *training.ModelTraining) error {
+	mt.Spec.Image = "trainer:latest"
+	return v.err
+}
+
+func (v stubStageValidator) ValidateAndSetDefaults(mp *packaging.ModelPackaging) error {
+	mp.Spec.Image = "packager:latest"
+	return v.err
+}
+
+func (v stubStageValidator) ValidatesMDAndSetDefaults(md *deployment.ModelDeployment) error {
+	roleName := "role-" + md.ID
+	md.Spec.RoleName = &roleName
+	return v.err
+}
+
+func TestGet(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Get", mock.Anything, "wine").Return(api_types.ModelPipeline{
+		ID:     "wine",
+		Status: api_types.ModelPipelineStatus{State: api_types.Running, CurrentStage: api_types.PackagingStage},
+	}, nil)
+	pipeline.SetupRoutes(router, service, stubValidator{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, strings.Replace(pipeline.GetURL, ":id", "wine", -1), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result api_types.ModelPipeline
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, api_types.PackagingStage, result.Status.CurrentStage)
+}
+
+func TestGetNotFound(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Get", mock.Anything, "wine").
+		Return(api_types.ModelPipeline{}, odahu_errors.NotFoundError{Entity: "wine"})
+	pipeline.SetupRoutes(router, service, stubValidator{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, strings.Replace(pipeline.GetURL, ":id", "wine", -1), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListByState(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("List", mock.Anything,
+		mock.AnythingOfType("filter.ListOption"),
+		mock.AnythingOfType("filter.ListOption"),
+		mock.AnythingOfType("filter.ListOption"),
+	).Run(func(args mock.Arguments) {
+		options := &filter.ListOptions{}
+		args.Get(1).(filter.ListOption)(options)
+		assert.Equal(t, []string{"failed"}, options.Filter.(*api_types.Filter).State)
+	}).Return([]api_types.ModelPipeline{{ID: "wine"}}, nil)
+	pipeline.SetupRoutes(router, service, stubValidator{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, pipeline.ListURL+"?state=failed", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestPost(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Create", mock.Anything, mock.MatchedBy(func(p *api_types.ModelPipeline) bool {
+		return p.ID == "wine" && p.Spec.Packaging.IntegrationName == "docker-rest"
+	})).Return(nil)
+	pipeline.SetupRoutes(router, service, stubValidator{})
+
+	body, _ := json.Marshal(api_types.ModelPipeline{
+		ID:   "wine",
+		Spec: api_types.ModelPipelineSpec{Packaging: packaging.ModelPackagingSpec{IntegrationName: "docker-rest"}},
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, pipeline.PostURL, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestPostInvalid(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	pipeline.SetupRoutes(router, service, stubValidator{err: errors.New("empty toolchain")})
+
+	body, _ := json.Marshal(api_types.ModelPipeline{ID: "wine"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, pipeline.PostURL, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	service.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPostSecretAccess(t *testing.T) {
+	specs := map[string]v1alpha1.ModelDeploymentSpec{
+		"connection": {Envs: []v1alpha1.DeploymentEnvironmentVariable{{
+			Name: "DB_PASSWORD",
+			ValueFrom: &v1alpha1.EnvironmentVariableSource{
+				ConnectionRef: &v1alpha1.KeySelector{Name: "db", Key: "password"},
+			},
+		}}},
+		"secret": {Envs: []v1alpha1.DeploymentEnvironmentVariable{{
+			Name: "DB_PASSWORD",
+			ValueFrom: &v1alpha1.EnvironmentVariableSource{
+				SecretKeyRef: &v1alpha1.KeySelector{Name: "wine-env", Key: "DB_PASSWORD"},
+			},
+		}}},
+		"secret-volume": {Volumes: []v1alpha1.DeploymentVolume{{
+			Name: "secrets", MountPath: "/etc/secrets", Secret: "wine-env",
+		}}},
+	}
+	tests := []struct {
+		role user.Role
+		code int
+	}{
+		{user.DataScientistRole, http.StatusForbidden},
+		{user.AdminRole, http.StatusCreated},
+	}
+	for name, spec := range specs {
+		spec := spec
+		for _, tt := range tests {
+			tt := tt
+			t.Run(name+"/"+string(tt.role), func(t *testing.T) {
+				router := gin.Default()
+				router.Use(func(c *gin.Context) {
+					c.Request = c.Request.WithContext(user.NewRolesContext(c.Request.Context(), []user.Role{tt.role}))
+				})
+				service := &mocks.Service{}
+				service.On("Create", mock.Anything, mock.Anything).Return(nil)
+				pipeline.SetupRoutes(router, service, stubValidator{})
+
+				body, _ := json.Marshal(api_types.ModelPipeline{
+					ID:   "wine",
+					Spec: api_types.ModelPipelineSpec{Deployment: &spec},
+				})
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest(http.MethodPost, pipeline.PostURL, bytes.NewReader(body))
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, tt.code, w.Code)
+			})
+		}
+	}
+}
+
+func TestValidatorSetsDefaultsOfStages(t *testing.T) {
+	validator := pipeline.NewValidator(stubStageValidator{}, stubStageValidator{}, stubStageValidator{})
+	p := &api_types.ModelPipeline{
+		ID:   "wine",
+		Spec: api_types.ModelPipelineSpec{Deployment: &v1alpha1.ModelDeploymentSpec{Predictor: "odahu-ml-server"}},
+	}
+
+	assert.NoError(t, validator.ValidatesAndSetDefaults(p))
+	assert.Equal(t, "trainer:latest", p.Spec.Training.Image)
+	assert.Equal(t, "packager:latest", p.Spec.Packaging.Image)
+	assert.Equal(t, "role-wine", *p.Spec.Deployment.RoleName)
+	// Templates are kept, because they are rendered by the pipeline runner
+	assert.Equal(t, api_types.DefaultArtifactNameTemplate, p.Spec.Packaging.ArtifactName)
+	assert.Equal(t, api_types.DefaultImageTemplate, p.Spec.Deployment.Image)
+}
+
+func TestValidatorCollectsErrorsOfStages(t *testing.T) {
+	validator := pipeline.NewValidator(
+		stubStageValidator{err: errors.New("training error")},
+		stubStageValidator{err: errors.New("packaging error")},
+		stubStageValidator{},
+	)
+
+	err := validator.ValidatesAndSetDefaults(&api_types.ModelPipeline{ID: "wine"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "training error")
+	assert.Contains(t, err.Error(), "packaging error")
+}
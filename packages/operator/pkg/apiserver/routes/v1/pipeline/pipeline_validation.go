@@ -0,0 +1,82 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	pipeline_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/pipeline"
+	"go.uber.org/multierr"
+)
+
+const (
+	ValidationPipelineErrorMessage = "Validation of model pipeline is failed"
+)
+
+type trainingValidator interface {
+	ValidatesAndSetDefaults(mt *training.ModelTraining) error
+}
+
+type packagingValidator interface {
+	ValidateAndSetDefaults(mp *packaging.ModelPackaging) error
+}
+
+type deploymentValidator interface {
+	ValidatesMDAndSetDefaults(md *deployment.ModelDeployment) error
+}
+
+// Validator validates stages of a pipeline in the same way as model trainings, packagings and deployments.
+// Templated fields are validated as is, because they are rendered only when the stage is started
+type Validator struct {
+	mtValidator trainingValidator
+	mpValidator packagingValidator
+	mdValidator deploymentValidator
+}
+
+func NewValidator(
+	mtValidator trainingValidator, mpValidator packagingValidator, mdValidator deploymentValidator,
+) *Validator {
+	return &Validator{mtValidator: mtValidator, mpValidator: mpValidator, mdValidator: mdValidator}
+}
+
+func (pv *Validator) ValidatesAndSetDefaults(p *pipeline.ModelPipeline) (err error) {
+	pipeline_service.SetDefaults(p)
+
+	// Every stage entity gets the pipeline ID
+	mt := training.ModelTraining{ID: p.ID, Spec: p.Spec.Training}
+	err = multierr.Append(err, pv.mtValidator.ValidatesAndSetDefaults(&mt))
+	p.Spec.Training = mt.Spec
+
+	mp := packaging.ModelPackaging{ID: p.ID, Spec: p.Spec.Packaging}
+	err = multierr.Append(err, pv.mpValidator.ValidateAndSetDefaults(&mp))
+	p.Spec.Packaging = mp.Spec
+
+	if p.Spec.Deployment != nil {
+		md := deployment.ModelDeployment{ID: p.ID, Spec: *p.Spec.Deployment}
+		err = multierr.Append(err, pv.mdValidator.ValidatesMDAndSetDefaults(&md))
+		p.Spec.Deployment = &md.Spec
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %s", ValidationPipelineErrorMessage, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	logutils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/log"
+	"net/http"
+)
+
+const (
+	GetURL    = "/predictor/:id"
+	ListURL   = "/predictor"
+	PostURL   = "/predictor"
+	PutURL    = "/predictor"
+	DeleteURL = "/predictor/:id"
+	idParam   = "id"
+)
+
+type Service interface {
+	Create(ctx context.Context, p *deployment.Predictor) (err error)
+	Update(ctx context.Context, p *deployment.Predictor) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	Get(ctx context.Context, id string) (res deployment.Predictor, err error)
+	List(ctx context.Context, options ...filter.ListOption) (res []deployment.Predictor, err error)
+}
+
+type controller struct {
+	service Service
+}
+
+func SetupRoutes(routes gin.IRoutes, service Service) {
+	controller := controller{service: service}
+	routes.GET(GetURL, controller.Get)
+	routes.GET(ListURL, controller.List)
+	routes.POST(PostURL, controller.Post)
+	routes.PUT(PutURL, controller.Put)
+	routes.DELETE(DeleteURL, controller.Delete)
+}
+
+// @Summary Get a Predictor
+// @Description Get a built-in or a custom Predictor by id
+// @Tags Predictor
+// @Name id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Predictor id"
+// @Success 200 {object} deployment.Predictor
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/predictor/{id} [get]
+func (cr *controller) Get(c *gin.Context) {
+	id := c.Param(idParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	p, err := cr.service.Get(ctx, id)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Retrieving %s Predictor", id))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// @Summary Create a Predictor
+// @Description Register a model server which can be used by Model Deployments
+// @Tags Predictor
+// @Accept  json
+// @Produce  json
+// @Param predictor body deployment.Predictor true "Predictor". Only `id` and `spec` are taken into account
+// @Success 201 {object} deployment.Predictor
+// @Failure 409 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/predictor [post]
+func (cr *controller) Post(c *gin.Context) {
+
+	var p deployment.Predictor
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	if err := c.ShouldBindJSON(&p); err != nil {
+		log.Error(err, "JSON binding of the Predictor is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	err := cr.service.Create(ctx, &p)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Creating %s Predictor", p.ID))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, p)
+}
+
+// @Summary Update a Predictor
+// @Description Update a custom Predictor. Built-in Predictors can not be changed.
+// @Description Model Deployments pick up the change on their next reconciliation
+// @Tags Predictor
+// @Accept  json
+// @Produce  json
+// @Param predictor body deployment.Predictor true "Predictor". Only `id` and `spec` are taken into account
+// @Success 200 {object} deployment.Predictor
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 403 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/predictor [put]
+func (cr *controller) Put(c *gin.Context) {
+
+	var p deployment.Predictor
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	if err := c.ShouldBindJSON(&p); err != nil {
+		log.Error(err, "JSON binding of the Predictor is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	err := cr.service.Update(ctx, &p)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Updating %s Predictor", p.ID))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// @Summary Delete a Predictor
+// @Description Delete a custom Predictor by id. Built-in Predictors and Predictors in use can not be deleted
+// @Tags Predictor
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Predictor id"
+// @Success 200 {object} httputil.HTTPResult
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 403 {object} httputil.HTTPResult
+// @Router /api/v1/predictor/{id} [delete]
+func (cr *controller) Delete(c *gin.Context) {
+
+	id := c.Param(idParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	err := cr.service.Delete(ctx, id)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Deleting %s Predictor", id))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, httputil.HTTPResult{Message: fmt.Sprintf("Predictor %s was deleted", id)})
+}
+
+// @Summary List Predictors
+// @Description List Predictors. Built-in Predictors are returned on the first page before the custom ones
+// @Tags Predictor
+// @Accept  json
+// @Produce  json
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Success 200 {array} deployment.Predictor
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/predictor [get]
+func (cr *controller) List(c *gin.Context) {
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	size, page, err := routes.URLParamsToFilter(c, nil, map[string]int{})
+	if err != nil {
+		log.Error(err, "Malformed url parameters of predictor request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	res, err := cr.service.List(ctx, filter.Size(size), filter.Page(page))
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, "Listing Predictors")
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,90 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package predictor_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/predictor"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/predictor/mocks"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"odahu-commons/predictors"
+	"strings"
+	"testing"
+)
+
+func TestGetBuiltin(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Get", mock.Anything, predictors.Triton.ID).
+		Return(deployment.NewBuiltinPredictor(predictors.Triton), nil)
+	predictor.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, strings.Replace(predictor.GetURL, ":id", predictors.Triton.ID, -1), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result deployment.Predictor
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.True(t, result.Builtin)
+	assert.Equal(t, predictors.TritonInspector, result.Spec.Inspector.Type)
+}
+
+func TestPost(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Create", mock.Anything, mock.MatchedBy(func(p *deployment.Predictor) bool {
+		return p.ID == "torchserve" && p.Spec.Inspector.OpenAPIPath == "/openapi.json"
+	})).Return(nil)
+	predictor.SetupRoutes(router, service)
+
+	body, _ := json.Marshal(deployment.Predictor{
+		ID: "torchserve",
+		Spec: deployment.PredictorSpec{Inspector: deployment.PredictorInspector{
+			Type: predictors.OpenAPIInspector, OpenAPIPath: "/openapi.json",
+		}},
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, predictor.PostURL, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestDeleteBuiltin(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Delete", mock.Anything, predictors.OdahuMLServer.ID).
+		Return(odahu_errors.ExtendedForbiddenError{Message: "built-in"})
+	predictor.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	url := strings.Replace(predictor.DeleteURL, ":id", predictors.OdahuMLServer.ID, -1)
+	req, _ := http.NewRequest(http.MethodDelete, url, nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,258 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/registry"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	logutils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/log"
+	"net/http"
+	"reflect"
+)
+
+const (
+	ListModelsURL      = "/model/registry"
+	GetModelURL        = "/model/registry/:name"
+	ListVersionsURL    = "/model/registry/:name/version"
+	GetVersionURL      = "/model/registry/:name/version/:version"
+	TransitionStageURL = "/model/registry/:name/version/:version/stage"
+	nameParam          = "name"
+	versionParam       = "version"
+)
+
+var (
+	versionFieldsCache = map[string]int{}
+)
+
+func init() {
+	elem := reflect.TypeOf(&registry.VersionFilter{}).Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		tagName := elem.Field(i).Tag.Get(registry.TagKey)
+
+		versionFieldsCache[tagName] = i
+	}
+}
+
+type Service interface {
+	GetModel(ctx context.Context, name string) (registry.Model, error)
+	ListModels(ctx context.Context, options ...filter.ListOption) ([]registry.Model, error)
+	GetVersion(ctx context.Context, model, version string) (registry.ModelVersion, error)
+	ListVersions(ctx context.Context, model string, options ...filter.ListOption) ([]registry.ModelVersion, error)
+	TransitionStage(
+		ctx context.Context, model, version string, transition registry.StageTransition,
+	) (registry.ModelVersion, error)
+}
+
+type controller struct {
+	service Service
+}
+
+func SetupRoutes(routes gin.IRoutes, service Service) {
+	controller := controller{service: service}
+	routes.GET(ListModelsURL, controller.ListModels)
+	routes.GET(GetModelURL, controller.GetModel)
+	routes.GET(ListVersionsURL, controller.ListVersions)
+	routes.GET(GetVersionURL, controller.GetVersion)
+	routes.PUT(TransitionStageURL, controller.TransitionStage)
+}
+
+// @Summary Get a Model
+// @Description Get a registered Model by name
+// @Tags Registry
+// @Name name
+// @Accept  json
+// @Produce  json
+// @Param name path string true "Model name"
+// @Success 200 {object} registry.Model
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/registry/{name} [get]
+func (cr *controller) GetModel(c *gin.Context) {
+	name := c.Param(nameParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	m, err := cr.service.GetModel(ctx, name)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Retrieving %s Model", name))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, m)
+}
+
+// @Summary List Models
+// @Description List Models which were registered by succeeded trainings
+// @Tags Registry
+// @Accept  json
+// @Produce  json
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Success 200 {array} registry.Model
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/registry [get]
+func (cr *controller) ListModels(c *gin.Context) {
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	size, page, err := routes.URLParamsToFilter(c, nil, map[string]int{})
+	if err != nil {
+		log.Error(err, "Malformed url parameters of model registry request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	res, err := cr.service.ListModels(ctx, filter.Size(size), filter.Page(page))
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, "Listing Models")
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary Get a Model Version
+// @Description Get a Model Version. Lineage contains the training, packagings and deployments of the version
+// @Tags Registry
+// @Accept  json
+// @Produce  json
+// @Param name path string true "Model name"
+// @Param version path string true "Model version"
+// @Success 200 {object} registry.ModelVersion
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/registry/{name}/version/{version} [get]
+func (cr *controller) GetVersion(c *gin.Context) {
+	name := c.Param(nameParam)
+	version := c.Param(versionParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	mv, err := cr.service.GetVersion(ctx, name, version)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Retrieving %s version of %s Model", version, name))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mv)
+}
+
+// @Summary List Model Versions
+// @Description List versions of a Model. The latest registered versions go first
+// @Tags Registry
+// @Accept  json
+// @Produce  json
+// @Param name path string true "Model name"
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Param stage query string false "Stage: none, staging, production or archived"
+// @Success 200 {array} registry.ModelVersion
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/registry/{name}/version [get]
+func (cr *controller) ListVersions(c *gin.Context) {
+	name := c.Param(nameParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	f := &registry.VersionFilter{}
+	size, page, err := routes.URLParamsToFilter(c, f, versionFieldsCache)
+	if err != nil {
+		log.Error(err, "Malformed url parameters of model version request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	res, err := cr.service.ListVersions(ctx, name, filter.ListFilter(f), filter.Size(size), filter.Page(page))
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Listing versions of %s Model", name))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary Transition a Model Version to a stage
+// @Description Move a Model Version to the staging, production, archived or none stage.
+// @Description Other versions of the model in the target stage are archived if archiveExisting is set
+// @Tags Registry
+// @Accept  json
+// @Produce  json
+// @Param name path string true "Model name"
+// @Param version path string true "Model version"
+// @Param transition body registry.StageTransition true "Stage transition"
+// @Success 200 {object} registry.ModelVersion
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/registry/{name}/version/{version}/stage [put]
+func (cr *controller) TransitionStage(c *gin.Context) {
+	name := c.Param(nameParam)
+	version := c.Param(versionParam)
+
+	var transition registry.StageTransition
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	if err := c.ShouldBindJSON(&transition); err != nil {
+		log.Error(err, "JSON binding of the stage transition is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	mv, err := cr.service.TransitionStage(ctx, name, version, transition)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Transitioning %s version of %s Model", version, name))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mv)
+}
@@ -0,0 +1,133 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package registry_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/registry"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/registry"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/registry/mocks"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func versionURL(url string) string {
+	return strings.NewReplacer(":name", "wine", ":version", "1.0").Replace(url)
+}
+
+func TestGetVersion(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("GetVersion", mock.Anything, "wine", "1.0").Return(api_types.ModelVersion{
+		Model:   "wine",
+		Version: "1.0",
+		Stage:   api_types.ProductionStage,
+		Lineage: api_types.Lineage{TrainingID: "wine-training"},
+	}, nil)
+	registry.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, versionURL(registry.GetVersionURL), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result api_types.ModelVersion
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "wine-training", result.Lineage.TrainingID)
+}
+
+func TestGetModelNotFound(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("GetModel", mock.Anything, "wine").
+		Return(api_types.Model{}, odahu_errors.NotFoundError{Entity: "wine"})
+	registry.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, versionURL(registry.GetModelURL), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListVersionsByStage(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("ListVersions", mock.Anything, "wine",
+		mock.AnythingOfType("filter.ListOption"),
+		mock.AnythingOfType("filter.ListOption"),
+		mock.AnythingOfType("filter.ListOption"),
+	).Run(func(args mock.Arguments) {
+		options := &filter.ListOptions{}
+		args.Get(2).(filter.ListOption)(options)
+		assert.Equal(t, []string{"production"}, options.Filter.(*api_types.VersionFilter).Stage)
+	}).Return([]api_types.ModelVersion{{Model: "wine", Version: "1.0"}}, nil)
+	registry.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, versionURL(registry.ListVersionsURL)+"?stage=production", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestTransitionStage(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	transition := api_types.StageTransition{Stage: api_types.ProductionStage, ArchiveExisting: true}
+	service.On("TransitionStage", mock.Anything, "wine", "1.0", transition).Return(api_types.ModelVersion{
+		Model:   "wine",
+		Version: "1.0",
+		Stage:   api_types.ProductionStage,
+	}, nil)
+	registry.SetupRoutes(router, service)
+
+	body, _ := json.Marshal(transition)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, versionURL(registry.TransitionStageURL), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestTransitionStageInvalid(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("TransitionStage", mock.Anything, "wine", "1.0", mock.Anything).Return(
+		api_types.ModelVersion{}, odahu_errors.InvalidEntityError{Entity: "wine/1.0"},
+	)
+	registry.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPut, versionURL(registry.TransitionStageURL), bytes.NewReader([]byte(`{"stage": "retired"}`)),
+	)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,333 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	logutils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/log"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+const (
+	GetURL             = "/subscriptions/:id"
+	ListURL            = "/subscriptions"
+	PostURL            = "/subscriptions"
+	PutURL             = "/subscriptions"
+	DeleteURL          = "/subscriptions/:id"
+	ListDeliveriesURL  = "/subscriptions/:id/deliveries"
+	RequeueDeliveryURL = "/subscriptions/:id/deliveries/:deliveryId/requeue"
+	idParam            = "id"
+	deliveryIDParam    = "deliveryId"
+)
+
+var (
+	deliveryFieldsCache = map[string]int{}
+)
+
+func init() {
+	elem := reflect.TypeOf(&subscription.DeliveryFilter{}).Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		tagName := elem.Field(i).Tag.Get(subscription.TagKey)
+
+		deliveryFieldsCache[tagName] = i
+	}
+}
+
+type Service interface {
+	Create(ctx context.Context, sub *subscription.Subscription) (err error)
+	Update(ctx context.Context, id string, sub *subscription.Subscription) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	Get(ctx context.Context, id string) (res subscription.Subscription, err error)
+	List(ctx context.Context, options ...filter.ListOption) (res []subscription.Subscription, err error)
+	ListDeliveries(ctx context.Context, id string, options ...filter.ListOption) (res []subscription.Delivery, err error)
+	RequeueDelivery(ctx context.Context, id string, deliveryID int) (err error)
+}
+
+type controller struct {
+	service Service
+}
+
+func SetupRoutes(routes gin.IRoutes, service Service) {
+	controller := controller{service: service}
+	routes.GET(GetURL, controller.Get)
+	routes.GET(ListURL, controller.List)
+	routes.POST(PostURL, controller.Post)
+	routes.PUT(PutURL, controller.Put)
+	routes.DELETE(DeleteURL, controller.Delete)
+	routes.GET(ListDeliveriesURL, controller.ListDeliveries)
+	routes.PUT(RequeueDeliveryURL, controller.RequeueDelivery)
+}
+
+// @Summary Get a Subscription
+// @Description Get a webhook Subscription by id. Secret is masked
+// @Tags Subscription
+// @Name id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Subscription id"
+// @Success 200 {object} subscription.Subscription
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/subscriptions/{id} [get]
+func (cr *controller) Get(c *gin.Context) {
+	id := c.Param(idParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	sub, err := cr.service.Get(ctx, id)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Retrieving %s Subscription", id))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
+
+// @Summary Create a Subscription
+// @Description Create a webhook Subscription. Only events raised after the creation are delivered
+// @Tags Subscription
+// @Accept  json
+// @Produce  json
+// @Param subscription body subscription.Subscription true "Subscription". Only `id` and `spec` are taken into account
+// @Success 201 {object} subscription.Subscription
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/subscriptions [post]
+func (cr *controller) Post(c *gin.Context) {
+
+	var sub subscription.Subscription
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	if err := c.ShouldBindJSON(&sub); err != nil {
+		log.Error(err, "JSON binding of the Subscription is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	err := cr.service.Create(ctx, &sub)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Creating %s Subscription", sub.ID))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, sub)
+}
+
+// @Summary Update a Subscription
+// @Description Update a webhook Subscription. The old secret is kept if the masked secret is passed
+// @Tags Subscription
+// @Accept  json
+// @Produce  json
+// @Param subscription body subscription.Subscription true "Subscription". Only `id` and `spec` are taken into account
+// @Success 200 {object} subscription.Subscription
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/subscriptions [put]
+func (cr *controller) Put(c *gin.Context) {
+
+	var sub subscription.Subscription
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	if err := c.ShouldBindJSON(&sub); err != nil {
+		log.Error(err, "JSON binding of the Subscription is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	err := cr.service.Update(ctx, sub.ID, &sub)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Updating %s Subscription", sub.ID))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
+
+// @Summary Delete a Subscription
+// @Description Delete a webhook Subscription with all its pending and dead-lettered deliveries
+// @Tags Subscription
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Subscription id"
+// @Success 200 {object} httputil.HTTPResult
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/subscriptions/{id} [delete]
+func (cr *controller) Delete(c *gin.Context) {
+
+	id := c.Param(idParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	err := cr.service.Delete(ctx, id)
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Deleting %s Subscription", id))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, httputil.HTTPResult{Message: fmt.Sprintf("Subscription %s was deleted", id)})
+}
+
+// @Summary List Subscriptions
+// @Description List webhook Subscriptions. Secrets are masked
+// @Tags Subscription
+// @Accept  json
+// @Produce  json
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Success 200 {array} subscription.Subscription
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/subscriptions [get]
+func (cr *controller) List(c *gin.Context) {
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	size, page, err := routes.URLParamsToFilter(c, nil, map[string]int{})
+	if err != nil {
+		log.Error(err, "Malformed url parameters of subscription request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	res, err := cr.service.List(ctx, filter.Size(size), filter.Page(page))
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, "Listing Subscriptions")
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary List deliveries of a Subscription
+// @Description List pending and dead-lettered deliveries of a Subscription. Successful deliveries are not stored
+// @Tags Subscription
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Subscription id"
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Param state query string false "Delivery state: pending or deadLetter"
+// @Success 200 {array} subscription.Delivery
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/subscriptions/{id}/deliveries [get]
+func (cr *controller) ListDeliveries(c *gin.Context) {
+
+	id := c.Param(idParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	f := &subscription.DeliveryFilter{}
+	size, page, err := routes.URLParamsToFilter(c, f, deliveryFieldsCache)
+	if err != nil {
+		log.Error(err, "Malformed url parameters of subscription deliveries request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	res, err := cr.service.ListDeliveries(ctx, id, filter.ListFilter(f), filter.Size(size), filter.Page(page))
+	if err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Listing deliveries of %s Subscription", id))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary Requeue a delivery
+// @Description Schedule a new series of attempts for a delivery, for example for a dead-lettered one
+// @Tags Subscription
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Subscription id"
+// @Param deliveryId path int true "Delivery id"
+// @Success 200 {object} httputil.HTTPResult
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/subscriptions/{id}/deliveries/{deliveryId}/requeue [put]
+func (cr *controller) RequeueDelivery(c *gin.Context) {
+
+	id := c.Param(idParam)
+
+	ctx := c.Request.Context()
+	log := logutils.FromContext(ctx)
+
+	deliveryID, err := strconv.Atoi(c.Param(deliveryIDParam))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{
+			Message: fmt.Sprintf("delivery ID must be an integer: %s", c.Param(deliveryIDParam)),
+		})
+		return
+	}
+
+	if err := cr.service.RequeueDelivery(ctx, id, deliveryID); err != nil {
+		code := errors.CalculateHTTPStatusCode(err)
+		if code == http.StatusInternalServerError {
+			log.Error(err, fmt.Sprintf("Requeuing delivery %d of %s Subscription", deliveryID, id))
+		}
+		c.AbortWithStatusJSON(code, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, httputil.HTTPResult{Message: fmt.Sprintf("Delivery %d was requeued", deliveryID)})
+}
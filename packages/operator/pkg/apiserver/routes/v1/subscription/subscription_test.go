@@ -0,0 +1,128 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package subscription_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes/v1/subscription/mocks"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Get", mock.Anything, "ci-hook").Return(api_types.Subscription{
+		ID:   "ci-hook",
+		Spec: api_types.SubscriptionSpec{URL: "https://ci.example.com/hook", Secret: api_types.SecretMask},
+	}, nil)
+	subscription.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, strings.Replace(subscription.GetURL, ":id", "ci-hook", -1), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result api_types.Subscription
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, api_types.SecretMask, result.Spec.Secret)
+}
+
+func TestGetNotFound(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Get", mock.Anything, "ci-hook").
+		Return(api_types.Subscription{}, odahu_errors.NotFoundError{Entity: "ci-hook"})
+	subscription.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, strings.Replace(subscription.GetURL, ":id", "ci-hook", -1), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPost(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("Create", mock.Anything, mock.MatchedBy(func(sub *api_types.Subscription) bool {
+		return sub.ID == "ci-hook" && sub.Spec.URL == "https://ci.example.com/hook"
+	})).Return(nil)
+	subscription.SetupRoutes(router, service)
+
+	body, _ := json.Marshal(api_types.Subscription{
+		ID:   "ci-hook",
+		Spec: api_types.SubscriptionSpec{URL: "https://ci.example.com/hook"},
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, subscription.PostURL, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestListDeliveriesByState(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	service.On("ListDeliveries", mock.Anything, "ci-hook",
+		mock.AnythingOfType("filter.ListOption"),
+		mock.AnythingOfType("filter.ListOption"),
+		mock.AnythingOfType("filter.ListOption"),
+	).Run(func(args mock.Arguments) {
+		options := &filter.ListOptions{}
+		args.Get(2).(filter.ListOption)(options)
+		assert.Equal(t, []string{string(api_types.DeliveryDeadLetter)},
+			options.Filter.(*api_types.DeliveryFilter).State)
+	}).Return([]api_types.Delivery{{ID: 1, SubscriptionID: "ci-hook", State: api_types.DeliveryDeadLetter}}, nil)
+	subscription.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	url := strings.Replace(subscription.ListDeliveriesURL, ":id", "ci-hook", -1) + "?state=deadLetter"
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result []api_types.Delivery
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Len(t, result, 1)
+}
+
+func TestRequeueDeliveryInvalidID(t *testing.T) {
+	router := gin.Default()
+	service := &mocks.Service{}
+	subscription.SetupRoutes(router, service)
+
+	w := httptest.NewRecorder()
+	url := strings.NewReplacer(":id", "ci-hook", ":deliveryId", "abc").Replace(subscription.RequeueDeliveryURL)
+	req, _ := http.NewRequest(http.MethodPut, url, nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	service.AssertNotCalled(t, "RequeueDelivery", mock.Anything, mock.Anything, mock.Anything)
+}
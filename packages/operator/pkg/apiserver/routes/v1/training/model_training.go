@@ -17,6 +17,7 @@
 package training
 
 import (
+	"context"
 	"fmt"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
 	kube_client "github.com/odahu/odahu-flow/packages/operator/pkg/kubeclient/trainingclient"
@@ -45,6 +47,7 @@ const (
 	UpdateModelTrainingURL     = "/model/training"
 	SaveModelTrainingResultURL = "/model/training/:id/result"
 	DeleteModelTrainingURL     = "/model/training/:id"
+	EventsModelTrainingURL     = "/model/training-events"
 	IDMtURLParam               = "id"
 	FollowURLParam             = "follow"
 )
@@ -62,10 +65,16 @@ func init() {
 	}
 }
 
+type ModelTrainingEventGetter interface {
+	Get(ctx context.Context, cursor int) ([]event.TrainingEvent, int, error)
+	Snapshot(ctx context.Context) ([]event.TrainingEvent, int, error)
+}
+
 type ModelTrainingController struct {
 	kubeClient   kube_client.Client
 	trainService mt_service.Service
 	validator    *MtValidator
+	eventsReader ModelTrainingEventGetter
 }
 
 // @Summary Get a Model Training
@@ -296,3 +305,46 @@ func (mtc *ModelTrainingController) getModelTrainingLog(c *gin.Context) {
 		return
 	}
 }
+
+// @Summary Get Last Changes for ModelTraining entities
+// @Description Get Last Changes for ModelTraining entity
+// @Tags Training
+// @Accept  json
+// @Produce  json
+// @Param cursor query int false "Cursor can be passed to get only new changes"
+// @Param snapshot query bool false "Return only the last event of each entity and the cursor to resume from"
+// @Success 200 {object} event.LatestTrainingEvents
+// @Failure 400 {object} httputil.HTTPResult
+// @Failure 410 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-events [get]
+func (mtc *ModelTrainingController) getTrainingEvents(c *gin.Context) {
+	var cursor int
+	var err error
+	if err = routes.ValidateAndParseCursor(c, &cursor); err != nil {
+		return
+	}
+
+	var snapshot bool
+	if err = routes.ValidateAndParseSnapshot(c, &snapshot); err != nil {
+		return
+	}
+
+	var events []event.TrainingEvent
+	var newCursor int
+	if snapshot {
+		events, newCursor, err = mtc.eventsReader.Snapshot(c.Request.Context())
+	} else {
+		events, newCursor, err = mtc.eventsReader.Get(c.Request.Context(), cursor)
+	}
+	if err != nil {
+		logMT.Error(err, "Retrieving list of ModelTraining events")
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, event.LatestTrainingEvents{
+		Events: events,
+		Cursor: newCursor,
+	})
+}
@@ -0,0 +1,102 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	httputil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	"net/http"
+)
+
+const (
+	GetModelTrainingMetricsURL  = "/model/training/:id/metrics"
+	SaveModelTrainingMetricsURL = "/model/training/:id/metrics"
+	MetricKeyURLParam           = "key"
+)
+
+type metricsService interface {
+	Save(ctx context.Context, trainingID string, metrics *training.ModelTrainingMetrics) error
+	Get(ctx context.Context, trainingID string, keys []string) (training.ModelTrainingMetrics, error)
+}
+
+type ModelTrainingMetricsController struct {
+	service metricsService
+}
+
+// @Summary Get metrics of a Model Training
+// @Description Get metrics and params which were reported by the trainer of a Model Training
+// @Tags Training
+// @Name id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model Training id"
+// @Param key query string false "Return only metrics with the key. Can be passed several times"
+// @Success 200 {object} training.ModelTrainingMetrics
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training/{id}/metrics [get]
+func (mtmc *ModelTrainingMetricsController) getMetrics(c *gin.Context) {
+	mtID := c.Param(IDMtURLParam)
+
+	metrics, err := mtmc.service.Get(c.Request.Context(), mtID, c.QueryArray(MetricKeyURLParam))
+	if err != nil {
+		logMT.Error(err, fmt.Sprintf("Retrieving metrics of %s model training", mtID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+// @Summary Save metrics of a Model Training
+// @Description Save metrics and params of a Model Training. Values of the same metric key and step
+// @Description and params with the same key are overwritten. Results is saved metrics.
+// @Param id path string true "Model Training id"
+// @Param metrics body training.ModelTrainingMetrics true "Model Training metrics"
+// @Tags Training
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} training.ModelTrainingMetrics
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training/{id}/metrics [put]
+func (mtmc *ModelTrainingMetricsController) saveMetrics(c *gin.Context) {
+	mtID := c.Param(IDMtURLParam)
+	metrics := &training.ModelTrainingMetrics{}
+
+	if err := c.ShouldBindJSON(metrics); err != nil {
+		logMT.Error(err, "JSON binding of the model training metrics is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if err := mtmc.service.Save(c.Request.Context(), mtID, metrics); err != nil {
+		logMT.Error(err, fmt.Sprintf("Saving metrics of %s model training", mtID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
@@ -0,0 +1,205 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package training
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	httputil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	"net/http"
+)
+
+const (
+	GetModelTrainingScheduleURL    = "/model/training-schedule/:id"
+	GetAllModelTrainingScheduleURL = "/model/training-schedule"
+	CreateModelTrainingScheduleURL = "/model/training-schedule"
+	UpdateModelTrainingScheduleURL = "/model/training-schedule"
+	DeleteModelTrainingScheduleURL = "/model/training-schedule/:id"
+	IDMtsURLParam                  = "id"
+)
+
+type scheduleService interface {
+	Create(ctx context.Context, mts *training.ModelTrainingSchedule) error
+	Update(ctx context.Context, mts *training.ModelTrainingSchedule) error
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (training.ModelTrainingSchedule, error)
+	List(ctx context.Context, options ...filter.ListOption) ([]training.ModelTrainingSchedule, error)
+}
+
+type ModelTrainingScheduleController struct {
+	service   scheduleService
+	validator *MtsValidator
+}
+
+// @Summary Get a Model Training Schedule
+// @Description Get a Model Training Schedule by id
+// @Tags Training
+// @Name id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model Training Schedule id"
+// @Success 200 {object} training.ModelTrainingSchedule
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-schedule/{id} [get]
+func (mtsc *ModelTrainingScheduleController) getMTS(c *gin.Context) {
+	mtsID := c.Param(IDMtsURLParam)
+
+	mts, err := mtsc.service.Get(c.Request.Context(), mtsID)
+	if err != nil {
+		logMT.Error(err, fmt.Sprintf("Retrieving of %s model training schedule", mtsID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, mts)
+}
+
+// @Summary Get list of Model Training Schedules
+// @Description Get list of Model Training Schedules
+// @Tags Training
+// @Accept  json
+// @Produce  json
+// @Param size path int false "Number of entities in a response"
+// @Param page path int false "Number of a page"
+// @Success 200 {array} training.ModelTrainingSchedule
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-schedule [get]
+func (mtsc *ModelTrainingScheduleController) getAllMTSs(c *gin.Context) {
+	size, page, err := routes.URLParamsToFilter(c, nil, map[string]int{})
+	if err != nil {
+		logMT.Error(err, "Malformed url parameters of model training schedule request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	mtsList, err := mtsc.service.List(c.Request.Context(), filter.Size(size), filter.Page(page))
+	if err != nil {
+		logMT.Error(err, "Retrieving list of model training schedules")
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, &mtsList)
+}
+
+// @Summary Create a Model Training Schedule
+// @Description Create a Model Training Schedule. The schedule starts Model Trainings from the template
+// @Description at scheduled times. Results is created Model Training Schedule.
+// @Param mts body training.ModelTrainingSchedule true "Create a Model Training Schedule"
+// @Tags Training
+// @Accept  json
+// @Produce  json
+// @Success 201 {object} training.ModelTrainingSchedule
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-schedule [post]
+func (mtsc *ModelTrainingScheduleController) createMTS(c *gin.Context) {
+	var mts training.ModelTrainingSchedule
+
+	if err := c.ShouldBindJSON(&mts); err != nil {
+		logMT.Error(err, "JSON binding of the model training schedule is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if err := mtsc.validator.ValidatesAndSetDefaults(&mts); err != nil {
+		logMT.Error(err, fmt.Sprintf("Validation of the model training schedule is failed: %v", mts))
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if err := mtsc.service.Create(c.Request.Context(), &mts); err != nil {
+		logMT.Error(err, fmt.Sprintf("Creation of the model training schedule: %v", mts))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, mts)
+}
+
+// @Summary Update a Model Training Schedule
+// @Description Update a Model Training Schedule. Trainings which were already started are not changed.
+// @Description Results is updated Model Training Schedule.
+// @Param mts body training.ModelTrainingSchedule true "Update a Model Training Schedule"
+// @Tags Training
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} training.ModelTrainingSchedule
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-schedule [put]
+func (mtsc *ModelTrainingScheduleController) updateMTS(c *gin.Context) {
+	var mts training.ModelTrainingSchedule
+
+	if err := c.ShouldBindJSON(&mts); err != nil {
+		logMT.Error(err, "JSON binding of the model training schedule is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if err := mtsc.validator.ValidatesAndSetDefaults(&mts); err != nil {
+		logMT.Error(err, fmt.Sprintf("Validation of the model training schedule is failed: %v", mts))
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if err := mtsc.service.Update(c.Request.Context(), &mts); err != nil {
+		logMT.Error(err, fmt.Sprintf("Update of the model training schedule: %v", mts))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, mts)
+}
+
+// @Summary Delete a Model Training Schedule
+// @Description Delete a Model Training Schedule by id. Trainings which were started by the schedule are kept
+// @Tags Training
+// @Name id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model Training Schedule id"
+// @Success 200 {object} httputil.HTTPResult
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-schedule/{id} [delete]
+func (mtsc *ModelTrainingScheduleController) deleteMTS(c *gin.Context) {
+	mtsID := c.Param(IDMtsURLParam)
+
+	if err := mtsc.service.Delete(c.Request.Context(), mtsID); err != nil {
+		logMT.Error(err, fmt.Sprintf("Deletion of %s model training schedule is failed", mtsID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, httputil.HTTPResult{Message: fmt.Sprintf("Model training schedule %s was deleted", mtsID)})
+}
@@ -0,0 +1,51 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package training
+
+import (
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+)
+
+const (
+	// ID of the training which is used to validate a template. Schedule ID is validated separately
+	templateValidationTrainingID = "schedule-template"
+	ValidationMtsErrorMessage    = "Validation of model training schedule template is failed"
+)
+
+// MtsValidator validates the training template of a schedule in the same way as model trainings
+type MtsValidator struct {
+	mtValidator *MtValidator
+}
+
+func NewMtsValidator(mtValidator *MtValidator) *MtsValidator {
+	return &MtsValidator{mtValidator: mtValidator}
+}
+
+func (mtsv *MtsValidator) ValidatesAndSetDefaults(mts *training.ModelTrainingSchedule) error {
+	mt := training.ModelTraining{
+		ID:   templateValidationTrainingID,
+		Spec: mts.Spec.Template,
+	}
+
+	if err := mtsv.mtValidator.ValidatesAndSetDefaults(&mt); err != nil {
+		return fmt.Errorf("%s: %s", ValidationMtsErrorMessage, err.Error())
+	}
+	mts.Spec.Template = mt.Spec
+
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apiserver/routes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	httputil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	"net/http"
+	"reflect"
+)
+
+const (
+	GetModelTrainingSweepURL    = "/model/training-sweep/:id"
+	GetAllModelTrainingSweepURL = "/model/training-sweep"
+	CreateModelTrainingSweepURL = "/model/training-sweep"
+	DeleteModelTrainingSweepURL = "/model/training-sweep/:id"
+	IDMtswURLParam              = "id"
+)
+
+var (
+	sweepFieldsCache = map[string]int{}
+)
+
+func init() {
+	elem := reflect.TypeOf(&training.SweepFilter{}).Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		tagName := elem.Field(i).Tag.Get(training.TagKey)
+
+		sweepFieldsCache[tagName] = i
+	}
+}
+
+type sweepService interface {
+	Create(ctx context.Context, mtsw *training.ModelTrainingSweep) error
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (training.ModelTrainingSweep, error)
+	List(ctx context.Context, options ...filter.ListOption) ([]training.ModelTrainingSweep, error)
+}
+
+type ModelTrainingSweepController struct {
+	service   sweepService
+	validator *MtswValidator
+}
+
+// @Summary Get a Model Training Sweep
+// @Description Get a Model Training Sweep by id
+// @Tags Training
+// @Name id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model Training Sweep id"
+// @Success 200 {object} training.ModelTrainingSweep
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-sweep/{id} [get]
+func (mtswc *ModelTrainingSweepController) getMTSW(c *gin.Context) {
+	mtswID := c.Param(IDMtswURLParam)
+
+	mtsw, err := mtswc.service.Get(c.Request.Context(), mtswID)
+	if err != nil {
+		logMT.Error(err, fmt.Sprintf("Retrieving of %s model training sweep", mtswID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, mtsw)
+}
+
+// @Summary Get list of Model Training Sweeps
+// @Description Get list of Model Training Sweeps
+// @Tags Training
+// @Accept  json
+// @Produce  json
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Param state query string false "Sweep state: running, succeeded or failed"
+// @Success 200 {array} training.ModelTrainingSweep
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-sweep [get]
+func (mtswc *ModelTrainingSweepController) getAllMTSWs(c *gin.Context) {
+	f := &training.SweepFilter{}
+	size, page, err := routes.URLParamsToFilter(c, f, sweepFieldsCache)
+	if err != nil {
+		logMT.Error(err, "Malformed url parameters of model training sweep request")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	mtswList, err := mtswc.service.List(
+		c.Request.Context(), filter.ListFilter(f), filter.Size(size), filter.Page(page),
+	)
+	if err != nil {
+		logMT.Error(err, "Retrieving list of model training sweeps")
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, &mtswList)
+}
+
+// @Summary Create a Model Training Sweep
+// @Description Create a Model Training Sweep. The sweep starts a Model Training from the template for every
+// @Description combination of swept hyperparameters and chooses the training with the best objective metric.
+// @Description Results is created Model Training Sweep with generated trials.
+// @Param mtsw body training.ModelTrainingSweep true "Create a Model Training Sweep"
+// @Tags Training
+// @Accept  json
+// @Produce  json
+// @Success 201 {object} training.ModelTrainingSweep
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-sweep [post]
+func (mtswc *ModelTrainingSweepController) createMTSW(c *gin.Context) {
+	var mtsw training.ModelTrainingSweep
+
+	if err := c.ShouldBindJSON(&mtsw); err != nil {
+		logMT.Error(err, "JSON binding of the model training sweep is failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if err := mtswc.validator.ValidatesAndSetDefaults(&mtsw); err != nil {
+		logMT.Error(err, fmt.Sprintf("Validation of the model training sweep is failed: %v", mtsw))
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	if err := mtswc.service.Create(c.Request.Context(), &mtsw); err != nil {
+		logMT.Error(err, fmt.Sprintf("Creation of the model training sweep: %v", mtsw))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, mtsw)
+}
+
+// @Summary Delete a Model Training Sweep
+// @Description Delete a Model Training Sweep by id. Trainings which were started by the sweep are kept
+// @Tags Training
+// @Name id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Model Training Sweep id"
+// @Success 200 {object} httputil.HTTPResult
+// @Failure 404 {object} httputil.HTTPResult
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /api/v1/model/training-sweep/{id} [delete]
+func (mtswc *ModelTrainingSweepController) deleteMTSW(c *gin.Context) {
+	mtswID := c.Param(IDMtswURLParam)
+
+	if err := mtswc.service.Delete(c.Request.Context(), mtswID); err != nil {
+		logMT.Error(err, fmt.Sprintf("Deletion of %s model training sweep is failed", mtswID))
+		c.AbortWithStatusJSON(errors.CalculateHTTPStatusCode(err), httputil.HTTPResult{Message: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, httputil.HTTPResult{Message: fmt.Sprintf("Model training sweep %s was deleted", mtswID)})
+}
@@ -0,0 +1,52 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training
+
+import (
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+)
+
+const (
+	// ID of the training which is used to validate a sweep template. Sweep ID is validated separately
+	sweepTemplateValidationTrainingID = "sweep-template"
+	ValidationMtswErrorMessage        = "Validation of model training sweep template is failed"
+)
+
+// MtswValidator validates the training template of a sweep in the same way as model trainings
+type MtswValidator struct {
+	mtValidator *MtValidator
+}
+
+func NewMtswValidator(mtValidator *MtValidator) *MtswValidator {
+	return &MtswValidator{mtValidator: mtValidator}
+}
+
+func (mtswv *MtswValidator) ValidatesAndSetDefaults(mtsw *training.ModelTrainingSweep) error {
+	mt := training.ModelTraining{
+		ID:   sweepTemplateValidationTrainingID,
+		Spec: mtsw.Spec.Template,
+	}
+
+	if err := mtswv.mtValidator.ValidatesAndSetDefaults(&mt); err != nil {
+		return fmt.Errorf("%s: %s", ValidationMtswErrorMessage, err.Error())
+	}
+	mtsw.Spec.Template = mt.Spec
+
+	return nil
+}
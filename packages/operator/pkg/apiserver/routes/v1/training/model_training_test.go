@@ -31,8 +31,10 @@ import (
 	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	kube_client "github.com/odahu/odahu-flow/packages/operator/pkg/kubeclient/trainingclient"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/odahuflow"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/audit"
 	conn_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection"
 	conn_k8s_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection/kubernetes"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
 	mt_postgres_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/training/postgres"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/service/toolchain"
 	mt_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training"
@@ -71,7 +73,9 @@ func (s *ModelTrainingRouteSuite) SetupSuite() {
 
 	s.k8sClient = kubeClient
 
-	s.trainService = mt_service.NewService(mt_postgres_repository.TrainingRepo{DB: db})
+	s.trainService = mt_service.NewService(
+		mt_postgres_repository.TrainingRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+	)
 
 	tiRepo := mt_postgres_repository.ToolchainRepo{DB: db}
 	s.toolchainService = toolchain.NewService(tiRepo)
@@ -169,7 +173,7 @@ func (s *ModelTrainingRouteSuite) registerHandlers(trainingConfig config.ModelTr
 
 	train_route.ConfigureRoutes(
 		trainGroup, trainingConfig, config.NvidiaResourceName,
-		s.trainService, s.toolchainService, s.connRepo, s.kubeTrainClient)
+		s.trainService, outbox.TrainingEventGetter{DB: db}, s.toolchainService, s.connRepo, s.kubeTrainClient)
 }
 
 func (s *ModelTrainingRouteSuite) newMultipleMtStubs() {
@@ -0,0 +1,49 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package training
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	conn_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection"
+)
+
+func ConfigureScheduleRoutes(
+	routeGroup *gin.RouterGroup,
+	config config.ModelTrainingConfig,
+	gpuResourceName string,
+	scheduleService scheduleService,
+	toolchainService toolchainGetter,
+	connRepo conn_repository.Repository,
+) {
+
+	mtsController := &ModelTrainingScheduleController{
+		service: scheduleService,
+		validator: NewMtsValidator(NewMtValidator(
+			toolchainService,
+			connRepo,
+			config,
+			gpuResourceName,
+		)),
+	}
+
+	routeGroup.GET(GetModelTrainingScheduleURL, mtsController.getMTS)
+	routeGroup.GET(GetAllModelTrainingScheduleURL, mtsController.getAllMTSs)
+	routeGroup.POST(CreateModelTrainingScheduleURL, mtsController.createMTS)
+	routeGroup.PUT(UpdateModelTrainingScheduleURL, mtsController.updateMTS)
+	routeGroup.DELETE(DeleteModelTrainingScheduleURL, mtsController.deleteMTS)
+}
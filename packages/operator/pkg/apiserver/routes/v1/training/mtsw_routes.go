@@ -0,0 +1,48 @@
+//
+//    Copyright 2021 EPAM Systems
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//
+
+package training
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	conn_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/connection"
+)
+
+func ConfigureSweepRoutes(
+	routeGroup *gin.RouterGroup,
+	config config.ModelTrainingConfig,
+	gpuResourceName string,
+	sweepService sweepService,
+	toolchainService toolchainGetter,
+	connRepo conn_repository.Repository,
+) {
+
+	mtswController := &ModelTrainingSweepController{
+		service: sweepService,
+		validator: NewMtswValidator(NewMtValidator(
+			toolchainService,
+			connRepo,
+			config,
+			gpuResourceName,
+		)),
+	}
+
+	routeGroup.GET(GetModelTrainingSweepURL, mtswController.getMTSW)
+	routeGroup.GET(GetAllModelTrainingSweepURL, mtswController.getAllMTSWs)
+	routeGroup.POST(CreateModelTrainingSweepURL, mtswController.createMTSW)
+	routeGroup.DELETE(DeleteModelTrainingSweepURL, mtswController.deleteMTSW)
+}
@@ -29,6 +29,7 @@ func ConfigureRoutes(
 	config config.ModelTrainingConfig,
 	gpuResourceName string,
 	trainService mt_service.Service,
+	trainEventsReader ModelTrainingEventGetter,
 	toolchainService toolchainGetter,
 	connRepo conn_repository.Repository,
 	trainKubeClient mt_kube_client.Client) {
@@ -36,6 +37,7 @@ func ConfigureRoutes(
 	mtController := ModelTrainingController{
 		trainService: trainService,
 		kubeClient:   trainKubeClient,
+		eventsReader: trainEventsReader,
 		validator: NewMtValidator(
 			toolchainService,
 			connRepo,
@@ -51,5 +53,6 @@ func ConfigureRoutes(
 	routeGroup.PUT(UpdateModelTrainingURL, mtController.updateMT)
 	routeGroup.PUT(SaveModelTrainingResultURL, mtController.saveMTResult)
 	routeGroup.DELETE(DeleteModelTrainingURL, mtController.deleteMT)
+	routeGroup.GET(EventsModelTrainingURL, mtController.getTrainingEvents)
 
 }
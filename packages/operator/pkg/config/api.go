@@ -34,8 +34,20 @@ type APIBackendConfig struct {
 	Local APILocalBackendConfig `json:"local"`
 }
 
+type APISecurityConfig struct {
+	// Settings to validate bearer tokens of API requests.
+	// If JWKS is enabled then every API request is authorized using role-based policies
+	JWKS JWKS `json:"jwks"`
+	// Dot-separated path to the JWT claim which contains the list of user roles
+	RolesClaim string `json:"rolesClaim"`
+	// Mapping between roles from the JWT claim and ODAHU roles (admin, data_scientist, viewer)
+	RolesMapping map[string]string `json:"rolesMapping"`
+}
+
 type APIConfig struct {
 	Backend APIBackendConfig `json:"backend"`
+	// Authorization settings of API requests
+	Security APISecurityConfig `json:"security"`
 	// API HTTP port
 	Port int `json:"port"`
 	// If true then only webserver will be setup.
@@ -56,5 +68,13 @@ func NewDefaultAPIConfig() APIConfig {
 			},
 		},
 		Port: 5000,
+		Security: APISecurityConfig{
+			RolesClaim: "realm_access.roles",
+			RolesMapping: map[string]string{
+				"odahu_admin":          "admin",
+				"odahu_data_scientist": "data_scientist",
+				"odahu_viewer":         "viewer",
+			},
+		},
 	}
 }
@@ -63,6 +63,10 @@ type Config struct {
 	Packaging      ModelPackagingConfig  `json:"packaging"`
 	Operator       OperatorConfig        `json:"operator"`
 	Batch          BatchConfig           `json:"batch"`
+	Outbox         OutboxConfig          `json:"outbox"`
+	Subscription   SubscriptionConfig    `json:"subscription"`
+	Pipeline       PipelineConfig        `json:"pipeline"`
+	Registry       RegistryConfig        `json:"registry"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -101,6 +105,10 @@ func LoadConfig() (*Config, error) {
 		Packaging:      NewDefaultModelPackagingConfig(),
 		Operator:       NewDefaultOperatorConfig(),
 		Batch:          NewDefaultBatchConfig(),
+		Outbox:         NewDefaultOutboxConfig(),
+		Subscription:   NewDefaultSubscriptionConfig(),
+		Pipeline:       NewDefaultPipelineConfig(),
+		Registry:       NewDefaultRegistryConfig(),
 	}
 
 	err := viper.Unmarshal(config)
@@ -73,6 +73,8 @@ type ModelDeploymentConfig struct {
 	DefaultResources odahuflowv1alpha1.ResourceRequirements `json:"defaultResources"`
 	// Custom Route prefix for model deployments
 	CustomRoutePrefix string `json:"customRoutePrefix"`
+	// Progressive rollouts of model routes
+	Rollout RolloutConfig `json:"rollout"`
 }
 
 func NewDefaultModelDeploymentConfig() ModelDeploymentConfig {
@@ -98,5 +100,6 @@ func NewDefaultModelDeploymentConfig() ModelDeploymentConfig {
 			},
 		},
 		CustomRoutePrefix: "/custom",
+		Rollout:           NewDefaultRolloutConfig(),
 	}
 }
@@ -0,0 +1,39 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package config
+
+import "time"
+
+type OutboxConfig struct {
+	// Superseded events older than the retention period are removed from the event log.
+	// The last event of each entity is never removed
+	RetentionPeriod time.Duration `json:"retentionPeriod"`
+	// How often compaction of the event log is launched. Zero value disables compaction
+	CompactionPeriod time.Duration `json:"compactionPeriod"`
+	// How often a keep-alive comment is sent to clients of event streams. New events are also looked up
+	// with this period in case a database notification about them was lost
+	StreamKeepAlivePeriod time.Duration `json:"streamKeepAlivePeriod"`
+}
+
+func NewDefaultOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		RetentionPeriod:       7 * 24 * time.Hour,
+		CompactionPeriod:      time.Hour,
+		StreamKeepAlivePeriod: 15 * time.Second,
+	}
+}
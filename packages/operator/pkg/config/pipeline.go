@@ -0,0 +1,34 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package config
+
+import "time"
+
+type PipelineConfig struct {
+	// Enable model pipelines API and the pipeline runner
+	Enabled bool `json:"enabled"`
+	// How often running pipelines are checked for finished stages
+	AdvancePeriod time.Duration `json:"advancePeriod"`
+}
+
+func NewDefaultPipelineConfig() PipelineConfig {
+	return PipelineConfig{
+		Enabled:       true,
+		AdvancePeriod: 10 * time.Second,
+	}
+}
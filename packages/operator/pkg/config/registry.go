@@ -0,0 +1,37 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package config
+
+import "time"
+
+type RegistryConfig struct {
+	// Enable model registry API and population of the registry from the event log
+	Enabled bool `json:"enabled"`
+	// How often new events are applied to the registry
+	SyncPeriod time.Duration `json:"syncPeriod"`
+	// Maximum number of events applied in a single transaction
+	BatchSize int `json:"batchSize"`
+}
+
+func NewDefaultRegistryConfig() RegistryConfig {
+	return RegistryConfig{
+		Enabled:    true,
+		SyncPeriod: 10 * time.Second,
+		BatchSize:  100,
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package config
+
+import "time"
+
+// Label selector of Istio metrics of a canary Model Deployment. Knative names workloads as <revision>-deployment
+const istioCanarySelector = `destination_workload_namespace="{{ .Namespace }}",` +
+	`destination_workload=~"{{ .Deployment }}-[0-9]+-deployment"`
+
+type RolloutConfig struct {
+	// Enable progressive rollouts of model routes
+	Enabled bool `json:"enabled"`
+	// How often rollouts are checked for finished steps
+	Period time.Duration `json:"period"`
+	// Prometheus server which collects Istio metrics of model deployments, for example http://prometheus:9090.
+	// Rollouts with error rate or latency limits can not advance without it
+	PrometheusURL string `json:"prometheusUrl"`
+	// Timeout of a Prometheus query
+	Timeout time.Duration `json:"timeout"`
+	// PromQL query which returns the share of failed canary responses.
+	// It is a Go template with .Namespace, .Deployment and .Window values
+	ErrorRateQuery string `json:"errorRateQuery"`
+	// PromQL query which returns the 99th percentile of canary latency in milliseconds.
+	// It is a Go template with .Namespace, .Deployment and .Window values
+	LatencyQuery string `json:"latencyQuery"`
+}
+
+func NewDefaultRolloutConfig() RolloutConfig {
+	return RolloutConfig{
+		Enabled: true,
+		Period:  10 * time.Second,
+		Timeout: 10 * time.Second,
+		ErrorRateQuery: `sum(rate(istio_requests_total{` + istioCanarySelector +
+			`,response_code=~"5.."}[{{ .Window }}])) / sum(rate(istio_requests_total{` + istioCanarySelector +
+			`}[{{ .Window }}]))`,
+		LatencyQuery: `histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket{` +
+			istioCanarySelector + `}[{{ .Window }}])) by (le))`,
+	}
+}
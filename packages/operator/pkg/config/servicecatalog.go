@@ -31,11 +31,27 @@ type ServiceCatalog struct {
 	WorkersCount int `json:"workersCount"`
 	// enabled Debug increase logger verbosity and format. Default: false
 	Debug bool `json:"debug"`
+	// StreamEvents enables receiving of new ModelRoute events from the API server event stream.
+	// If it is disabled, events are polled every FetchTimeout seconds. Default: true
+	StreamEvents bool `json:"streamEvents"`
+	// StateFile is a path of the file where the catalog persists inspected models and the cursor
+	// of handled events. After restart the catalog is restored from the file and only new events are handled.
+	// If it is empty, the catalog is kept only in memory. Default: empty
+	StateFile string `json:"stateFile"`
+	// ReinspectionPeriod configures how often (in seconds) models of the catalog are inspected again
+	// to refresh their swagger and health. Zero disables the re-inspection. Default: 60
+	ReinspectionPeriod int `json:"reinspectionPeriod"`
+	// UnhealthyThreshold is the number of consecutive failed inspections after which the route
+	// is considered unhealthy and excluded from the aggregated swagger. Default: 3
+	UnhealthyThreshold int `json:"unhealthyThreshold"`
 }
 
 func NewDefaultServiceCatalogConfig() ServiceCatalog {
 	return ServiceCatalog{
-		FetchTimeout: 5,
-		WorkersCount: 4,
+		FetchTimeout:       5,
+		WorkersCount:       4,
+		StreamEvents:       true,
+		ReinspectionPeriod: 60,
+		UnhealthyThreshold: 3,
 	}
 }
@@ -0,0 +1,52 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package config
+
+import "time"
+
+type SubscriptionConfig struct {
+	// Enable webhook subscriptions API and delivery of events
+	Enabled bool `json:"enabled"`
+	// How often new events are dispatched to webhook endpoints
+	DispatchPeriod time.Duration `json:"dispatchPeriod"`
+	// Maximum number of events or deliveries processed by a single dispatch
+	BatchSize int `json:"batchSize"`
+	// Delivery is moved to the dead-letter state after this number of failed attempts
+	MaxAttempts int `json:"maxAttempts"`
+	// Delay before the second attempt. The delay is doubled after each failed attempt
+	InitialBackoff time.Duration `json:"initialBackoff"`
+	// Upper bound of the delay between attempts
+	MaxBackoff time.Duration `json:"maxBackoff"`
+	// Timeout of a webhook request
+	Timeout time.Duration `json:"timeout"`
+	// Webhooks are not sent to loopback, link-local and private network addresses, because they can
+	// reach internal services of the cluster. Hosts of this list are allowed regardless of their addresses
+	AllowedHosts []string `json:"allowedHosts"`
+}
+
+func NewDefaultSubscriptionConfig() SubscriptionConfig {
+	return SubscriptionConfig{
+		Enabled:        true,
+		DispatchPeriod: 5 * time.Second,
+		BatchSize:      100,
+		MaxAttempts:    10,
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     time.Hour,
+		Timeout:        10 * time.Second,
+	}
+}
@@ -59,6 +59,12 @@ type ModelTrainingConfig struct {
 	//   * kubernetes
 	//   * postgres
 	ToolchainIntegrationRepositoryType RepositoryType `json:"toolchainIntegrationRepositoryType"`
+
+	// How often training schedules are checked for due runs. Cron schedules have minute precision
+	SchedulePeriod time.Duration `json:"schedulePeriod"`
+
+	// How often training sweeps start pending trials and collect results of finished ones
+	SweepPeriod time.Duration `json:"sweepPeriod"`
 }
 
 func NewDefaultModelTrainingConfig() ModelTrainingConfig {
@@ -81,6 +87,8 @@ func NewDefaultModelTrainingConfig() ModelTrainingConfig {
 			},
 		},
 		ToolchainIntegrationRepositoryType: RepositoryPostgresType,
+		SchedulePeriod:                     10 * time.Second,
+		SweepPeriod:                        10 * time.Second,
 	}
 }
 
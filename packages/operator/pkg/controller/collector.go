@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"database/sql"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/connection"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
@@ -14,17 +15,26 @@ import (
 	deploy_kube_client "github.com/odahu/odahu-flow/packages/operator/pkg/kubeclient/deploymentclient"
 	pack_kube_client "github.com/odahu/odahu-flow/packages/operator/pkg/kubeclient/packagingclient"
 	train_kube_client "github.com/odahu/odahu-flow/packages/operator/pkg/kubeclient/trainingclient"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/audit"
 	batch_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/batch/postgres"
 	deploy_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/postgres"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
 	pack_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/packaging/postgres"
+	pipeline_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/pipeline/postgres"
+	registry_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/registry/postgres"
 	route_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/route/postgres"
+	subscription_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/subscription/postgres"
 	train_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/training/postgres"
 	batch_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/batch"
 	dep_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment"
 	pack_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/packaging"
+	pipeline_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/pipeline"
+	registry_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/registry"
 	route_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
+	subscription_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/subscription"
 	train_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training"
+	schedule_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_schedule"
+	sweep_service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_sweep"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
@@ -44,7 +54,9 @@ func SetupRunners(runMgr *WorkersManager, kubeMgr manager.Manager, db *sql.DB, c
 	kConfig := kubeMgr.GetConfig()
 
 	if cfg.Training.Enabled {
-		trainService := train_service.NewService(train_repo.TrainingRepo{DB: db})
+		trainService := train_service.NewService(
+			train_repo.TrainingRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+		)
 		trainKubeClient := train_kube_client.NewClient(
 			cfg.Training.Namespace,
 			cfg.Training.ToolchainIntegrationNamespace,
@@ -57,10 +69,24 @@ func SetupRunners(runMgr *WorkersManager, kubeMgr manager.Manager, db *sql.DB, c
 			training.NewAdapter(trainService, trainKubeClient, kubeMgr),
 		)
 		runMgr.AddRunnable(&trainWorker)
+
+		// Due runs are kept until they are started, so the next attempt retries them
+		scheduleRunner := NewPeriodicRunner("training-scheduler", cfg.Training.SchedulePeriod,
+			schedule_service.NewScheduler(train_repo.TrainingScheduleRepo{DB: db}, trainService).Schedule,
+		)
+		runMgr.AddRunnable(&scheduleRunner)
+
+		// Sweeps keep their state in the database, so the next attempt continues from it
+		sweepRunner := NewPeriodicRunner("training-sweeper", cfg.Training.SweepPeriod,
+			sweep_service.NewSweeper(train_repo.TrainingSweepRepo{DB: db}, trainService).Sweep,
+		)
+		runMgr.AddRunnable(&sweepRunner)
 	}
 
 	if cfg.Packaging.Enabled {
-		packService := pack_service.NewService(pack_repo.PackagingRepo{DB: db})
+		packService := pack_service.NewService(
+			pack_repo.PackagingRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+		)
 		packKubeClient := pack_kube_client.NewClient(
 			cfg.Packaging.Namespace,
 			cfg.Packaging.PackagingIntegrationNamespace,
@@ -77,7 +103,7 @@ func SetupRunners(runMgr *WorkersManager, kubeMgr manager.Manager, db *sql.DB, c
 
 	if cfg.Deployment.Enabled {
 		depService := dep_service.NewService(deploy_repo.DeploymentRepo{DB: db}, route_repo.RouteRepo{DB: db},
-		outbox.EventPublisher{DB: db})
+			outbox.EventPublisher{DB: db}, audit.Recorder{DB: db})
 		deployKubeClient := deploy_kube_client.NewClient(cfg.Deployment.Namespace, kClient)
 
 		deployWorker := NewGenericWorker(
@@ -87,7 +113,9 @@ func SetupRunners(runMgr *WorkersManager, kubeMgr manager.Manager, db *sql.DB, c
 		runMgr.AddRunnable(&deployWorker)
 
 
-		routeService := route_service.NewService(route_repo.RouteRepo{DB: db}, outbox.EventPublisher{DB: db})
+		routeService := route_service.NewService(
+			route_repo.RouteRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+		)
 
 		routeWorker := NewGenericWorker(
 			"route", cfg.Common.LaunchPeriod,
@@ -95,6 +123,17 @@ func SetupRunners(runMgr *WorkersManager, kubeMgr manager.Manager, db *sql.DB, c
 		)
 		runMgr.AddRunnable(&routeWorker)
 
+		if cfg.Deployment.Rollout.Enabled {
+			// Rollouts keep their state in the database, so the next attempt continues from it
+			rolloutRunner := NewPeriodicRunner("route-roller", cfg.Deployment.Rollout.Period,
+				route_service.NewRoller(
+					route_repo.RouteRepo{DB: db}, outbox.EventPublisher{DB: db},
+					route_service.NewPrometheusMetrics(cfg.Deployment.Rollout, cfg.Deployment.Namespace),
+				).Rollout,
+			)
+			runMgr.AddRunnable(&rolloutRunner)
+		}
+
 	}
 
 	if cfg.Batch.Enabled {
@@ -102,8 +141,12 @@ func SetupRunners(runMgr *WorkersManager, kubeMgr manager.Manager, db *sql.DB, c
 		connService := dummyConnGetter{}
 
 		batchJobService := batch_service.NewJobService(
-			batch_repo.BIJRepo{DB: db}, batch_repo.BISRepo{DB: db}, &connService)
-		batchServiceService := batch_service.NewInferenceServiceService(batch_repo.BISRepo{DB: db})
+			batch_repo.BIJRepo{DB: db}, batch_repo.BISRepo{DB: db}, &connService,
+			outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+		)
+		batchServiceService := batch_service.NewInferenceServiceService(
+			batch_repo.BISRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+		)
 		batchKubeClient := batch_kube_client.NewClient(kClient, cfg.Batch.Namespace, kConfig)
 
 		batchWorker := NewGenericWorker(
@@ -113,4 +156,55 @@ func SetupRunners(runMgr *WorkersManager, kubeMgr manager.Manager, db *sql.DB, c
 		runMgr.AddRunnable(&batchWorker)
 	}
 
+	if cfg.Subscription.Enabled {
+		// Undelivered events are kept in the database, so the next dispatch retries them
+		dispatcher := NewPeriodicRunner("subscription-dispatcher", cfg.Subscription.DispatchPeriod,
+			subscription_service.NewDispatcher(
+				subscription_repo.SubscriptionRepo{DB: db}, subscription_repo.DeliveryRepo{DB: db},
+				outbox.EventLog{DB: db}, cfg.Subscription,
+			).Dispatch,
+		)
+		runMgr.AddRunnable(&dispatcher)
+	}
+
+	if cfg.Pipeline.Enabled {
+		pipelineService := pipeline_service.NewService(
+			pipeline_repo.PipelineRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+			train_service.NewService(
+				train_repo.TrainingRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+			),
+			pack_service.NewService(
+				pack_repo.PackagingRepo{DB: db}, outbox.EventPublisher{DB: db}, audit.Recorder{DB: db},
+			),
+			dep_service.NewService(deploy_repo.DeploymentRepo{DB: db}, route_repo.RouteRepo{DB: db},
+				outbox.EventPublisher{DB: db}, audit.Recorder{DB: db}),
+		)
+		// Pipelines stay running until they finish, so the next attempt continues them
+		pipelineRunner := NewPeriodicRunner("pipeline-runner", cfg.Pipeline.AdvancePeriod,
+			pipeline_service.NewRunner(pipelineService).Advance,
+		)
+		runMgr.AddRunnable(&pipelineRunner)
+	}
+
+	if cfg.Registry.Enabled {
+		// The registry cursor is saved together with changes, so the next attempt repeats failed events
+		registrySyncer := NewPeriodicRunner("registry-syncer", cfg.Registry.SyncPeriod,
+			registry_service.NewSyncer(registry_repo.RegistryRepo{DB: db}, outbox.EventLog{DB: db}, cfg.Registry.BatchSize).Sync,
+		)
+		runMgr.AddRunnable(&registrySyncer)
+	}
+
+	if cfg.Outbox.CompactionPeriod > 0 {
+		compactor := NewPeriodicRunner("outbox-compactor", cfg.Outbox.CompactionPeriod,
+			func(ctx context.Context) error {
+				removed, err := outbox.Compactor{DB: db}.Compact(ctx, cfg.Outbox.RetentionPeriod)
+				if removed > 0 {
+					log.Info("Outbox is compacted", "removed", removed)
+				}
+				return err
+			},
+		)
+		runMgr.AddRunnable(&compactor)
+	}
+
 }
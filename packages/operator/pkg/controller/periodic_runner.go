@@ -0,0 +1,65 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PeriodicRunner calls a function with the period until the context is done.
+// Errors are only logged, because periodic work keeps its state in the database and
+// the next call retries what was not done
+type PeriodicRunner struct {
+	name   string
+	period time.Duration
+	run    func(ctx context.Context) error
+}
+
+func NewPeriodicRunner(name string, period time.Duration, run func(ctx context.Context) error) PeriodicRunner {
+	return PeriodicRunner{
+		name:   name,
+		period: period,
+		run:    run,
+	}
+}
+
+// Return name of runner
+func (r *PeriodicRunner) String() string {
+	return r.name
+}
+
+func (r *PeriodicRunner) Run(ctx context.Context) error {
+
+	log.Info(fmt.Sprintf("%v is running", r.String()))
+
+	t := time.NewTicker(r.period)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := r.run(ctx); err != nil {
+				log.Error(err, fmt.Sprintf("Error in %v", r.String()))
+			}
+		case <-ctx.Done():
+			log.Info(fmt.Sprintf("Cancellation signal was received in %v", r.String()))
+			return nil
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/controller"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPeriodicRunner(t *testing.T) {
+	var calls int32
+	runner := controller.NewPeriodicRunner("test-runner", time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		// Errors do not stop the runner
+		return errors.New("failed")
+	})
+	assert.Equal(t, "test-runner", runner.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- runner.Run(ctx) }()
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) >= 2 }, time.Second, time.Millisecond)
+	cancel()
+	assert.NoError(t, <-done)
+}
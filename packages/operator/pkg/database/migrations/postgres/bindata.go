@@ -18,6 +18,28 @@
 // pkg/database/migrations/postgres/sources/000008_outbox.up.sql (210B)
 // pkg/database/migrations/postgres/sources/000009_batch.down.sql (756B)
 // pkg/database/migrations/postgres/sources/000009_batch.up.sql (1.313kB)
+// pkg/database/migrations/postgres/sources/000010_audit.down.sql (691B)
+// pkg/database/migrations/postgres/sources/000010_audit.up.sql (1.213kB)
+// pkg/database/migrations/postgres/sources/000011_outbox_history.down.sql (1.113kB)
+// pkg/database/migrations/postgres/sources/000011_outbox_history.up.sql (1.13kB)
+// pkg/database/migrations/postgres/sources/000012_subscription.up.sql (1.687kB)
+// pkg/database/migrations/postgres/sources/000012_subscription.down.sql (748B)
+// pkg/database/migrations/postgres/sources/000013_outbox_notify.up.sql (1.229kB)
+// pkg/database/migrations/postgres/sources/000013_outbox_notify.down.sql (772B)
+// pkg/database/migrations/postgres/sources/000014_training_schedule.up.sql (895B)
+// pkg/database/migrations/postgres/sources/000014_training_schedule.down.sql (713B)
+// pkg/database/migrations/postgres/sources/000015_pipeline.up.sql (999B)
+// pkg/database/migrations/postgres/sources/000015_pipeline.down.sql (704B)
+// pkg/database/migrations/postgres/sources/000016_training_sweep.up.sql (1.017kB)
+// pkg/database/migrations/postgres/sources/000016_training_sweep.down.sql (710B)
+// pkg/database/migrations/postgres/sources/000017_training_metrics.up.sql (1.489kB)
+// pkg/database/migrations/postgres/sources/000017_training_metrics.down.sql (763B)
+// pkg/database/migrations/postgres/sources/000018_model_registry.up.sql (1.824kB)
+// pkg/database/migrations/postgres/sources/000018_model_registry.down.sql (784B)
+// pkg/database/migrations/postgres/sources/000019_deployment_revision.down.sql (715B)
+// pkg/database/migrations/postgres/sources/000019_deployment_revision.up.sql (1.374kB)
+// pkg/database/migrations/postgres/sources/000020_predictor.down.sql (704B)
+// pkg/database/migrations/postgres/sources/000020_predictor.up.sql (852B)
 
 package postgres
 
@@ -446,6 +468,446 @@ func _000009_batchUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000010_auditDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x51\xc1\x8e\x9b\x30\x10\xbd\xf3\x15\xa3\x9c\xda\x2a\x0d\xdb\x1c\x9b\x13\x49\xd8\xd6\x6a\x02\xab\x98\xed\x76\x4f\x95\x03\x03\x8c\x04\x36\xb5\x4d\x59\xfe\xbe\x43\x36\xac\xb2\xaa\x65\xc9\xb2\xe7\xcd\x9b\xf7\x9e\xc3\x4f\x01\x4c\x1b\xa6\xb5\x33\xdd\x68\xa9\xaa\x3d\xac\xef\xd6\x5f\x20\x7e\x88\x8e\x20\x47\xe7\xb1\x75\x37\xa8\x03\xe5\xa8\x1d\x16\xd0\xeb\x02\x2d\xf8\x1a\x21\xea\x54\xce\xc7\xb5\xb2\x84\x9f\x68\x1d\x19\x0d\xeb\xd5\x1d\x7c\x98\x00\x8b\x6b\x69\xf1\x71\x33\xd3\x8c\xa6\x87\x56\x8d\xa0\x8d\x87\xde\x21\xf3\x90\x83\x92\x1a\x04\x7c\xc9\xb1\xf3\x40\x1a\x72\xd3\x76\x0d\x29\x9d\x23\x0c\xe4\xeb\xcb\xac\x2b\xd3\x6a\xe6\x79\xbe\xf2\x98\xb3\x57\xdc\xa2\xb8\xa9\xe3\x5b\x79\x0b\x06\xe5\x6f\x0c\x4c\xab\xf6\xbe\xfb\x1a\x86\xc3\x30\xac\xd4\x45\xfc\xca\xd8\x2a\x6c\x5e\xe1\x2e\x3c\x88\x5d\x9c\xc8\xf8\x33\x1b\xb8\x69\x7c\xd4\x0d\x3a\x07\x16\xff\xf4\x64\x39\x80\xf3\x08\xaa\x63\x81\xb9\x3a\xb3\xec\x46\x0d\x60\x2c\xa8\xca\x22\xd7\xbc\x99\x0c\x0c\x96\x3c\xe9\x6a\x09\xce\x94\x7e\x50\x16\x67\xaa\x82\x9c\xb7\x74\xee\xfd\xbb\x1c\x67\xb9\x9c\xc4\x2d\x80\x93\x54\x1a\x16\x91\x04\x21\x17\xb0\x8d\xa4\x90\xcb\x99\xe8\x49\x64\xdf\xd3\xc7\x0c\x9e\xa2\xd3\x29\x4a\x32\x11\x4b\x48\x4f\xb0\x4b\x93\xbd\xc8\x44\x9a\xf0\xed\x1e\xa2\xe4\x19\x7e\x88\x64\xbf\x04\xe4\x14\x79\x16\xbe\x74\x76\x72\xc2\x72\x69\x4a\x18\x8b\xb7\x38\x25\xe2\x3b\x29\xa5\x79\x95\xe6\x3a\xcc\xa9\xa4\x9c\x6d\xea\xaa\x57\x15\x42\x65\xfe\xa2\xd5\xec\x0e\x3a\xb4\x2d\xb9\xe9\xc7\x1d\x0b\x2d\x66\xaa\x86\x5a\xf2\xca\x5f\x9e\xff\xf3\x38\x0d\x0c\x83\x60\x1b\x7f\x13\xc9\x26\x08\xf6\xa7\xf4\x01\xb2\x68\x7b\x88\x41\xdc\x43\xfc\x4b\xc8\x4c\x82\x29\x54\xdd\xff\x56\x7d\x41\x9e\x21\xbb\xf4\x78\x14\xd9\x26\xf8\x07\x1a\x7a\xd4\x8f\xb3\x02\x00\x00")
+
+func _000010_auditDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000010_auditDownSql,
+		"000010_audit.down.sql",
+	)
+}
+
+func _000010_auditDownSql() (*asset, error) {
+	bytes, err := _000010_auditDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000010_audit.down.sql", size: 691, mode: os.FileMode(0664), modTime: time.Unix(1792189842, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1a, 0x58, 0xca, 0xb1, 0xce, 0xac, 0x19, 0x6f, 0x50, 0xae, 0xd0, 0x31, 0x26, 0x6e, 0x5c, 0x34, 0xfb, 0xf0, 0x73, 0xa3, 0xa4, 0x6b, 0xba, 0x79, 0x31, 0x8c, 0xab, 0x96, 0x71, 0x37, 0xe2, 0x26}}
+	return a, nil
+}
+
+var __000010_auditUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x52\x4d\x73\x9b\x30\x14\xbc\xf3\x2b\xde\xf8\xe4\x74\x5c\x3b\xf1\xb4\x39\x34\x27\xd9\x21\x89\x5a\x1b\x32\x40\xbe\x7a\xf1\xc8\xf0\xc0\x9a\x82\x44\x25\x11\xe2\x7f\x5f\x81\x8d\x83\x9b\x34\x53\x86\x19\x90\xde\x6a\xdf\xee\x3e\x4d\x3e\x39\xd0\xbc\xd0\x3c\x73\x59\x6e\x15\xcf\x36\x06\xa6\xa7\xd3\x33\x70\x6f\xc9\x12\xc2\xad\x36\x58\xe8\x1e\x6a\xc1\x63\x14\x1a\x13\xa8\x44\x82\x0a\xcc\x06\x81\x94\x2c\xb6\x9f\x7d\x65\x04\xf7\xa8\x34\x97\x02\xa6\xe3\x53\x18\x36\x80\xc1\xbe\x34\x38\xb9\xe8\x68\xb6\xb2\x82\x82\x6d\x41\x48\x03\x95\x46\xcb\xc3\x35\xa4\x3c\x47\xc0\x97\x18\x4b\x03\x5c\x40\x2c\x8b\x32\xe7\x4c\xc4\x08\x35\x37\x9b\xb6\xd7\x9e\x69\xdc\xf1\x3c\xed\x79\xe4\xda\x30\x7b\x84\xd9\x43\xa5\x5d\xa5\x7d\x30\x30\xd3\x33\xd0\x3c\x1b\x63\xca\x6f\x93\x49\x5d\xd7\x63\xd6\x8a\x1f\x4b\x95\x4d\xf2\x1d\x5c\x4f\x16\x74\xee\x7a\xa1\xfb\xd9\x1a\xe8\x1d\xbc\x13\x39\x6a\x0d\x0a\x7f\x57\x5c\xd9\x00\xd6\x5b\x60\xa5\x15\x18\xb3\xb5\x95\x9d\xb3\x1a\xa4\x02\x96\x29\xb4\x35\x23\x1b\x03\xb5\xe2\x86\x8b\x6c\x04\x5a\xa6\xa6\x66\x0a\x3b\xaa\x84\x6b\xa3\xf8\xba\x32\x47\x39\x76\x72\x6d\x12\x7d\x80\x4d\x92\x09\x18\x90\x10\x68\x38\x80\x19\x09\x69\x38\xea\x88\x1e\x68\x74\xe3\xdf\x45\xf0\x40\x82\x80\x78\x11\x75\x43\xf0\x03\x98\xfb\xde\x25\x8d\xa8\xef\xd9\xd5\x15\x10\xef\x09\x7e\x50\xef\x72\x04\x68\x53\xb4\xbd\xf0\xa5\x54\x8d\x13\x2b\x97\x37\x09\x63\x72\x88\x33\x44\x3c\x92\x92\xca\x9d\x34\x5d\x62\xcc\x53\x1e\x5b\x9b\x22\xab\x58\x86\x90\xc9\x67\x54\xc2\xba\x83\x12\x55\xc1\x75\x33\x71\x6d\x85\x26\x1d\x55\xce\x0b\x6e\x98\x69\xb7\xdf\x78\x6c\x1a\x4e\x1c\x67\xe6\x5e\x53\xef\xc2\x71\xe6\x81\x4b\x22\x17\x22\x32\x5b\xb8\x40\xaf\xc0\xf3\x23\x70\x1f\x69\x18\x85\x20\x13\xb6\xa9\x56\xac\x4a\xb8\x71\x86\x4e\x43\xcc\x93\xc3\x1c\x61\x46\xaf\x43\x37\xa0\x64\x01\xb7\x01\x5d\x92\xc0\x3a\x75\x9f\x46\x2d\x0c\x85\xe1\x66\xbb\xfa\xc5\x45\x02\xf7\x24\x98\xdf\x90\x60\x78\xfe\xe5\x04\x5a\x72\xef\x6e\xb1\x38\x82\xb5\xa4\x1f\xc0\xa4\x75\xd9\x9a\xe9\xc1\xce\xce\xdf\xc0\xec\x55\x56\x82\x15\x08\x3d\xd8\xf4\xab\xc5\xfd\xd5\xb4\x60\x3c\xdf\x5b\xf8\x00\x96\x30\x83\x86\xef\xd8\x22\xba\x74\xc3\x88\x2c\x6f\xa3\x9f\x6f\xb4\xe5\xc9\xaa\x19\x50\xf3\xff\x3d\xf4\xbd\xd9\x6e\x5b\x60\xfd\xde\x76\xc2\xd3\xb4\x8b\xaf\xdd\x76\x4e\x5e\x27\x60\xaf\x89\xfb\xf8\xef\x09\xac\x0e\x69\xbd\x80\xef\xf5\x2b\x30\xec\xe5\x3d\x7a\x4d\xd5\x72\xff\x27\x75\x67\xf6\x5d\xf2\xae\xd8\x4a\xf5\x97\x4b\x1a\x5d\x38\x7f\x00\x4c\x08\x34\x6f\xbd\x04\x00\x00")
+
+func _000010_auditUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000010_auditUpSql,
+		"000010_audit.up.sql",
+	)
+}
+
+func _000010_auditUpSql() (*asset, error) {
+	bytes, err := _000010_auditUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000010_audit.up.sql", size: 1213, mode: os.FileMode(0664), modTime: time.Unix(1792189842, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x63, 0x8a, 0x25, 0x3d, 0x6a, 0xc3, 0x5, 0x16, 0xf1, 0x51, 0x4d, 0xea, 0x3a, 0x51, 0x5b, 0xf, 0x99, 0xa7, 0x7f, 0x6a, 0xe0, 0xe6, 0x54, 0x2f, 0x5b, 0x9f, 0x33, 0x18, 0xf8, 0xcb, 0x74, 0xb3}}
+	return a, nil
+}
+
+var __000011_outbox_historyDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x52\xd1\x6e\x9b\x40\x10\x7c\xe7\x2b\x56\x7e\x4a\x2a\xc7\x4e\xf2\x58\xab\x95\x88\x7d\x69\x50\x6d\xb0\x80\x34\xc9\x93\x75\x86\x35\x3e\x15\xee\xe8\xdd\x61\xcc\xdf\x67\xc1\x10\xd9\x6a\x23\x15\x59\x32\xc7\xce\xce\xce\xcc\xde\xf4\x8b\x03\xed\x0f\xda\x67\xae\xca\x46\x8b\x6c\x6f\xe1\xfe\xf6\xfe\x0e\xd8\xda\x5d\x41\xd4\x18\x8b\x85\x39\x43\x2d\x45\x82\xd2\x60\x0a\x95\x4c\x51\x83\xdd\x23\xb8\x25\x4f\xe8\xaf\xaf\x8c\xe1\x17\x6a\x23\x94\x84\xfb\xc9\x2d\x5c\xb5\x80\x51\x5f\x1a\x5d\xcf\x06\x9a\x46\x55\x50\xf0\x06\xa4\xb2\x50\x19\x24\x1e\x61\x60\x27\x72\x04\x3c\x26\x58\x5a\x10\x12\x12\x55\x94\xb9\xe0\x32\x41\xa8\x85\xdd\x77\xb3\x7a\xa6\xc9\xc0\xf3\xd6\xf3\xa8\xad\xe5\xd4\xc2\xa9\xa9\xa4\xd3\xee\x1c\x0c\xdc\x9e\x19\x68\x9f\xbd\xb5\xe5\xd7\xe9\xb4\xae\xeb\x09\xef\xc4\x4f\x94\xce\xa6\xf9\x09\x6e\xa6\x4b\x6f\xce\xfc\x88\xdd\x90\x81\xb3\xc6\x67\x99\xa3\x31\xa0\xf1\x4f\x25\x34\x05\xb0\x6d\x80\x97\x24\x30\xe1\x5b\x92\x9d\xf3\x1a\x94\x06\x9e\x69\xa4\x9a\x55\xad\x81\x5a\x0b\x2b\x64\x36\x06\xa3\x76\xb6\xe6\x1a\x07\xaa\x54\x18\xab\xc5\xb6\xb2\x17\x39\x0e\x72\x29\x89\x73\x00\x25\xc9\x25\x8c\xdc\x08\xbc\x68\x04\x0f\x6e\xe4\x45\xe3\x81\xe8\xc5\x8b\x9f\x82\xe7\x18\x5e\xdc\x30\x74\xfd\xd8\x63\x11\x04\x21\xcc\x03\x7f\xe1\xc5\x5e\xe0\xd3\xe9\x11\x5c\xff\x0d\x7e\x7a\xfe\x62\x0c\x48\x29\xd2\x2c\x3c\x96\xba\x75\x42\x72\x45\x9b\x30\xa6\x1f\x71\x46\x88\x17\x52\x76\xea\x24\xcd\x94\x98\x88\x9d\x48\xc8\xa6\xcc\x2a\x9e\x21\x64\xea\x80\x5a\x92\x3b\x28\x51\x17\xc2\xb4\x1b\x37\x24\x34\x1d\xa8\x72\x51\x08\xcb\x6d\xf7\xf9\x2f\x8f\xed\xc0\xa9\xe3\x3c\xb0\x1f\x9e\x3f\x73\x9c\x45\x18\xac\x21\x76\x1f\x96\x0c\xbc\x47\x60\xaf\x5e\x14\x47\xa0\x52\xbe\xaf\x36\xaa\xb2\x5b\x75\xdc\xb4\x77\x81\x27\x2d\x1b\xc1\x6f\x6e\x60\xad\xf1\x20\x54\x65\xc0\xd0\xf6\x0a\x0e\x87\xfe\xce\x19\xab\xc8\x1b\x65\x96\x37\xdd\xbc\x9c\x1b\x0b\x78\x40\x69\xdb\x4b\x81\xb4\x6b\xa0\x77\x61\x1b\x67\xc1\x96\x2c\x66\xf0\x18\x06\xab\x8b\x51\x40\x41\x2b\xe7\xe5\x89\x85\xac\x57\x72\xe5\x74\xd1\x10\x7e\x1e\xc3\xdd\xbf\x3b\x64\x87\x39\x75\xc9\x49\x37\x70\x93\x69\x55\x95\xf0\x0d\xd4\xc5\xd9\xf5\x17\x2d\xa2\x13\xb1\x11\xe9\xa9\xfe\x71\x3a\x55\xe9\xe5\x3b\x7d\x16\xa9\x73\x3d\xa4\x43\x0b\x64\xaf\x9f\xa5\x93\x72\x8b\x56\x14\x48\x0c\xc7\xd9\x7f\xe0\x3b\x25\x9b\x8f\xa9\xd4\xe3\x2e\x63\x16\xf6\x2b\xb8\xf0\xd6\x91\xd1\x85\x8a\xe2\xd0\xf5\xfc\xf8\x33\xc6\xf2\x37\x36\x24\x75\x1e\xac\x56\x5e\x3c\x73\xde\x01\xb6\xfa\x62\x78\x59\x04\x00\x00")
+
+func _000011_outbox_historyDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000011_outbox_historyDownSql,
+		"000011_outbox_history.down.sql",
+	)
+}
+
+func _000011_outbox_historyDownSql() (*asset, error) {
+	bytes, err := _000011_outbox_historyDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000011_outbox_history.down.sql", size: 1113, mode: os.FileMode(0664), modTime: time.Unix(1792190803, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x98, 0xa1, 0x17, 0xd0, 0xd0, 0x97, 0xd5, 0xee, 0x0, 0x9a, 0xaa, 0xe7, 0x61, 0xbc, 0x47, 0x4d, 0xf4, 0xa4, 0x5f, 0xeb, 0x7e, 0xbe, 0x30, 0x51, 0xbf, 0xc1, 0x38, 0x4c, 0x82, 0x99, 0x12, 0x87}}
+	return a, nil
+}
+
+var __000011_outbox_historyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x53\x4b\x8f\x9b\x30\x10\xbe\xf3\x2b\x46\x39\x65\xab\x6c\xb2\x9b\x53\xd5\x3d\x39\x09\xdb\xb5\x9a\x40\x05\xec\xeb\x14\x39\x30\x21\x96\x00\x53\xdb\x2c\xc9\xbf\xef\x98\x84\x8a\xb4\x55\x55\x84\x84\xcc\x3c\xbe\xc7\x8c\x67\x9f\x3c\x70\x2f\xb8\x67\xa9\xea\x93\x96\xf9\xc1\xc2\xfc\x6e\x7e\x0f\xfe\x77\xb6\x81\xf8\x64\x2c\x96\x66\x90\xb5\x96\x29\x56\x06\x33\x68\xaa\x0c\x35\xd8\x03\x02\xab\x45\x4a\x9f\x4b\x64\x02\x2f\xa8\x8d\x54\x15\xcc\xa7\x77\x30\x76\x09\xa3\x4b\x68\x74\xf3\xd0\xb7\x39\xa9\x06\x4a\x71\x82\x4a\x59\x68\x0c\x52\x1f\x69\x60\x2f\x0b\x04\x3c\xa6\x58\x5b\x90\x15\xa4\xaa\xac\x0b\x29\xaa\x14\xa1\x95\xf6\xd0\x61\x5d\x3a\x4d\xfb\x3e\xef\x97\x3e\x6a\x67\x05\x95\x08\x2a\xaa\xe9\xb4\x1f\x26\x83\xb0\x03\x01\xee\x39\x58\x5b\x7f\x99\xcd\xda\xb6\x9d\x8a\x8e\xfc\x54\xe9\x7c\x56\x9c\xd3\xcd\x6c\xcd\x97\x7e\x10\xfb\xb7\x24\x60\x50\xf8\x5c\x15\x68\x0c\x68\xfc\xd1\x48\x4d\x06\xec\x4e\x20\x6a\x22\x98\x8a\x1d\xd1\x2e\x44\x0b\x4a\x83\xc8\x35\x52\xcc\x2a\x27\xa0\xd5\xd2\xca\x2a\x9f\x80\x51\x7b\xdb\x0a\x8d\x7d\xab\x4c\x1a\xab\xe5\xae\xb1\x57\x3e\xf6\x74\xc9\x89\x61\x02\x39\x29\x2a\x18\xb1\x18\x78\x3c\x82\x05\x8b\x79\x3c\xe9\x1b\xbd\xf2\xe4\x29\x7c\x4e\xe0\x95\x45\x11\x0b\x12\xee\xc7\x10\x46\xb0\x0c\x83\x15\x4f\x78\x18\xd0\xe9\x11\x58\xf0\x0e\xdf\x78\xb0\x9a\x00\x92\x8b\x84\x85\xc7\x5a\x3b\x25\x44\x57\x3a\x87\x31\xfb\x65\x67\x8c\x78\x45\x65\xaf\xce\xd4\x4c\x8d\xa9\xdc\xcb\x94\x64\x56\x79\x23\x72\x84\x5c\x7d\xa0\xae\x48\x1d\xd4\xa8\x4b\x69\xdc\xc4\x0d\x11\xcd\xfa\x56\x85\x2c\xa5\x15\xb6\xfb\xfd\x87\x46\x07\x38\xf3\xbc\x85\xff\x95\x07\x0f\x9e\xc7\xd6\x89\x1f\x41\xc2\x16\x6b\x1f\x54\x26\x0e\xcd\x56\x35\x76\xa7\x8e\xc0\x56\x2b\xf8\x1e\xf1\x0d\x8b\x48\x83\xff\x0e\x63\x99\xd1\x0e\x79\xcb\xc8\x67\x89\x0f\x24\xca\x7f\x03\xfe\x08\x41\x98\x80\xff\xc6\xe3\x24\xbe\x2a\xdf\xe6\x5a\x35\xf5\x16\x2b\x2b\xed\x69\x2b\xb3\x23\x84\xc1\x75\xff\x31\x7e\x50\xf4\x9c\x47\xfe\xf4\x89\x13\xe8\x70\xfe\x17\x26\x13\x16\xad\x2c\xf1\xef\x10\x7d\xd4\x11\xbf\xbd\x05\xdf\x21\x1a\xb7\xa2\x02\x3a\xdc\xf3\x72\xf3\x15\x74\xeb\x65\x0f\x34\x6c\x72\x9d\xb6\x4c\x14\x6e\x8f\xdc\x2d\x10\x29\x2d\xc2\xb6\x21\x7a\x05\xa4\x14\xdf\x21\xad\x61\x49\x13\xe8\xb6\xf0\x92\x41\x4e\xf7\x8c\xcf\x4e\xfe\x83\xf1\xa0\x64\xec\xb9\x69\x0d\x7c\xe8\xa6\xf7\xc2\xa2\xe5\x13\x8b\xc6\xf7\xf3\xcf\x37\xc3\x09\x4c\xba\xec\xdf\x39\x2d\x38\xcd\x31\xb9\x5c\x2e\x87\x19\x3c\xaf\xd7\x5e\x37\xa9\x70\xb3\xe1\xc9\x83\xf7\x13\x27\xd6\x25\x7a\x6a\x04\x00\x00")
+
+func _000011_outbox_historyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000011_outbox_historyUpSql,
+		"000011_outbox_history.up.sql",
+	)
+}
+
+func _000011_outbox_historyUpSql() (*asset, error) {
+	bytes, err := _000011_outbox_historyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000011_outbox_history.up.sql", size: 1130, mode: os.FileMode(0664), modTime: time.Unix(1792190803, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6a, 0x91, 0x83, 0x7d, 0x19, 0xdb, 0xfb, 0x4f, 0xb5, 0xaa, 0xfd, 0xac, 0xe2, 0x96, 0x9, 0xd5, 0xac, 0xc8, 0x50, 0x6d, 0xcb, 0x68, 0x25, 0xfc, 0x64, 0xf4, 0xae, 0xfb, 0x12, 0x3f, 0xde, 0xe8}}
+	return a, nil
+}
+
+var __000012_subscriptionUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x54\x4d\x73\xda\x30\x10\xbd\xfb\x57\xec\x70\x09\x74\x28\x24\x99\x4e\x0e\xcd\x49\x18\x91\xa8\x05\xc3\xd8\x22\x1f\xbd\x30\xc2\x16\xa0\x29\xd8\xae\x24\x07\xf8\xf7\x5d\x01\x4e\x0d\xf9\x6a\xab\x61\xc6\x63\xfb\xed\xdb\xf7\xde\xae\x69\x7f\xf2\xc0\xfd\xc0\x1d\x3f\xcb\xb7\x5a\xcd\x17\x16\x2e\xcf\x2f\x2f\x80\x8e\xc8\x00\xa2\xad\xb1\x72\x65\x2a\xa8\xbe\x8a\x65\x6a\x64\x02\x45\x9a\x48\x0d\x76\x21\x81\xe4\x22\xc6\xcb\xe1\x4d\x13\xee\xa4\x36\x2a\x4b\xe1\xb2\x75\x0e\x75\x07\xa8\x1d\x5e\xd5\x1a\xd7\x25\xcd\x36\x2b\x60\x25\xb6\x90\x66\x16\x0a\x23\x91\x47\x19\x98\xa9\xa5\x04\xb9\x89\x65\x6e\x41\xa5\x10\x67\xab\x7c\xa9\x44\x1a\x4b\x58\x2b\xbb\xd8\xf5\x3a\x30\xb5\x4a\x9e\xc7\x03\x4f\x36\xb5\x02\x4b\x04\x16\xe5\x78\x37\xab\x82\x41\xd8\x8a\x01\x77\x16\xd6\xe6\x5f\xdb\xed\xf5\x7a\xdd\x12\x3b\xf1\xad\x4c\xcf\xdb\xcb\x3d\xdc\xb4\xfb\xcc\xa7\x41\x44\x3f\xa3\x81\x4a\xe1\x38\x5d\x4a\x63\x40\xcb\x5f\x85\xd2\x18\xc0\x74\x0b\x22\x47\x81\xb1\x98\xa2\xec\xa5\x58\x43\xa6\x41\xcc\xb5\xc4\x77\x36\x73\x06\xd6\x5a\x59\x95\xce\x9b\x60\xb2\x99\x5d\x0b\x2d\x4b\xaa\x44\x19\xab\xd5\xb4\xb0\x47\x39\x96\x72\x31\x89\x2a\x00\x93\x14\x29\xd4\x48\x04\x2c\xaa\x41\x87\x44\x2c\x6a\x96\x44\xf7\x8c\xdf\x0e\xc7\x1c\xee\x49\x18\x92\x80\x33\x1a\xc1\x30\x04\x7f\x18\x74\x19\x67\xc3\x00\xef\x7a\x40\x82\x47\xf8\xce\x82\x6e\x13\x24\xa6\x88\xbd\xe4\x26\xd7\xce\x09\xca\x55\x2e\x61\x99\x3c\xc7\x19\x49\x79\x24\x65\x96\xed\xa5\x99\x5c\xc6\x6a\xa6\x62\xb4\x99\xce\x0b\x31\x97\x30\xcf\x9e\xa4\x4e\xd1\x1d\xe4\x52\xaf\x94\x71\x13\x37\x28\x34\x29\xa9\x96\x6a\xa5\xac\xb0\xbb\xc7\x2f\x3c\xba\x86\x6d\xcf\xeb\xd0\x1b\x16\x5c\x7b\x9e\x1f\x52\xc2\x29\x70\xd2\xe9\x53\x60\x3d\x08\x86\x1c\xe8\x03\x8b\x78\x04\x59\x22\x16\xc5\xc4\x14\x53\x13\x6b\x95\x3b\x3a\xaf\xee\x39\x7e\x95\xec\x67\x79\x47\x42\xff\x96\x84\xf5\xab\x2f\x0d\x18\x85\x6c\x40\x42\xb4\x4b\x1f\x9b\x3b\x50\xac\xa5\x70\x19\x72\x36\xa0\x11\x27\x83\x11\xff\xb1\x23\x0f\xc6\xfd\xfe\x1e\x51\xe4\xc9\x07\x08\xe7\xdd\x5d\xbf\x45\xc3\xa0\x73\x58\xa0\x13\x04\xfa\x2c\xcc\xeb\x08\xaf\xf1\xaf\xfe\x26\x89\x5c\x2a\x0c\x77\x7b\x62\xb4\x3c\x1d\x76\x13\xd1\x90\x91\xfe\x4b\xbb\x47\x34\x58\x57\xcd\xe6\x59\x50\xc9\x83\x5b\x12\xf1\x90\xb0\x80\x1f\x44\x94\x7d\x8f\xd5\xcc\x7e\x7a\xd5\xee\x21\xed\xd1\x90\x06\x3e\x7d\x75\x34\x55\xe4\x30\x80\xf1\xa8\xeb\x7c\x87\x98\x6c\xc8\x7c\xee\x1e\x75\x69\x9f\xe2\x23\x9f\x44\x3e\xe9\xd2\x3f\xf9\xc9\x4a\x65\x29\xfb\xe2\xaa\x71\x92\xb4\xb0\xf8\x77\x94\x5b\x53\x22\x51\x3c\xbd\xa1\xe1\x51\xe2\xd8\xa2\x47\xc6\x7d\x0e\xe7\xfb\x9a\x54\x6e\xec\xe4\x50\xe8\xee\xdf\x9e\xf4\x52\x18\x3b\x91\x5a\xe3\xd2\xef\x0e\xa7\x0f\x1c\x4e\xe6\xf9\xcc\x7e\x76\x76\xbc\x62\xe5\x79\x9b\x5e\x3e\xc9\xd4\x56\x6c\x7e\xb4\x2e\xf8\xcd\xd2\x87\xbf\x5f\x97\x49\x52\x48\x1c\xfa\xc6\xa5\xfc\x0e\x0c\xea\xbb\xb8\x9b\x47\xb9\x60\xd7\xff\x6c\x7a\xb2\x72\x1b\xef\x30\xfa\xf7\x15\x1c\x17\x35\x71\xc7\x77\xbe\x87\x83\x01\xe3\xd7\xde\x6f\xdf\x18\x4a\x20\x97\x06\x00\x00")
+
+func _000012_subscriptionUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000012_subscriptionUpSql,
+		"000012_subscription.up.sql",
+	)
+}
+
+func _000012_subscriptionUpSql() (*asset, error) {
+	bytes, err := _000012_subscriptionUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000012_subscription.up.sql", size: 1687, mode: os.FileMode(0664), modTime: time.Unix(1792192281, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb4, 0xdf, 0x17, 0x1e, 0x24, 0x24, 0xed, 0xd7, 0x4e, 0xea, 0x86, 0xb4, 0xca, 0xb7, 0x4c, 0xb9, 0xae, 0x6a, 0x62, 0x40, 0x78, 0x2b, 0x78, 0x72, 0x57, 0x5f, 0xf8, 0x7, 0xad, 0x70, 0xa5, 0x32}}
+	return a, nil
+}
+
+var __000012_subscriptionDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x91\x41\x6f\x9b\x40\x10\x85\xef\xfc\x8a\x91\x4f\x69\xe5\x9a\xd4\xc7\xfa\x84\x6d\xd2\xae\x6a\x43\x64\x48\x93\x9c\xa2\x35\x8c\x61\x24\xbc\xbb\xdd\x5d\x4a\xf8\xf7\x1d\x1c\x13\x11\xf5\xd2\x15\x12\x5a\x66\xe6\x9b\xf7\x1e\xe1\xe7\x00\x86\x07\x86\xb3\xd1\xa6\xb7\x54\xd5\x1e\x96\xb7\xcb\xaf\x10\xdf\x47\x7b\xc8\x7a\xe7\xf1\xec\x26\x5d\x3b\x2a\x50\x39\x2c\xa1\x55\x25\x5a\xf0\x35\x42\x64\x64\xc1\xaf\x6b\x65\x0e\xbf\xd0\x3a\xd2\x0a\x96\x8b\x5b\xb8\x19\x1a\x66\xd7\xd2\xec\xd3\x6a\xc4\xf4\xba\x85\xb3\xec\x41\x69\x0f\xad\x43\xe6\x90\x83\x13\x35\x08\xf8\x5a\xa0\xf1\x40\x0a\x0a\x7d\x36\x0d\x49\x55\x20\x74\xe4\xeb\xcb\xae\x2b\x69\x31\x72\x9e\xaf\x1c\x7d\xf4\x92\x47\x24\x0f\x19\xbe\x9d\xa6\xcd\x20\xfd\xc4\xc0\x70\x6a\xef\xcd\xb7\x30\xec\xba\x6e\x21\x2f\xe2\x17\xda\x56\x61\xf3\xd6\xee\xc2\x9d\xd8\xc4\x49\x16\x7f\x61\x03\x93\xc1\x07\xd5\xa0\x73\x60\xf1\x77\x4b\x96\x03\x38\xf6\x20\x0d\x0b\x2c\xe4\x91\x65\x37\xb2\x03\x6d\x41\x56\x16\xb9\xe6\xf5\x60\xa0\xb3\xe4\x49\x55\x73\x70\xfa\xe4\x3b\x69\x71\x44\x95\xe4\xbc\xa5\x63\xeb\x3f\xe4\x38\xca\xe5\x24\xa6\x0d\x9c\xa4\x54\x30\x8b\x32\x10\xd9\x0c\xd6\x51\x26\xb2\xf9\x08\x7a\x14\xf9\x8f\xf4\x21\x87\xc7\xe8\x70\x88\x92\x5c\xc4\x19\xa4\x07\xd8\xa4\xc9\x56\xe4\x22\x4d\xf8\x76\x07\x51\xf2\x0c\x3f\x45\xb2\x9d\x03\x72\x8a\xbc\x0b\x5f\x8d\x1d\x9c\xb0\x5c\x1a\x12\xc6\xf2\x3d\xce\x0c\xf1\x83\x94\x93\x7e\x93\xe6\x0c\x16\x74\xa2\x82\x6d\xaa\xaa\x95\x15\x42\xa5\xff\xa0\x55\xec\x0e\x0c\xda\x33\xb9\xe1\x8f\x3b\x16\x5a\x8e\xa8\x86\xce\xe4\xa5\xbf\x7c\xfe\xc7\xe3\xb0\x30\x0c\x82\x75\xfc\x5d\x24\xab\x20\xd8\x1e\xd2\x7b\xc8\xa3\xf5\x2e\x06\x71\x07\xf1\x93\xc8\xf2\x0c\x74\x29\xeb\xf6\xc5\xb5\x47\x57\x58\x32\x03\xe8\xa5\xc4\x86\x78\x6d\xbf\xfa\xdf\x09\x66\x6f\xd2\xfd\x5e\xe4\xab\xe0\x2f\x8e\xf5\x08\x99\xec\x02\x00\x00")
+
+func _000012_subscriptionDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000012_subscriptionDownSql,
+		"000012_subscription.down.sql",
+	)
+}
+
+func _000012_subscriptionDownSql() (*asset, error) {
+	bytes, err := _000012_subscriptionDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000012_subscription.down.sql", size: 748, mode: os.FileMode(0664), modTime: time.Unix(1792192281, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xdd, 0xf, 0xac, 0x28, 0x5, 0xdb, 0x8c, 0x13, 0xf5, 0x22, 0x74, 0x3, 0xce, 0x2d, 0xeb, 0x2d, 0xb4, 0x4e, 0x57, 0x6c, 0x3b, 0xd4, 0xf3, 0x27, 0xd7, 0xaa, 0x54, 0xf, 0xf7, 0x44, 0x8f, 0x40}}
+	return a, nil
+}
+
+var __000013_outbox_notifyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x53\xc1\x6e\xa3\x30\x10\xbd\xf3\x15\xa3\xaa\x52\xdb\x55\x9a\x74\x7b\xdc\x9c\x28\x71\x52\xb4\x09\x64\x0d\x6c\xda\x53\xe4\x80\x43\x2c\x11\x9b\xda\xa6\x29\x7f\xbf\x63\x12\xba\x69\xb7\x5a\x0b\x09\xd9\x9e\x79\xf3\xde\x9b\xf1\xe8\x9b\x07\xee\x03\xb7\x02\x55\xb7\x5a\x94\x3b\x0b\xf7\x77\xf7\xdf\x81\x2c\xfd\x05\x24\xad\xb1\x7c\x6f\xce\xa2\xe6\x22\xe7\xd2\xf0\x02\x1a\x59\x70\x0d\x76\xc7\xc1\xaf\x59\x8e\xbf\xd3\xcd\x00\x7e\x73\x6d\x84\x92\x70\x3f\xbc\x83\x6b\x17\x70\x71\xba\xba\xb8\x19\xf7\x30\xad\x6a\x60\xcf\x5a\x90\xca\x42\x63\x38\xe2\x08\x03\x5b\x51\x71\xe0\x6f\x39\xaf\x2d\x08\x09\xb9\xda\xd7\x95\x60\x32\xe7\x70\x10\x76\xd7\xd5\x3a\x21\x0d\x7b\x9c\xe7\x13\x8e\xda\x58\x86\x29\x0c\x93\x6a\xdc\x6d\xcf\x83\x81\xd9\x33\x01\x6e\xed\xac\xad\x7f\x8c\x46\x87\xc3\x61\xc8\x3a\xf2\x43\xa5\xcb\x51\x75\x0c\x37\xa3\x79\x18\x90\x28\x21\xb7\x28\xe0\x2c\x31\x93\x15\x37\x06\x34\x7f\x69\x84\x46\x03\x36\x2d\xb0\x1a\x09\xe6\x6c\x83\xb4\x2b\x76\x00\xa5\x81\x95\x9a\xe3\x9d\x55\x4e\xc0\x41\x0b\x2b\x64\x39\x00\xa3\xb6\xf6\xc0\x34\xef\xa1\x0a\x61\xac\x16\x9b\xc6\x7e\xf0\xb1\xa7\x8b\x4e\x9c\x07\xa0\x93\x4c\xc2\x85\x9f\x40\x98\x5c\xc0\x83\x9f\x84\xc9\xa0\x07\x5a\x85\xe9\x63\x9c\xa5\xb0\xf2\x29\xf5\xa3\x34\x24\x09\xc4\x14\x82\x38\x9a\x84\x69\x18\x47\xb8\x9b\x82\x1f\x3d\xc3\xcf\x30\x9a\x0c\x80\xa3\x8b\x58\x8b\xbf\xd5\xda\x29\x41\xba\xc2\x39\xcc\x8b\x77\x3b\x13\xce\x3f\x50\xd9\xaa\x23\x35\x53\xf3\x5c\x6c\x45\x8e\x32\x65\xd9\xb0\x92\x43\xa9\x5e\xb9\x96\xa8\x0e\x6a\xae\xf7\xc2\xb8\x8e\x1b\x24\x5a\xf4\x50\x95\xd8\x0b\xcb\x6c\x77\xfc\x8f\x46\x57\x70\xe4\x79\x0f\x64\x16\x46\x63\xcf\xbb\xbd\x05\x82\x70\x2d\x9a\x66\xb8\x76\xa2\xf9\x2b\x97\xd6\x39\xc1\xa4\x54\x0d\x8e\x40\xe7\x83\x2a\xd8\xae\x59\xab\xc6\x6e\xd4\x1b\xe4\x3b\xbc\xe3\xd5\xdf\xd9\x38\xe6\x94\x5a\x35\x35\x30\xcc\x84\x9a\xb5\x95\x62\xa8\x0e\xf1\x97\xca\x58\xec\x4d\xf2\x6b\x0e\x05\xaf\x04\x56\x33\x6e\xf8\x9c\xa8\x13\x49\x25\x2b\xec\xe8\xd6\x22\x53\x9c\x3c\x24\xef\xd4\xa0\x03\x55\x81\xfd\x68\xba\x46\x5b\x6e\xdc\x6c\x31\xb0\x9a\x49\xc3\x72\x97\xe8\x05\x94\xf8\x29\x71\xbe\x53\xb2\x9c\xfb\x01\x81\x69\x16\x05\xce\xfe\x0f\x7c\xd7\x5d\xb5\xf6\xfa\x06\xc3\xd2\x8c\x62\x6f\x52\x1a\xce\x66\x84\x82\x9f\x78\x97\x97\x47\x2f\x3c\xe7\xdc\x92\xd0\x69\x4c\x17\x50\x97\x7d\xce\xd5\x39\xd0\xd5\x00\x22\xb2\x1a\x76\x6a\xd7\x9d\x5a\xf7\xac\x70\x1d\x71\x21\xca\xe6\xf3\xb1\x47\xa2\xc9\x18\x61\x61\xee\x47\xb3\xcc\x9f\x11\xa8\xab\xba\x34\x2f\x15\xba\x3d\xa1\xf1\xf2\xbd\x78\x38\x05\xf2\x14\x26\x69\xf2\x15\xd9\x35\x0e\x61\x59\xa2\x21\x9f\xb4\x8c\x7b\xd1\x3d\xca\x7f\x72\x3b\x6a\xfe\x34\x75\xb5\xf0\x51\xd1\xb4\x3b\xf8\x04\xd8\x9d\xa1\x68\x20\x7e\xf0\x08\x34\x5e\x79\xe4\x89\x04\x19\x56\x58\xd2\x38\x20\x93\x8c\x92\xaf\xcd\x44\x39\x41\xbc\x58\x84\xe9\xd8\xfb\x03\xa6\x49\xe4\xc6\xcd\x04\x00\x00")
+
+func _000013_outbox_notifyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000013_outbox_notifyUpSql,
+		"000013_outbox_notify.up.sql",
+	)
+}
+
+func _000013_outbox_notifyUpSql() (*asset, error) {
+	bytes, err := _000013_outbox_notifyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000013_outbox_notify.up.sql", size: 1229, mode: os.FileMode(0664), modTime: time.Unix(1792193193, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1b, 0x1c, 0x69, 0xb7, 0xea, 0x2e, 0xa, 0xc5, 0x9f, 0xc2, 0x9c, 0x0, 0xae, 0x6b, 0x16, 0x84, 0x85, 0xc4, 0x4c, 0x56, 0x66, 0x99, 0xa1, 0xfd, 0xe8, 0xf5, 0xf7, 0xd0, 0x3a, 0xc7, 0xb3, 0xfe}}
+	return a, nil
+}
+
+var __000013_outbox_notifyDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x92\x41\x8f\x9b\x30\x10\x85\xef\xfc\x8a\x51\x4e\xdb\x2a\x0d\xdb\x1c\x9b\x13\x4b\x48\xd6\xea\x06\x56\x40\xba\xbb\xa7\xc8\xc0\x40\x2c\x11\x9b\xda\xa6\x84\x7f\xdf\x21\x09\x2b\xa2\x4a\xb5\x90\x90\x99\x99\xcf\xef\x3d\xec\x7e\x75\x60\x78\x60\x58\xbe\x6a\x7a\x2d\xaa\xa3\x85\xe5\xe3\xf2\x3b\x04\xaf\xde\x0e\x92\xde\x58\x3c\x99\x49\xd7\x8b\xc8\x51\x1a\x2c\xa0\x95\x05\x6a\xb0\x47\x04\xaf\xe1\x39\xbd\x6e\x95\x39\xfc\x42\x6d\x84\x92\xb0\x5c\x3c\xc2\xc3\xd0\x30\xbb\x95\x66\x5f\x56\x23\xa6\x57\x2d\x9c\x78\x0f\x52\x59\x68\x0d\x12\x47\x18\x28\x45\x8d\x80\xe7\x1c\x1b\x0b\x42\x42\xae\x4e\x4d\x2d\xb8\xcc\x11\x3a\x61\x8f\x97\xb3\x6e\xa4\xc5\xc8\xf9\xb8\x71\x54\x66\x39\x8d\x70\x1a\x6a\x68\x57\x4e\x9b\x81\xdb\x89\x81\x61\x1d\xad\x6d\x7e\xb8\x6e\xd7\x75\x0b\x7e\x11\xbf\x50\xba\x72\xeb\x6b\xbb\x71\x5f\x98\x1f\x84\x49\xf0\x8d\x0c\x4c\x06\xf7\xb2\x46\x63\x40\xe3\xef\x56\x68\x0a\x20\xeb\x81\x37\x24\x30\xe7\x19\xc9\xae\x79\x07\x4a\x03\xaf\x34\x52\xcd\xaa\xc1\x40\xa7\x85\x15\xb2\x9a\x83\x51\xa5\xed\xb8\xc6\x11\x55\x08\x63\xb5\xc8\x5a\x7b\x97\xe3\x28\x97\x92\x98\x36\x50\x92\x5c\xc2\xcc\x4b\x80\x25\x33\x78\xf2\x12\x96\xcc\x47\xd0\x1b\x4b\x9f\xa3\x7d\x0a\x6f\x5e\x1c\x7b\x61\xca\x82\x04\xa2\x18\xfc\x28\x5c\xb3\x94\x45\x21\xed\x36\xe0\x85\x1f\xf0\x93\x85\xeb\x39\x20\xa5\x48\x67\xe1\xb9\xd1\x83\x13\x92\x2b\x86\x84\xb1\xf8\x8c\x33\x41\xbc\x93\x52\xaa\xab\x34\xd3\x60\x2e\x4a\x91\x93\x4d\x59\xb5\xbc\x42\xa8\xd4\x1f\xd4\x92\xdc\x41\x83\xfa\x24\xcc\xf0\xc7\x0d\x09\x2d\x46\x54\x2d\x4e\xc2\x72\x7b\xf9\xfc\x8f\xc7\xe1\x40\xd7\x71\x9e\x82\x2d\x0b\x57\x8e\xb3\x8e\xa3\x57\x48\x63\xb6\xdd\x06\x31\xb0\x0d\x04\xef\x2c\x49\x13\x50\x05\x3f\xb6\x07\xd5\xda\x4c\x9d\x0f\x74\x53\x44\xd9\x1f\x28\x96\xaa\x22\x58\x14\xde\x95\x57\x57\xc6\x66\x1f\xfa\x83\xf1\xff\x43\x1e\xe8\x1e\x3a\x7e\xb4\xdb\xb1\x74\xe5\xfc\x05\x75\x01\xc0\x15\x04\x03\x00\x00")
+
+func _000013_outbox_notifyDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000013_outbox_notifyDownSql,
+		"000013_outbox_notify.down.sql",
+	)
+}
+
+func _000013_outbox_notifyDownSql() (*asset, error) {
+	bytes, err := _000013_outbox_notifyDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000013_outbox_notify.down.sql", size: 772, mode: os.FileMode(0664), modTime: time.Unix(1792193193, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x67, 0xb2, 0x33, 0xf, 0xcf, 0x8b, 0xbf, 0xe7, 0xc2, 0x48, 0x46, 0x95, 0x5b, 0xa5, 0x9e, 0xc3, 0xd7, 0xb3, 0x58, 0x4, 0x80, 0xe8, 0x7a, 0xf2, 0xe8, 0x1, 0xdb, 0x6e, 0x5c, 0x4, 0xb2, 0x84}}
+	return a, nil
+}
+
+var __000014_training_scheduleUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x52\x4d\x8f\xda\x30\x10\xbd\xe7\x57\x8c\x38\x41\x45\xc9\x16\x55\x3d\x94\x93\x61\xb3\x5d\xb7\x24\x41\x49\xd8\x2d\xbd\x20\x93\x0c\xc1\x52\x88\x53\xdb\x69\x96\x7f\xbf\x63\x20\x15\xdb\x56\x6a\x14\xc9\x72\xfc\xfc\xbe\x26\xfe\x3b\x0f\xdc\x0b\xee\x59\xa8\xe6\xa4\x65\x79\xb0\x30\xbd\x9b\x7e\x80\x60\xc5\x42\x48\x4f\xc6\xe2\xd1\xdc\xa0\x96\x32\xc7\xda\x60\x01\x6d\x5d\xa0\x06\x7b\x40\x60\x8d\xc8\x69\xb9\x9e\x8c\xe1\x09\xb5\x91\xaa\x86\xe9\xe4\x0e\x86\x0e\x30\xb8\x1e\x0d\x46\xb3\x9e\xe6\xa4\x5a\x38\x8a\x13\xd4\xca\x42\x6b\x90\x78\xa4\x81\xbd\xac\x10\xf0\x25\xc7\xc6\x82\xac\x21\x57\xc7\xa6\x92\xa2\xce\x11\x3a\x69\x0f\x67\xad\x2b\xd3\xa4\xe7\xd9\x5c\x79\xd4\xce\x0a\xba\x22\xe8\x52\x43\xbb\xfd\x2d\x18\x84\xbd\x09\xe0\x9e\x83\xb5\xcd\x67\xdf\xef\xba\x6e\x22\xce\xe6\x27\x4a\x97\x7e\x75\x81\x1b\x7f\xc9\x17\x41\x94\x06\xef\x29\xc0\xcd\xc5\x75\x5d\xa1\x31\xa0\xf1\x67\x2b\x35\x15\xb0\x3b\x81\x68\xc8\x60\x2e\x76\x64\xbb\x12\x1d\x28\x0d\xa2\xd4\x48\x67\x56\xb9\x00\x9d\x96\x56\xd6\xe5\x18\x8c\xda\xdb\x4e\x68\xec\xa9\x0a\x69\xac\x96\xbb\xd6\xbe\xe9\xb1\xb7\x4b\x4d\xdc\x02\xa8\x49\x51\xc3\x80\xa5\xc0\xd3\x01\xcc\x59\xca\xd3\x71\x4f\xf4\xcc\xb3\xc7\x78\x9d\xc1\x33\x4b\x12\x16\x65\x3c\x48\x21\x4e\x60\x11\x47\xf7\x3c\xe3\x71\x44\xbb\x07\x60\xd1\x06\xbe\xf1\xe8\x7e\x0c\x48\x2d\x92\x16\xbe\x34\xda\x25\x21\xbb\xd2\x35\x8c\xc5\xef\x3a\x53\xc4\x37\x56\xf6\xea\x62\xcd\x34\x98\xcb\xbd\xcc\x29\x66\x5d\xb6\xa2\x44\x28\xd5\x2f\xd4\x35\xa5\x83\x06\xf5\x51\x1a\x37\x71\x43\x46\x8b\x9e\xaa\x92\x47\x69\x85\x3d\x7f\xfe\x2b\xa3\x13\xf4\x3d\xcf\x9b\x07\x5f\x78\x34\xf3\xbc\x45\x12\xb0\x2c\x80\x8c\xcd\x97\x01\xf0\x07\x88\xe2\x0c\x82\xef\x3c\xcd\x52\x50\x85\x38\xb4\x5b\x45\x2a\xc2\x2a\xbd\xb5\x9a\xe6\x4c\xb2\x5b\x43\x63\x2b\xda\x0a\xbd\xa1\xe7\xe4\x64\x71\x19\xed\x13\x4b\x16\x8f\x2c\x19\x7e\xfa\x38\x82\x55\xc2\x43\x96\x50\xfa\x60\x33\x3e\x83\x72\x8d\xc2\x55\x9a\xf1\x30\x48\x33\x16\xae\xb2\x1f\x67\xa9\x68\xbd\x5c\x5e\x10\x6d\x53\xfc\x07\xe1\xaa\x70\xeb\xd7\x34\x8e\xe6\xd7\xff\xe9\x0f\x04\xc5\x6e\xcd\xbf\x11\xde\xc8\xa5\x8d\xc3\x90\x67\x33\xef\x15\x6b\xb1\x61\x2f\x7f\x03\x00\x00")
+
+func _000014_training_scheduleUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000014_training_scheduleUpSql,
+		"000014_training_schedule.up.sql",
+	)
+}
+
+func _000014_training_scheduleUpSql() (*asset, error) {
+	bytes, err := _000014_training_scheduleUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000014_training_schedule.up.sql", size: 895, mode: os.FileMode(0664), modTime: time.Unix(1792193925, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8b, 0x88, 0xd1, 0xff, 0xf8, 0x3e, 0xb2, 0xd5, 0xc0, 0x17, 0x13, 0xf7, 0x94, 0x87, 0x1f, 0x97, 0xca, 0x66, 0x27, 0x9b, 0x76, 0xa1, 0xcd, 0x93, 0x25, 0x48, 0xb4, 0x7c, 0xaa, 0x93, 0xe, 0xee}}
+	return a, nil
+}
+
+var __000014_training_scheduleDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x51\xc1\x8e\x9b\x30\x10\xbd\xf3\x15\xa3\x9c\xb6\x55\x1a\xb6\x39\x36\x27\x92\xb0\xad\xd5\x04\x56\x31\xdb\xdd\x3d\x45\x0e\x0c\x60\x09\x6c\xd7\x36\x65\xf9\xfb\x0e\xd9\x50\xb1\xaa\x85\x84\xec\x79\xf3\xe6\xbd\x37\xe1\xe7\x00\xc6\x0f\xc6\xb3\xd3\x66\xb0\xb2\xaa\x3d\xac\xef\xd7\x5f\x21\x7e\x8c\x8e\xc0\x07\xe7\xb1\x75\x33\xd4\x41\xe6\xa8\x1c\x16\xd0\xa9\x02\x2d\xf8\x1a\x21\x32\x22\xa7\xdf\xad\xb2\x84\x5f\x68\x9d\xd4\x0a\xd6\xab\x7b\xb8\x1b\x01\x8b\x5b\x69\xf1\x69\x33\xd1\x0c\xba\x83\x56\x0c\xa0\xb4\x87\xce\x21\xf1\x48\x07\xa5\x6c\x10\xf0\x2d\x47\xe3\x41\x2a\xc8\x75\x6b\x1a\x29\x54\x8e\xd0\x4b\x5f\x5f\x67\xdd\x98\x56\x13\xcf\xeb\x8d\x47\x5f\xbc\xa0\x16\x41\x4d\x86\x6e\xe5\x1c\x0c\xc2\xcf\x0c\x8c\xa7\xf6\xde\x7c\x0b\xc3\xbe\xef\x57\xe2\x2a\x7e\xa5\x6d\x15\x36\xef\x70\x17\x1e\xd8\x2e\x4e\x78\xfc\x85\x0c\xcc\x1a\x9f\x54\x83\xce\x81\xc5\xdf\x9d\xb4\x14\xc0\x65\x00\x61\x48\x60\x2e\x2e\x24\xbb\x11\x3d\x68\x0b\xa2\xb2\x48\x35\xaf\x47\x03\xbd\x95\x5e\xaa\x6a\x09\x4e\x97\xbe\x17\x16\x27\xaa\x42\x3a\x6f\xe5\xa5\xf3\x1f\x72\x9c\xe4\x52\x12\x73\x00\x25\x29\x14\x2c\x22\x0e\x8c\x2f\x60\x1b\x71\xc6\x97\x13\xd1\x33\xcb\x7e\xa4\x4f\x19\x3c\x47\xa7\x53\x94\x64\x2c\xe6\x90\x9e\x60\x97\x26\x7b\x96\xb1\x34\xa1\xdb\x03\x44\xc9\x2b\xfc\x64\xc9\x7e\x09\x48\x29\xd2\x2c\x7c\x33\x76\x74\x42\x72\xe5\x98\x30\x16\xff\xe2\xe4\x88\x1f\xa4\x94\xfa\x5d\x9a\x33\x98\xcb\x52\xe6\x64\x53\x55\x9d\xa8\x10\x2a\xfd\x07\xad\x22\x77\x60\xd0\xb6\xd2\x8d\x1b\x77\x24\xb4\x98\xa8\x1a\xd9\x4a\x2f\xfc\xf5\xf9\x3f\x8f\xe3\xc0\x30\x08\x82\x6d\xfc\x9d\x25\x9b\x20\xd8\x9f\xd2\x47\xc8\xa2\xed\x21\x06\xf6\x00\xf1\x0b\xe3\x19\x07\x5d\x88\xba\x3b\x6b\xe2\x17\x5e\xdb\xb3\xb7\xb4\x61\x1a\x78\x76\xb4\xb0\xa2\x6b\x90\xfa\x76\xe9\xf1\xc8\xb2\x4d\xf0\x17\xed\x0c\x3e\x05\xc9\x02\x00\x00")
+
+func _000014_training_scheduleDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000014_training_scheduleDownSql,
+		"000014_training_schedule.down.sql",
+	)
+}
+
+func _000014_training_scheduleDownSql() (*asset, error) {
+	bytes, err := _000014_training_scheduleDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000014_training_schedule.down.sql", size: 713, mode: os.FileMode(0664), modTime: time.Unix(1792193925, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc, 0x39, 0x6e, 0xa5, 0xae, 0x30, 0xfc, 0x18, 0x44, 0x81, 0x2a, 0x88, 0x45, 0x2e, 0xb6, 0x28, 0xba, 0x98, 0x96, 0xdb, 0xd7, 0xcf, 0x56, 0xb5, 0x6a, 0xed, 0x2b, 0x82, 0x35, 0x2, 0xbc, 0xe5}}
+	return a, nil
+}
+
+var __000015_pipelineUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x52\x5b\x6f\xda\x30\x14\x7e\xcf\xaf\x38\xe2\xa5\x30\x51\xe8\xaa\x69\x0f\x43\xaa\x64\x68\xba\x7a\x23\x09\x4a\x42\x5b\xf6\x82\x4c\x72\x08\x96\x42\xec\xd9\xce\x02\xff\x7e\x76\x08\x1b\xdd\x45\x5b\x14\xc9\x72\xfc\x9d\xef\xe6\x8c\xdf\x78\xe0\x5e\x70\xcf\x4c\xc8\xa3\xe2\xc5\xce\xc0\xed\xcd\xed\x5b\xf0\x17\x24\x80\xe4\xa8\x0d\xee\xf5\x05\x6a\xce\x33\xac\x34\xe6\x50\x57\x39\x2a\x30\x3b\x04\x22\x59\x66\x97\xee\x64\x08\x4f\xa8\x34\x17\x15\xdc\x8e\x6e\xa0\xef\x00\xbd\xee\xa8\x37\x98\x9c\x69\x8e\xa2\x86\x3d\x3b\x42\x25\x0c\xd4\x1a\x2d\x0f\xd7\xb0\xe5\x25\x02\x1e\x32\x94\x06\x78\x05\x99\xd8\xcb\x92\xb3\x2a\x43\x68\xb8\xd9\xb5\x5a\x1d\xd3\xe8\xcc\xb3\xea\x78\xc4\xc6\x30\x3b\xc2\xec\x90\xb4\xbb\xed\x25\x18\x98\xb9\x08\xe0\x9e\x9d\x31\xf2\xc3\x78\xdc\x34\xcd\x88\xb5\xe6\x47\x42\x15\xe3\xf2\x04\xd7\xe3\x39\x9d\xf9\x61\xe2\x5f\xdb\x00\x17\x83\xcb\xaa\x44\xad\x41\xe1\xd7\x9a\x2b\x5b\xc0\xe6\x08\x4c\x5a\x83\x19\xdb\x58\xdb\x25\x6b\x40\x28\x60\x85\x42\x7b\x66\x84\x0b\xd0\x28\x6e\x78\x55\x0c\x41\x8b\xad\x69\x98\xc2\x33\x55\xce\xb5\x51\x7c\x53\x9b\x57\x3d\x9e\xed\xda\x26\x2e\x01\xb6\x49\x56\x41\x8f\x24\x40\x93\x1e\x4c\x49\x42\x93\xe1\x99\xe8\x99\xa6\x8f\xd1\x32\x85\x67\x12\xc7\x24\x4c\xa9\x9f\x40\x14\xc3\x2c\x0a\xef\x69\x4a\xa3\xd0\xee\x1e\x80\x84\x2b\xf8\x4c\xc3\xfb\x21\xa0\x6d\xd1\x6a\xe1\x41\x2a\x97\xc4\xda\xe5\xae\x61\xcc\x7f\xd4\x99\x20\xbe\xb2\xb2\x15\x27\x6b\x5a\x62\xc6\xb7\x3c\xb3\x31\xab\xa2\x66\x05\x42\x21\xbe\xa1\xaa\x6c\x3a\x90\xa8\xf6\x5c\xbb\x1b\xd7\xd6\x68\x7e\xa6\x2a\xf9\x9e\x1b\x66\xda\xcf\xbf\x65\x74\x82\x63\xcf\xf3\xa6\xfe\x47\x1a\x4e\x3c\x6f\x16\xfb\x24\xf5\x21\x25\xd3\xb9\x0f\xf4\x01\xc2\x28\x05\xff\x85\x26\x69\x02\x22\x67\xbb\x7a\x2d\xac\x0a\x33\x42\xad\x25\x97\x58\xf2\x0a\xbd\xbe\xe7\x54\x78\x7e\xba\xd1\x27\x12\xcf\x1e\x49\xdc\x7f\xff\x6e\x00\x8b\x98\x06\x24\xb6\xa1\xfd\xd5\xb0\x05\x65\x0a\x99\x6b\x32\xa5\x81\x9f\xa4\x24\x58\xa4\x5f\x5a\x85\x70\x39\x9f\x9f\x10\xb5\xcc\xff\x81\x70\x0d\xb8\xf5\x53\x12\x85\xd3\xee\x37\xfa\x05\x61\xd3\xd6\xfa\xcf\x08\x6f\xf0\x33\xa4\xbd\x0a\xff\xe5\xff\x42\xae\x1d\x27\xae\x79\x7e\x80\x28\xfc\x1b\x08\xfa\xfd\x4e\xfa\xfa\xee\x0e\xae\xda\x91\xab\x41\x2b\x18\x05\x01\x4d\x27\xde\x77\x81\x69\xe9\xbf\xe7\x03\x00\x00")
+
+func _000015_pipelineUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000015_pipelineUpSql,
+		"000015_pipeline.up.sql",
+	)
+}
+
+func _000015_pipelineUpSql() (*asset, error) {
+	bytes, err := _000015_pipelineUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000015_pipeline.up.sql", size: 999, mode: os.FileMode(0664), modTime: time.Unix(1792194744, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa9, 0x2c, 0x95, 0x5a, 0x18, 0xec, 0x52, 0x2c, 0xff, 0xae, 0xdf, 0x67, 0x9f, 0x26, 0x46, 0x3d, 0x5, 0xd8, 0xeb, 0xbd, 0x19, 0xa3, 0xce, 0x81, 0xef, 0xd2, 0x8b, 0xec, 0xdb, 0x92, 0x96, 0x33}}
+	return a, nil
+}
+
+var __000015_pipelineDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x51\xc1\x8e\x9b\x30\x10\xbd\xf3\x15\xa3\x9c\xda\x2a\x0d\xdb\x1c\x9b\x13\x49\xd8\xd6\x6a\x02\xab\xc0\x76\xbb\xa7\x95\x03\x03\x8c\x04\xb6\x6b\x9b\xb2\xfc\x7d\x87\x6c\xa8\xb2\xaa\x65\xc9\xb2\xe7\xcd\x9b\xf7\x9e\xc3\x4f\x01\x4c\x1b\xa6\xb5\xd3\x66\xb4\x54\x37\x1e\xd6\x77\xeb\x2f\x10\x3f\x44\x47\xc8\x46\xe7\xb1\x73\x37\xa8\x03\x15\xa8\x1c\x96\xd0\xab\x12\x2d\xf8\x06\x21\x32\xb2\xe0\xe3\x5a\x59\xc2\x4f\xb4\x8e\xb4\x82\xf5\xea\x0e\x3e\x4c\x80\xc5\xb5\xb4\xf8\xb8\x99\x69\x46\xdd\x43\x27\x47\x50\xda\x43\xef\x90\x79\xc8\x41\x45\x2d\x02\xbe\x16\x68\x3c\x90\x82\x42\x77\xa6\x25\xa9\x0a\x84\x81\x7c\x73\x99\x75\x65\x5a\xcd\x3c\xcf\x57\x1e\x7d\xf6\x92\x5b\x24\x37\x19\xbe\x55\xb7\x60\x90\xfe\xc6\xc0\xb4\x1a\xef\xcd\xd7\x30\x1c\x86\x61\x25\x2f\xe2\x57\xda\xd6\x61\xfb\x06\x77\xe1\x41\xec\xe2\x24\x8b\x3f\xb3\x81\x9b\xc6\x47\xd5\xa2\x73\x60\xf1\x77\x4f\x96\x03\x38\x8f\x20\x0d\x0b\x2c\xe4\x99\x65\xb7\x72\x00\x6d\x41\xd6\x16\xb9\xe6\xf5\x64\x60\xb0\xe4\x49\xd5\x4b\x70\xba\xf2\x83\xb4\x38\x53\x95\xe4\xbc\xa5\x73\xef\xdf\xe5\x38\xcb\xe5\x24\x6e\x01\x9c\xa4\x54\xb0\x88\x32\x10\xd9\x02\xb6\x51\x26\xb2\xe5\x4c\xf4\x24\xf2\xef\xe9\x63\x0e\x4f\xd1\xe9\x14\x25\xb9\x88\x33\x48\x4f\xb0\x4b\x93\xbd\xc8\x45\x9a\xf0\xed\x1e\xa2\xe4\x19\x7e\x88\x64\xbf\x04\xe4\x14\x79\x16\xbe\x1a\x3b\x39\x61\xb9\x34\x25\x8c\xe5\xbf\x38\x33\xc4\x77\x52\x2a\xfd\x26\xcd\x19\x2c\xa8\xa2\x82\x6d\xaa\xba\x97\x35\x42\xad\xff\xa0\x55\xec\x0e\x0c\xda\x8e\xdc\xf4\xe3\x8e\x85\x96\x33\x55\x4b\x1d\x79\xe9\x2f\xcf\xff\x79\x9c\x06\x86\x41\x10\x6c\xe3\x6f\x22\xd9\x04\xc1\xfe\x94\x3e\x40\x1e\x6d\x0f\x31\x88\x7b\x88\x7f\x89\x2c\xcf\x40\x97\xb2\xe9\x5f\x34\xf3\x4b\xaf\xed\x8b\x21\x83\x2d\x29\x64\xf8\x2e\x3d\x1e\x45\xbe\x09\xfe\x02\x2e\xa7\x2c\xed\xc0\x02\x00\x00")
+
+func _000015_pipelineDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000015_pipelineDownSql,
+		"000015_pipeline.down.sql",
+	)
+}
+
+func _000015_pipelineDownSql() (*asset, error) {
+	bytes, err := _000015_pipelineDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000015_pipeline.down.sql", size: 704, mode: os.FileMode(0664), modTime: time.Unix(1792194744, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0x7, 0x6b, 0xb9, 0x93, 0x5e, 0x70, 0xb7, 0xf6, 0x44, 0xe4, 0xb7, 0xfc, 0xa, 0xa4, 0x57, 0x97, 0x8b, 0x54, 0x5f, 0x71, 0x3e, 0xdb, 0x51, 0x45, 0x9b, 0x16, 0xc6, 0x7e, 0xf3, 0xe7, 0xfc}}
+	return a, nil
+}
+
+var __000016_training_sweepUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x52\x5d\x6f\xda\x30\x14\x7d\xcf\xaf\xb8\xe2\xa5\x30\x51\xe8\xaa\x69\x0f\x43\xaa\x64\x68\xba\x7a\x83\x04\x25\xa1\x2d\x7b\x41\x26\xb9\x04\x4b\x10\x67\xb6\xb3\xc0\xbf\xdf\x75\x20\x1b\xdd\xa6\x4d\x8b\x22\x59\x8e\xcf\x3d\x5f\xce\xf0\x8d\x07\xee\x05\xf7\x4c\x54\x79\xd4\x32\xdf\x5a\xb8\xbd\xb9\x7d\x0b\xfe\x9c\xcd\x20\x3e\x1a\x8b\x7b\x73\x81\x9a\xca\x14\x0b\x83\x19\x54\x45\x86\x1a\xec\x16\x81\x95\x22\xa5\xe5\x7c\xd2\x87\x27\xd4\x46\xaa\x02\x6e\x07\x37\xd0\x75\x80\xce\xf9\xa8\xd3\x1b\xb5\x34\x47\x55\xc1\x5e\x1c\xa1\x50\x16\x2a\x83\xc4\x23\x0d\x6c\xe4\x0e\x01\x0f\x29\x96\x16\x64\x01\xa9\xda\x97\x3b\x29\x8a\x14\xa1\x96\x76\xdb\x68\x9d\x99\x06\x2d\xcf\xf2\xcc\xa3\xd6\x56\xd0\x88\xa0\xa1\x92\x76\x9b\x4b\x30\x08\x7b\x11\xc0\x3d\x5b\x6b\xcb\x0f\xc3\x61\x5d\xd7\x03\xd1\x98\x1f\x28\x9d\x0f\x77\x27\xb8\x19\x4e\xf9\xc4\x0f\x62\xff\x9a\x02\x5c\x0c\x2e\x8a\x1d\x1a\x03\x1a\xbf\x56\x52\x53\x01\xeb\x23\x88\x92\x0c\xa6\x62\x4d\xb6\x77\xa2\x06\xa5\x41\xe4\x1a\xe9\xcc\x2a\x17\xa0\xd6\xd2\xca\x22\xef\x83\x51\x1b\x5b\x0b\x8d\x2d\x55\x26\x8d\xd5\x72\x5d\xd9\x57\x3d\xb6\x76\xa9\x89\x4b\x00\x35\x29\x0a\xe8\xb0\x18\x78\xdc\x81\x31\x8b\x79\xdc\x6f\x89\x9e\x79\xf2\x18\x2e\x12\x78\x66\x51\xc4\x82\x84\xfb\x31\x84\x11\x4c\xc2\xe0\x9e\x27\x3c\x0c\x68\xf7\x00\x2c\x58\xc2\x67\x1e\xdc\xf7\x01\xa9\x45\xd2\xc2\x43\xa9\x5d\x12\xb2\x2b\x5d\xc3\x98\xfd\xa8\x33\x46\x7c\x65\x65\xa3\x4e\xd6\x4c\x89\xa9\xdc\xc8\x94\x62\x16\x79\x25\x72\x84\x5c\x7d\x43\x5d\x50\x3a\x28\x51\xef\xa5\x71\x37\x6e\xc8\x68\xd6\x52\xed\xe4\x5e\x5a\x61\x9b\xcf\xbf\x65\x74\x82\x43\xcf\xf3\xc6\xfe\x47\x1e\x8c\x3c\x6f\x12\xf9\x2c\xf1\x21\x61\xe3\xa9\x0f\xfc\x01\x82\x30\x01\xff\x85\xc7\x49\x0c\x2a\x13\xdb\x6a\xa5\x48\x45\x58\xa5\x57\x56\xd3\x3d\x93\xec\xca\xd4\x88\xa5\xd7\xf5\x9c\x96\xcc\x4e\xf7\xfa\xc4\xa2\xc9\x23\x8b\xba\xef\xdf\xf5\x60\x1e\xf1\x19\x8b\x28\xba\xbf\xec\x37\xa0\x54\xa3\x70\x7d\x26\x7c\xe6\xc7\x09\x9b\xcd\x93\x2f\x8d\x4e\xb0\x98\x4e\x4f\x88\xaa\xcc\xfe\x81\x70\x3d\xb8\xf5\x53\x1c\x06\xe3\xf3\xcf\xf4\x0b\x82\x32\x57\xe6\xcf\x08\xaf\xf7\x33\x2a\x5d\x88\xff\xf2\x3f\x51\x57\x8e\x19\x57\x32\x3b\x40\x18\xfc\x1d\x0a\xdd\xee\xd9\xc6\xf5\xdd\x1d\x5c\x35\x83\x57\xbd\x46\x3c\x9c\xcd\x78\x32\xf2\xbe\x03\xe5\xa8\x04\x23\xf9\x03\x00\x00")
+
+func _000016_training_sweepUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000016_training_sweepUpSql,
+		"000016_training_sweep.up.sql",
+	)
+}
+
+func _000016_training_sweepUpSql() (*asset, error) {
+	bytes, err := _000016_training_sweepUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000016_training_sweep.up.sql", size: 1017, mode: os.FileMode(0664), modTime: time.Unix(1792195554, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4e, 0x98, 0x90, 0xf0, 0x26, 0xb, 0xe5, 0x1f, 0xa3, 0x6d, 0xd3, 0x95, 0xb5, 0x1b, 0x52, 0x3, 0x97, 0x2b, 0xdc, 0xf6, 0x90, 0xa5, 0xf8, 0xe7, 0x55, 0x5c, 0x11, 0x89, 0x71, 0xfd, 0x56, 0xf9}}
+	return a, nil
+}
+
+var __000016_training_sweepDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x51\xc1\x8e\x9b\x30\x10\xbd\xf3\x15\xa3\x9c\xb6\x55\x1a\xb6\x39\x36\x27\x92\xb0\xad\xd5\x04\x56\x31\xdb\xdd\x3d\x45\x0e\x0c\x60\x09\x6c\xd7\x36\x65\xf9\xfb\x0e\xd9\x50\xb1\xaa\x65\xc9\xb2\xe7\xcd\x9b\xf7\x9e\xc3\xcf\x01\x8c\x1b\xc6\xb5\xd3\x66\xb0\xb2\xaa\x3d\xac\xef\xd7\x5f\x21\x7e\x8c\x8e\xc0\x07\xe7\xb1\x75\x33\xd4\x41\xe6\xa8\x1c\x16\xd0\xa9\x02\x2d\xf8\x1a\x21\x32\x22\xa7\xe3\x56\x59\xc2\x2f\xb4\x4e\x6a\x05\xeb\xd5\x3d\xdc\x8d\x80\xc5\xad\xb4\xf8\xb4\x99\x68\x06\xdd\x41\x2b\x06\x50\xda\x43\xe7\x90\x78\xa4\x83\x52\x36\x08\xf8\x96\xa3\xf1\x20\x15\xe4\xba\x35\x8d\x14\x2a\x47\xe8\xa5\xaf\xaf\xb3\x6e\x4c\xab\x89\xe7\xf5\xc6\xa3\x2f\x5e\x50\x8b\xa0\x26\x43\xb7\x72\x0e\x06\xe1\x67\x06\xc6\x55\x7b\x6f\xbe\x85\x61\xdf\xf7\x2b\x71\x15\xbf\xd2\xb6\x0a\x9b\x77\xb8\x0b\x0f\x6c\x17\x27\x3c\xfe\x42\x06\x66\x8d\x4f\xaa\x41\xe7\xc0\xe2\xef\x4e\x5a\x0a\xe0\x32\x80\x30\x24\x30\x17\x17\x92\xdd\x88\x1e\xb4\x05\x51\x59\xa4\x9a\xd7\xa3\x81\xde\x4a\x2f\x55\xb5\x04\xa7\x4b\xdf\x0b\x8b\x13\x55\x21\x9d\xb7\xf2\xd2\xf9\x0f\x39\x4e\x72\x29\x89\x39\x80\x92\x14\x0a\x16\x11\x07\xc6\x17\xb0\x8d\x38\xe3\xcb\x89\xe8\x99\x65\x3f\xd2\xa7\x0c\x9e\xa3\xd3\x29\x4a\x32\x16\x73\x48\x4f\xb0\x4b\x93\x3d\xcb\x58\x9a\xd0\xed\x01\xa2\xe4\x15\x7e\xb2\x64\xbf\x04\xa4\x14\x69\x16\xbe\x19\x3b\x3a\x21\xb9\x72\x4c\x18\x8b\x7f\x71\x72\xc4\x0f\x52\x4a\xfd\x2e\xcd\x19\xcc\x65\x29\x73\xb2\xa9\xaa\x4e\x54\x08\x95\xfe\x83\x56\x91\x3b\x30\x68\x5b\xe9\xc6\x1f\x77\x24\xb4\x98\xa8\x1a\xd9\x4a\x2f\xfc\xf5\xf9\x3f\x8f\xe3\xc0\x30\x08\x82\x6d\xfc\x9d\x25\x9b\x20\xd8\x9f\xd2\x47\xc8\xa2\xed\x21\x06\xf6\x00\xf1\x0b\xe3\x19\x07\x5d\x88\xba\x3b\x6b\xe2\x17\x5e\xdb\xb3\xb7\xf4\xc3\x34\xf0\xec\x7a\x44\x43\x4d\xbb\xf4\x78\x64\xd9\x26\xf8\x0b\x6a\xf6\x4f\xa9\xc6\x02\x00\x00")
+
+func _000016_training_sweepDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000016_training_sweepDownSql,
+		"000016_training_sweep.down.sql",
+	)
+}
+
+func _000016_training_sweepDownSql() (*asset, error) {
+	bytes, err := _000016_training_sweepDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000016_training_sweep.down.sql", size: 710, mode: os.FileMode(0664), modTime: time.Unix(1792195554, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x50, 0x1e, 0x3c, 0xe9, 0xa, 0x26, 0xbe, 0xed, 0x9a, 0xee, 0x0, 0x81, 0x34, 0xa2, 0x47, 0x9f, 0x19, 0x9, 0x90, 0xc4, 0xa9, 0x5e, 0xd1, 0xcb, 0x85, 0xd7, 0x32, 0x99, 0xfe, 0x2e, 0xde, 0xb6}}
+	return a, nil
+}
+
+var __000017_training_metricsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x53\x5d\x6f\xda\x30\x14\x7d\xcf\xaf\xb8\xe2\xa9\x4c\x0c\x3a\xb4\xed\x61\x7d\x32\xc1\x6d\xad\x41\x82\x12\xd3\x8f\xbd\x54\x26\x18\xb0\x4a\xe2\xcc\x76\x9a\xf2\xef\x77\x0d\x84\x42\xb7\x6e\xda\x9e\x66\x45\x8a\x6c\x9f\x7b\xee\xb9\xe7\x24\xbd\x77\x01\xf8\x07\xfc\x0a\x75\xb9\x31\x6a\xb9\x72\xd0\x3f\xef\x7f\x00\x3a\x21\x63\x48\x37\xd6\xc9\xdc\x1e\xa1\x46\x2a\x93\x85\x95\x73\xa8\x8a\xb9\x34\xe0\x56\x12\x48\x29\x32\x7c\xed\x6f\x3a\x70\x23\x8d\x55\xba\x80\x7e\xf7\x1c\xce\x3c\xa0\xb5\xbf\x6a\xb5\x2f\x1a\x9a\x8d\xae\x20\x17\x1b\x28\xb4\x83\xca\x4a\xe4\x51\x16\x16\x6a\x2d\x41\x3e\x67\xb2\x74\xa0\x0a\xc8\x74\x5e\xae\x95\x28\x32\x09\xb5\x72\xab\x6d\xaf\x3d\x53\xb7\xe1\xb9\xdf\xf3\xe8\x99\x13\x58\x22\xb0\xa8\xc4\xdd\xe2\x18\x0c\xc2\x1d\x0d\xe0\xd7\xca\xb9\xf2\x4b\xaf\x57\xd7\x75\x57\x6c\xc5\x77\xb5\x59\xf6\xd6\x3b\xb8\xed\x8d\x58\x48\xa3\x94\xbe\xc7\x01\x8e\x0a\xa7\xc5\x5a\x5a\x0b\x46\x7e\xaf\x94\x41\x03\x66\x1b\x10\x25\x0a\xcc\xc4\x0c\x65\xaf\x45\x0d\xda\x80\x58\x1a\x89\x77\x4e\xfb\x01\x6a\xa3\x9c\x2a\x96\x1d\xb0\x7a\xe1\x6a\x61\x64\x43\x35\x57\xd6\x19\x35\xab\xdc\x89\x8f\x8d\x5c\x74\xe2\x18\x80\x4e\x8a\x02\x5a\x24\x05\x96\xb6\x60\x40\x52\x96\x76\x1a\xa2\x5b\xc6\xaf\xe3\x29\x87\x5b\x92\x24\x24\xe2\x8c\xa6\x10\x27\x10\xc6\xd1\x90\x71\x16\x47\xb8\xbb\x04\x12\xdd\xc3\x57\x16\x0d\x3b\x20\xd1\x45\xec\x25\x9f\x4b\xe3\x27\x41\xb9\xca\x3b\x2c\xe7\x07\x3b\x53\x29\x4f\xa4\x2c\xf4\x4e\x9a\x2d\x65\xa6\x16\x2a\xc3\x31\x8b\x65\x25\x96\x12\x96\xfa\x49\x9a\x02\xa7\x83\x52\x9a\x5c\x59\x9f\xb8\x45\xa1\xf3\x86\x6a\xad\x72\xe5\x84\xdb\x1e\xff\x34\xa3\x6f\xd8\x0b\x82\x60\x40\xaf\x58\x74\x11\x04\x61\x42\x09\xa7\xc0\xc9\x60\x44\x81\x5d\x42\x14\x73\xa0\x77\x2c\xe5\x29\xe8\xb9\x58\x55\x0f\x1a\xbb\x08\xa7\xcd\x83\x33\x98\x33\xb6\x7d\xc8\x25\x3a\x94\x05\x67\x81\x6f\x76\x38\x55\x73\xb8\x21\x49\x78\x4d\x92\xb3\xcf\x1f\xdb\xbb\xb4\x3d\x59\x34\x1d\x8d\x82\x26\x7e\xf4\x27\xe5\x09\x61\x11\xdf\xb3\xbf\x22\x7d\xd9\x2f\x1e\x0f\x45\x7e\x25\xf4\x92\x26\x34\x0a\xe9\x9b\xb2\x4e\xe0\x71\x04\xd3\xc9\xd0\x0f\x96\x50\x6c\xc8\x42\xee\x8f\x86\x74\x44\xf1\x28\x24\x69\x48\x86\xb4\xb3\xad\x78\x94\x9b\x43\x55\xa3\xbf\xff\xe9\xbc\x7d\xa2\x7f\x07\xc5\x1f\xb2\x6c\xa0\x03\x76\xe5\x87\x78\x59\xa7\xd0\x27\xb1\xae\xe4\xfe\x66\x18\x4f\xbd\xb7\x93\x84\x86\x2c\xc5\x4f\xe3\x15\xd4\xa9\x5c\x5a\x27\x72\x4f\xcd\xd9\x18\xe5\x92\xf1\x84\x7f\xfb\x15\xeb\x24\x61\x63\x92\xe0\x37\x45\xef\xf1\xf7\x7e\x31\xbe\xe3\xa7\xe8\x6c\xf5\xb5\x83\xf6\xbf\x85\x5a\x0a\x23\xf2\x3f\x65\xfa\x17\x79\x6e\xf9\xfe\xa3\x38\xdf\xce\x87\xd3\x3b\xfe\x46\x8c\xbf\x35\x7c\x6f\x75\x3c\x1e\x33\x7e\x11\xfc\x00\x08\xf4\x1e\x29\xd1\x05\x00\x00")
+
+func _000017_training_metricsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000017_training_metricsUpSql,
+		"000017_training_metrics.up.sql",
+	)
+}
+
+func _000017_training_metricsUpSql() (*asset, error) {
+	bytes, err := _000017_training_metricsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000017_training_metrics.up.sql", size: 1489, mode: os.FileMode(0664), modTime: time.Unix(1792196138, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5f, 0x4a, 0x90, 0xf9, 0xa1, 0x52, 0xff, 0x59, 0xb7, 0x94, 0xa3, 0xf0, 0xad, 0xf, 0xdf, 0x8e, 0xac, 0xfc, 0x6a, 0xfe, 0x96, 0x19, 0x46, 0xb2, 0xf8, 0xf3, 0x77, 0x71, 0x25, 0x10, 0xcc, 0x3}}
+	return a, nil
+}
+
+var __000017_training_metricsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x51\xcd\x8e\x9b\x30\x10\xbe\xf3\x14\xa3\x9c\xda\x2a\x0d\xdb\x1c\x9b\x13\x49\xd8\xd6\x6a\x02\xab\x98\xed\x76\x4f\x91\x03\x03\x58\x02\xdb\xb5\x4d\x59\xde\xbe\x43\x36\xac\x58\xf5\x54\xcb\x92\x65\xcf\xcc\xf7\xe7\xf0\x53\x00\xe3\x86\x71\xed\xb4\x19\xac\xac\x6a\x0f\xeb\xbb\xf5\x17\x88\x1f\xa2\x23\xf0\xc1\x79\x6c\xdd\xac\xeb\x20\x73\x54\x0e\x0b\xe8\x54\x81\x16\x7c\x8d\x10\x19\x91\xd3\x71\xab\x2c\xe1\x27\x5a\x27\xb5\x82\xf5\xea\x0e\x3e\x8c\x0d\x8b\x5b\x69\xf1\x71\x33\xc1\x0c\xba\x83\x56\x0c\xa0\xb4\x87\xce\x21\xe1\x48\x07\xa5\x6c\x10\xf0\x25\x47\xe3\x41\x2a\xc8\x75\x6b\x1a\x29\x54\x8e\xd0\x4b\x5f\x5f\xb9\x6e\x48\xab\x09\xe7\xf9\x86\xa3\x2f\x5e\xd0\x88\xa0\x21\x43\xb7\x72\xde\x0c\xc2\xcf\x0c\x8c\xab\xf6\xde\x7c\x0d\xc3\xbe\xef\x57\xe2\x2a\x7e\xa5\x6d\x15\x36\xaf\xed\x2e\x3c\xb0\x5d\x9c\xf0\xf8\x33\x19\x98\x0d\x3e\xaa\x06\x9d\x03\x8b\xbf\x3b\x69\x29\x80\xcb\x00\xc2\x90\xc0\x5c\x5c\x48\x76\x23\x7a\xd0\x16\x44\x65\x91\x6a\x5e\x8f\x06\x7a\x2b\xbd\x54\xd5\x12\x9c\x2e\x7d\x2f\x2c\x4e\x50\x85\x74\xde\xca\x4b\xe7\xdf\xe5\x38\xc9\xa5\x24\xe6\x0d\x94\xa4\x50\xb0\x88\x38\x30\xbe\x80\x6d\xc4\x19\x5f\x4e\x40\x4f\x2c\xfb\x9e\x3e\x66\xf0\x14\x9d\x4e\x51\x92\xb1\x98\x43\x7a\x82\x5d\x9a\xec\x59\xc6\xd2\x84\x6e\xf7\x10\x25\xcf\xf0\x83\x25\xfb\x25\x20\xa5\x48\x5c\xf8\x62\xec\xe8\x84\xe4\xca\x31\x61\x2c\xde\xe2\xe4\x88\xef\xa4\x94\xfa\x55\x9a\x33\x98\xcb\x52\xe6\x64\x53\x55\x9d\xa8\x10\x2a\xfd\x07\xad\x22\x77\x60\xd0\xb6\xd2\x8d\x3f\xee\x48\x68\x31\x41\x35\xb2\x95\x5e\xf8\xeb\xf3\x3f\x1e\x47\xc2\x30\x08\x82\x6d\xfc\x8d\x25\x9b\x20\xd8\x9f\xd2\x07\xc8\xa2\xed\x21\x06\x76\x0f\xf1\x2f\xc6\x33\x0e\xba\x10\x75\x77\xd6\x84\x2f\xbc\xb6\x67\x6f\xe9\x87\x89\xf0\x6c\x84\x15\xed\xe6\xff\x66\x5a\xa4\x3c\x73\x62\xda\xa5\xc7\x23\xcb\x36\xc1\x5f\x4c\x61\xef\x13\xfb\x02\x00\x00")
+
+func _000017_training_metricsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000017_training_metricsDownSql,
+		"000017_training_metrics.down.sql",
+	)
+}
+
+func _000017_training_metricsDownSql() (*asset, error) {
+	bytes, err := _000017_training_metricsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000017_training_metrics.down.sql", size: 763, mode: os.FileMode(0664), modTime: time.Unix(1792196138, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x87, 0xed, 0xed, 0xed, 0x67, 0x6e, 0xbf, 0xa0, 0x5d, 0x73, 0x68, 0xf2, 0x40, 0x38, 0x54, 0xe7, 0xfb, 0xd9, 0x75, 0x54, 0xcc, 0xe, 0x9d, 0x9c, 0xed, 0xf8, 0x33, 0x55, 0xfc, 0x66, 0xd8, 0x55}}
+	return a, nil
+}
+
+var __000018_model_registryUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x54\x5d\x6f\xda\x30\x14\x7d\xcf\xaf\xb8\xea\x4b\x61\xa2\x65\xad\xb4\x3d\xac\xda\x24\x13\xdc\xd6\x5b\x08\x28\x31\xfd\xd8\x0b\x32\x89\x09\xd6\x42\x9c\xd9\x4e\x29\xff\x7e\x76\x48\xba\xb0\xa1\xae\xb3\x22\x45\xb6\x8f\xcf\xbd\xe7\xdc\x6b\x0f\xdf\x79\xe0\x3e\x70\xc3\x97\xe5\x4e\x89\x6c\x6d\xe0\xf2\xfd\xe5\x05\xe0\x19\x9a\x40\xbc\xd3\x86\x6f\x74\x07\x15\x88\x84\x17\x9a\xa7\x50\x15\x29\x57\x60\xd6\x1c\x50\xc9\x12\xfb\x6b\x76\x06\x70\xc7\x95\x16\xb2\x80\xcb\xf3\xf7\xd0\x73\x80\x93\x66\xeb\xa4\x7f\xd5\xd2\xec\x64\x05\x1b\xb6\x83\x42\x1a\xa8\x34\xb7\x3c\x42\xc3\x4a\xe4\x1c\xf8\x73\xc2\x4b\x03\xa2\x80\x44\x6e\xca\x5c\xb0\x22\xe1\xb0\x15\x66\x5d\xc7\x6a\x98\xce\x5b\x9e\xc7\x86\x47\x2e\x0d\xb3\x47\x98\x3d\x54\xda\xd9\xaa\x0b\x06\x66\x3a\x02\xdc\x58\x1b\x53\x7e\x1a\x0e\xb7\xdb\xed\x39\xab\x93\x3f\x97\x2a\x1b\xe6\x7b\xb8\x1e\x06\xc4\xc7\x61\x8c\xcf\xac\x80\xce\xc1\x79\x91\x73\xad\x41\xf1\x9f\x95\x50\xd6\x80\xe5\x0e\x58\x69\x13\x4c\xd8\xd2\xa6\x9d\xb3\x2d\x48\x05\x2c\x53\xdc\xee\x19\xe9\x04\x6c\x95\x30\xa2\xc8\x06\xa0\xe5\xca\x6c\x99\xe2\x2d\x55\x2a\xb4\x51\x62\x59\x99\x03\x1f\xdb\x74\xad\x13\x5d\x80\x75\x92\x15\x70\x82\x62\x20\xf1\x09\x8c\x50\x4c\xe2\x41\x4b\x74\x4f\xe8\xed\x74\x4e\xe1\x1e\x45\x11\x0a\x29\xc1\x31\x4c\x23\xf0\xa7\xe1\x98\x50\x32\x0d\xed\xec\x1a\x50\xf8\x08\xdf\x48\x38\x1e\x00\xb7\x2e\xda\x58\xfc\xb9\x54\x4e\x89\x4d\x57\x38\x87\x79\xfa\x62\x67\xcc\xf9\x41\x2a\x2b\xb9\x4f\x4d\x97\x3c\x11\x2b\x91\x58\x99\x45\x56\xb1\x8c\x43\x26\x9f\xb8\x2a\xac\x3a\x28\xb9\xda\x08\xed\x2a\xae\x6d\xa2\x69\x4b\x95\x8b\x8d\x30\xcc\xd4\xcb\x7f\x69\x74\x01\x87\x9e\xe7\x8d\xf0\x0d\x09\xaf\x3c\xcf\x8f\x30\xa2\x18\x28\x1a\x05\x18\xc8\x35\x84\x53\x0a\xf8\x81\xc4\x34\x06\x99\xb2\x75\xb5\xd8\xc8\x94\xe7\x5e\xcf\x73\xcc\x05\xdb\x70\xf8\x3d\xee\x50\xe4\xdf\xa2\xa8\x77\xf9\xe1\x63\x1f\x66\x11\x99\xa0\xc8\x0a\xc6\x8f\x83\x1a\x9c\x28\xce\x9c\x8b\xcd\xa0\x64\x82\x63\x8a\x26\x33\xfa\x1d\xea\x28\xe1\x3c\x08\xf6\xc8\xaa\x4c\xdf\x88\xcc\x2d\x4e\x9b\xc5\x53\xd3\xe7\x07\x09\xb4\x48\xaf\xff\x66\x59\x2d\x51\x23\xaf\x5e\x3b\xae\xee\x85\xbc\xdd\xb5\x95\x8e\x69\x84\x48\x48\x8f\x11\x36\xb3\xd5\x0f\xaf\x63\x17\x44\xf8\x1a\x47\x38\xf4\xf1\xa1\xb7\x5d\xc8\x34\x84\xf9\x6c\xec\x52\x8f\xac\x07\x11\xf1\xa9\x5b\x1a\xe3\x00\xdb\x25\x1f\xc5\x3e\x1a\xe3\xbd\x15\xad\x07\xaf\x24\xbb\x07\x6a\xe3\xba\xe6\x4f\x55\x17\x16\x77\x0c\xb8\x68\x8b\xd1\xa9\xc2\xb1\x7a\xbe\xb9\x9c\xaf\x00\x99\x32\x62\xc5\x12\xb3\xa8\xfb\x8a\xe2\x07\xfa\x92\x66\x0b\xb4\xd2\xaf\xd1\x3c\xa0\x70\x7a\xda\x74\x80\x28\xf8\x6f\x3d\x5f\xe3\x69\x38\xfa\xf3\xcc\x1e\xd8\xe9\x47\xe8\xd5\x46\x0f\x5a\xcf\xfa\xdd\x16\xb1\xf7\x13\x3f\xfc\xbb\x45\x16\x2f\xc9\x8a\xf4\xd9\xd5\xe4\x08\x06\x7a\x07\x8a\x6c\x90\xff\x8c\x21\x36\x56\x9a\x7e\x2d\xc2\x3c\x26\xe1\x0d\xd8\xab\x0b\xbd\x5e\x6b\xc5\xd9\x17\x38\xdd\x9f\x3c\xed\x3b\x61\x67\x67\x30\x93\x5a\xb8\x07\xa0\x7d\x8f\x15\xcf\xdc\xbb\xb6\x73\x6f\xa3\x9b\xcb\xca\x2c\xe5\x33\xf0\x27\x5e\x18\xc8\x65\xf6\xd6\xfb\xd2\xf2\x2c\x92\x4a\x69\xa9\x9a\x7b\x23\xf6\xb5\x8e\x27\x28\x08\xdc\x85\xe8\x5a\xef\xdf\x62\xff\x1b\xf4\x2c\xe4\x33\x5c\xf4\x9b\x4e\xaa\x0f\xc3\x88\xdc\x38\xf4\xe1\xbd\x9d\x4e\x26\x84\x5e\x79\xbf\x00\x7a\x68\x2e\x5b\x20\x07\x00\x00")
+
+func _000018_model_registryUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000018_model_registryUpSql,
+		"000018_model_registry.up.sql",
+	)
+}
+
+func _000018_model_registryUpSql() (*asset, error) {
+	bytes, err := _000018_model_registryUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000018_model_registry.up.sql", size: 1824, mode: os.FileMode(0664), modTime: time.Unix(1792196548, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x57, 0x25, 0xbc, 0x9b, 0x70, 0x44, 0x2c, 0xe0, 0xc4, 0xdc, 0x21, 0xab, 0x4e, 0x3, 0xc3, 0xd2, 0xea, 0x50, 0x7f, 0xc8, 0xe9, 0x39, 0xef, 0xb, 0x5e, 0xf3, 0x53, 0xf7, 0x2c, 0xc8, 0xc9, 0x1e}}
+	return a, nil
+}
+
+var __000018_model_registryDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x52\xc1\x8e\x9b\x30\x10\xbd\xf3\x15\xa3\x9c\xda\x2a\x0d\xdb\x1c\x9b\x13\x49\xd8\xd6\x6a\x02\xab\x98\xed\x76\x4f\x91\x03\x03\x58\x02\x9b\xda\x66\x59\xfe\xbe\xe3\x24\xac\xb2\xea\x61\x2d\x4b\xc8\x9e\x37\x6f\xde\x7b\x26\xfc\x12\x80\xdf\xe0\xd7\x46\x77\xa3\x91\x55\xed\x60\x79\xb7\xfc\x06\xf1\x43\xb4\x07\x3e\x5a\x87\xad\xbd\x41\xed\x64\x8e\xca\x62\x01\xbd\x2a\xd0\x80\xab\x11\xa2\x4e\xe4\xf4\xb9\x56\xe6\xf0\x1b\x8d\x95\x5a\xc1\x72\x71\x07\x9f\x3c\x60\x76\x2d\xcd\x3e\xaf\x26\x9a\x51\xf7\xd0\x8a\x11\x94\x76\xd0\x5b\x24\x1e\x69\xa1\x94\x0d\x02\xbe\xe6\xd8\x39\x90\x0a\x72\xdd\x76\x8d\x14\x2a\x47\x18\xa4\xab\xcf\xb3\xae\x4c\x8b\x89\xe7\xf9\xca\xa3\x4f\x4e\x50\x8b\xa0\xa6\x8e\x4e\xe5\x2d\x18\x84\xbb\x31\xe0\x57\xed\x5c\xf7\x3d\x0c\x87\x61\x58\x88\xb3\xf8\x85\x36\x55\xd8\x5c\xe0\x36\xdc\xb1\x4d\x9c\xf0\xf8\x2b\x19\xb8\x69\x7c\x54\x0d\x5a\x0b\x06\xff\xf6\xd2\x50\x00\xa7\x11\x44\x47\x02\x73\x71\x22\xd9\x8d\x18\x40\x1b\x10\x95\x41\xaa\x39\xed\x0d\x0c\x46\x3a\xa9\xaa\x39\x58\x5d\xba\x41\x18\x9c\xa8\x0a\x69\x9d\x91\xa7\xde\xbd\xcb\x71\x92\x4b\x49\xdc\x02\x28\x49\xa1\x60\x16\x71\x60\x7c\x06\xeb\x88\x33\x3e\x9f\x88\x9e\x58\xf6\x33\x7d\xcc\xe0\x29\x3a\x1c\xa2\x24\x63\x31\x87\xf4\x00\x9b\x34\xd9\xb2\x8c\xa5\x09\x9d\xee\x21\x4a\x9e\xe1\x17\x4b\xb6\x73\x40\x4a\x91\x66\xe1\x6b\x67\xbc\x13\x92\x2b\x7d\xc2\x58\xbc\xc5\xc9\x11\xdf\x49\x29\xf5\x45\x9a\xed\x30\x97\xa5\xcc\xc9\xa6\xaa\x7a\x51\x21\x54\xfa\x05\x8d\x22\x77\xd0\xa1\x69\xa5\xf5\x2f\x6e\x49\x68\x31\x51\x35\xb2\x95\x4e\xb8\xf3\xf5\x7f\x1e\xfd\xc0\x30\x08\x82\x75\xfc\x83\x25\xab\x20\xd8\x1e\xd2\x07\xc8\xa2\xf5\x2e\x06\x76\x0f\xf1\x1f\xc6\x33\x0e\xba\x10\x75\x7f\x6c\x75\x81\xcd\xd1\x60\xe5\x23\x19\x8f\x79\x6f\xac\x36\xab\x8f\x3b\x5e\x2e\x7f\xe1\xc7\x48\x1a\xbf\x49\xf7\x7b\x96\xad\x82\x7f\x5d\x07\x47\xb7\x10\x03\x00\x00")
+
+func _000018_model_registryDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000018_model_registryDownSql,
+		"000018_model_registry.down.sql",
+	)
+}
+
+func _000018_model_registryDownSql() (*asset, error) {
+	bytes, err := _000018_model_registryDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000018_model_registry.down.sql", size: 784, mode: os.FileMode(0664), modTime: time.Unix(1792196548, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbb, 0xec, 0x56, 0x90, 0x63, 0x25, 0x28, 0x82, 0xa6, 0xfb, 0xf7, 0xc8, 0x27, 0x25, 0x17, 0x27, 0x87, 0xce, 0xbd, 0xe3, 0x25, 0x99, 0xbe, 0xed, 0x6e, 0x5e, 0x3f, 0xcd, 0x9d, 0x6e, 0xd6, 0xb7}}
+	return a, nil
+}
+
+var __000019_deployment_revisionDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x51\xc1\x8e\x9b\x30\x10\xbd\xf3\x15\xa3\x9c\xda\x2a\x0d\xdb\x1c\x9b\x13\x49\xd8\xd6\x6a\x02\xab\x98\xed\x76\x4f\x91\x03\x03\x58\x02\xdb\xb5\xcd\xb2\xfc\x7d\x87\x6c\x58\xb1\xaa\x65\xc9\xb2\xe7\xcd\x9b\xf7\x9e\xc3\x2f\x01\x8c\x1b\xc6\xb5\xd3\x66\xb0\xb2\xaa\x3d\xac\xef\xd6\xdf\x20\x7e\x88\x8e\xc0\x07\xe7\xb1\x75\x33\xd4\x41\xe6\xa8\x1c\x16\xd0\xa9\x02\x2d\xf8\x1a\x21\x32\x22\xa7\xe3\x56\x59\xc2\x6f\xb4\x4e\x6a\x05\xeb\xd5\x1d\x7c\x1a\x01\x8b\x5b\x69\xf1\x79\x33\xd1\x0c\xba\x83\x56\x0c\xa0\xb4\x87\xce\x21\xf1\x48\x07\xa5\x6c\x10\xf0\x35\x47\xe3\x41\x2a\xc8\x75\x6b\x1a\x29\x54\x8e\xd0\x4b\x5f\x5f\x67\xdd\x98\x56\x13\xcf\xf3\x8d\x47\x5f\xbc\xa0\x16\x41\x4d\x86\x6e\xe5\x1c\x0c\xc2\xcf\x0c\x8c\xab\xf6\xde\x7c\x0f\xc3\xbe\xef\x57\xe2\x2a\x7e\xa5\x6d\x15\x36\x6f\x70\x17\x1e\xd8\x2e\x4e\x78\xfc\x95\x0c\xcc\x1a\x1f\x55\x83\xce\x81\xc5\xbf\x9d\xb4\x14\xc0\x65\x00\x61\x48\x60\x2e\x2e\x24\xbb\x11\x3d\x68\x0b\xa2\xb2\x48\x35\xaf\x47\x03\xbd\x95\x5e\xaa\x6a\x09\x4e\x97\xbe\x17\x16\x27\xaa\x42\x3a\x6f\xe5\xa5\xf3\x1f\x72\x9c\xe4\x52\x12\x73\x00\x25\x29\x14\x2c\x22\x0e\x8c\x2f\x60\x1b\x71\xc6\x97\x13\xd1\x13\xcb\x7e\xa6\x8f\x19\x3c\x45\xa7\x53\x94\x64\x2c\xe6\x90\x9e\x60\x97\x26\x7b\x96\xb1\x34\xa1\xdb\x3d\x44\xc9\x33\xfc\x62\xc9\x7e\x09\x48\x29\xd2\x2c\x7c\x35\x76\x74\x42\x72\xe5\x98\x30\x16\xef\x71\x72\xc4\x0f\x52\x4a\xfd\x26\xcd\x19\xcc\x65\x29\x73\xb2\xa9\xaa\x4e\x54\x08\x95\x7e\x41\xab\xc8\x1d\x18\xb4\xad\x74\xe3\x8f\x3b\x12\x5a\x4c\x54\x8d\x6c\xa5\x17\xfe\xfa\xfc\x9f\xc7\x71\x60\x18\x04\xc1\x36\xfe\xc1\x92\x4d\x10\xec\x4f\xe9\x03\x64\xd1\xf6\x10\x03\xbb\x87\xf8\x0f\xe3\x19\x07\x5d\x88\xba\x3b\x6b\xe2\x17\x5e\xdb\x73\x81\xa6\xd1\x43\x8b\xca\x9f\x2d\xbe\xc8\x71\x20\x75\xee\xd2\xe3\x91\x65\x9b\xe0\x1f\xa9\x11\xfd\xea\xcb\x02\x00\x00")
+
+func _000019_deployment_revisionDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000019_deployment_revisionDownSql,
+		"000019_deployment_revision.down.sql",
+	)
+}
+
+func _000019_deployment_revisionDownSql() (*asset, error) {
+	bytes, err := _000019_deployment_revisionDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000019_deployment_revision.down.sql", size: 715, mode: os.FileMode(0664), modTime: time.Unix(1792198388, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb0, 0x94, 0x33, 0x2a, 0x4c, 0xdf, 0x8b, 0x3f, 0x8e, 0xc0, 0x64, 0xf6, 0xf8, 0x7c, 0x6f, 0xad, 0xbc, 0xd5, 0x1c, 0x65, 0x7d, 0xfa, 0x39, 0x28, 0x6a, 0xd0, 0x59, 0xaf, 0x5d, 0x72, 0xa, 0xab}}
+	return a, nil
+}
+
+var __000019_deployment_revisionUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x53\x51\x6f\xda\x30\x10\x7e\xcf\xaf\x38\xf1\x04\x53\x0a\x6d\x35\xed\x61\x7d\x32\xc1\x14\x6f\x90\xa0\x24\xb4\xeb\x5e\x2a\x93\x18\xb0\x06\x71\x66\x3b\xa5\xfc\xfb\x9d\x13\x42\x61\xd3\xaa\x5a\x48\x24\xe7\xef\xbe\xfb\xee\xbb\xcb\xe0\x93\x07\xee\x07\xee\x04\xaa\x3c\x68\xb9\xde\x58\xb8\xbd\xbe\xbd\x01\x3a\x27\x33\x48\x0e\xc6\x8a\x9d\x39\x43\x4d\x65\x26\x0a\x23\x72\xa8\x8a\x5c\x68\xb0\x1b\x01\xa4\xe4\x19\xfe\x1d\x6f\x7c\x78\x10\xda\x48\x55\xc0\x6d\xff\x1a\xba\x0e\xd0\x39\x5e\x75\x7a\x77\x2d\xcd\x41\x55\xb0\xe3\x07\x28\x94\x85\xca\x08\xe4\x91\x06\x56\x72\x2b\x40\xbc\x66\xa2\xb4\x20\x0b\xc8\xd4\xae\xdc\x4a\x5e\x64\x02\xf6\xd2\x6e\xea\x5a\x47\xa6\x7e\xcb\xf3\x74\xe4\x51\x4b\xcb\x31\x85\x63\x52\x89\x6f\xab\x73\x30\x70\x7b\xd6\x80\x3b\x1b\x6b\xcb\xaf\x83\xc1\x7e\xbf\xef\xf3\x5a\x7c\x5f\xe9\xf5\x60\xdb\xc0\xcd\x60\xca\x02\x1a\x26\xf4\x0a\x1b\x38\x4b\x5c\x14\x5b\x61\x0c\x68\xf1\xbb\x92\x1a\x0d\x58\x1e\x80\x97\x28\x30\xe3\x4b\x94\xbd\xe5\x7b\x50\x1a\xf8\x5a\x0b\xbc\xb3\xca\x35\xb0\xd7\xd2\xca\x62\xed\x83\x51\x2b\xbb\xe7\x5a\xb4\x54\xb9\x34\x56\xcb\x65\x65\x2f\x7c\x6c\xe5\xa2\x13\xe7\x00\x74\x92\x17\xd0\x21\x09\xb0\xa4\x03\x43\x92\xb0\xc4\x6f\x89\x1e\x59\x3a\x89\x16\x29\x3c\x92\x38\x26\x61\xca\x68\x02\x51\x0c\x41\x14\x8e\x58\xca\xa2\x10\xdf\xc6\x40\xc2\x27\xf8\xce\xc2\x91\x0f\x02\x5d\xc4\x5a\xe2\xb5\xd4\xae\x13\x94\x2b\x9d\xc3\x22\x3f\xd9\x99\x08\x71\x21\x65\xa5\x1a\x69\xa6\x14\x99\x5c\xc9\x0c\xdb\x2c\xd6\x15\x5f\x0b\x58\xab\x17\xa1\x0b\xec\x0e\x4a\xa1\x77\xd2\xb8\x89\x1b\x14\x9a\xb7\x54\x5b\xb9\x93\x96\xdb\x3a\xfc\x4f\x8f\xae\xe0\xc0\xf3\xbc\x21\xbd\x67\xe1\x9d\xe7\x05\x31\x25\x29\x85\x94\x0c\xa7\x14\xd8\x18\xc2\x28\x05\xfa\x83\x25\x69\x02\x2a\xe7\x9b\xea\x59\x61\x15\x6e\x95\x7e\xce\x45\xb9\x55\x87\x9d\x28\xec\xb3\x16\x2f\xd2\x95\xf5\xba\x5e\x6d\xea\xdb\x8d\xcc\xe1\x81\xc4\xc1\x84\xc4\xdd\x2f\x9f\x7b\x35\x5b\xb8\x98\x4e\xbd\x76\xfe\x68\x50\x92\xc6\x84\x85\xe9\x07\xe8\xcf\x63\xab\x5f\x27\x0e\x77\x62\x3a\xa6\x31\x0d\x03\xfa\x8e\xcc\x8b\x84\x28\x84\xc5\x7c\xe4\x5a\x8d\x29\x2a\x60\x41\xea\x42\x23\x3a\xa5\x18\x0a\x48\x12\x90\x11\xf5\xeb\x8c\xb6\x7a\x93\x87\x4a\xe9\x3d\x8d\xeb\xe7\xb6\x9b\x06\x97\x69\xc1\xdd\x96\x34\x27\x65\x33\xe4\x25\xb3\x79\xfa\xf3\x2f\x9c\x9b\xe0\x9b\x8e\x6f\x49\x14\x0e\x8f\xcf\x97\xb8\x79\xcc\x66\x24\xc6\x8d\xa1\x4f\xd0\xbd\xb0\xd4\x3f\x69\xea\x79\xf8\x21\x7b\x57\x57\x40\x5f\x71\x4f\xdd\x0e\xbc\x01\x0d\xac\x85\x75\xa3\x96\x1a\xb2\x4a\x6b\x0c\x35\xb5\xb9\xa9\x17\x60\x25\xb5\xb1\x27\x26\x8f\xe1\x77\x16\xa7\xae\xbf\xe8\x03\xa3\xf8\xaf\x22\xbf\xf5\xc1\xaf\x8b\xf5\xbc\x04\x2d\x45\x73\x1d\xe6\xc6\xc7\x79\x93\x29\x4d\x02\xda\xad\xca\xbc\x41\x15\x6a\xdf\xed\xf5\x1a\xb4\x37\x8e\xa3\xd9\x3b\x03\x7c\x9c\xe0\x90\x9b\x26\x58\xf2\xb6\x4c\x38\x38\xdc\xa3\xf1\xd4\x0d\x71\x14\xb9\xf8\x84\x85\xf7\x6e\x99\xa3\xd9\x8c\xa5\x77\xde\x1f\x88\x24\xd2\x24\x5e\x05\x00\x00")
+
+func _000019_deployment_revisionUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000019_deployment_revisionUpSql,
+		"000019_deployment_revision.up.sql",
+	)
+}
+
+func _000019_deployment_revisionUpSql() (*asset, error) {
+	bytes, err := _000019_deployment_revisionUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000019_deployment_revision.up.sql", size: 1374, mode: os.FileMode(0664), modTime: time.Unix(1792198388, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x89, 0x8e, 0x25, 0x16, 0x0, 0xda, 0xb9, 0x8a, 0x7d, 0x14, 0xda, 0x59, 0x4f, 0xc6, 0x92, 0xe9, 0x83, 0x21, 0x7d, 0x2b, 0xb5, 0xd9, 0x6b, 0xe, 0x1b, 0x82, 0xce, 0x4c, 0xaa, 0x4d, 0x8d, 0x1c}}
+	return a, nil
+}
+
+var __000020_predictorDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x51\xc1\x8e\x9b\x30\x10\xbd\xf3\x15\xa3\x9c\xda\x2a\x0d\xdb\x1c\x9b\x13\x49\xd8\xd6\x6a\x02\xab\x98\xed\x76\x4f\x2b\x07\x06\x18\x09\x6c\x6a\x9b\xb2\xfc\x7d\x87\x6c\xa8\xb2\xaa\x65\x09\xdb\xf3\xe6\xcd\x7b\x8f\xf0\x53\x00\xd3\x86\x69\xed\x4c\x37\x5a\xaa\x6a\x0f\xeb\xbb\xf5\x17\x88\x1f\xa2\x23\xc8\xd1\x79\x6c\xdd\x0d\xea\x40\x39\x6a\x87\x05\xf4\xba\x40\x0b\xbe\x46\x88\x3a\x95\xf3\xe7\x5a\x59\xc2\x4f\xb4\x8e\x8c\x86\xf5\xea\x0e\x3e\x4c\x80\xc5\xb5\xb4\xf8\xb8\x99\x69\x46\xd3\x43\xab\x46\xd0\xc6\x43\xef\x90\x79\xc8\x41\x49\x0d\x02\xbe\xe6\xd8\x79\x20\x0d\xb9\x69\xbb\x86\x94\xce\x11\x06\xf2\xf5\x65\xd6\x95\x69\x35\xf3\x3c\x5f\x79\xcc\xd9\x2b\x6e\x51\xdc\xd4\xf1\xad\xbc\x05\x83\xf2\x37\x06\xa6\x55\x7b\xdf\x7d\x0d\xc3\x61\x18\x56\xea\x22\x7e\x65\x6c\x15\x36\x6f\x70\x17\x1e\xc4\x2e\x4e\x64\xfc\x99\x0d\xdc\x34\x3e\xea\x06\x9d\x03\x8b\xbf\x7b\xb2\x1c\xc0\x79\x04\xd5\xb1\xc0\x5c\x9d\x59\x76\xa3\x06\x30\x16\x54\x65\x91\x6b\xde\x4c\x06\x06\x4b\x9e\x74\xb5\x04\x67\x4a\x3f\x28\x8b\x33\x55\x41\xce\x5b\x3a\xf7\xfe\x5d\x8e\xb3\x5c\x4e\xe2\x16\xc0\x49\x2a\x0d\x8b\x48\x82\x90\x0b\xd8\x46\x52\xc8\xe5\x4c\xf4\x24\xb2\xef\xe9\x63\x06\x4f\xd1\xe9\x14\x25\x99\x88\x25\xa4\x27\xd8\xa5\xc9\x5e\x64\x22\x4d\xf8\x76\x0f\x51\xf2\x0c\x3f\x44\xb2\x5f\x02\x72\x8a\x3c\x0b\x5f\x3b\x3b\x39\x61\xb9\x34\x25\x8c\xc5\xbf\x38\x25\xe2\x3b\x29\xa5\x79\x93\xe6\x3a\xcc\xa9\xa4\x9c\x6d\xea\xaa\x57\x15\x42\x65\xfe\xa0\xd5\xec\x0e\x3a\xb4\x2d\xb9\xe9\x8f\x3b\x16\x5a\xcc\x54\x0d\xb5\xe4\x95\xbf\x3c\xff\xe7\x71\x1a\x18\x06\xc1\x36\xfe\x26\x92\x4d\x10\xec\x4f\xe9\x03\x64\xd1\xf6\x10\x83\xb8\x87\xf8\x97\x90\x99\x04\x53\xa8\xba\x7f\x31\x4c\xaf\xbc\xb1\x2f\xac\xb9\xa0\x9c\x4f\x8c\xdf\xa5\xc7\xa3\xc8\x36\xc1\x5f\xfa\x20\x0b\xec\xc0\x02\x00\x00")
+
+func _000020_predictorDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000020_predictorDownSql,
+		"000020_predictor.down.sql",
+	)
+}
+
+func _000020_predictorDownSql() (*asset, error) {
+	bytes, err := _000020_predictorDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000020_predictor.down.sql", size: 704, mode: os.FileMode(0664), modTime: time.Unix(1792200180, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe2, 0x1f, 0xa1, 0x25, 0xbb, 0x13, 0x7, 0xfb, 0x8e, 0x17, 0x6e, 0x1, 0x3a, 0x7a, 0xa4, 0x9f, 0x62, 0x91, 0x7b, 0x6e, 0xe8, 0x3e, 0xaf, 0xb6, 0xb3, 0x67, 0x8a, 0x39, 0x59, 0xbf, 0x42, 0xc6}}
+	return a, nil
+}
+
+var __000020_predictorUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x52\xc1\x8e\x9b\x30\x14\xbc\xf3\x15\x4f\x39\x25\x55\x1a\xb6\x51\xd5\x43\x73\x72\xb2\x6c\xd7\x6d\x80\x08\x3b\xbb\x4d\x2f\x2b\x07\x5e\x88\x25\x82\xa9\x6d\xca\xe6\xef\xd7\x4e\xa0\xca\xaa\x87\x5a\x48\xc6\xbc\x79\xf3\x66\xc6\x84\x1f\x02\xf0\x0f\xf8\xb5\x52\xcd\x59\xcb\xf2\x68\x61\x7e\x37\xff\x04\xd1\x86\xc4\xc0\xce\xc6\xe2\xc9\xdc\xa0\xd6\x32\xc7\xda\x60\x01\x6d\x5d\xa0\x06\x7b\x44\x20\x8d\xc8\xdd\xd6\x57\xa6\xf0\x84\xda\x48\x55\xc3\x7c\x76\x07\x63\x0f\x18\xf5\xa5\xd1\x64\x31\xd0\x9c\x55\x0b\x27\x71\x86\x5a\x59\x68\x0d\x3a\x1e\x69\xe0\x20\x2b\x04\x7c\xcd\xb1\xb1\x20\x6b\xc8\xd5\xa9\xa9\xa4\xa8\x73\x84\x4e\xda\xe3\x65\x56\xcf\x34\x1b\x78\x76\x3d\x8f\xda\x5b\xe1\x5a\x84\x6b\x6a\xdc\xe9\x70\x0b\x06\x61\x6f\x0c\xf8\x75\xb4\xb6\xf9\x1a\x86\x5d\xd7\xcd\xc4\x45\xfc\x4c\xe9\x32\xac\xae\x70\x13\xae\xe9\x2a\x4a\x58\xf4\xd1\x19\xb8\x69\xdc\xd6\x15\x1a\x03\x1a\x7f\xb7\x52\xbb\x00\xf6\x67\x10\x8d\x13\x98\x8b\xbd\x93\x5d\x89\x0e\x94\x06\x51\x6a\x74\x35\xab\xbc\x81\x4e\x4b\x2b\xeb\x72\x0a\x46\x1d\x6c\x27\x34\x0e\x54\x85\x34\x56\xcb\x7d\x6b\xdf\xe5\x38\xc8\x75\x49\xdc\x02\x5c\x92\xa2\x86\x11\x61\x40\xd9\x08\x96\x84\x51\x36\x1d\x88\x9e\x29\x7f\x4c\xb7\x1c\x9e\x49\x96\x91\x84\xd3\x88\x41\x9a\xc1\x2a\x4d\xee\x29\xa7\x69\xe2\x4e\x0f\x40\x92\x1d\xfc\xa0\xc9\xfd\x14\xd0\xa5\xe8\x66\xe1\x6b\xa3\xbd\x13\x27\x57\xfa\x84\xb1\xf8\x1b\x27\x43\x7c\x27\xe5\xa0\xae\xd2\x4c\x83\xb9\x3c\xc8\xdc\xd9\xac\xcb\x56\x94\x08\xa5\xfa\x83\xba\x76\xee\xa0\x41\x7d\x92\xc6\xdf\xb8\x71\x42\x8b\x81\xaa\x92\x27\x69\x85\xbd\x7c\xfe\xc7\xa3\x1f\x18\x06\xc1\x32\xfa\x46\x93\x45\x10\xac\xb2\x88\xf0\x08\x38\x59\xae\x23\xa0\x0f\x90\xa4\x1c\xa2\x9f\x94\x71\x06\xaa\x10\xc7\xf6\x45\xb9\x21\xc2\x2a\xfd\xe2\x94\x17\x32\x77\x6f\xc1\x38\xf0\x53\x64\x71\xbd\xd1\x27\x92\xad\x1e\x49\x36\xfe\xf2\x79\x02\x9b\x8c\xc6\x24\x73\xa6\xa3\xdd\xf4\x02\xca\x35\x0a\x9f\x24\xa7\x71\xc4\x38\x89\x37\xfc\xd7\x65\x44\xb2\x5d\xaf\xaf\x88\xb6\x29\xfe\x83\xf0\x09\xf8\xfd\x3b\x4b\x93\x65\xff\x1b\x0d\x88\x60\xe2\x3d\xa4\x71\x4c\xf9\x22\x78\x03\x34\x94\x68\xd0\x54\x03\x00\x00")
+
+func _000020_predictorUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000020_predictorUpSql,
+		"000020_predictor.up.sql",
+	)
+}
+
+func _000020_predictorUpSql() (*asset, error) {
+	bytes, err := _000020_predictorUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000020_predictor.up.sql", size: 852, mode: os.FileMode(0664), modTime: time.Unix(1792200180, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x55, 0x96, 0x60, 0x27, 0x6a, 0x68, 0xff, 0xf9, 0xbc, 0xb3, 0xe5, 0xd, 0x9b, 0x4c, 0x3e, 0x65, 0x8a, 0xb, 0x8f, 0x60, 0x8c, 0xf4, 0xe6, 0x7f, 0x27, 0x13, 0xf4, 0x9b, 0xd0, 0x26, 0xfb, 0x8a}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -555,6 +1017,28 @@ var _bindata = map[string]func() (*asset, error){
 	"000008_outbox.up.sql":                              _000008_outboxUpSql,
 	"000009_batch.down.sql":                             _000009_batchDownSql,
 	"000009_batch.up.sql":                               _000009_batchUpSql,
+	"000010_audit.down.sql":                             _000010_auditDownSql,
+	"000010_audit.up.sql":                               _000010_auditUpSql,
+	"000011_outbox_history.down.sql":                    _000011_outbox_historyDownSql,
+	"000011_outbox_history.up.sql":                      _000011_outbox_historyUpSql,
+	"000012_subscription.up.sql":                        _000012_subscriptionUpSql,
+	"000012_subscription.down.sql":                      _000012_subscriptionDownSql,
+	"000013_outbox_notify.up.sql":                       _000013_outbox_notifyUpSql,
+	"000013_outbox_notify.down.sql":                     _000013_outbox_notifyDownSql,
+	"000014_training_schedule.up.sql":                   _000014_training_scheduleUpSql,
+	"000014_training_schedule.down.sql":                 _000014_training_scheduleDownSql,
+	"000015_pipeline.up.sql":                            _000015_pipelineUpSql,
+	"000015_pipeline.down.sql":                          _000015_pipelineDownSql,
+	"000016_training_sweep.up.sql":                      _000016_training_sweepUpSql,
+	"000016_training_sweep.down.sql":                    _000016_training_sweepDownSql,
+	"000017_training_metrics.up.sql":                    _000017_training_metricsUpSql,
+	"000017_training_metrics.down.sql":                  _000017_training_metricsDownSql,
+	"000018_model_registry.up.sql":                      _000018_model_registryUpSql,
+	"000018_model_registry.down.sql":                    _000018_model_registryDownSql,
+	"000019_deployment_revision.down.sql":               _000019_deployment_revisionDownSql,
+	"000019_deployment_revision.up.sql":                 _000019_deployment_revisionUpSql,
+	"000020_predictor.down.sql":                         _000020_predictorDownSql,
+	"000020_predictor.up.sql":                           _000020_predictorUpSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -619,6 +1103,28 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"000008_outbox.up.sql":                              {_000008_outboxUpSql, map[string]*bintree{}},
 	"000009_batch.down.sql":                             {_000009_batchDownSql, map[string]*bintree{}},
 	"000009_batch.up.sql":                               {_000009_batchUpSql, map[string]*bintree{}},
+	"000010_audit.down.sql":                             {_000010_auditDownSql, map[string]*bintree{}},
+	"000010_audit.up.sql":                               {_000010_auditUpSql, map[string]*bintree{}},
+	"000011_outbox_history.down.sql":                    {_000011_outbox_historyDownSql, map[string]*bintree{}},
+	"000011_outbox_history.up.sql":                      {_000011_outbox_historyUpSql, map[string]*bintree{}},
+	"000012_subscription.up.sql":                        {_000012_subscriptionUpSql, map[string]*bintree{}},
+	"000012_subscription.down.sql":                      {_000012_subscriptionDownSql, map[string]*bintree{}},
+	"000013_outbox_notify.up.sql":                       {_000013_outbox_notifyUpSql, map[string]*bintree{}},
+	"000013_outbox_notify.down.sql":                     {_000013_outbox_notifyDownSql, map[string]*bintree{}},
+	"000014_training_schedule.up.sql":                   {_000014_training_scheduleUpSql, map[string]*bintree{}},
+	"000014_training_schedule.down.sql":                 {_000014_training_scheduleDownSql, map[string]*bintree{}},
+	"000015_pipeline.up.sql":                            {_000015_pipelineUpSql, map[string]*bintree{}},
+	"000015_pipeline.down.sql":                          {_000015_pipelineDownSql, map[string]*bintree{}},
+	"000016_training_sweep.up.sql":                      {_000016_training_sweepUpSql, map[string]*bintree{}},
+	"000016_training_sweep.down.sql":                    {_000016_training_sweepDownSql, map[string]*bintree{}},
+	"000017_training_metrics.up.sql":                    {_000017_training_metricsUpSql, map[string]*bintree{}},
+	"000017_training_metrics.down.sql":                  {_000017_training_metricsDownSql, map[string]*bintree{}},
+	"000018_model_registry.up.sql":                      {_000018_model_registryUpSql, map[string]*bintree{}},
+	"000018_model_registry.down.sql":                    {_000018_model_registryDownSql, map[string]*bintree{}},
+	"000019_deployment_revision.down.sql":               {_000019_deployment_revisionDownSql, map[string]*bintree{}},
+	"000019_deployment_revision.up.sql":                 {_000019_deployment_revisionUpSql, map[string]*bintree{}},
+	"000020_predictor.down.sql":                         {_000020_predictorDownSql, map[string]*bintree{}},
+	"000020_predictor.up.sql":                           {_000020_predictorUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
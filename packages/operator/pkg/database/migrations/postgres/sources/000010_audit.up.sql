@@ -0,0 +1,37 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS odahu_audit
+(
+    id          BIGSERIAL PRIMARY KEY,
+    entity_kind VARCHAR(64)  NOT NULL,
+    entity_id   VARCHAR(64)  NOT NULL,
+    operation   VARCHAR(16)  NOT NULL,
+    username    VARCHAR(256) NOT NULL,
+    email       VARCHAR(256) NOT NULL,
+    datetime    TIMESTAMPTZ  NOT NULL,
+    old_spec    JSONB,
+    new_spec    JSONB,
+    diff        JSONB
+);
+
+CREATE INDEX IF NOT EXISTS odahu_audit_entity_idx ON odahu_audit (entity_kind, entity_id);
+CREATE INDEX IF NOT EXISTS odahu_audit_datetime_idx ON odahu_audit (datetime);
+
+COMMIT;
@@ -0,0 +1,33 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+BEGIN;
+
+DROP TABLE IF EXISTS odahu_outbox_compaction;
+
+-- Previous schema version stores only the last event of each entity
+DELETE FROM odahu_outbox AS o
+WHERE EXISTS(
+    SELECT 1 FROM odahu_outbox AS n
+    WHERE n.event_group = o.event_group AND n.entity_id = o.entity_id AND n.id > o.id
+);
+
+DROP INDEX IF EXISTS odahu_outbox_datetime_idx;
+DROP INDEX IF EXISTS odahu_outbox_group_entity_idx;
+ALTER TABLE odahu_outbox DROP CONSTRAINT IF EXISTS odahu_outbox_pkey;
+
+COMMIT;
@@ -0,0 +1,32 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+BEGIN;
+
+ALTER TABLE odahu_outbox ADD PRIMARY KEY (id);
+
+CREATE INDEX IF NOT EXISTS odahu_outbox_group_entity_idx ON odahu_outbox (event_group, entity_id, id);
+CREATE INDEX IF NOT EXISTS odahu_outbox_datetime_idx ON odahu_outbox (datetime);
+
+-- Events of a group with ID less than or equal to compacted_until can be removed by compaction
+CREATE TABLE IF NOT EXISTS odahu_outbox_compaction
+(
+    event_group     VARCHAR(128) PRIMARY KEY,
+    compacted_until BIGINT       NOT NULL
+);
+
+COMMIT;
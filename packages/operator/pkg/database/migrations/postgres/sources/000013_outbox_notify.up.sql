@@ -0,0 +1,37 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+BEGIN;
+
+-- Every inserted event is announced on odahu_outbox channel with the event group as a payload.
+-- PostgreSQL delivers notifications only after commit and folds duplicates of a transaction
+CREATE OR REPLACE FUNCTION odahu_outbox_notify() RETURNS TRIGGER AS
+$$
+BEGIN
+    PERFORM pg_notify('odahu_outbox', NEW.event_group);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS odahu_outbox_notify_trigger ON odahu_outbox;
+CREATE TRIGGER odahu_outbox_notify_trigger
+    AFTER INSERT
+    ON odahu_outbox
+    FOR EACH ROW
+EXECUTE PROCEDURE odahu_outbox_notify();
+
+COMMIT;
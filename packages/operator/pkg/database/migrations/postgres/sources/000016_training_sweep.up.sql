@@ -0,0 +1,32 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS odahu_operator_training_sweep
+(
+    id      VARCHAR(64) PRIMARY KEY,
+    created TIMESTAMPTZ NOT NULL,
+    updated TIMESTAMPTZ NOT NULL,
+    spec    JSONB       NOT NULL,
+    status  JSONB       NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS odahu_operator_training_sweep_state_idx ON odahu_operator_training_sweep ((status ->> 'state'));
+
+COMMIT;
@@ -0,0 +1,45 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS odahu_operator_training_metric
+(
+    training_id VARCHAR(64)      NOT NULL
+        CONSTRAINT odahu_training_metric_training_fk
+            REFERENCES odahu_operator_training
+            ON UPDATE RESTRICT ON DELETE CASCADE,
+    key         VARCHAR(250)     NOT NULL,
+    step        BIGINT           NOT NULL,
+    value       DOUBLE PRECISION NOT NULL,
+    timestamp   TIMESTAMPTZ      NOT NULL,
+    PRIMARY KEY (training_id, key, step)
+);
+
+CREATE TABLE IF NOT EXISTS odahu_operator_training_param
+(
+    training_id VARCHAR(64)  NOT NULL
+        CONSTRAINT odahu_training_param_training_fk
+            REFERENCES odahu_operator_training
+            ON UPDATE RESTRICT ON DELETE CASCADE,
+    key         VARCHAR(250) NOT NULL,
+    value       TEXT         NOT NULL,
+    PRIMARY KEY (training_id, key)
+);
+
+COMMIT;
@@ -0,0 +1,55 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS odahu_model
+(
+    name           VARCHAR(256) PRIMARY KEY,
+    created        TIMESTAMPTZ  NOT NULL,
+    updated        TIMESTAMPTZ  NOT NULL,
+    latest_version VARCHAR(256) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS odahu_model_version
+(
+    model         VARCHAR(256) NOT NULL
+        CONSTRAINT odahu_model_version_model_fk
+            REFERENCES odahu_model
+            ON UPDATE RESTRICT ON DELETE CASCADE,
+    version       VARCHAR(256) NOT NULL,
+    stage         VARCHAR(16)  NOT NULL,
+    stage_updated TIMESTAMPTZ,
+    created       TIMESTAMPTZ  NOT NULL,
+    updated       TIMESTAMPTZ  NOT NULL,
+    artifact_name TEXT         NOT NULL DEFAULT '',
+    lineage       JSONB        NOT NULL,
+    PRIMARY KEY (model, version)
+);
+
+CREATE INDEX IF NOT EXISTS odahu_model_version_artifact_idx ON odahu_model_version (artifact_name);
+CREATE INDEX IF NOT EXISTS odahu_model_version_images_idx ON odahu_model_version USING GIN ((lineage -> 'images'));
+
+-- Position of the registry in the outbox event log
+CREATE TABLE IF NOT EXISTS odahu_model_registry_cursor
+(
+    id     SMALLINT PRIMARY KEY CHECK (id = 1),
+    cursor BIGINT NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,40 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS odahu_operator_deployment_revision
+(
+    deployment_id VARCHAR(64) NOT NULL
+        CONSTRAINT odahu_operator_deployment_revision_deployment_fk
+            REFERENCES odahu_operator_deployment
+            ON UPDATE RESTRICT ON DELETE CASCADE,
+    revision      INTEGER     NOT NULL,
+    created       TIMESTAMPTZ NOT NULL,
+    spec          JSONB       NOT NULL,
+    PRIMARY KEY (deployment_id, revision)
+);
+
+-- Existing deployments get their current spec as the first revision
+INSERT INTO odahu_operator_deployment_revision (deployment_id, revision, created, spec)
+SELECT id, 1, COALESCE(updated, now()), spec
+FROM odahu_operator_deployment
+WHERE spec IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+COMMIT;
@@ -5,6 +5,7 @@ import (
 	"github.com/odahu/odahu-flow/packages/operator/pkg/deployment/bindata" //nolint
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"odahu-commons/predictors"
 	"path"
 	"text/template"
 )
@@ -26,26 +27,58 @@ func ReadDefaultPoliciesAndRender(roleName string, predictorsPolicyFilename stri
 			return nil, err
 		}
 
-		tpl, err := template.New("_").Parse(string(bts))
+		policy, err := renderPolicy(string(bts), roleName)
 		if err != nil {
 			return nil, err
 		}
 
-		b := bytes.NewBuffer([]byte{})
-		err = tpl.Execute(b, struct {
-			Role string
-		}{
-			Role: roleName,
-		})
-		if err != nil {
-			return nil, err
-		}
+		policies[file] = policy
+	}
+	return policies, nil
+}
+
+// RenderPredictorPolicies renders default policies together with the policy of the predictor.
+// Policies of built-in predictors are read from assets, policies of custom ones are taken from their spec
+func RenderPredictorPolicies(roleName string, predictor predictors.Predictor) (map[string]string, error) {
+	policies, err := ReadDefaultPoliciesAndRender(roleName, predictor.OpaPolicyFilename)
+	if err != nil || len(predictor.OpaPolicyFilename) > 0 {
+		return policies, err
+	}
 
-		policies[file] = b.String()
+	policy, err := renderPolicy(predictor.OpaPolicy, roleName)
+	if err != nil {
+		return nil, err
 	}
+	policies[PredictorPolicyFilename(predictor.ID)] = policy
+
 	return policies, nil
 }
 
+// PredictorPolicyFilename returns name of the config map key of a custom predictor policy.
+// The prefix prevents collisions with names of default policies
+func PredictorPolicyFilename(predictorID string) string {
+	return "predictor-" + predictorID + ".rego"
+}
+
+func renderPolicy(policy string, roleName string) (string, error) {
+	tpl, err := template.New("_").Parse(policy)
+	if err != nil {
+		return "", err
+	}
+
+	b := bytes.NewBuffer([]byte{})
+	err = tpl.Execute(b, struct {
+		Role string
+	}{
+		Role: roleName,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
 // Builds default polices for model injected into configmap
 func BuildDefaultPolicyConfigMap(cmName string, cmNs string, policies map[string]string) *v1.ConfigMap {
 	return &v1.ConfigMap{
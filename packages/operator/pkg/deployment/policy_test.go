@@ -21,3 +21,26 @@ func TestReadDefaultPoliciesAndRender(t *testing.T) {
 
 	assert.Contains(t, data["odahu_ml_server.rego"], roleName)
 }
+
+func TestRenderPredictorPolicies(t *testing.T) {
+	predictor := predictors.Predictor{
+		ID:        "torchserve",
+		OpaPolicy: "package odahu.core\n\nallow {\n  input.role == \"{{.Role}}\"\n}\n",
+	}
+
+	data, err := RenderPredictorPolicies(roleName, predictor)
+	assert.NoError(t, err)
+
+	assert.Len(t, data, 3)
+	assert.Contains(t, data, "mapper.rego")
+	assert.Contains(t, data, "roles.rego")
+	assert.Contains(t, data[PredictorPolicyFilename(predictor.ID)], roleName)
+}
+
+func TestRenderBuiltinPredictorPolicies(t *testing.T) {
+	data, err := RenderPredictorPolicies(roleName, predictors.Triton)
+	assert.NoError(t, err)
+
+	assert.Len(t, data, 3)
+	assert.Contains(t, data["triton.rego"], roleName)
+}
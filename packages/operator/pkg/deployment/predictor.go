@@ -0,0 +1,46 @@
+/*
+ * Copyright 2021 EPAM Systems
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"odahu-commons/predictors"
+)
+
+// PredictorClient returns predictors from the API server
+type PredictorClient interface {
+	GetPredictor(id string) (*deployment.Predictor, error)
+}
+
+// PredictorResolver resolves predictors by ID. Built-in predictors are resolved without requests to the API server
+type PredictorResolver struct {
+	Client PredictorClient
+}
+
+func (r PredictorResolver) GetPredictor(id string) (predictors.Predictor, error) {
+	if p, ok := predictors.Predictors[id]; ok {
+		return p, nil
+	}
+
+	p, err := r.Client.GetPredictor(id)
+	if err != nil {
+		return predictors.Predictor{}, fmt.Errorf("unable to get predictor %s: %w", id, err)
+	}
+
+	return p.ToPredictor(), nil
+}
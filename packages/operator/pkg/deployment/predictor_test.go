@@ -0,0 +1,37 @@
+package deployment_test
+
+import (
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	. "github.com/odahu/odahu-flow/packages/operator/pkg/deployment" //nolint
+	"github.com/stretchr/testify/assert"
+	"odahu-commons/predictors"
+	"testing"
+)
+
+type predictorClient map[string]deployment.Predictor
+
+func (c predictorClient) GetPredictor(id string) (*deployment.Predictor, error) {
+	p, ok := c[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &p, nil
+}
+
+func TestPredictorResolver(t *testing.T) {
+	resolver := PredictorResolver{Client: predictorClient{
+		"torchserve": {ID: "torchserve", Spec: deployment.PredictorSpec{OpaPolicy: "package odahu.core"}},
+	}}
+
+	p, err := resolver.GetPredictor(predictors.OdahuMLServer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, predictors.OdahuMLServer, p)
+
+	p, err = resolver.GetPredictor("torchserve")
+	assert.NoError(t, err)
+	assert.Equal(t, "package odahu.core", p.OpaPolicy)
+
+	_, err = resolver.GetPredictor("missing")
+	assert.Error(t, err)
+}
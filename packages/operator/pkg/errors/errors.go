@@ -126,3 +126,20 @@ type CreatingJobServiceNotFound struct {
 func (e CreatingJobServiceNotFound) Error() string {
 	return fmt.Sprintf(`Unable to create job: "%s". There is no service with ID: %s`, e.Entity, e.Service)
 }
+
+// Error means that events after the cursor were removed by compaction of the event log.
+// Consumer must fetch a snapshot and resume from the cursor of the snapshot
+type CursorCompactedError struct {
+	Cursor int
+	// ID of the last compacted event. Zero if it is unknown
+	CompactedUntil int
+}
+
+func (e CursorCompactedError) Error() string {
+	return fmt.Sprintf("events after cursor %d were compacted, fetch a snapshot to resume", e.Cursor)
+}
+
+func IsCursorCompactedError(err error) bool {
+	_, ok := err.(CursorCompactedError)
+	return ok
+}
@@ -37,6 +37,10 @@ func CalculateHTTPStatusCode(err error) int {
 		return http.StatusNotFound
 	}
 
+	if IsCursorCompactedError(err) {
+		return http.StatusGone
+	}
+
 	return http.StatusInternalServerError
 }
 
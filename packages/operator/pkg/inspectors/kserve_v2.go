@@ -0,0 +1,182 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package inspectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	model_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/predict_v2"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+const (
+	v2ModelsPath          = "/v2/models"
+	v2RepositoryIndexPath = "/v2/repository/index"
+)
+
+// KServeV2Inspector inspects any model server which implements the KServe (Open Inference Protocol) V2.
+// Model metadata is fetched from the /v2/models/{name} endpoint and the OpenAPI document is generated
+// from the input and output tensors of the model
+type KServeV2Inspector struct {
+	EdgeURL    url.URL
+	HTTPClient httpClient
+	// Name of the inspected model. If empty, the first model of the repository index is inspected
+	ModelName string
+}
+
+// Inspect returns the configured model or the first model of the repository index
+func (k KServeV2Inspector) Inspect(prefix string, hostHeader string, log *zap.SugaredLogger) (
+	model_types.ServedModel, error,
+) {
+	modelName := k.ModelName
+	if len(modelName) == 0 {
+		modelNames, err := k.listModels(prefix, hostHeader, log)
+		if err != nil {
+			return model_types.ServedModel{}, err
+		}
+		if len(modelNames) > 1 {
+			log.Infow("model server serves more than 1 model, the first one is returned", "prefix", prefix)
+		}
+		modelName = modelNames[0]
+	}
+
+	return k.inspectModel(prefix, hostHeader, modelName, log)
+}
+
+// InspectAll returns the configured model or every model of the repository index
+func (k KServeV2Inspector) InspectAll(prefix string, hostHeader string, log *zap.SugaredLogger) (
+	[]model_types.ServedModel, error,
+) {
+	modelNames := []string{k.ModelName}
+	if len(k.ModelName) == 0 {
+		var err error
+		if modelNames, err = k.listModels(prefix, hostHeader, log); err != nil {
+			return nil, err
+		}
+	}
+
+	servedModels := make([]model_types.ServedModel, 0, len(modelNames))
+	for _, modelName := range modelNames {
+		servedModel, err := k.inspectModel(prefix, hostHeader, modelName, log)
+		if err != nil {
+			return nil, err
+		}
+		servedModels = append(servedModels, servedModel)
+	}
+	return servedModels, nil
+}
+
+// listModels returns names of the models from the repository index
+func (k KServeV2Inspector) listModels(prefix string, hostHeader string, log *zap.SugaredLogger) ([]string, error) {
+	log.Info("getting a list of served models")
+
+	var models []TritonModelMeta
+	if err := k.doRequest(http.MethodPost, prefix, v2RepositoryIndexPath, hostHeader, &models); err != nil {
+		log.Errorw("failed to fetch model repository", "prefix", prefix, zap.Error(err))
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, fmt.Errorf("model server serves 0 models on prefix %s", prefix)
+	}
+
+	modelNames := make([]string, 0, len(models))
+	for _, model := range models {
+		modelNames = append(modelNames, model.Name)
+	}
+	return modelNames, nil
+}
+
+func (k KServeV2Inspector) inspectModel(
+	prefix string, hostHeader string, modelName string, log *zap.SugaredLogger,
+) (model_types.ServedModel, error) {
+	log.Infow("getting model metadata", "model", modelName)
+	var metadata predict_v2.MetadataModelResponse
+	metadataPath := path.Join(v2ModelsPath, modelName)
+	if err := k.doRequest(http.MethodGet, prefix, metadataPath, hostHeader, &metadata); err != nil {
+		log.Errorw("failed to fetch model metadata", "prefix", prefix, "model", modelName, zap.Error(err))
+		return model_types.ServedModel{}, err
+	}
+	if len(metadata.Name) == 0 {
+		return model_types.ServedModel{}, fmt.Errorf("metadata of model %s on prefix %s has no name", modelName, prefix)
+	}
+
+	modelVersion := ""
+	if metadata.Versions != nil && len(*metadata.Versions) > 0 {
+		versions := *metadata.Versions
+		modelVersion = versions[len(versions)-1]
+	}
+
+	spec, err := json.MarshalIndent(BuildV2OpenAPISpec(metadata, modelVersion), "", "  ")
+	if err != nil {
+		return model_types.ServedModel{}, err
+	}
+
+	return model_types.ServedModel{
+		Swagger: model_types.Swagger2{Raw: spec},
+		Metadata: model_types.Metadata{
+			ModelName:    metadata.Name,
+			ModelVersion: modelVersion,
+		},
+	}, nil
+}
+
+// doRequest sends a request to the model server and decodes the JSON response into the result
+func (k KServeV2Inspector) doRequest(method, prefix, endpoint, hostHeader string, result interface{}) error {
+	requestURL := url.URL{
+		Scheme: k.EdgeURL.Scheme,
+		Host:   k.EdgeURL.Host,
+		Path:   path.Join(k.EdgeURL.Path, prefix, endpoint),
+	}
+
+	response, err := k.HTTPClient.Do(&http.Request{
+		Method: method,
+		URL:    &requestURL,
+		Host:   hostHeader,
+	})
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", requestURL.String(), err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode >= 400 {
+		errorStr := fmt.Sprintf("Request to %s returned status code: %d. Body: %s",
+			requestURL.String(), response.StatusCode, body)
+		for _, tempCode := range temporaryErrorCodes {
+			if tempCode == response.StatusCode {
+				return temporaryErr{fmt.Errorf("%s; may be temporary", errorStr)}
+			}
+		}
+		return errors.New(errorStr)
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("unable to decode response of %s: %w", requestURL.String(), err)
+	}
+	return nil
+}
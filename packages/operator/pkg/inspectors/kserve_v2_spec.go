@@ -0,0 +1,297 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package inspectors
+
+import (
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/predict_v2"
+	"path"
+	"strings"
+)
+
+// Maximum number of elements of an example tensor
+const maxExampleTensorSize = 64
+
+// JSON schema types of tensor elements by V2 protocol datatypes
+var v2DatatypeSchemaTypes = map[string]string{
+	"BOOL":   "boolean",
+	"UINT8":  "integer",
+	"UINT16": "integer",
+	"UINT32": "integer",
+	"UINT64": "integer",
+	"INT8":   "integer",
+	"INT16":  "integer",
+	"INT32":  "integer",
+	"INT64":  "integer",
+	"FP16":   "number",
+	"FP32":   "number",
+	"FP64":   "number",
+	"BYTES":  "string",
+}
+
+// BuildV2OpenAPISpec builds a Swagger 2.0 document of a model which is served using the V2 protocol.
+// Request and response schemas contain the names, shapes and datatypes of the model tensors
+func BuildV2OpenAPISpec(metadata predict_v2.MetadataModelResponse, modelVersion string) map[string]interface{} {
+	modelPath := path.Join(v2ModelsPath, metadata.Name)
+
+	var inputs, outputs []predict_v2.MetadataTensor
+	if metadata.Inputs != nil {
+		inputs = *metadata.Inputs
+	}
+	if metadata.Outputs != nil {
+		outputs = *metadata.Outputs
+	}
+
+	definitions := map[string]interface{}{
+		"ModelMetadata": modelMetadataSchema(),
+	}
+	for _, tensor := range inputs {
+		definitions[tensorDefinitionName("Input", tensor)] = tensorSchema(tensor, true)
+	}
+	for _, tensor := range outputs {
+		definitions[tensorDefinitionName("Output", tensor)] = tensorSchema(tensor, true)
+	}
+
+	inferRequest := map[string]interface{}{
+		"type":     "object",
+		"required": []string{"inputs"},
+		"properties": map[string]interface{}{
+			"id":     map[string]interface{}{"type": "string"},
+			"inputs": tensorsSchema("Input", inputs),
+		},
+	}
+	if example, ok := tensorsExample(inputs); ok {
+		inferRequest["example"] = map[string]interface{}{"inputs": example}
+	}
+	definitions["InferRequest"] = inferRequest
+	definitions["InferResponse"] = map[string]interface{}{
+		"type":     "object",
+		"required": []string{"model_name", "outputs"},
+		"properties": map[string]interface{}{
+			"id":            map[string]interface{}{"type": "string"},
+			"model_name":    map[string]interface{}{"type": "string"},
+			"model_version": map[string]interface{}{"type": "string"},
+			"outputs":       tensorsSchema("Output", outputs),
+		},
+	}
+
+	return map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":       metadata.Name,
+			"version":     modelVersion,
+			"description": fmt.Sprintf("%s served by %s using the V2 inference protocol", metadata.Name, metadata.Platform),
+		},
+		"paths": map[string]interface{}{
+			modelPath: map[string]interface{}{
+				"get": map[string]interface{}{
+					"produces":   []string{"application/json"},
+					"parameters": []interface{}{},
+					"responses": map[string]interface{}{
+						"200": map[string]interface{}{
+							"description": "Get model metadata",
+							"schema":      map[string]interface{}{"$ref": "#/definitions/ModelMetadata"},
+						},
+					},
+				},
+			},
+			modelPath + "/ready": map[string]interface{}{
+				"get": map[string]interface{}{
+					"parameters": []interface{}{},
+					"responses": map[string]interface{}{
+						"200": map[string]interface{}{"description": "Model is ready to infer"},
+					},
+				},
+			},
+			modelPath + "/infer": map[string]interface{}{
+				"post": map[string]interface{}{
+					"consumes": []string{"application/json"},
+					"produces": []string{"application/json"},
+					"parameters": []interface{}{
+						map[string]interface{}{
+							"in":       "body",
+							"name":     "body",
+							"required": true,
+							"schema":   map[string]interface{}{"$ref": "#/definitions/InferRequest"},
+						},
+					},
+					"responses": map[string]interface{}{
+						"200": map[string]interface{}{
+							"description": "Inference result",
+							"schema":      map[string]interface{}{"$ref": "#/definitions/InferResponse"},
+						},
+					},
+				},
+			},
+		},
+		"definitions": definitions,
+	}
+}
+
+func tensorDefinitionName(kind string, tensor predict_v2.MetadataTensor) string {
+	return kind + "_" + tensor.Name
+}
+
+// tensorSchema describes a tensor. If strict is set, the name and the datatype are restricted to the tensor ones
+func tensorSchema(tensor predict_v2.MetadataTensor, strict bool) map[string]interface{} {
+	elementType, ok := v2DatatypeSchemaTypes[tensor.Datatype]
+	if !ok {
+		elementType = "string"
+	}
+
+	name := map[string]interface{}{"type": "string"}
+	datatype := map[string]interface{}{"type": "string"}
+	if strict {
+		name["enum"] = []string{tensor.Name}
+		datatype["enum"] = []string{tensor.Datatype}
+	}
+
+	return map[string]interface{}{
+		"type":        "object",
+		"description": fmt.Sprintf("Tensor %s of %s datatype and %v shape", tensor.Name, tensor.Datatype, tensor.Shape),
+		"required":    []string{"name", "shape", "datatype", "data"},
+		"properties": map[string]interface{}{
+			"name":     name,
+			"datatype": datatype,
+			"shape": map[string]interface{}{
+				"type":    "array",
+				"items":   map[string]interface{}{"type": "integer", "format": "int64"},
+				"example": tensor.Shape,
+			},
+			"data": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": elementType},
+			},
+		},
+	}
+}
+
+// tensorsSchema describes a list of tensors. Swagger 2.0 does not support oneOf, so a list of several tensors
+// is described by a schema whose name and datatype are restricted to the names and datatypes of all tensors
+func tensorsSchema(kind string, tensors []predict_v2.MetadataTensor) map[string]interface{} {
+	schema := map[string]interface{}{"type": "array"}
+
+	switch len(tensors) {
+	case 0:
+		schema["items"] = tensorSchema(predict_v2.MetadataTensor{}, false)
+		return schema
+	case 1:
+		schema["items"] = map[string]interface{}{"$ref": "#/definitions/" + tensorDefinitionName(kind, tensors[0])}
+		return schema
+	}
+
+	names := make([]string, 0, len(tensors))
+	datatypes := make([]string, 0, len(tensors))
+	definitions := make([]string, 0, len(tensors))
+	for _, tensor := range tensors {
+		names = append(names, tensor.Name)
+		definitions = append(definitions, tensorDefinitionName(kind, tensor))
+		if !containsString(datatypes, tensor.Datatype) {
+			datatypes = append(datatypes, tensor.Datatype)
+		}
+	}
+
+	items := tensorSchema(predict_v2.MetadataTensor{}, false)
+	items["description"] = "One of the tensors: " + strings.Join(definitions, ", ")
+	properties := items["properties"].(map[string]interface{})
+	properties["name"] = map[string]interface{}{"type": "string", "enum": names}
+	properties["datatype"] = map[string]interface{}{"type": "string", "enum": datatypes}
+	properties["data"] = map[string]interface{}{"type": "array", "items": map[string]interface{}{}}
+
+	schema["items"] = items
+	schema["minItems"] = len(tensors)
+	schema["maxItems"] = len(tensors)
+	return schema
+}
+
+// tensorsExample returns example tensors. Variable dimensions (-1) are replaced with 1.
+// No example is returned if the tensors are too large to be a readable example
+func tensorsExample(tensors []predict_v2.MetadataTensor) ([]interface{}, bool) {
+	examples := make([]interface{}, 0, len(tensors))
+	for _, tensor := range tensors {
+		shape := make([]int, 0, len(tensor.Shape))
+		size := 1
+		for _, dim := range tensor.Shape {
+			if dim < 0 {
+				dim = 1
+			}
+			shape = append(shape, dim)
+			size *= dim
+		}
+		if size > maxExampleTensorSize {
+			return nil, false
+		}
+
+		var element interface{}
+		switch v2DatatypeSchemaTypes[tensor.Datatype] {
+		case "boolean":
+			element = false
+		case "integer", "number":
+			element = 0
+		default:
+			element = ""
+		}
+		data := make([]interface{}, size)
+		for i := range data {
+			data[i] = element
+		}
+
+		examples = append(examples, map[string]interface{}{
+			"name":     tensor.Name,
+			"shape":    shape,
+			"datatype": tensor.Datatype,
+			"data":     data,
+		})
+	}
+	return examples, true
+}
+
+func modelMetadataSchema() map[string]interface{} {
+	stringArray := map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+	tensorMetadata := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":     map[string]interface{}{"type": "string"},
+			"datatype": map[string]interface{}{"type": "string"},
+			"shape": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "integer", "format": "int64"},
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":     map[string]interface{}{"type": "string"},
+			"versions": stringArray,
+			"platform": map[string]interface{}{"type": "string"},
+			"inputs":   map[string]interface{}{"type": "array", "items": tensorMetadata},
+			"outputs":  map[string]interface{}{"type": "array", "items": tensorMetadata},
+		},
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package inspectors_test
+
+import (
+	"encoding/json"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/inspectors"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const (
+	v2ModelMetadata = `{
+		"name": "my-model",
+		"versions": ["1", "2"],
+		"platform": "onnxruntime_onnx",
+		"inputs": [
+			{"name": "input_ids", "datatype": "INT64", "shape": [-1, 4]},
+			{"name": "attention_mask", "datatype": "INT64", "shape": [-1, 4]}
+		],
+		"outputs": [{"name": "logits", "datatype": "FP32", "shape": [-1, 2]}]
+	}`
+)
+
+type kserveV2InspectorSuite struct {
+	suite.Suite
+	inspector inspectors.KServeV2Inspector
+	requests  []*http.Request
+}
+
+func TestKServeV2InspectorSuite(t *testing.T) {
+	suite.Run(t, new(kserveV2InspectorSuite))
+}
+
+func (s *kserveV2InspectorSuite) SetupTest() {
+	s.requests = nil
+	s.inspector = inspectors.KServeV2Inspector{EdgeURL: url.URL{}}
+}
+
+func (s *kserveV2InspectorSuite) respond(responses map[string]string) {
+	s.inspector.HTTPClient = &httpClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			s.requests = append(s.requests, req)
+			body, ok := responses[req.URL.Path]
+			if !ok {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+		},
+	}
+}
+
+func (s *kserveV2InspectorSuite) TestInspect() {
+	s.inspector.ModelName = "my-model"
+	s.respond(map[string]string{someURLPrefix + "/v2/models/my-model": v2ModelMetadata})
+
+	model, err := s.inspector.Inspect(someURLPrefix, "host", logger.Sugar())
+	s.Require().NoError(err)
+
+	s.Assertions.Len(s.requests, 1)
+	s.Assertions.Equal("host", s.requests[0].Host)
+	s.Assertions.Equal("my-model", model.Metadata.ModelName)
+	s.Assertions.Equal("2", model.Metadata.ModelVersion)
+
+	spec := map[string]interface{}{}
+	s.Require().NoError(json.Unmarshal(model.Swagger.Raw, &spec))
+	s.Assertions.Contains(spec["paths"], "/v2/models/my-model/infer")
+	s.Assertions.Contains(spec["paths"], "/v2/models/my-model/ready")
+
+	definitions := spec["definitions"].(map[string]interface{})
+	s.Assertions.Contains(definitions, "Input_input_ids")
+	s.Assertions.Contains(definitions, "Input_attention_mask")
+
+	// Single output tensor is referenced directly
+	responseProperties := definitions["InferResponse"].(map[string]interface{})["properties"]
+	responseOutputs := responseProperties.(map[string]interface{})["outputs"]
+	s.Assertions.Equal(
+		map[string]interface{}{"$ref": "#/definitions/Output_logits"},
+		responseOutputs.(map[string]interface{})["items"],
+	)
+	logits := definitions["Output_logits"].(map[string]interface{})["properties"].(map[string]interface{})
+	s.Assertions.Equal([]interface{}{"FP32"}, logits["datatype"].(map[string]interface{})["enum"])
+	s.Assertions.Equal("number", logits["data"].(map[string]interface{})["items"].(map[string]interface{})["type"])
+
+	// Several input tensors are restricted to their names
+	requestInputs := definitions["InferRequest"].(map[string]interface{})["properties"].(map[string]interface{})["inputs"]
+	inputItems := requestInputs.(map[string]interface{})["items"].(map[string]interface{})
+	s.Assertions.Equal(
+		[]interface{}{"input_ids", "attention_mask"},
+		inputItems["properties"].(map[string]interface{})["name"].(map[string]interface{})["enum"],
+	)
+
+	example := definitions["InferRequest"].(map[string]interface{})["example"].(map[string]interface{})
+	exampleInput := example["inputs"].([]interface{})[0].(map[string]interface{})
+	s.Assertions.Equal([]interface{}{float64(1), float64(4)}, exampleInput["shape"])
+	s.Assertions.Len(exampleInput["data"], 4)
+}
+
+func (s *kserveV2InspectorSuite) TestInspect_RepositoryIndex() {
+	s.respond(map[string]string{
+		someURLPrefix + "/v2/repository/index": `[{"name": "my-model", "state": "READY"}]`,
+		someURLPrefix + "/v2/models/my-model":  v2ModelMetadata,
+	})
+
+	model, err := s.inspector.Inspect(someURLPrefix, "", logger.Sugar())
+	s.Require().NoError(err)
+
+	s.Assertions.Equal("my-model", model.Metadata.ModelName)
+	s.Assertions.Len(s.requests, 2)
+	s.Assertions.Equal(http.MethodPost, s.requests[0].Method)
+	s.Assertions.Equal(http.MethodGet, s.requests[1].Method)
+}
+
+func (s *kserveV2InspectorSuite) TestInspectAll() {
+	s.respond(map[string]string{
+		someURLPrefix + "/v2/repository/index": `[{"name": "my-model"}, {"name": "another-model"}]`,
+		someURLPrefix + "/v2/models/my-model":  v2ModelMetadata,
+		someURLPrefix + "/v2/models/another-model": `{
+			"name": "another-model", "platform": "sklearn",
+			"inputs": [{"name": "input-0", "datatype": "FP32", "shape": [-1, 3]}],
+			"outputs": [{"name": "output-0", "datatype": "INT64", "shape": [-1]}]
+		}`,
+	})
+
+	models, err := s.inspector.InspectAll(someURLPrefix, "", logger.Sugar())
+	s.Require().NoError(err)
+	s.Require().Len(models, 2)
+
+	s.Assertions.Equal("my-model", models[0].Metadata.ModelName)
+	s.Assertions.Equal("another-model", models[1].Metadata.ModelName)
+	s.Assertions.Contains(string(models[1].Swagger.Raw), "/v2/models/another-model/infer")
+	s.Assertions.Contains(string(models[1].Swagger.Raw), "Input_input-0")
+}
+
+func (s *kserveV2InspectorSuite) TestInspectAll_ModelName() {
+	s.inspector.ModelName = "my-model"
+	s.respond(map[string]string{someURLPrefix + "/v2/models/my-model": v2ModelMetadata})
+
+	models, err := s.inspector.InspectAll(someURLPrefix, "", logger.Sugar())
+	s.Require().NoError(err)
+	s.Assertions.Len(models, 1)
+	s.Assertions.Len(s.requests, 1)
+}
+
+func (s *kserveV2InspectorSuite) TestInspect_NoModels() {
+	s.respond(map[string]string{someURLPrefix + "/v2/repository/index": `[]`})
+
+	_, err := s.inspector.Inspect(someURLPrefix, "", logger.Sugar())
+	s.Assertions.Error(err)
+	s.Assertions.Contains(err.Error(), "serves 0 models")
+}
+
+func (s *kserveV2InspectorSuite) TestInspect_UnknownModel() {
+	s.inspector.ModelName = "unknown"
+	s.respond(map[string]string{})
+
+	_, err := s.inspector.Inspect(someURLPrefix, "", logger.Sugar())
+	s.Assertions.Error(err)
+	s.Assertions.Contains(err.Error(), "status code: 404")
+}
+
+func (s *kserveV2InspectorSuite) TestInspect_Temporary() {
+	s.inspector.ModelName = "my-model"
+	s.inspector.HTTPClient = &httpClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+
+	_, err := s.inspector.Inspect(someURLPrefix, "", logger.Sugar())
+	s.Require().Error(err)
+	tempErr, ok := err.(interface{ Temporary() bool })
+	s.Assertions.True(ok)
+	s.Assertions.True(tempErr.Temporary())
+}
@@ -17,16 +17,14 @@
 package inspectors
 
 import (
-	"encoding/json"
-	"fmt"
 	model_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
 	"go.uber.org/zap"
-	"io/ioutil"
 	"net/http"
 	"net/url"
-	"path"
 )
 
+const odahuMLServerInfoPath = "/api/model/info"
+
 type OdahuMLServerInspector struct {
 	EdgeURL    url.URL
 	HTTPClient httpClient
@@ -57,72 +55,12 @@ var temporaryErrorCodes = []int{
 }
 
 func (o OdahuMLServerInspector) Inspect(
-	prefix string, hostHeader string, log *zap.SugaredLogger) (model model_types.ServedModel, err error) {
-
-	modelRequest := o.generateModelRequest(prefix, hostHeader)
-	log.Infow("metadata inspect request", "path", modelRequest.URL.Path,
-		"hostHeader", modelRequest.Host)
-
-	var response *http.Response
-	response, err = o.HTTPClient.Do(modelRequest)
-	if err != nil {
-		log.Error(err, "Can not get swagger response for prefix")
-		return model, err
-	}
-
-	if response.StatusCode >= 400 {
-		var body []byte
-		_, _ = response.Body.Read(body)
-		errorStr := fmt.Sprintf("Request to %s returned status code: %d. Body: %s",
-			modelRequest.URL, response.StatusCode, body)
-
-		for _, tempCode := range temporaryErrorCodes {
-			if tempCode == response.StatusCode {
-				return model, temporaryErr{
-					fmt.Errorf(errorStr + "; may be temporary"),
-				}
-			}
-		}
-		return model, fmt.Errorf(errorStr)
-	}
-
-	defer func() {
-		if err := response.Body.Close(); err != nil {
-			log.Errorw("Unable to close response body", zap.Error(err))
-		}
-	}()
-
-	rawBody, err := ioutil.ReadAll(response.Body)
-	log.Debugw("Get response from model", "content", string(rawBody))
-
-	swaggerMeta := &SwaggerMetadata{}
-	err = json.Unmarshal(rawBody, swaggerMeta)
-	if err != nil {
-		return model_types.ServedModel{}, err
-	}
-
-	model = model_types.ServedModel{
-		Swagger: model_types.Swagger2{Raw: rawBody},
-		Metadata: model_types.Metadata{
-			ModelName:    swaggerMeta.Info.Title,
-			ModelVersion: swaggerMeta.Info.Version,
-		},
-	}
-
-	return model, nil
-}
-
-func (o OdahuMLServerInspector) generateModelRequest(prefix string, hostHeader string) *http.Request {
-
-	MlServerURL := url.URL{
-		Scheme: o.EdgeURL.Scheme,
-		Host:   o.EdgeURL.Host,
-		Path:   path.Join(o.EdgeURL.Path, prefix, "/api/model/info"),
-	}
-
-	return &http.Request{
-		Method: http.MethodGet,
-		URL:    &MlServerURL,
-		Host:   hostHeader,
-	}
+	prefix string, hostHeader string, log *zap.SugaredLogger) (model_types.ServedModel, error) {
+
+	// ODAHU ML Server serves the Swagger document of the model on the info endpoint
+	return OpenAPIInspector{
+		EdgeURL:    o.EdgeURL,
+		HTTPClient: o.HTTPClient,
+		Path:       odahuMLServerInfoPath,
+	}.Inspect(prefix, hostHeader, log)
 }
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2021 EPAM Systems
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	model_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// OpenAPIInspector fetches an OpenAPI (Swagger) document from the configured path of a model server.
+// Model name and version are taken from the title and the version of the document
+type OpenAPIInspector struct {
+	EdgeURL    url.URL
+	HTTPClient httpClient
+	// Path of the document relative to the model URL prefix
+	Path string
+}
+
+func (o OpenAPIInspector) Inspect(
+	prefix string, hostHeader string, log *zap.SugaredLogger) (model model_types.ServedModel, err error) {
+
+	modelRequest := o.generateModelRequest(prefix, hostHeader)
+	log.Infow("metadata inspect request", "path", modelRequest.URL.Path,
+		"hostHeader", modelRequest.Host)
+
+	var response *http.Response
+	response, err = o.HTTPClient.Do(modelRequest)
+	if err != nil {
+		log.Error(err, "Can not get swagger response for prefix")
+		return model, err
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			log.Errorw("Unable to close response body", zap.Error(err))
+		}
+	}()
+
+	if response.StatusCode >= 400 {
+		body, _ := ioutil.ReadAll(response.Body)
+		errorStr := fmt.Sprintf("Request to %s returned status code: %d. Body: %s",
+			modelRequest.URL, response.StatusCode, body)
+
+		for _, tempCode := range temporaryErrorCodes {
+			if tempCode == response.StatusCode {
+				return model, temporaryErr{
+					fmt.Errorf("%s; may be temporary", errorStr),
+				}
+			}
+		}
+		return model, errors.New(errorStr)
+	}
+
+	rawBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return model_types.ServedModel{}, err
+	}
+	log.Debugw("Get response from model", "content", string(rawBody))
+
+	swaggerMeta := &SwaggerMetadata{}
+	err = json.Unmarshal(rawBody, swaggerMeta)
+	if err != nil {
+		return model_types.ServedModel{}, err
+	}
+	if len(swaggerMeta.Info.Title) == 0 {
+		return model_types.ServedModel{}, fmt.Errorf(
+			"document %s does not contain the title of the model", modelRequest.URL,
+		)
+	}
+
+	model = model_types.ServedModel{
+		Swagger: model_types.Swagger2{Raw: rawBody},
+		Metadata: model_types.Metadata{
+			ModelName:    swaggerMeta.Info.Title,
+			ModelVersion: swaggerMeta.Info.Version,
+		},
+	}
+
+	return model, nil
+}
+
+func (o OpenAPIInspector) generateModelRequest(prefix string, hostHeader string) *http.Request {
+
+	documentURL := url.URL{
+		Scheme: o.EdgeURL.Scheme,
+		Host:   o.EdgeURL.Host,
+		Path:   path.Join(o.EdgeURL.Path, prefix, o.Path),
+	}
+
+	return &http.Request{
+		Method: http.MethodGet,
+		URL:    &documentURL,
+		Host:   hostHeader,
+	}
+}
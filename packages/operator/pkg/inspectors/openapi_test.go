@@ -0,0 +1,126 @@
+/*
+ * Copyright 2021 EPAM Systems
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspectors_test
+
+import (
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/inspectors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"odahu-commons/predictors"
+	"strings"
+	"testing"
+)
+
+const openAPIDocument = `{"openapi": "3.0.0", "info": {"title": "wine", "version": "1.2"}, "paths": {}}`
+
+func newDocumentClient(t *testing.T, expectedPath string, statusCode int, body string) *httpClient {
+	return &httpClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, expectedPath, req.URL.Path)
+			assert.Equal(t, "wine.example.com", req.Host)
+			return &http.Response{
+				StatusCode: statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestOpenAPIInspect(t *testing.T) {
+	inspector := inspectors.OpenAPIInspector{
+		EdgeURL:    url.URL{Scheme: "http", Host: "edge", Path: "/base"},
+		HTTPClient: newDocumentClient(t, "/base/some/prefix/openapi.json", http.StatusOK, openAPIDocument),
+		Path:       "/openapi.json",
+	}
+
+	model, err := inspector.Inspect(someURLPrefix, "wine.example.com", logger.Sugar())
+	assert.NoError(t, err)
+	assert.Equal(t, "wine", model.Metadata.ModelName)
+	assert.Equal(t, "1.2", model.Metadata.ModelVersion)
+	assert.JSONEq(t, openAPIDocument, string(model.Swagger.Raw))
+}
+
+func TestOpenAPIInspect_NoTitle(t *testing.T) {
+	inspector := inspectors.OpenAPIInspector{
+		HTTPClient: newDocumentClient(t, "some/prefix/openapi.json", http.StatusOK, `{"info": {}}`),
+		Path:       "/openapi.json",
+	}
+
+	_, err := inspector.Inspect(someURLPrefix, "wine.example.com", logger.Sugar())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not contain the title")
+}
+
+func TestOpenAPIInspect_TemporaryError(t *testing.T) {
+	inspector := inspectors.OpenAPIInspector{
+		HTTPClient: newDocumentClient(t, "some/prefix/openapi.json", http.StatusServiceUnavailable, ""),
+		Path:       "/openapi.json",
+	}
+
+	_, err := inspector.Inspect(someURLPrefix, "wine.example.com", logger.Sugar())
+	temporary, ok := err.(interface{ Temporary() bool })
+	assert.True(t, ok)
+	assert.True(t, temporary.Temporary())
+}
+
+type predictorGetter map[string]predictors.Predictor
+
+func (g predictorGetter) GetPredictor(id string) (predictors.Predictor, error) {
+	p, ok := g[id]
+	if !ok {
+		return p, errors.New("unknown predictor " + id)
+	}
+	return p, nil
+}
+
+func TestRegistry(t *testing.T) {
+	registry, err := inspectors.NewRegistry("http://edge", &httpClient{}, predictorGetter{
+		predictors.Triton.ID: predictors.Triton,
+		"torchserve": {
+			ID:        "torchserve",
+			Inspector: predictors.Inspector{Type: predictors.OpenAPIInspector, OpenAPIPath: "/api-description"},
+		},
+		"mlserver": {
+			ID:        "mlserver",
+			Inspector: predictors.Inspector{Type: predictors.KServeV2Inspector, ModelName: "my-model"},
+		},
+		"broken": {ID: "broken", Inspector: predictors.Inspector{Type: "unknown"}},
+	})
+	assert.NoError(t, err)
+
+	inspector, err := registry.GetInspector(predictors.Triton.ID)
+	assert.NoError(t, err)
+	assert.IsType(t, inspectors.TritonInspector{}, inspector)
+
+	inspector, err = registry.GetInspector("torchserve")
+	assert.NoError(t, err)
+	assert.Equal(t, "/api-description", inspector.(inspectors.OpenAPIInspector).Path)
+	assert.Equal(t, "edge", inspector.(inspectors.OpenAPIInspector).EdgeURL.Host)
+
+	inspector, err = registry.GetInspector("mlserver")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-model", inspector.(inspectors.KServeV2Inspector).ModelName)
+
+	_, err = registry.GetInspector("broken")
+	assert.Error(t, err)
+
+	_, err = registry.GetInspector("missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2021 EPAM Systems
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspectors
+
+import (
+	"fmt"
+	"net/url"
+	"odahu-commons/predictors"
+)
+
+// PredictorGetter returns built-in predictors and predictors registered at runtime
+type PredictorGetter interface {
+	GetPredictor(id string) (predictors.Predictor, error)
+}
+
+// Registry returns inspectors of predictors. Predictors are resolved on every call,
+// so predictors registered or changed at runtime are inspected without a restart
+type Registry struct {
+	edgeURL    url.URL
+	client     httpClient
+	predictors PredictorGetter
+}
+
+func NewRegistry(edgeURLString string, client httpClient, predictorGetter PredictorGetter) (*Registry, error) {
+	edgeURL, err := url.Parse(edgeURLString)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Registry{
+		edgeURL:    *edgeURL,
+		client:     client,
+		predictors: predictorGetter,
+	}, nil
+}
+
+// GetInspector returns the inspector of the predictor
+func (r *Registry) GetInspector(predictorID string) (ModelServerInspector, error) {
+	predictor, err := r.predictors.GetPredictor(predictorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInspector(r.edgeURL, r.client, predictor.Inspector)
+}
+
+// NewInspector creates an inspector of the type
+func NewInspector(edgeURL url.URL, client httpClient, inspector predictors.Inspector) (ModelServerInspector, error) {
+	switch inspector.Type {
+	case predictors.OdahuMLServerInspector:
+		return OdahuMLServerInspector{EdgeURL: edgeURL, HTTPClient: client}, nil
+	case predictors.TritonInspector:
+		return TritonInspector{EdgeURL: edgeURL, HTTPClient: client}, nil
+	case predictors.OpenAPIInspector:
+		return OpenAPIInspector{EdgeURL: edgeURL, HTTPClient: client, Path: inspector.OpenAPIPath}, nil
+	case predictors.KServeV2Inspector:
+		return KServeV2Inspector{EdgeURL: edgeURL, HTTPClient: client, ModelName: inspector.ModelName}, nil
+	default:
+		return nil, fmt.Errorf("unknown inspector type %q", inspector.Type)
+	}
+}
@@ -38,15 +38,30 @@ type TritonInspector struct {
 	HTTPClient httpClient
 }
 
+// Inspect returns the first model served by Triton. Use InspectAll to get every model
 func (t TritonInspector) Inspect(prefix string, hostHeader string, log *zap.SugaredLogger) (
 	model_types.ServedModel, error,
+) {
+	models, err := t.InspectAll(prefix, hostHeader, log)
+	if err != nil {
+		return model_types.ServedModel{}, err
+	}
+	if len(models) > 1 {
+		log.Infow("triton server serves more than 1 model, the first one is returned", "prefix", prefix)
+	}
+	return models[0], nil
+}
+
+// InspectAll returns every model of the Triton model repository
+func (t TritonInspector) InspectAll(prefix string, hostHeader string, log *zap.SugaredLogger) (
+	[]model_types.ServedModel, error,
 ) {
 	log.Info("getting a list of served models")
 	listModelsRequest := t.generateListModelsRequest(prefix, hostHeader)
 	response, err := t.HTTPClient.Do(listModelsRequest)
 	if err != nil {
 		log.Errorw("failed to fetch model repository", "prefix", prefix)
-		return model_types.ServedModel{}, fmt.Errorf("failed to fetch model repository on prefix %s", prefix)
+		return nil, fmt.Errorf("failed to fetch model repository on prefix %s", prefix)
 	}
 
 	var models []TritonModelMeta
@@ -54,21 +69,13 @@ func (t TritonInspector) Inspect(prefix string, hostHeader string, log *zap.Suga
 	defer response.Body.Close()
 	if decoder.Decode(&models) != nil {
 		log.Errorw("failed to unmarshall model repository", "prefix", prefix)
-		return model_types.ServedModel{}, fmt.Errorf("failed to unmarshall model repository on prefix %s", prefix)
+		return nil, fmt.Errorf("failed to unmarshall model repository on prefix %s", prefix)
 	}
 	log.Info("found models", models)
 
-	var model TritonModelMeta
-
-	switch len(models) {
-	case 0:
+	if len(models) == 0 {
 		log.Errorw("triton server serves 0 models", "prefix", prefix)
-		return model_types.ServedModel{}, fmt.Errorf("triton server serves 0 models on prefix %s", prefix)
-	case 1:
-		model = models[0]
-	default:
-		log.Warnw("triton server serves more than 1 model", "prefix", prefix)
-		model = models[0]
+		return nil, fmt.Errorf("triton server serves 0 models on prefix %s", prefix)
 	}
 
 	log.Info("rendering spec template")
@@ -76,29 +83,33 @@ func (t TritonInspector) Inspect(prefix string, hostHeader string, log *zap.Suga
 
 	tpl, err := template.New("_").Parse(string(specTemplate))
 	if err != nil {
-		return model_types.ServedModel{}, err
-	}
-
-	b := bytes.NewBuffer([]byte{})
-	err = tpl.Execute(b, struct {
-		ModelName    string
-		ModelVersion string
-	}{
-		ModelName:    model.Name,
-		ModelVersion: model.Version,
-	})
-	if err != nil {
-		return model_types.ServedModel{}, err
+		return nil, err
 	}
 
-	specBytes := b.Bytes()
-	return model_types.ServedModel{
-		Swagger: model_types.Swagger2{Raw: specBytes},
-		Metadata: model_types.Metadata{
+	servedModels := make([]model_types.ServedModel, 0, len(models))
+	for _, model := range models {
+		b := bytes.NewBuffer([]byte{})
+		err = tpl.Execute(b, struct {
+			ModelName    string
+			ModelVersion string
+		}{
 			ModelName:    model.Name,
 			ModelVersion: model.Version,
-		},
-	}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		servedModels = append(servedModels, model_types.ServedModel{
+			Swagger: model_types.Swagger2{Raw: b.Bytes()},
+			Metadata: model_types.Metadata{
+				ModelName:    model.Name,
+				ModelVersion: model.Version,
+			},
+		})
+	}
+
+	return servedModels, nil
 }
 
 func (t *TritonInspector) generateListModelsRequest(prefix string, hostHeader string) *http.Request {
@@ -137,6 +137,33 @@ func (s *tritonInspectorSuite) TestInspect_MoreThanOneModel() {
 	}
 }
 
+func (s *tritonInspectorSuite) TestInspectAll() {
+	// Simulates that Triton has 2 models; we expect inspector to generate a spec for every model
+	s.inspector.HTTPClient = &httpClient{
+		f: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body: ioutil.NopCloser(strings.NewReader(
+					`[{"name": "my-model", "version": "11", "ready": true},
+                        {"name": "another-model", "version": "22", "ready": true}]`)),
+			}, nil
+		},
+	}
+
+	models, err := s.inspector.InspectAll(someURLPrefix, "", logger.Sugar())
+	s.Assertions.NoError(err)
+	s.Assertions.Len(models, 2)
+
+	s.Assertions.Equal("my-model", models[0].Metadata.ModelName)
+	s.Assertions.Equal("11", models[0].Metadata.ModelVersion)
+	s.Assertions.Contains(string(models[0].Swagger.Raw), "/v2/models/my-model/infer")
+
+	s.Assertions.Equal("another-model", models[1].Metadata.ModelName)
+	s.Assertions.Equal("22", models[1].Metadata.ModelVersion)
+	s.Assertions.Contains(string(models[1].Swagger.Raw), "/v2/models/another-model/infer")
+	s.Assertions.NotContains(string(models[1].Swagger.Raw), "/v2/models/my-model/infer")
+}
+
 func (s *tritonInspectorSuite) TestInspect_NoResponse() {
 	// Simulates that Triton server does not respond
 	s.inspector.HTTPClient = &httpClient{
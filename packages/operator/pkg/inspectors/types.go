@@ -34,6 +34,14 @@ type ModelServerInspector interface {
 	Inspect(prefix string, hostHeader string, log *zap.SugaredLogger) (model_types.ServedModel, error)
 }
 
+// MultiModelServerInspector is implemented by inspectors of model servers
+// which may serve several models behind one prefix
+type MultiModelServerInspector interface {
+	ModelServerInspector
+	// InspectAll returns Metadata and Swagger of every model served behind the prefix
+	InspectAll(prefix string, hostHeader string, log *zap.SugaredLogger) ([]model_types.ServedModel, error)
+}
+
 type temporaryErr struct {
 	error
 }
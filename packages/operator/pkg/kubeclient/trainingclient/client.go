@@ -111,6 +111,13 @@ func (c *trainingK8SClient) SaveModelTrainingResult(id string, result *v1alpha1.
 		if len(result.RunID) != 0 {
 			oldResult.RunID = result.RunID
 		}
+		// Metrics can be reported several times during a training, so new values are merged with old ones
+		for name, value := range result.Metrics {
+			if oldResult.Metrics == nil {
+				oldResult.Metrics = make(map[string]float64, len(result.Metrics))
+			}
+			oldResult.Metrics[name] = value
+		}
 	} else {
 		oldResult = result
 	}
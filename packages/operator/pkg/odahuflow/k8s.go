@@ -42,3 +42,7 @@ func GenerateTrainingResultCMName(mtID string) string {
 func GenerateDeploymentConnectionSecretName(connName string) string {
 	return fmt.Sprintf("%s-regsecret", connName)
 }
+
+func GenerateDeploymentEnvSecretName(mdID string) string {
+	return fmt.Sprintf("%s-env", mdID)
+}
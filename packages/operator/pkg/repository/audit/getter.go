@@ -0,0 +1,132 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"time"
+)
+
+var (
+	MaxSize   = 500
+	FirstPage = 0
+)
+
+type Getter struct {
+	DB *sql.DB
+}
+
+// List returns audit records ordered by ID. Records can be filtered by audit.RecordFilter
+func (g Getter) List(ctx context.Context, options ...filter.ListOption) (records []audit.Record, err error) {
+	listOptions := &filter.ListOptions{
+		Filter: nil,
+		Page:   &FirstPage,
+		Size:   &MaxSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	offset := *listOptions.Size * (*listOptions.Page)
+
+	sb := sq.
+		Select(
+			IDCol, EntityKindCol, EntityIDCol, OperationCol, UsernameCol, EmailCol, DatetimeCol,
+			OldSpecCol, NewSpecCol, DiffCol,
+		).
+		From(Table).
+		OrderBy(IDCol).
+		Offset(uint64(offset)).
+		Limit(uint64(*listOptions.Size)).
+		PlaceholderFormat(sq.Dollar)
+
+	if listOptions.Filter != nil {
+		recordFilter, ok := listOptions.Filter.(*audit.RecordFilter)
+		if !ok {
+			return nil, fmt.Errorf("unexpected filter type: %T", listOptions.Filter)
+		}
+
+		sb = utils.TransformFilter(sb, recordFilter)
+		if sb, err = whereDatetime(sb, recordFilter); err != nil {
+			return nil, err
+		}
+	}
+
+	stmt, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := g.DB.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	records = make([]audit.Record, 0)
+	for rows.Next() {
+		var r audit.Record
+		var oldSpec, newSpec, diff []byte
+		if err = rows.Scan(
+			&r.ID, &r.EntityKind, &r.EntityID, &r.Operation, &r.User.Username, &r.User.Email, &r.Datetime,
+			&oldSpec, &newSpec, &diff,
+		); err != nil {
+			log.Error(err, "Unable to scan audit record")
+			return nil, err
+		}
+		r.OldSpec, r.NewSpec, r.Diff = oldSpec, newSpec, diff
+
+		records = append(records, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		err = fmt.Errorf("error during rows iteration: %v", err)
+	}
+
+	return records, err
+}
+
+func whereDatetime(sb sq.SelectBuilder, recordFilter *audit.RecordFilter) (sq.SelectBuilder, error) {
+	for _, from := range recordFilter.From {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return sb, err
+		}
+		sb = sb.Where(sq.GtOrEq{DatetimeCol: t})
+	}
+
+	for _, to := range recordFilter.To {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return sb, err
+		}
+		sb = sb.Where(sq.LtOrEq{DatetimeCol: t})
+	}
+
+	return sb, nil
+}
@@ -0,0 +1,125 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	sq "github.com/Masterminds/squirrel"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/user"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"time"
+)
+
+const (
+	Table = "odahu_audit"
+)
+
+const (
+	IDCol         = "id"
+	EntityKindCol = "entity_kind"
+	EntityIDCol   = "entity_id"
+	OperationCol  = "operation"
+	UsernameCol   = "username"
+	EmailCol      = "email"
+	DatetimeCol   = "datetime"
+	OldSpecCol    = "old_spec"
+	NewSpecCol    = "new_spec"
+	DiffCol       = "diff"
+)
+
+var log = logf.Log.WithName("audit-repository")
+
+type Recorder struct {
+	DB *sql.DB
+}
+
+// Record stores the change to the audit log. The author of the change is taken from ctx.
+// Pass the transaction of the entity change to guarantee that the record is stored
+// only if the change is committed
+func (r Recorder) Record(ctx context.Context, tx *sql.Tx, change audit.Change) error {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	record, err := newRecord(ctx, change)
+	if err != nil {
+		return err
+	}
+
+	stmt, args, err := sq.
+		Insert(Table).
+		Columns(
+			EntityKindCol, EntityIDCol, OperationCol, UsernameCol, EmailCol, DatetimeCol,
+			OldSpecCol, NewSpecCol, DiffCol,
+		).
+		Values(
+			record.EntityKind, record.EntityID, record.Operation, record.User.Username, record.User.Email,
+			record.Datetime, nullJSON(record.OldSpec), nullJSON(record.NewSpec), nullJSON(record.Diff),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	return err
+}
+
+func newRecord(ctx context.Context, change audit.Change) (record audit.Record, err error) {
+	record = audit.Record{
+		EntityKind: change.EntityKind,
+		EntityID:   change.EntityID,
+		Operation:  change.Operation,
+		Datetime:   time.Now().UTC(),
+	}
+	record.User, _ = user.FromContext(ctx)
+
+	if change.OldSpec != nil {
+		if record.OldSpec, err = json.Marshal(change.OldSpec); err != nil {
+			return record, err
+		}
+	}
+	if change.NewSpec != nil {
+		if record.NewSpec, err = json.Marshal(change.NewSpec); err != nil {
+			return record, err
+		}
+	}
+	if change.OldSpec != nil && change.NewSpec != nil {
+		if record.Diff, err = jsonpatch.CreateMergePatch(record.OldSpec, record.NewSpec); err != nil {
+			return record, err
+		}
+	}
+
+	return record, nil
+}
+
+// nullJSON converts empty JSON to SQL NULL
+func nullJSON(value []byte) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+	return string(value)
+}
@@ -41,6 +41,10 @@ var (
 	log = logf.Log.WithName("batch-inference--repository--postgres")
 	MaxSize   = 500
 	FirstPage = 0
+	txOptions = &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  false,
+	}
 )
 
 // InferenceJob persistence repository
@@ -262,4 +266,8 @@ func (r BIJRepo) SetDeletionMark(ctx context.Context, tx *sql.Tx, id string, val
 	}
 
 	return utils.SetDeletionMark(ctx, qrr, BatchInferenceJobTable, id, value)
-}
\ No newline at end of file
+}
+
+func (r BIJRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return r.DB.BeginTx(ctx, txOptions)
+}
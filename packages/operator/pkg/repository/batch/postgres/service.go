@@ -247,3 +247,7 @@ func (r BISRepo) Get(ctx context.Context, tx *sql.Tx, id string) (res api_types.
 		return res, nil
 	}
 }
+
+func (r BISRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return r.DB.BeginTx(ctx, txOptions)
+}
@@ -5,7 +5,7 @@ package mocks
 import (
 	context "context"
 
-	apisdeployment "github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	deployment "github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
 
 	filter "github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
 
@@ -44,13 +44,13 @@ func (_m *Repository) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
 	return r0, r1
 }
 
-// CreateModelDeployment provides a mock function with given fields: ctx, tx, md
-func (_m *Repository) SaveModelDeployment(ctx context.Context, tx *sql.Tx, md *apisdeployment.ModelDeployment) error {
-	ret := _m.Called(ctx, tx, md)
+// DeleteModelDeployment provides a mock function with given fields: ctx, tx, id
+func (_m *Repository) DeleteModelDeployment(ctx context.Context, tx *sql.Tx, id string) error {
+	ret := _m.Called(ctx, tx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, *apisdeployment.ModelDeployment) error); ok {
-		r0 = rf(ctx, tx, md)
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, string) error); ok {
+		r0 = rf(ctx, tx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -58,30 +58,37 @@ func (_m *Repository) SaveModelDeployment(ctx context.Context, tx *sql.Tx, md *a
 	return r0
 }
 
-// DeleteModelDeployment provides a mock function with given fields: ctx, tx, id
-func (_m *Repository) DeleteModelDeployment(ctx context.Context, tx *sql.Tx, id string) error {
+// GetLastRevision provides a mock function with given fields: ctx, tx, id
+func (_m *Repository) GetLastRevision(ctx context.Context, tx *sql.Tx, id string) (int, error) {
 	ret := _m.Called(ctx, tx, id)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, string) error); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, string) int); ok {
 		r0 = rf(ctx, tx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sql.Tx, string) error); ok {
+		r1 = rf(ctx, tx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetModelDeployment provides a mock function with given fields: ctx, tx, id
-func (_m *Repository) GetModelDeployment(ctx context.Context, tx *sql.Tx, id string) (*apisdeployment.ModelDeployment, error) {
+func (_m *Repository) GetModelDeployment(ctx context.Context, tx *sql.Tx, id string) (*deployment.ModelDeployment, error) {
 	ret := _m.Called(ctx, tx, id)
 
-	var r0 *apisdeployment.ModelDeployment
-	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, string) *apisdeployment.ModelDeployment); ok {
+	var r0 *deployment.ModelDeployment
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, string) *deployment.ModelDeployment); ok {
 		r0 = rf(ctx, tx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*apisdeployment.ModelDeployment)
+			r0 = ret.Get(0).(*deployment.ModelDeployment)
 		}
 	}
 
@@ -96,22 +103,23 @@ func (_m *Repository) GetModelDeployment(ctx context.Context, tx *sql.Tx, id str
 }
 
 // GetModelDeploymentList provides a mock function with given fields: ctx, tx, options
-func (_m *Repository) GetModelDeploymentList(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) ([]apisdeployment.ModelDeployment, error) {
+func (_m *Repository) GetModelDeploymentList(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) ([]deployment.ModelDeployment, error) {
 	_va := make([]interface{}, len(options))
 	for _i := range options {
 		_va[_i] = options[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, tx)
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, tx)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 []apisdeployment.ModelDeployment
-	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, ...filter.ListOption) []apisdeployment.ModelDeployment); ok {
+	var r0 []deployment.ModelDeployment
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, ...filter.ListOption) []deployment.ModelDeployment); ok {
 		r0 = rf(ctx, tx, options...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]apisdeployment.ModelDeployment)
+			r0 = ret.Get(0).([]deployment.ModelDeployment)
 		}
 	}
 
@@ -125,6 +133,87 @@ func (_m *Repository) GetModelDeploymentList(ctx context.Context, tx *sql.Tx, op
 	return r0, r1
 }
 
+// GetRevision provides a mock function with given fields: ctx, tx, id, revision
+func (_m *Repository) GetRevision(ctx context.Context, tx *sql.Tx, id string, revision int) (deployment.ModelDeploymentRevision, error) {
+	ret := _m.Called(ctx, tx, id, revision)
+
+	var r0 deployment.ModelDeploymentRevision
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, string, int) deployment.ModelDeploymentRevision); ok {
+		r0 = rf(ctx, tx, id, revision)
+	} else {
+		r0 = ret.Get(0).(deployment.ModelDeploymentRevision)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sql.Tx, string, int) error); ok {
+		r1 = rf(ctx, tx, id, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisionList provides a mock function with given fields: ctx, tx, id, options
+func (_m *Repository) GetRevisionList(ctx context.Context, tx *sql.Tx, id string, options ...filter.ListOption) ([]deployment.ModelDeploymentRevision, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, tx)
+	_ca = append(_ca, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []deployment.ModelDeploymentRevision
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, string, ...filter.ListOption) []deployment.ModelDeploymentRevision); ok {
+		r0 = rf(ctx, tx, id, options...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]deployment.ModelDeploymentRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sql.Tx, string, ...filter.ListOption) error); ok {
+		r1 = rf(ctx, tx, id, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveModelDeployment provides a mock function with given fields: ctx, tx, md
+func (_m *Repository) SaveModelDeployment(ctx context.Context, tx *sql.Tx, md *deployment.ModelDeployment) error {
+	ret := _m.Called(ctx, tx, md)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, *deployment.ModelDeployment) error); ok {
+		r0 = rf(ctx, tx, md)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRevision provides a mock function with given fields: ctx, tx, rev
+func (_m *Repository) SaveRevision(ctx context.Context, tx *sql.Tx, rev deployment.ModelDeploymentRevision) error {
+	ret := _m.Called(ctx, tx, rev)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, deployment.ModelDeploymentRevision) error); ok {
+		r0 = rf(ctx, tx, rev)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDeletionMark provides a mock function with given fields: ctx, tx, id, value
 func (_m *Repository) SetDeletionMark(ctx context.Context, tx *sql.Tx, id string, value bool) error {
 	ret := _m.Called(ctx, tx, id, value)
@@ -140,11 +229,11 @@ func (_m *Repository) SetDeletionMark(ctx context.Context, tx *sql.Tx, id string
 }
 
 // UpdateModelDeployment provides a mock function with given fields: ctx, tx, md
-func (_m *Repository) UpdateModelDeployment(ctx context.Context, tx *sql.Tx, md *apisdeployment.ModelDeployment) error {
+func (_m *Repository) UpdateModelDeployment(ctx context.Context, tx *sql.Tx, md *deployment.ModelDeployment) error {
 	ret := _m.Called(ctx, tx, md)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, *apisdeployment.ModelDeployment) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, *deployment.ModelDeployment) error); ok {
 		r0 = rf(ctx, tx, md)
 	} else {
 		r0 = ret.Error(0)
@@ -0,0 +1,214 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+)
+
+const PredictorTable = "odahu_operator_predictor"
+
+// Persistence repository of predictors which are registered at runtime
+type PredictorRepo struct {
+	DB *sql.DB
+}
+
+func (repo PredictorRepo) Create(ctx context.Context, tx *sql.Tx, p deployment.Predictor) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Insert(PredictorTable).
+		Columns("id", "spec", "created", "updated").
+		Values(p.ID, p.Spec, p.CreatedAt, p.UpdatedAt).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		pqError, ok := err.(*pq.Error)
+		if ok && pqError.Code == uniqueViolationPostgresCode {
+			return odahuErrors.AlreadyExistError{Entity: p.ID}
+		}
+		return err
+	}
+	return nil
+}
+
+func (repo PredictorRepo) Get(ctx context.Context, tx *sql.Tx, id string) (res deployment.Predictor, err error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	query, args, err := sq.Select("id", "spec", "created", "updated").
+		From(PredictorTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return res, err
+	}
+
+	err = qrr.QueryRowContext(ctx, query, args...).Scan(&res.ID, &res.Spec, &res.CreatedAt, &res.UpdatedAt)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return res, odahuErrors.NotFoundError{Entity: id}
+	case err != nil:
+		log.Error(err, "error during sql query")
+		return res, err
+	default:
+		return res, nil
+	}
+}
+
+func (repo PredictorRepo) List(
+	ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []deployment.Predictor, err error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	listOptions := &filter.ListOptions{
+		Filter: nil,
+		Page:   &FirstPage,
+		Size:   &MaxSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	offset := *listOptions.Size * (*listOptions.Page)
+
+	stmt, args, err := sq.Select("id", "spec", "created", "updated").
+		From(PredictorTable).
+		OrderBy("id").
+		Offset(uint64(offset)).
+		Limit(uint64(*listOptions.Size)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	// To avoid nil
+	res = make([]deployment.Predictor, 0)
+
+	for rows.Next() {
+		p := deployment.Predictor{}
+		if err := rows.Scan(&p.ID, &p.Spec, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (repo PredictorRepo) Update(ctx context.Context, tx *sql.Tx, p deployment.Predictor) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(PredictorTable).
+		Set("spec", p.Spec).
+		Set("updated", p.UpdatedAt).
+		Where(sq.Eq{"id": p.ID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execPredictorStatement(ctx, qrr, p.ID, stmt, args)
+}
+
+func (repo PredictorRepo) Delete(ctx context.Context, tx *sql.Tx, id string) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Delete(PredictorTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execPredictorStatement(ctx, qrr, id, stmt, args)
+}
+
+func (repo PredictorRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return repo.DB.BeginTx(ctx, txOptions)
+}
+
+// execPredictorStatement executes the statement and returns NotFoundError if no rows were affected
+func execPredictorStatement(ctx context.Context, qrr utils.Querier, id string, stmt string, args []interface{}) error {
+	result, err := qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return odahuErrors.NotFoundError{Entity: id}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	postgres_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/postgres"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"odahu-commons/predictors"
+	"testing"
+	"time"
+)
+
+const predictorID = "torchserve"
+
+func TestPredictorRepository(t *testing.T) {
+	req := require.New(t)
+	repo := postgres_repo.PredictorRepo{DB: db}
+	ctx := context.Background()
+	defer func() {
+		err := repo.Delete(ctx, nil, predictorID)
+		if err != nil && !odahuErrors.IsNotFoundError(err) {
+			t.Fatal(err)
+		}
+	}()
+
+	created := deployment.Predictor{
+		ID:        predictorID,
+		CreatedAt: time.Now().Round(time.Microsecond),
+		UpdatedAt: time.Now().Round(time.Microsecond),
+		Spec: deployment.PredictorSpec{
+			Ports:                  []corev1.ContainerPort{{Name: "http1", ContainerPort: 8080}},
+			InferenceEndpointRegex: ".*/predictions/.*",
+			Inspector:              deployment.PredictorInspector{Type: predictors.OpenAPIInspector, OpenAPIPath: "/"},
+		},
+	}
+	req.NoError(repo.Create(ctx, nil, created))
+	req.True(odahuErrors.IsAlreadyExistError(repo.Create(ctx, nil, created)))
+
+	created.Spec.Inspector.OpenAPIPath = "/api-description"
+	req.NoError(repo.Update(ctx, nil, created))
+
+	fetched, err := repo.Get(ctx, nil, predictorID)
+	req.NoError(err)
+	req.Equal(created.Spec, fetched.Spec)
+
+	list, err := repo.List(ctx, nil)
+	req.NoError(err)
+	req.Len(list, 1)
+
+	req.NoError(repo.Delete(ctx, nil, predictorID))
+	_, err = repo.Get(ctx, nil, predictorID)
+	req.True(odahuErrors.IsNotFoundError(err))
+	req.True(odahuErrors.IsNotFoundError(repo.Update(ctx, nil, created)))
+}
@@ -0,0 +1,161 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+)
+
+const ModelDeploymentRevisionTable = "odahu_operator_deployment_revision"
+
+func revisionID(id string, revision int) string {
+	return fmt.Sprintf("%s/%d", id, revision)
+}
+
+func (repo DeploymentRepo) SaveRevision(
+	ctx context.Context, tx *sql.Tx, rev deployment.ModelDeploymentRevision) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Insert(ModelDeploymentRevisionTable).
+		Columns("deployment_id", "revision", "created", "spec").
+		Values(rev.DeploymentID, rev.Revision, rev.CreatedAt, rev.Spec).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolationPostgresCode {
+		return odahuErrors.AlreadyExistError{Entity: revisionID(rev.DeploymentID, rev.Revision)}
+	}
+	return err
+}
+
+func (repo DeploymentRepo) GetRevision(
+	ctx context.Context, tx *sql.Tx, id string, revision int) (res deployment.ModelDeploymentRevision, err error) {
+
+	revisions, err := repo.queryRevisions(ctx, tx, sq.Eq{"deployment_id": id, "revision": revision}, nil)
+	if err != nil {
+		return res, err
+	}
+	if len(revisions) == 0 {
+		return res, odahuErrors.NotFoundError{Entity: revisionID(id, revision)}
+	}
+	return revisions[0], nil
+}
+
+// GetRevisionList returns revisions of the deployment starting from the latest one
+func (repo DeploymentRepo) GetRevisionList(
+	ctx context.Context, tx *sql.Tx, id string, options ...filter.ListOption,
+) ([]deployment.ModelDeploymentRevision, error) {
+
+	listOptions := &filter.ListOptions{
+		Page: &FirstPage,
+		Size: &MaxSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	return repo.queryRevisions(ctx, tx, sq.Eq{"deployment_id": id}, func(sb sq.SelectBuilder) sq.SelectBuilder {
+		return sb.OrderBy("revision DESC").
+			Offset(uint64(*listOptions.Size * (*listOptions.Page))).
+			Limit(uint64(*listOptions.Size))
+	})
+}
+
+// GetLastRevision returns the number of the latest revision of the deployment or 0 if there are no revisions
+func (repo DeploymentRepo) GetLastRevision(ctx context.Context, tx *sql.Tx, id string) (int, error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Select("COALESCE(MAX(revision), 0)").
+		From(ModelDeploymentRevisionTable).
+		Where(sq.Eq{"deployment_id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var revision int
+	err = qrr.QueryRowContext(ctx, stmt, args...).Scan(&revision)
+	return revision, err
+}
+
+func (repo DeploymentRepo) queryRevisions(
+	ctx context.Context, tx *sql.Tx, where sq.Sqlizer,
+	modify func(sb sq.SelectBuilder) sq.SelectBuilder) (res []deployment.ModelDeploymentRevision, err error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	sb := sq.Select("deployment_id", "revision", "created", "spec").
+		From(ModelDeploymentRevisionTable).
+		Where(where).
+		PlaceholderFormat(sq.Dollar)
+	if modify != nil {
+		sb = modify(sb)
+	}
+
+	stmt, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	res = make([]deployment.ModelDeploymentRevision, 0)
+	for rows.Next() {
+		var rev deployment.ModelDeploymentRevision
+		if err := rows.Scan(&rev.DeploymentID, &rev.Revision, &rev.CreatedAt, &rev.Spec); err != nil {
+			return nil, err
+		}
+		res = append(res, rev)
+	}
+	return res, rows.Err()
+}
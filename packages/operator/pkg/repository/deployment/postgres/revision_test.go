@@ -0,0 +1,79 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	postgres_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestModelDeploymentRevisions(t *testing.T) {
+
+	repo := postgres_repo.DeploymentRepo{DB: db}
+	ctx := context.Background()
+	as := assert.New(t)
+
+	as.NoError(repo.SaveModelDeployment(ctx, nil, &deployment.ModelDeployment{ID: mdID}))
+	defer func() {
+		as.NoError(repo.DeleteModelDeployment(ctx, nil, mdID))
+		// Revisions are deleted together with the deployment
+		last, err := repo.GetLastRevision(ctx, nil, mdID)
+		as.NoError(err)
+		as.Zero(last)
+	}()
+
+	last, err := repo.GetLastRevision(ctx, nil, mdID)
+	as.NoError(err)
+	as.Zero(last)
+
+	for i, image := range []string{"image:1", "image:2", "image:3"} {
+		as.NoError(repo.SaveRevision(ctx, nil, deployment.ModelDeploymentRevision{
+			DeploymentID: mdID,
+			Revision:     i + 1,
+			CreatedAt:    time.Now().Round(time.Microsecond),
+			Spec:         v1alpha1.ModelDeploymentSpec{Image: image},
+		}))
+	}
+	as.Exactly(odahuErrors.AlreadyExistError{Entity: mdID + "/3"}, repo.SaveRevision(
+		ctx, nil, deployment.ModelDeploymentRevision{DeploymentID: mdID, Revision: 3, CreatedAt: time.Now()},
+	))
+
+	last, err = repo.GetLastRevision(ctx, nil, mdID)
+	as.NoError(err)
+	as.Equal(3, last)
+
+	rev, err := repo.GetRevision(ctx, nil, mdID, 2)
+	as.NoError(err)
+	as.Equal("image:2", rev.Spec.Image)
+
+	_, err = repo.GetRevision(ctx, nil, mdID, 4)
+	as.Exactly(odahuErrors.NotFoundError{Entity: mdID + "/4"}, err)
+
+	revisions, err := repo.GetRevisionList(ctx, nil, mdID, filter.Size(2), filter.Page(0))
+	as.NoError(err)
+	as.Len(revisions, 2)
+	as.Equal(3, revisions[0].Revision)
+	as.Equal(2, revisions[1].Revision)
+}
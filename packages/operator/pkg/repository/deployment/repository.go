@@ -37,9 +37,16 @@ type Repository interface {
 	UpdateModelDeploymentStatus(ctx context.Context, tx *sql.Tx, id string, s v1alpha1.ModelDeploymentStatus) error
 	SaveModelDeployment(ctx context.Context, tx *sql.Tx, md *deployment.ModelDeployment) error
 	SetDeletionMark(ctx context.Context, tx *sql.Tx, id string, value bool) error
+	SaveRevision(ctx context.Context, tx *sql.Tx, rev deployment.ModelDeploymentRevision) error
+	GetRevision(ctx context.Context, tx *sql.Tx, id string, revision int) (deployment.ModelDeploymentRevision, error)
+	GetRevisionList(
+		ctx context.Context, tx *sql.Tx, id string, options ...filter.ListOption,
+	) ([]deployment.ModelDeploymentRevision, error)
+	GetLastRevision(ctx context.Context, tx *sql.Tx, id string) (int, error)
 	BeginTransaction(ctx context.Context) (*sql.Tx, error)
 }
 
 type MdFilter struct {
-	RoleName []string `name:"roleName" postgres:"spec->>'roleName'"`
+	RoleName  []string `name:"roleName" postgres:"spec->>'roleName'"`
+	Predictor []string `name:"predictor" postgres:"spec->>'predictor'"`
 }
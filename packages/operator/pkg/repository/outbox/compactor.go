@@ -0,0 +1,117 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"time"
+)
+
+const (
+	CompactionTable   = "odahu_outbox_compaction"
+	CompactedUntilCol = "compacted_until"
+)
+
+// Compactor removes superseded events from the outbox event log
+type Compactor struct {
+	DB *sql.DB
+}
+
+// Compact removes events which are older than the retention period and are superseded by
+// a newer event of the same entity. So the last event of each entity is never removed and
+// the log always contains a snapshot of entities. Compaction watermark of each event group is moved
+// to the ID of the last removed event, that allows getters to detect compacted cursors.
+// Returns a number of removed events.
+func (c Compactor) Compact(ctx context.Context, retention time.Duration) (removed int, err error) {
+	tx, err := c.DB.BeginTx(ctx, txOptions)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	stmt, args, err := sq.
+		Delete(Table + " AS o").
+		Where(sq.Lt{"o." + DatetimeCol: time.Now().Add(-retention)}).
+		Where(fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM %[1]s AS n WHERE n.%[2]s = o.%[2]s AND n.%[3]s = o.%[3]s AND n.%[4]s > o.%[4]s)",
+			Table, EventGroupCol, EntityIDCol, IDCol,
+		)).
+		Suffix(fmt.Sprintf("RETURNING o.%s, o.%s", EventGroupCol, IDCol)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	watermarks := make(map[event.Group]int)
+	for rows.Next() {
+		var group event.Group
+		var id int
+		if err = rows.Scan(&group, &id); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		removed++
+		if id > watermarks[group] {
+			watermarks[group] = id
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("error during rows iteration: %v", err)
+	}
+	if err = rows.Close(); err != nil {
+		return 0, err
+	}
+
+	for group, compactedUntil := range watermarks {
+		if err = moveWatermark(ctx, tx, group, compactedUntil); err != nil {
+			return 0, err
+		}
+	}
+
+	return removed, nil
+}
+
+func moveWatermark(ctx context.Context, tx *sql.Tx, group event.Group, compactedUntil int) error {
+	stmt, args, err := sq.
+		Insert(CompactionTable).
+		Columns(EventGroupCol, CompactedUntilCol).
+		Values(group, compactedUntil).
+		Suffix(fmt.Sprintf(
+			"ON CONFLICT (%[1]s) DO UPDATE SET %[2]s = GREATEST(%[3]s.%[2]s, EXCLUDED.%[2]s)",
+			EventGroupCol, CompactedUntilCol, CompactionTable,
+		)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, stmt, args...)
+	return err
+}
@@ -6,105 +6,470 @@ import (
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"sort"
+	"time"
 )
 
-type RouteEventGetter struct {
-	DB *sql.DB
+// DefaultHistoryPageSize is the maximal number of events returned by Get of event getters
+// if their PageSize is not set
+const DefaultHistoryPageSize = 1000
+
+var readTxOptions = &sql.TxOptions{
+	Isolation: sql.LevelRepeatableRead,
+	ReadOnly:  true,
 }
 
-func (rer RouteEventGetter) Get(
-	ctx context.Context, cursor int) (routes []event.RouteEvent, newCursor int, err error)  {
-	stmt, args, err := sq.
+// rawEvent is an outbox row with not parsed payload
+type rawEvent struct {
+	ID        int
+	EntityID  string
+	EventType event.Type
+	Payload   []byte
+	Datetime  time.Time
+}
+
+// getHistory returns at most pageSize events of the group after the cursor.
+// CursorCompactedError is returned if some of these events were already removed by compaction
+func getHistory(
+	ctx context.Context, db *sql.DB, group event.Group, cursor int, pageSize int,
+) (events []rawEvent, err error) {
+	tx, err := db.BeginTx(ctx, readTxOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	// Watermark and events must be read in the same transaction. Otherwise compaction could be
+	// committed between these reads and consumer would silently lose events
+	compactedUntil, err := getCompactedUntil(ctx, tx, group)
+	if err != nil {
+		return nil, err
+	}
+	// Zero cursor means that consumer has no state, so the compacted log is a consistent view for it
+	if cursor != 0 && cursor < compactedUntil {
+		return nil, odahu_errors.CursorCompactedError{Cursor: cursor, CompactedUntil: compactedUntil}
+	}
+
+	return queryEvents(ctx, tx, sq.
 		Select(IDCol, EntityIDCol, EventTypeCol, PayloadCol, DatetimeCol).
 		From(Table).
-		Where(sq.Eq{EventGroupCol: event.ModelRouteEventGroup}).
+		Where(sq.Eq{EventGroupCol: group}).
 		Where(sq.Gt{IDCol: cursor}).
-		PlaceholderFormat(sq.Dollar).
 		OrderBy(IDCol).
-		ToSql()
+		Limit(uint64(historyPageSize(pageSize))),
+	)
+}
+
+func historyPageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return DefaultHistoryPageSize
+	}
+	return pageSize
+}
+
+// getSnapshot returns the last event of each entity of the group ordered by ID
+func getSnapshot(ctx context.Context, db *sql.DB, group event.Group) (events []rawEvent, err error) {
+	tx, err := db.BeginTx(ctx, readTxOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
 
+	events, err = queryEvents(ctx, tx, sq.
+		Select(IDCol, EntityIDCol, EventTypeCol, PayloadCol, DatetimeCol).
+		Options(fmt.Sprintf("DISTINCT ON (%s)", EntityIDCol)).
+		From(Table).
+		Where(sq.Eq{EventGroupCol: group}).
+		OrderBy(EntityIDCol, IDCol+" DESC"),
+	)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	var rows *sql.Rows
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
 
-	rows, err = rer.DB.QueryContext(ctx, stmt, args...)
+func getCompactedUntil(ctx context.Context, tx *sql.Tx, group event.Group) (compactedUntil int, err error) {
+	stmt, args, err := sq.
+		Select(CompactedUntilCol).
+		From(CompactionTable).
+		Where(sq.Eq{EventGroupCol: group}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
 	if err != nil {
-		return
+		return 0, err
 	}
 
-	for rows.Next() {
-		var e event.RouteEvent
-		if err = rows.Scan(&newCursor, &e.EntityID, &e.EventType, &e.Payload, &e.Datetime); err != nil {
-			log.Error(err, "Unable to scan route")
-			return routes, newCursor, err
+	err = tx.QueryRowContext(ctx, stmt, args...).Scan(&compactedUntil)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return compactedUntil, err
+}
+
+func queryEvents(ctx context.Context, tx *sql.Tx, sb sq.SelectBuilder) (events []rawEvent, err error) {
+	stmt, args, err := sb.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
 		}
-		availableTypes := []event.Type{
-			event.ModelRouteCreatedEventType, event.ModelRouteUpdatedEventType, event.ModelRouteDeletedEventType,
-			event.ModelRouteDeletionMarkIsSetEventType, event.ModelRouteStatusUpdatedEventType,
+	}()
+
+	for rows.Next() {
+		var e rawEvent
+		if err = rows.Scan(&e.ID, &e.EntityID, &e.EventType, &e.Payload, &e.Datetime); err != nil {
+			log.Error(err, "Unable to scan event")
+			return nil, err
 		}
-		if !EventTypeOK(availableTypes, e.EventType) {
-			log.Error(fmt.Errorf("unknown event for ModelRouteEventGroup: %v", e.EventType), "")
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		err = fmt.Errorf("error during rows iteration: %v", err)
+	}
+
+	return events, err
+}
+
+// convertEvents calls convert for each event of known type and returns the cursor of the last event.
+// Events of unknown types are skipped, but the cursor is moved past them
+func convertEvents(raws []rawEvent, cursor int, group event.Group, types []event.Type,
+	convert func(raw rawEvent) error) (newCursor int, err error) {
+	newCursor = cursor
+	for _, raw := range raws {
+		newCursor = raw.ID
+		if !EventTypeOK(types, raw.EventType) {
+			log.Error(fmt.Errorf("unknown event for %s event group: %v", group, raw.EventType), "")
 			continue
 		}
 
-		routes = append(routes, e)
+		if err = convert(raw); err != nil {
+			log.Error(err, "Unable to scan event payload", "group", group)
+			return newCursor, err
+		}
 	}
 
-	if err = rows.Err(); err != nil {
-		err = fmt.Errorf("error during rows iteration: %v", err)
+	return newCursor, nil
+}
+
+// scanPayload parses payload of the event. NULL payload leaves dest untouched
+func scanPayload(e rawEvent, dest sql.Scanner) error {
+	if e.Payload == nil {
+		return nil
+	}
+	return dest.Scan(e.Payload)
+}
+
+var routeEventTypes = []event.Type{
+	event.ModelRouteCreatedEventType, event.ModelRouteUpdatedEventType, event.ModelRouteDeletedEventType,
+	event.ModelRouteDeletionMarkIsSetEventType, event.ModelRouteStatusUpdatedEventType,
+}
+
+type RouteEventGetter struct {
+	DB *sql.DB
+	// Maximal number of events returned by Get. DefaultHistoryPageSize is used if it is zero
+	PageSize int
+}
+
+// Get returns at most PageSize ModelRoute events after the cursor and the cursor of the last event.
+// Next events are returned by Get with the returned cursor.
+// CursorCompactedError is returned if the cursor was compacted, use Snapshot to resume in this case
+func (g RouteEventGetter) Get(
+	ctx context.Context, cursor int) (routes []event.RouteEvent, newCursor int, err error) {
+	raws, err := getHistory(ctx, g.DB, event.ModelRouteEventGroup, cursor, g.PageSize)
+	if err != nil {
+		return nil, cursor, err
 	}
 
+	return toRouteEvents(raws, cursor)
+}
+
+// Snapshot returns the last event of each ModelRoute and the cursor to resume from
+func (g RouteEventGetter) Snapshot(
+	ctx context.Context) (routes []event.RouteEvent, newCursor int, err error) {
+	raws, err := getSnapshot(ctx, g.DB, event.ModelRouteEventGroup)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toRouteEvents(raws, 0)
+}
+
+func toRouteEvents(
+	raws []rawEvent, cursor int) (routes []event.RouteEvent, newCursor int, err error) {
+	convert := func(raw rawEvent) error {
+		e := event.RouteEvent{EntityID: raw.EntityID, EventType: raw.EventType, Datetime: raw.Datetime}
+		if err := scanPayload(raw, &e.Payload); err != nil {
+			return err
+		}
+		routes = append(routes, e)
+		return nil
+	}
+	newCursor, err = convertEvents(raws, cursor, event.ModelRouteEventGroup, routeEventTypes, convert)
+
 	return routes, newCursor, err
 }
 
+var deploymentEventTypes = []event.Type{
+	event.ModelDeploymentCreatedEventType, event.ModelDeploymentUpdatedEventType,
+	event.ModelDeploymentDeletedEventType, event.ModelDeploymentStatusUpdatedEventType,
+	event.ModelDeploymentDeletionMarkIsSetEventType,
+}
+
 type DeploymentEventGetter struct {
 	DB *sql.DB
+	// Maximal number of events returned by Get. DefaultHistoryPageSize is used if it is zero
+	PageSize int
 }
 
-func (rer DeploymentEventGetter) Get(ctx context.Context, cursor int) (routes []event.DeploymentEvent,
-	newCursor int, err error)  {
+// Get returns at most PageSize ModelDeployment events after the cursor and the cursor of the last event.
+// Next events are returned by Get with the returned cursor.
+// CursorCompactedError is returned if the cursor was compacted, use Snapshot to resume in this case
+func (g DeploymentEventGetter) Get(
+	ctx context.Context, cursor int) (deps []event.DeploymentEvent, newCursor int, err error) {
+	raws, err := getHistory(ctx, g.DB, event.ModelDeploymentEventGroup, cursor, g.PageSize)
+	if err != nil {
+		return nil, cursor, err
+	}
 
-	stmt, args, err := sq.
-		Select(IDCol, EntityIDCol, EventTypeCol, PayloadCol, DatetimeCol).
-		From(Table).
-		Where(sq.Eq{EventGroupCol: event.ModelDeploymentEventGroup}).
-		Where(sq.Gt{IDCol: cursor}).
-		PlaceholderFormat(sq.Dollar).
-		OrderBy(IDCol).
-		ToSql()
+	return toDeploymentEvents(raws, cursor)
+}
 
+// Snapshot returns the last event of each ModelDeployment and the cursor to resume from
+func (g DeploymentEventGetter) Snapshot(
+	ctx context.Context) (deps []event.DeploymentEvent, newCursor int, err error) {
+	raws, err := getSnapshot(ctx, g.DB, event.ModelDeploymentEventGroup)
 	if err != nil {
-		return
+		return nil, 0, err
 	}
 
-	var rows *sql.Rows
+	return toDeploymentEvents(raws, 0)
+}
+
+func toDeploymentEvents(
+	raws []rawEvent, cursor int) (deps []event.DeploymentEvent, newCursor int, err error) {
+	convert := func(raw rawEvent) error {
+		e := event.DeploymentEvent{EntityID: raw.EntityID, EventType: raw.EventType, Datetime: raw.Datetime}
+		if err := scanPayload(raw, &e.Payload); err != nil {
+			return err
+		}
+		deps = append(deps, e)
+		return nil
+	}
+	newCursor, err = convertEvents(raws, cursor, event.ModelDeploymentEventGroup, deploymentEventTypes, convert)
 
-	rows, err = rer.DB.QueryContext(ctx, stmt, args...)
+	return deps, newCursor, err
+}
+
+var trainingEventTypes = []event.Type{
+	event.ModelTrainingCreatedEventType, event.ModelTrainingUpdatedEventType,
+	event.ModelTrainingDeletedEventType, event.ModelTrainingStatusUpdatedEventType,
+}
+
+type TrainingEventGetter struct {
+	DB *sql.DB
+	// Maximal number of events returned by Get. DefaultHistoryPageSize is used if it is zero
+	PageSize int
+}
+
+// Get returns at most PageSize ModelTraining events after the cursor and the cursor of the last event.
+// Next events are returned by Get with the returned cursor.
+// CursorCompactedError is returned if the cursor was compacted, use Snapshot to resume in this case
+func (g TrainingEventGetter) Get(
+	ctx context.Context, cursor int) (trainings []event.TrainingEvent, newCursor int, err error) {
+	raws, err := getHistory(ctx, g.DB, event.ModelTrainingEventGroup, cursor, g.PageSize)
 	if err != nil {
-		return
+		return nil, cursor, err
 	}
 
-	for rows.Next() {
-		var e event.DeploymentEvent
-		if err = rows.Scan(&newCursor, &e.EntityID, &e.EventType, &e.Payload, &e.Datetime); err != nil {
-			log.Error(err, "Unable to scan route")
-			return
+	return toTrainingEvents(raws, cursor)
+}
+
+// Snapshot returns the last event of each ModelTraining and the cursor to resume from
+func (g TrainingEventGetter) Snapshot(
+	ctx context.Context) (trainings []event.TrainingEvent, newCursor int, err error) {
+	raws, err := getSnapshot(ctx, g.DB, event.ModelTrainingEventGroup)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toTrainingEvents(raws, 0)
+}
+
+func toTrainingEvents(
+	raws []rawEvent, cursor int) (trainings []event.TrainingEvent, newCursor int, err error) {
+	convert := func(raw rawEvent) error {
+		e := event.TrainingEvent{EntityID: raw.EntityID, EventType: raw.EventType, Datetime: raw.Datetime}
+		if err := scanPayload(raw, &e.Payload); err != nil {
+			return err
 		}
+		trainings = append(trainings, e)
+		return nil
+	}
+	newCursor, err = convertEvents(raws, cursor, event.ModelTrainingEventGroup, trainingEventTypes, convert)
+
+	return trainings, newCursor, err
+}
+
+var packagingEventTypes = []event.Type{
+	event.ModelPackagingCreatedEventType, event.ModelPackagingUpdatedEventType,
+	event.ModelPackagingDeletedEventType, event.ModelPackagingStatusUpdatedEventType,
+}
+
+type PackagingEventGetter struct {
+	DB *sql.DB
+	// Maximal number of events returned by Get. DefaultHistoryPageSize is used if it is zero
+	PageSize int
+}
+
+// Get returns at most PageSize ModelPackaging events after the cursor and the cursor of the last event.
+// Next events are returned by Get with the returned cursor.
+// CursorCompactedError is returned if the cursor was compacted, use Snapshot to resume in this case
+func (g PackagingEventGetter) Get(
+	ctx context.Context, cursor int) (packagings []event.PackagingEvent, newCursor int, err error) {
+	raws, err := getHistory(ctx, g.DB, event.ModelPackagingEventGroup, cursor, g.PageSize)
+	if err != nil {
+		return nil, cursor, err
+	}
 
-		availableTypes := []event.Type{
-			event.ModelDeploymentCreatedEventType, event.ModelDeploymentUpdatedEventType, event.ModelDeploymentDeletedEventType,
-			event.ModelDeploymentStatusUpdatedEventType, event.ModelDeploymentDeletionMarkIsSetEventType,
+	return toPackagingEvents(raws, cursor)
+}
+
+// Snapshot returns the last event of each ModelPackaging and the cursor to resume from
+func (g PackagingEventGetter) Snapshot(
+	ctx context.Context) (packagings []event.PackagingEvent, newCursor int, err error) {
+	raws, err := getSnapshot(ctx, g.DB, event.ModelPackagingEventGroup)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toPackagingEvents(raws, 0)
+}
+
+func toPackagingEvents(
+	raws []rawEvent, cursor int) (packagings []event.PackagingEvent, newCursor int, err error) {
+	convert := func(raw rawEvent) error {
+		e := event.PackagingEvent{EntityID: raw.EntityID, EventType: raw.EventType, Datetime: raw.Datetime}
+		if err := scanPayload(raw, &e.Payload); err != nil {
+			return err
 		}
-		if !EventTypeOK(availableTypes, e.EventType) {
-			log.Error(fmt.Errorf("unknown event for ModelDeploymentEventGroup: %v", e.EventType), "")
-			continue
+		packagings = append(packagings, e)
+		return nil
+	}
+	newCursor, err = convertEvents(raws, cursor, event.ModelPackagingEventGroup, packagingEventTypes, convert)
+
+	return packagings, newCursor, err
+}
+
+var inferenceJobEventTypes = []event.Type{
+	event.InferenceJobCreatedEventType, event.InferenceJobDeletedEventType,
+	event.InferenceJobStatusUpdatedEventType,
+}
+
+type InferenceJobEventGetter struct {
+	DB *sql.DB
+	// Maximal number of events returned by Get. DefaultHistoryPageSize is used if it is zero
+	PageSize int
+}
+
+// Get returns at most PageSize InferenceJob events after the cursor and the cursor of the last event.
+// Next events are returned by Get with the returned cursor.
+// CursorCompactedError is returned if the cursor was compacted, use Snapshot to resume in this case
+func (g InferenceJobEventGetter) Get(
+	ctx context.Context, cursor int) (jobs []event.InferenceJobEvent, newCursor int, err error) {
+	raws, err := getHistory(ctx, g.DB, event.InferenceJobEventGroup, cursor, g.PageSize)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	return toInferenceJobEvents(raws, cursor)
+}
+
+// Snapshot returns the last event of each InferenceJob and the cursor to resume from
+func (g InferenceJobEventGetter) Snapshot(
+	ctx context.Context) (jobs []event.InferenceJobEvent, newCursor int, err error) {
+	raws, err := getSnapshot(ctx, g.DB, event.InferenceJobEventGroup)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toInferenceJobEvents(raws, 0)
+}
+
+func toInferenceJobEvents(
+	raws []rawEvent, cursor int) (jobs []event.InferenceJobEvent, newCursor int, err error) {
+	convert := func(raw rawEvent) error {
+		e := event.InferenceJobEvent{EntityID: raw.EntityID, EventType: raw.EventType, Datetime: raw.Datetime}
+		if err := scanPayload(raw, &e.Payload); err != nil {
+			return err
 		}
-		routes = append(routes, e)
+		jobs = append(jobs, e)
+		return nil
 	}
-	if err = rows.Err(); err != nil {
-		err = fmt.Errorf("error during rows iteration: %v", err)
+	newCursor, err = convertEvents(raws, cursor, event.InferenceJobEventGroup, inferenceJobEventTypes, convert)
+
+	return jobs, newCursor, err
+}
+
+var inferenceServiceEventTypes = []event.Type{
+	event.InferenceServiceCreatedEventType, event.InferenceServiceUpdatedEventType,
+	event.InferenceServiceDeletedEventType,
+}
+
+type InferenceServiceEventGetter struct {
+	DB *sql.DB
+	// Maximal number of events returned by Get. DefaultHistoryPageSize is used if it is zero
+	PageSize int
+}
+
+// Get returns at most PageSize InferenceService events after the cursor and the cursor of the last event.
+// Next events are returned by Get with the returned cursor.
+// CursorCompactedError is returned if the cursor was compacted, use Snapshot to resume in this case
+func (g InferenceServiceEventGetter) Get(
+	ctx context.Context, cursor int) (services []event.InferenceServiceEvent, newCursor int, err error) {
+	raws, err := getHistory(ctx, g.DB, event.InferenceServiceEventGroup, cursor, g.PageSize)
+	if err != nil {
+		return nil, cursor, err
 	}
-	return routes, newCursor, err
-}
\ No newline at end of file
+
+	return toInferenceServiceEvents(raws, cursor)
+}
+
+// Snapshot returns the last event of each InferenceService and the cursor to resume from
+func (g InferenceServiceEventGetter) Snapshot(
+	ctx context.Context) (services []event.InferenceServiceEvent, newCursor int, err error) {
+	raws, err := getSnapshot(ctx, g.DB, event.InferenceServiceEventGroup)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toInferenceServiceEvents(raws, 0)
+}
+
+func toInferenceServiceEvents(
+	raws []rawEvent, cursor int) (services []event.InferenceServiceEvent, newCursor int, err error) {
+	convert := func(raw rawEvent) error {
+		e := event.InferenceServiceEvent{EntityID: raw.EntityID, EventType: raw.EventType, Datetime: raw.Datetime}
+		if err := scanPayload(raw, &e.Payload); err != nil {
+			return err
+		}
+		services = append(services, e)
+		return nil
+	}
+	newCursor, err = convertEvents(raws, cursor, event.InferenceServiceEventGroup, inferenceServiceEventTypes, convert)
+
+	return services, newCursor, err
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -131,4 +133,152 @@ func TestModelDeploymentGet(t *testing.T) {
 	stmt, _, _ := sq.Delete(outbox.Table).ToSql()
 	_, _ = db.Exec(stmt)
 
-}
\ No newline at end of file
+}
+
+func TestModelTrainingGetAndSnapshot(t *testing.T) {
+	eventPublisher := &outbox.EventPublisher{DB: db}
+	trainingEventGetter := &outbox.TrainingEventGetter{DB: db}
+
+	payload1 := training.ModelTraining{ID: "mt1", Spec: v1alpha1.ModelTrainingSpec{Toolchain: "mlflow"}}
+	event1 := event.Event{
+		EntityID: "mt1", EventType: event.ModelTrainingCreatedEventType,
+		EventGroup: event.ModelTrainingEventGroup, Datetime: time.Now().Round(time.Microsecond).UTC(),
+		Payload: payload1,
+	}
+	err := eventPublisher.PublishEvent(context.Background(), nil, event1)
+	assert.NoError(t, err)
+
+	payload2 := payload1
+	payload2.Status.State = v1alpha1.ModelTrainingSucceeded
+	event2 := event.Event{
+		EntityID: "mt1", EventType: event.ModelTrainingStatusUpdatedEventType,
+		EventGroup: event.ModelTrainingEventGroup, Datetime: time.Now().Round(time.Microsecond).UTC(),
+		Payload: payload2,
+	}
+	err = eventPublisher.PublishEvent(context.Background(), nil, event2)
+	assert.NoError(t, err)
+
+	// Events of other groups must not be returned
+	err = eventPublisher.PublishEvent(context.Background(), nil, event.Event{
+		EntityID: "md1", EventType: event.ModelDeploymentCreatedEventType,
+		EventGroup: event.ModelDeploymentEventGroup, Datetime: time.Now().Round(time.Microsecond).UTC(),
+		Payload: deployment.ModelDeployment{},
+	})
+	assert.NoError(t, err)
+
+	trainings, newC, err := trainingEventGetter.Get(context.TODO(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, trainings, 2)
+	assert.Equal(t, event.ModelTrainingCreatedEventType, trainings[0].EventType)
+	assert.Equal(t, payload1, trainings[0].Payload)
+	assert.Equal(t, event.ModelTrainingStatusUpdatedEventType, trainings[1].EventType)
+	assert.Equal(t, payload2, trainings[1].Payload)
+
+	// Snapshot contains only the last event of the training
+	snapshot, snapshotC, err := trainingEventGetter.Snapshot(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, snapshot, 1)
+	assert.Equal(t, event.ModelTrainingStatusUpdatedEventType, snapshot[0].EventType)
+	assert.Equal(t, payload2, snapshot[0].Payload)
+	assert.GreaterOrEqual(t, snapshotC, newC)
+
+	stmt, _, _ := sq.Delete(outbox.Table).ToSql()
+	_, _ = db.Exec(stmt)
+
+}
+
+func TestModelRouteHistoryAndCompaction(t *testing.T) {
+	eventPublisher := &outbox.EventPublisher{DB: db}
+	routeEventGetter := &outbox.RouteEventGetter{DB: db}
+	compactor := outbox.Compactor{DB: db}
+	defer func() {
+		stmt, _, _ := sq.Delete(outbox.Table).ToSql()
+		_, _ = db.Exec(stmt)
+		stmt, _, _ = sq.Delete(outbox.CompactionTable).ToSql()
+		_, _ = db.Exec(stmt)
+	}()
+
+	old := time.Now().Add(-time.Hour).Round(time.Microsecond).UTC()
+	for _, e := range []event.Event{
+		{EntityID: "route1", EventType: event.ModelRouteCreatedEventType, Datetime: old,
+			Payload: deployment.ModelRoute{ID: "route1"}},
+		{EntityID: "route1", EventType: event.ModelRouteUpdatedEventType, Datetime: old,
+			Payload: deployment.ModelRoute{ID: "route1"}},
+		{EntityID: "route2", EventType: event.ModelRouteCreatedEventType, Datetime: old,
+			Payload: deployment.ModelRoute{ID: "route2"}},
+		{EntityID: "route1", EventType: event.ModelRouteDeletedEventType, Datetime: old},
+	} {
+		e.EventGroup = event.ModelRouteEventGroup
+		assert.NoError(t, eventPublisher.PublishEvent(context.Background(), nil, e))
+	}
+
+	// All intermediate events are kept in the history
+	routes, cursor, err := routeEventGetter.Get(context.TODO(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, routes, 4)
+	_, firstCursor, err := routeEventGetter.Get(context.TODO(), cursor-4)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, firstCursor)
+
+	// Compaction respects retention period
+	removed, err := compactor.Compact(context.TODO(), 2*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	removed, err = compactor.Compact(context.TODO(), time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	// Cursor that points before compacted events can not be used anymore
+	_, _, err = routeEventGetter.Get(context.TODO(), cursor-3)
+	assert.True(t, odahu_errors.IsCursorCompactedError(err))
+	_, err = outbox.EventLog{DB: db}.GetAfter(context.TODO(), cursor-3, 10)
+	assert.True(t, odahu_errors.IsCursorCompactedError(err))
+	// Consumer without state reads the compacted log
+	records, err := outbox.EventLog{DB: db}.GetAfter(context.TODO(), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	// Snapshot contains the last event of each entity
+	routes, snapshotCursor, err := routeEventGetter.Snapshot(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, snapshotCursor)
+	assert.Len(t, routes, 2)
+	assert.Equal(t, "route2", routes[0].EntityID)
+	assert.Equal(t, event.ModelRouteCreatedEventType, routes[0].EventType)
+	assert.Equal(t, "route1", routes[1].EntityID)
+	assert.Equal(t, event.ModelRouteDeletedEventType, routes[1].EventType)
+
+	// Consumer can resume from the snapshot cursor
+	routes, newCursor, err := routeEventGetter.Get(context.TODO(), snapshotCursor)
+	assert.NoError(t, err)
+	assert.Len(t, routes, 0)
+	assert.Equal(t, snapshotCursor, newCursor)
+}
+
+func TestModelRouteGetPages(t *testing.T) {
+	eventPublisher := &outbox.EventPublisher{DB: db}
+	routeEventGetter := &outbox.RouteEventGetter{DB: db, PageSize: 2}
+	defer func() {
+		stmt, _, _ := sq.Delete(outbox.Table).ToSql()
+		_, _ = db.Exec(stmt)
+	}()
+
+	for _, id := range []string{"route1", "route2", "route3"} {
+		assert.NoError(t, eventPublisher.PublishEvent(context.Background(), nil, event.Event{
+			EntityID: id, EventType: event.ModelRouteCreatedEventType, EventGroup: event.ModelRouteEventGroup,
+			Datetime: time.Now().UTC(), Payload: deployment.ModelRoute{ID: id},
+		}))
+	}
+
+	routes, cursor, err := routeEventGetter.Get(context.TODO(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, routes, 2)
+	assert.Equal(t, "route2", routes[1].EntityID)
+
+	// Consumer continues from the returned cursor
+	routes, _, err = routeEventGetter.Get(context.TODO(), cursor)
+	assert.NoError(t, err)
+	assert.Len(t, routes, 1)
+	assert.Equal(t, "route3", routes[0].EntityID)
+}
@@ -0,0 +1,123 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+)
+
+// EventLog reads events of all groups from the outbox event log
+type EventLog struct {
+	DB *sql.DB
+}
+
+// LastID returns ID of the last event in the event log or zero if the log is empty
+func (l EventLog) LastID(ctx context.Context) (id int, err error) {
+	stmt, args, err := sq.
+		Select(fmt.Sprintf("COALESCE(MAX(%s), 0)", IDCol)).
+		From(Table).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = l.DB.QueryRowContext(ctx, stmt, args...).Scan(&id)
+	return id, err
+}
+
+// GetAfter returns at most limit events after the cursor ordered by ID.
+// CursorCompactedError is returned if events of any group after the cursor were already removed by compaction
+func (l EventLog) GetAfter(ctx context.Context, cursor int, limit int) (records []event.Record, err error) {
+	tx, err := l.DB.BeginTx(ctx, readTxOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	// Watermarks and events must be read in the same transaction, see getHistory
+	compactedUntil, err := getMaxCompactedUntil(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != 0 && cursor < compactedUntil {
+		return nil, odahu_errors.CursorCompactedError{Cursor: cursor, CompactedUntil: compactedUntil}
+	}
+
+	stmt, args, err := sq.
+		Select(IDCol, EntityIDCol, EventTypeCol, EventGroupCol, PayloadCol, DatetimeCol).
+		From(Table).
+		Where(sq.Gt{IDCol: cursor}).
+		OrderBy(IDCol).
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	for rows.Next() {
+		var r event.Record
+		// Payload is NULL for events without payload, for example for deletion events.
+		// Scanning to []byte is used because the driver buffer is copied only for this destination type
+		var payload []byte
+		if err = rows.Scan(&r.ID, &r.EntityID, &r.EventType, &r.EventGroup, &payload, &r.Datetime); err != nil {
+			log.Error(err, "Unable to scan event")
+			return nil, err
+		}
+		r.Payload = payload
+		records = append(records, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		err = fmt.Errorf("error during rows iteration: %v", err)
+	}
+
+	return records, err
+}
+
+// getMaxCompactedUntil returns the highest compaction watermark of all event groups
+func getMaxCompactedUntil(ctx context.Context, tx *sql.Tx) (compactedUntil int, err error) {
+	stmt, args, err := sq.
+		Select(fmt.Sprintf("COALESCE(MAX(%s), 0)", CompactedUntilCol)).
+		From(CompactionTable).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.QueryRowContext(ctx, stmt, args...).Scan(&compactedUntil)
+	return compactedUntil, err
+}
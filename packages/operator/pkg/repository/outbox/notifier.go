@@ -0,0 +1,121 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package outbox
+
+import (
+	"github.com/lib/pq"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"sync"
+	"time"
+)
+
+const (
+	// PostgreSQL channel which is notified by odahu_outbox_notify trigger about committed events.
+	// Payload of a notification is the event group
+	NotifyChannel = "odahu_outbox"
+
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+)
+
+// Notifier signals subscribers that new events of a group were committed to the event log.
+// Signals carry no data, subscribers are expected to read new events by their cursor.
+// Signals of a subscriber are coalesced while it is busy, so a signal can stand for several events
+type Notifier struct {
+	listener *pq.Listener
+
+	mu          sync.Mutex
+	subscribers map[event.Group]map[chan struct{}]struct{}
+}
+
+// NewNotifier starts listening for event log notifications using a dedicated database connection.
+// Connection is re-established automatically, Close must be called to release it
+func NewNotifier(connString string) *Notifier {
+	n := &Notifier{
+		subscribers: make(map[event.Group]map[chan struct{}]struct{}),
+	}
+	n.listener = pq.NewListener(connString, minReconnectInterval, maxReconnectInterval,
+		func(ev pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Error(err, "Event log listener connection problem", "event", ev)
+			}
+		},
+	)
+
+	go n.listen()
+	go n.run()
+
+	return n
+}
+
+// Subscribe returns a channel which receives a signal every time new events of the group are committed.
+// Returned function must be called to stop receiving signals
+func (n *Notifier) Subscribe(group event.Group) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.subscribers[group]; !ok {
+		n.subscribers[group] = make(map[chan struct{}]struct{})
+	}
+	n.subscribers[group][ch] = struct{}{}
+
+	return ch, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.subscribers[group], ch)
+	}
+}
+
+// Close stops listening and releases the database connection
+func (n *Notifier) Close() error {
+	return n.listener.Close()
+}
+
+func (n *Notifier) listen() {
+	// Listen blocks until the connection is established
+	if err := n.listener.Listen(NotifyChannel); err != nil {
+		log.Error(err, "Unable to listen for event log notifications", "channel", NotifyChannel)
+	}
+}
+
+func (n *Notifier) run() {
+	// Notify channel is closed by Listener.Close
+	for notification := range n.listener.Notify {
+		n.mu.Lock()
+		if notification == nil {
+			// Listener sends nil after reconnection, because notifications could be lost
+			for _, subs := range n.subscribers {
+				signal(subs)
+			}
+		} else {
+			signal(n.subscribers[event.Group(notification.Extra)])
+		}
+		n.mu.Unlock()
+	}
+}
+
+func signal(subs map[chan struct{}]struct{}) {
+	for ch := range subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// Subscriber has not handled the previous signal yet
+		}
+	}
+}
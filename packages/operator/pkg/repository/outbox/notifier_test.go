@@ -0,0 +1,48 @@
+package outbox_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNotifierSignalsCommittedEvents(t *testing.T) {
+	req := require.New(t)
+
+	notifier := outbox.NewNotifier(connString)
+	defer func() {
+		req.NoError(notifier.Close())
+	}()
+	routeNotifications, unsubscribeRoutes := notifier.Subscribe(event.ModelRouteEventGroup)
+	defer unsubscribeRoutes()
+	trainingNotifications, unsubscribeTrainings := notifier.Subscribe(event.ModelTrainingEventGroup)
+	defer unsubscribeTrainings()
+
+	// Listener connects in background, so events are published until the first signal
+	publisher := outbox.EventPublisher{DB: db}
+	deadline := time.After(10 * time.Second)
+	for received := false; !received; {
+		req.NoError(publisher.PublishEvent(context.Background(), nil, event.Event{
+			EntityID:   "mr",
+			EventType:  event.ModelRouteCreatedEventType,
+			EventGroup: event.ModelRouteEventGroup,
+		}))
+
+		select {
+		case <-routeNotifications:
+			received = true
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			req.FailNow("Notification was not received")
+		}
+	}
+
+	select {
+	case <-trainingNotifications:
+		req.Fail("Subscriber of another group must not be signaled")
+	default:
+	}
+}
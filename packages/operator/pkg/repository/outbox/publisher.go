@@ -58,26 +58,12 @@ func (repo EventPublisher) PublishEvent(ctx context.Context, tx *sql.Tx, event e
 		defer func(){db_utils.FinishTx(tx, err, log)}()
 	}
 
-	// First, delete previous event with the same ID, because outbox table currently stores
-	// Only last event with EntityID
-	stmt, args, err := sq.
-		Delete(Table).
-		Where(sq.Eq{EntityIDCol: event.EntityID, EventGroupCol: event.EventGroup}).
-		PlaceholderFormat(sq.Dollar).ToSql()
-	if err != nil {
-		return
-	}
-
-	_, err = tx.ExecContext(ctx, stmt, args...)
-	if err != nil {
-		return
-	}
-
 	if event.Datetime.IsZero() {
 		event.Datetime = time.Now()
 	}
 
-	stmt, args, err = sq.
+	// Outbox table is an append-only event log. Superseded events are removed only by Compactor
+	stmt, args, err := sq.
 		Insert(Table).
 		Columns(EntityIDCol, EventTypeCol, EventGroupCol, DatetimeCol, PayloadCol).
 		Values(event.EntityID, event.EventType, event.EventGroup, event.Datetime, event.Payload).
@@ -18,7 +18,8 @@ import (
 )
 
 var (
-	db *sql.DB
+	db         *sql.DB
+	connString string
 )
 
 func Wrapper(m *testing.M) int {
@@ -26,7 +27,7 @@ func Wrapper(m *testing.M) int {
 
 	var closeDB func() error
 	var err error
-	db, _, closeDB, err = testenvs.SetupTestDB()
+	db, connString, closeDB, err = testenvs.SetupTestDB()
 	defer func() {
 		if err := closeDB(); err != nil {
 			log.Print("Error during release test DB resources")
@@ -112,6 +113,12 @@ func TestRaiseEvent(t *testing.T) {
 	assert.NoError(t, rows.Scan(&e.EntityID, &e.EventType, &e.EventGroup, &e.Datetime, &p))
 	e.Datetime = e.Datetime.UTC()
 	e.Payload = p
+	assert.Equal(t, event1, e)
+
+	assert.True(t, rows.Next())
+	assert.NoError(t, rows.Scan(&e.EntityID, &e.EventType, &e.EventGroup, &e.Datetime, &p))
+	e.Datetime = e.Datetime.UTC()
+	e.Payload = p
 	assert.Equal(t, event2, e)
 
 	assert.True(t, rows.Next())
@@ -0,0 +1,233 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	PipelineTable               = "odahu_operator_pipeline"
+	uniqueViolationPostgresCode = pq.ErrorCode("23505") // unique_violation
+)
+
+var (
+	log       = logf.Log.WithName("pipeline-repository--postgres")
+	MaxSize   = 500
+	FirstPage = 0
+	txOptions = &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  false,
+	}
+)
+
+// Model pipeline persistence repository
+type PipelineRepo struct {
+	DB *sql.DB
+}
+
+func (r PipelineRepo) Create(ctx context.Context, tx *sql.Tx, p pipeline.ModelPipeline) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.
+		Insert(PipelineTable).
+		Columns("id", "spec", "status", "created", "updated").
+		Values(p.ID, p.Spec, p.Status, p.CreatedAt, p.UpdatedAt).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		pqError, ok := err.(*pq.Error)
+		if ok && pqError.Code == uniqueViolationPostgresCode {
+			return odahuErrors.AlreadyExistError{Entity: p.ID}
+		}
+		return err
+	}
+	return nil
+}
+
+func (r PipelineRepo) Get(ctx context.Context, tx *sql.Tx, id string) (res pipeline.ModelPipeline, err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	query, args, err := sq.
+		Select("id", "spec", "status", "created", "updated").
+		From(PipelineTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return res, err
+	}
+
+	err = qrr.QueryRowContext(ctx, query, args...).
+		Scan(&res.ID, &res.Spec, &res.Status, &res.CreatedAt, &res.UpdatedAt)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return res, odahuErrors.NotFoundError{Entity: id}
+	case err != nil:
+		log.Error(err, "error during sql query")
+		return res, err
+	default:
+		return res, nil
+	}
+}
+
+// List returns pipelines ordered by ID. Pipelines can be filtered by pipeline.Filter
+func (r PipelineRepo) List(
+	ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []pipeline.ModelPipeline, err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	listOptions := &filter.ListOptions{
+		Filter: nil,
+		Page:   &FirstPage,
+		Size:   &MaxSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	offset := *listOptions.Size * (*listOptions.Page)
+
+	sb := sq.Select("id", "spec", "status", "created", "updated").From(PipelineTable).
+		OrderBy("id").
+		Offset(uint64(offset)).
+		Limit(uint64(*listOptions.Size)).
+		PlaceholderFormat(sq.Dollar)
+
+	if listOptions.Filter != nil {
+		pipelineFilter, ok := listOptions.Filter.(*pipeline.Filter)
+		if !ok {
+			return nil, fmt.Errorf("unexpected filter type: %T", listOptions.Filter)
+		}
+		sb = utils.TransformFilter(sb, pipelineFilter)
+	}
+
+	stmt, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	// To avoid nil
+	res = make([]pipeline.ModelPipeline, 0)
+
+	for rows.Next() {
+		p := pipeline.ModelPipeline{}
+		if err := rows.Scan(&p.ID, &p.Spec, &p.Status, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r PipelineRepo) UpdateStatus(
+	ctx context.Context, tx *sql.Tx, id string, status pipeline.ModelPipelineStatus) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(PipelineTable).
+		Set("status", status).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execAffectingRow(ctx, qrr, id, stmt, args)
+}
+
+func (r PipelineRepo) Delete(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Delete(PipelineTable).Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execAffectingRow(ctx, qrr, id, stmt, args)
+}
+
+func (r PipelineRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return r.DB.BeginTx(ctx, txOptions)
+}
+
+// execAffectingRow executes the statement and returns NotFoundError if no rows were affected
+func execAffectingRow(ctx context.Context, qrr utils.Querier, id string, stmt string, args []interface{}) error {
+	result, err := qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return odahuErrors.NotFoundError{Entity: id}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	postgres_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/pipeline/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	pipelineID = "wine"
+)
+
+func TestPipelineRepository(t *testing.T) {
+	req := require.New(t)
+	repo := postgres_repo.PipelineRepo{DB: db}
+	ctx := context.TODO()
+	defer func() {
+		err := repo.Delete(ctx, nil, pipelineID)
+		if err != nil && !odahuErrors.IsNotFoundError(err) {
+			t.Fatal(err)
+		}
+	}()
+
+	created := pipeline.ModelPipeline{
+		ID:        pipelineID,
+		CreatedAt: time.Now().Round(time.Microsecond),
+		UpdatedAt: time.Now().Round(time.Microsecond),
+		Status:    pipeline.ModelPipelineStatus{State: pipeline.Running},
+	}
+	created.Spec.Packaging.ArtifactName = pipeline.DefaultArtifactNameTemplate
+	req.NoError(repo.Create(ctx, nil, created))
+	req.True(odahuErrors.IsAlreadyExistError(repo.Create(ctx, nil, created)))
+
+	status := pipeline.ModelPipelineStatus{
+		State:        pipeline.Running,
+		CurrentStage: pipeline.PackagingStage,
+		Stages: []pipeline.StageStatus{{
+			Stage:    pipeline.TrainingStage,
+			EntityID: pipelineID,
+			State:    "succeeded",
+			Results:  map[string]string{"artifactName": "wine-1"},
+		}},
+	}
+	req.NoError(repo.UpdateStatus(ctx, nil, pipelineID, status))
+
+	fetched, err := repo.Get(ctx, nil, pipelineID)
+	req.NoError(err)
+	req.Equal(created.Spec, fetched.Spec)
+	req.Equal(status, fetched.Status)
+
+	list, err := repo.List(ctx, nil, filter.ListFilter(&pipeline.Filter{State: []string{string(pipeline.Running)}}))
+	req.NoError(err)
+	req.Len(list, 1)
+
+	list, err = repo.List(ctx, nil, filter.ListFilter(&pipeline.Filter{State: []string{string(pipeline.Failed)}}))
+	req.NoError(err)
+	req.Len(list, 0)
+
+	req.NoError(repo.Delete(ctx, nil, pipelineID))
+	_, err = repo.Get(ctx, nil, pipelineID)
+	req.True(odahuErrors.IsNotFoundError(err))
+	req.True(odahuErrors.IsNotFoundError(repo.UpdateStatus(ctx, nil, pipelineID, status)))
+}
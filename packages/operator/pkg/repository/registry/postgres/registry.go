@@ -0,0 +1,367 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/registry"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	ModelTable                  = "odahu_model"
+	ModelVersionTable           = "odahu_model_version"
+	CursorTable                 = "odahu_model_registry_cursor"
+	uniqueViolationPostgresCode = pq.ErrorCode("23505") // unique_violation
+	// The cursor table has a single row with this ID
+	cursorRowID = 1
+)
+
+var (
+	log       = logf.Log.WithName("registry-repository--postgres")
+	MaxSize   = 500
+	FirstPage = 0
+	txOptions = &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  false,
+	}
+	versionColumns = []string{
+		"model", "version", "stage", "stage_updated", "created", "updated", "lineage",
+	}
+)
+
+// Model registry persistence repository
+type RegistryRepo struct {
+	DB *sql.DB
+}
+
+func (r RegistryRepo) GetModel(ctx context.Context, tx *sql.Tx, name string) (res registry.Model, err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	query, args, err := sq.
+		Select("name", "created", "updated", "latest_version").
+		From(ModelTable).
+		Where(sq.Eq{"name": name}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return res, err
+	}
+
+	err = qrr.QueryRowContext(ctx, query, args...).
+		Scan(&res.Name, &res.CreatedAt, &res.UpdatedAt, &res.LatestVersion)
+	if err == sql.ErrNoRows {
+		return res, odahuErrors.NotFoundError{Entity: name}
+	}
+	return res, err
+}
+
+func (r RegistryRepo) ListModels(
+	ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []registry.Model, err error) {
+
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	listOptions := &filter.ListOptions{
+		Page: &FirstPage,
+		Size: &MaxSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	stmt, args, err := sq.
+		Select("name", "created", "updated", "latest_version").
+		From(ModelTable).
+		OrderBy("name").
+		Offset(uint64(*listOptions.Size * (*listOptions.Page))).
+		Limit(uint64(*listOptions.Size)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	res = make([]registry.Model, 0)
+	for rows.Next() {
+		var m registry.Model
+		if err := rows.Scan(&m.Name, &m.CreatedAt, &m.UpdatedAt, &m.LatestVersion); err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
+
+// CreateVersion registers the version and makes it the latest version of the model.
+// The model is created if it is missed
+func (r RegistryRepo) CreateVersion(ctx context.Context, tx *sql.Tx, mv registry.ModelVersion) error {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.
+		Insert(ModelTable).
+		Columns("name", "created", "updated", "latest_version").
+		Values(mv.Model, mv.CreatedAt, mv.CreatedAt, mv.Version).
+		Suffix("ON CONFLICT (name) DO UPDATE SET updated = EXCLUDED.updated, latest_version = EXCLUDED.latest_version").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = qrr.ExecContext(ctx, stmt, args...); err != nil {
+		return err
+	}
+
+	stmt, args, err = sq.
+		Insert(ModelVersionTable).
+		Columns(append(versionColumns, "artifact_name")...).
+		Values(mv.Model, mv.Version, mv.Stage, mv.StageUpdatedAt, mv.CreatedAt, mv.UpdatedAt, mv.Lineage,
+			mv.Lineage.ArtifactName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	if pqError, ok := err.(*pq.Error); ok && pqError.Code == uniqueViolationPostgresCode {
+		return odahuErrors.AlreadyExistError{Entity: versionID(mv.Model, mv.Version)}
+	}
+	return err
+}
+
+// UpdateVersion updates stage and lineage of the version
+func (r RegistryRepo) UpdateVersion(ctx context.Context, tx *sql.Tx, mv registry.ModelVersion) error {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.
+		Update(ModelVersionTable).
+		Set("stage", mv.Stage).
+		Set("stage_updated", mv.StageUpdatedAt).
+		Set("updated", mv.UpdatedAt).
+		Set("lineage", mv.Lineage).
+		Set("artifact_name", mv.Lineage.ArtifactName).
+		Where(sq.Eq{"model": mv.Model, "version": mv.Version}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return odahuErrors.NotFoundError{Entity: versionID(mv.Model, mv.Version)}
+	}
+	return nil
+}
+
+func (r RegistryRepo) GetVersion(
+	ctx context.Context, tx *sql.Tx, model, version string) (res registry.ModelVersion, err error) {
+
+	versions, err := r.queryVersions(ctx, tx, sq.Eq{"model": model, "version": version}, nil)
+	if err != nil {
+		return res, err
+	}
+	if len(versions) == 0 {
+		return res, odahuErrors.NotFoundError{Entity: versionID(model, version)}
+	}
+	return versions[0], nil
+}
+
+// ListVersions returns versions of the model starting from the latest registered
+func (r RegistryRepo) ListVersions(
+	ctx context.Context, tx *sql.Tx, model string, options ...filter.ListOption) ([]registry.ModelVersion, error) {
+
+	listOptions := &filter.ListOptions{
+		Page: &FirstPage,
+		Size: &MaxSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	var versionFilter *registry.VersionFilter
+	if listOptions.Filter != nil {
+		var ok bool
+		versionFilter, ok = listOptions.Filter.(*registry.VersionFilter)
+		if !ok {
+			return nil, fmt.Errorf("unexpected filter type: %T", listOptions.Filter)
+		}
+	}
+
+	return r.queryVersions(ctx, tx, sq.Eq{"model": model}, func(sb sq.SelectBuilder) sq.SelectBuilder {
+		if versionFilter != nil {
+			sb = utils.TransformFilter(sb, versionFilter)
+		}
+		return sb.OrderBy("created DESC", "version").
+			Offset(uint64(*listOptions.Size * (*listOptions.Page))).
+			Limit(uint64(*listOptions.Size))
+	})
+}
+
+// FindVersionsByArtifact returns versions which were trained into the artifact
+func (r RegistryRepo) FindVersionsByArtifact(
+	ctx context.Context, tx *sql.Tx, artifactName string) ([]registry.ModelVersion, error) {
+	return r.queryVersions(ctx, tx, sq.Eq{"artifact_name": artifactName}, nil)
+}
+
+// FindVersionsByImage returns versions which were packaged into the image
+func (r RegistryRepo) FindVersionsByImage(
+	ctx context.Context, tx *sql.Tx, image string) ([]registry.ModelVersion, error) {
+	return r.queryVersions(ctx, tx, sq.Expr("lineage -> 'images' @> to_jsonb(?::text)", image), nil)
+}
+
+func (r RegistryRepo) queryVersions(
+	ctx context.Context, tx *sql.Tx, where sq.Sqlizer,
+	modify func(sb sq.SelectBuilder) sq.SelectBuilder) (res []registry.ModelVersion, err error) {
+
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	sb := sq.Select(versionColumns...).
+		From(ModelVersionTable).
+		Where(where).
+		PlaceholderFormat(sq.Dollar)
+	if modify != nil {
+		sb = modify(sb)
+	}
+
+	stmt, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	res = make([]registry.ModelVersion, 0)
+	for rows.Next() {
+		var mv registry.ModelVersion
+		if err := rows.Scan(
+			&mv.Model, &mv.Version, &mv.Stage, &mv.StageUpdatedAt, &mv.CreatedAt, &mv.UpdatedAt, &mv.Lineage,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, mv)
+	}
+	return res, rows.Err()
+}
+
+// GetCursor returns ID of the last event log record which was processed by the registry
+func (r RegistryRepo) GetCursor(ctx context.Context, tx *sql.Tx) (cursor int, err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	query, args, err := sq.
+		Select("cursor").
+		From(CursorTable).
+		Where(sq.Eq{"id": cursorRowID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = qrr.QueryRowContext(ctx, query, args...).Scan(&cursor)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return cursor, err
+}
+
+func (r RegistryRepo) SaveCursor(ctx context.Context, tx *sql.Tx, cursor int) error {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.
+		Insert(CursorTable).
+		Columns("id", "cursor").
+		Values(cursorRowID, cursor).
+		Suffix("ON CONFLICT (id) DO UPDATE SET cursor = EXCLUDED.cursor").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	return err
+}
+
+func (r RegistryRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return r.DB.BeginTx(ctx, txOptions)
+}
+
+func versionID(model, version string) string {
+	return fmt.Sprintf("%s/%s", model, version)
+}
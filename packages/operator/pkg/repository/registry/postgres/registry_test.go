@@ -0,0 +1,89 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/registry"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	registry_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/registry/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRegistryRepository(t *testing.T) {
+	req := require.New(t)
+	repo := registry_repo.RegistryRepo{DB: db}
+	ctx := context.TODO()
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	v1 := registry.ModelVersion{
+		Model: "wine", Version: "1.0", Stage: registry.NoneStage, CreatedAt: now, UpdatedAt: now,
+		Lineage: registry.Lineage{TrainingID: "wine-1", ArtifactName: "wine-1.0.zip"},
+	}
+	v2 := v1
+	v2.Version = "2.0"
+	v2.CreatedAt = now.Add(time.Minute)
+	v2.Lineage = registry.Lineage{TrainingID: "wine-2", ArtifactName: "wine-2.0.zip"}
+
+	req.NoError(repo.CreateVersion(ctx, nil, v1))
+	req.NoError(repo.CreateVersion(ctx, nil, v2))
+	req.True(odahuErrors.IsAlreadyExistError(repo.CreateVersion(ctx, nil, v1)))
+
+	model, err := repo.GetModel(ctx, nil, "wine")
+	req.NoError(err)
+	req.Equal("2.0", model.LatestVersion)
+
+	v1.Stage = registry.ProductionStage
+	v1.Lineage.Images = []string{"registry/wine:1.0"}
+	req.NoError(repo.UpdateVersion(ctx, nil, v1))
+
+	found, err := repo.FindVersionsByImage(ctx, nil, "registry/wine:1.0")
+	req.NoError(err)
+	req.Len(found, 1)
+	req.Equal("1.0", found[0].Version)
+
+	found, err = repo.FindVersionsByArtifact(ctx, nil, "wine-2.0.zip")
+	req.NoError(err)
+	req.Len(found, 1)
+	req.Equal("2.0", found[0].Version)
+
+	versions, err := repo.ListVersions(ctx, nil, "wine")
+	req.NoError(err)
+	req.Len(versions, 2)
+	req.Equal("2.0", versions[0].Version)
+
+	versions, err = repo.ListVersions(ctx, nil, "wine", filter.ListFilter(&registry.VersionFilter{
+		Stage: []string{string(registry.ProductionStage)},
+	}))
+	req.NoError(err)
+	req.Len(versions, 1)
+
+	_, err = repo.GetVersion(ctx, nil, "wine", "3.0")
+	req.True(odahuErrors.IsNotFoundError(err))
+
+	cursor, err := repo.GetCursor(ctx, nil)
+	req.NoError(err)
+	req.Equal(0, cursor)
+	req.NoError(repo.SaveCursor(ctx, nil, 42))
+	cursor, err = repo.GetCursor(ctx, nil)
+	req.NoError(err)
+	req.Equal(42, cursor)
+}
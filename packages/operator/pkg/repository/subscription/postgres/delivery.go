@@ -0,0 +1,223 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"strconv"
+	"time"
+)
+
+const (
+	DeliveryTable = "odahu_subscription_delivery"
+)
+
+var deliveryColumns = []string{
+	"id", "subscription_id", "state", "attempts", "next_attempt", "last_error", "created", "event",
+}
+
+// Delivery persistence repository
+type DeliveryRepo struct {
+	DB *sql.DB
+}
+
+// Create inserts pending deliveries. IDs of deliveries are generated by database
+func (r DeliveryRepo) Create(ctx context.Context, tx *sql.Tx, deliveries []api_types.Delivery) (err error) {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	ib := sq.Insert(DeliveryTable).
+		Columns("subscription_id", "state", "attempts", "next_attempt", "last_error", "created", "event")
+	for _, d := range deliveries {
+		ib = ib.Values(d.SubscriptionID, d.State, d.Attempts, d.NextAttemptTime, d.LastError, d.CreatedAt, d.Event)
+	}
+
+	stmt, args, err := ib.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	return err
+}
+
+// Update saves result of a failed delivery attempt
+func (r DeliveryRepo) Update(ctx context.Context, tx *sql.Tx, d api_types.Delivery) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(DeliveryTable).
+		Set("state", d.State).
+		Set("attempts", d.Attempts).
+		Set("next_attempt", d.NextAttemptTime).
+		Set("last_error", d.LastError).
+		Where(sq.Eq{"id": d.ID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execAffectingRow(ctx, qrr, strconv.Itoa(d.ID), stmt, args)
+}
+
+// Delete removes the delivery. Successful deliveries are not stored
+func (r DeliveryRepo) Delete(ctx context.Context, tx *sql.Tx, id int) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Delete(DeliveryTable).Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execAffectingRow(ctx, qrr, strconv.Itoa(id), stmt, args)
+}
+
+// Requeue moves the delivery of the subscription to the pending state and resets its attempts
+func (r DeliveryRepo) Requeue(
+	ctx context.Context, tx *sql.Tx, subscriptionID string, id int, nextAttempt time.Time) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(DeliveryTable).
+		Set("state", api_types.DeliveryPending).
+		Set("attempts", 0).
+		Set("next_attempt", nextAttempt).
+		Where(sq.Eq{"id": id, "subscription_id": subscriptionID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execAffectingRow(ctx, qrr, strconv.Itoa(id), stmt, args)
+}
+
+// ListDue returns at most limit pending deliveries which next attempt time is not after now
+func (r DeliveryRepo) ListDue(
+	ctx context.Context, tx *sql.Tx, now time.Time, limit int) (res []api_types.Delivery, err error) {
+	return r.query(ctx, tx, sq.Select(deliveryColumns...).
+		From(DeliveryTable).
+		Where(sq.Eq{"state": api_types.DeliveryPending}).
+		Where(sq.LtOrEq{"next_attempt": now}).
+		OrderBy("next_attempt", "id").
+		Limit(uint64(limit)),
+	)
+}
+
+// List returns deliveries of the subscription ordered by ID. Deliveries can be filtered by api_types.DeliveryFilter
+func (r DeliveryRepo) List(
+	ctx context.Context, tx *sql.Tx, subscriptionID string, options ...filter.ListOption,
+) (res []api_types.Delivery, err error) {
+	listOptions := &filter.ListOptions{
+		Filter: nil,
+		Page:   &FirstPage,
+		Size:   &MaxSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	offset := *listOptions.Size * (*listOptions.Page)
+
+	sb := sq.Select(deliveryColumns...).
+		From(DeliveryTable).
+		Where(sq.Eq{"subscription_id": subscriptionID}).
+		OrderBy("id").
+		Offset(uint64(offset)).
+		Limit(uint64(*listOptions.Size))
+
+	if listOptions.Filter != nil {
+		deliveryFilter, ok := listOptions.Filter.(*api_types.DeliveryFilter)
+		if !ok {
+			return nil, fmt.Errorf("unexpected filter type: %T", listOptions.Filter)
+		}
+
+		sb = utils.TransformFilter(sb, deliveryFilter)
+	}
+
+	return r.query(ctx, tx, sb)
+}
+
+func (r DeliveryRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return r.DB.BeginTx(ctx, txOptions)
+}
+
+func (r DeliveryRepo) query(ctx context.Context, tx *sql.Tx, sb sq.SelectBuilder) (res []api_types.Delivery, err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sb.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	// To avoid nil
+	res = make([]api_types.Delivery, 0)
+
+	for rows.Next() {
+		d := api_types.Delivery{}
+		err := rows.Scan(
+			&d.ID, &d.SubscriptionID, &d.State, &d.Attempts, &d.NextAttemptTime, &d.LastError, &d.CreatedAt, &d.Event,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
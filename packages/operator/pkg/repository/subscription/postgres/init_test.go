@@ -0,0 +1,54 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres_test
+
+import (
+	"database/sql"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/testhelpers/testenvs"
+	"log"
+	"os"
+	"testing"
+)
+
+var (
+	db *sql.DB
+)
+
+func Wrapper(m *testing.M) int {
+	// Setup Test DB
+
+	var closeDB func() error
+	var err error
+	db, _, closeDB, err = testenvs.SetupTestDB()
+	defer func() {
+		if err := closeDB(); err != nil {
+			log.Print("Error during release test DB resources")
+		}
+	}()
+	if err != nil {
+		return -1
+	}
+
+	return m.Run()
+}
+
+func TestMain(m *testing.M) {
+
+	os.Exit(Wrapper(m))
+
+}
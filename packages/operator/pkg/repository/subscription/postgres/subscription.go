@@ -0,0 +1,243 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	SubscriptionTable           = "odahu_subscription"
+	uniqueViolationPostgresCode = pq.ErrorCode("23505") // unique_violation
+)
+
+var (
+	log       = logf.Log.WithName("subscription-repository--postgres")
+	MaxSize   = 500
+	FirstPage = 0
+	txOptions = &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  false,
+	}
+)
+
+// Subscription persistence repository
+type SubscriptionRepo struct {
+	DB *sql.DB
+}
+
+func (r SubscriptionRepo) Create(ctx context.Context, tx *sql.Tx, s api_types.Subscription) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.
+		Insert(SubscriptionTable).
+		Columns("id", "spec", "status", "created", "updated").
+		Values(s.ID, s.Spec, s.Status, s.CreatedAt, s.UpdatedAt).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		pqError, ok := err.(*pq.Error)
+		if ok && pqError.Code == uniqueViolationPostgresCode {
+			return odahuErrors.AlreadyExistError{Entity: s.ID}
+		}
+		return err
+	}
+	return nil
+}
+
+// Update updates spec of the subscription. Status is managed only by UpdateStatus
+func (r SubscriptionRepo) Update(ctx context.Context, tx *sql.Tx, id string, s api_types.Subscription) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(SubscriptionTable).
+		Set("spec", s.Spec).
+		Set("updated", s.UpdatedAt).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execAffectingRow(ctx, qrr, id, stmt, args)
+}
+
+func (r SubscriptionRepo) UpdateStatus(
+	ctx context.Context, tx *sql.Tx, id string, status api_types.SubscriptionStatus) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(SubscriptionTable).
+		Set("status", status).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execAffectingRow(ctx, qrr, id, stmt, args)
+}
+
+func (r SubscriptionRepo) Delete(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Delete(SubscriptionTable).Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execAffectingRow(ctx, qrr, id, stmt, args)
+}
+
+func (r SubscriptionRepo) Get(ctx context.Context, tx *sql.Tx, id string) (res api_types.Subscription, err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	query, args, err := sq.
+		Select("id", "spec", "status", "created", "updated").
+		From(SubscriptionTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return res, err
+	}
+
+	err = qrr.QueryRowContext(ctx, query, args...).
+		Scan(&res.ID, &res.Spec, &res.Status, &res.CreatedAt, &res.UpdatedAt)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return res, odahuErrors.NotFoundError{Entity: id}
+	case err != nil:
+		log.Error(err, "error during sql query")
+		return res, err
+	default:
+		return res, nil
+	}
+}
+
+func (r SubscriptionRepo) List(
+	ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []api_types.Subscription, err error) {
+	var qrr utils.Querier
+	qrr = r.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	listOptions := &filter.ListOptions{
+		Filter: nil,
+		Page:   &FirstPage,
+		Size:   &MaxSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	offset := *listOptions.Size * (*listOptions.Page)
+
+	stmt, args, err := sq.Select("id", "spec", "status", "created", "updated").From(SubscriptionTable).
+		OrderBy("id").
+		Offset(uint64(offset)).
+		Limit(uint64(*listOptions.Size)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	// To avoid nil
+	res = make([]api_types.Subscription, 0)
+
+	for rows.Next() {
+		s := api_types.Subscription{}
+		if err := rows.Scan(&s.ID, &s.Spec, &s.Status, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r SubscriptionRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return r.DB.BeginTx(ctx, txOptions)
+}
+
+// execAffectingRow executes the statement and returns NotFoundError if no rows were affected
+func execAffectingRow(ctx context.Context, qrr utils.Querier, id string, stmt string, args []interface{}) error {
+	result, err := qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return odahuErrors.NotFoundError{Entity: id}
+	}
+
+	return nil
+}
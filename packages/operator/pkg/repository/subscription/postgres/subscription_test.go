@@ -0,0 +1,109 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres_test
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/subscription/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSubscriptionCreateGet(t *testing.T) {
+	req := require.New(t)
+	defer func() {
+		req.NoError(cleanupSubscriptions())
+	}()
+	r := postgres.SubscriptionRepo{DB: db}
+
+	sub := api_types.Subscription{
+		ID: "ci-hook",
+		Spec: api_types.SubscriptionSpec{
+			URL:         "https://ci.example.com/hook",
+			Secret:      "secret",
+			EventGroups: []event.Group{event.ModelTrainingEventGroup},
+		},
+		Status: api_types.SubscriptionStatus{Cursor: 10},
+	}
+	req.NoError(r.Create(context.TODO(), nil, sub))
+
+	err := r.Create(context.TODO(), nil, sub)
+	req.True(odahu_errors.IsAlreadyExistError(err))
+
+	res, err := r.Get(context.TODO(), nil, sub.ID)
+	req.NoError(err)
+	req.Equal(sub.Spec, res.Spec)
+	req.Equal(sub.Status, res.Status)
+}
+
+func TestDeliveriesDueAndCascadeDelete(t *testing.T) {
+	req := require.New(t)
+	defer func() {
+		req.NoError(cleanupSubscriptions())
+	}()
+	r := postgres.SubscriptionRepo{DB: db}
+	dr := postgres.DeliveryRepo{DB: db}
+	ctx := context.TODO()
+	now := time.Now().UTC()
+
+	req.NoError(r.Create(ctx, nil, api_types.Subscription{ID: "ci-hook"}))
+	req.NoError(dr.Create(ctx, nil, []api_types.Delivery{
+		{SubscriptionID: "ci-hook", State: api_types.DeliveryPending, NextAttemptTime: now.Add(-time.Minute),
+			Event: event.Record{ID: 1, EventType: event.ModelTrainingCreatedEventType}},
+		{SubscriptionID: "ci-hook", State: api_types.DeliveryPending, NextAttemptTime: now.Add(time.Hour),
+			Event: event.Record{ID: 2, EventType: event.ModelTrainingUpdatedEventType}},
+		{SubscriptionID: "ci-hook", State: api_types.DeliveryDeadLetter, NextAttemptTime: now.Add(-time.Minute),
+			Event: event.Record{ID: 3, EventType: event.ModelTrainingDeletedEventType}},
+	}))
+
+	due, err := dr.ListDue(ctx, nil, now, 10)
+	req.NoError(err)
+	req.Len(due, 1)
+	req.Equal(1, due[0].Event.ID)
+
+	deadLetters, err := dr.List(ctx, nil, "ci-hook", filter.ListFilter(&api_types.DeliveryFilter{
+		State: []string{string(api_types.DeliveryDeadLetter)},
+	}))
+	req.NoError(err)
+	req.Len(deadLetters, 1)
+
+	req.NoError(dr.Requeue(ctx, nil, "ci-hook", deadLetters[0].ID, now))
+	due, err = dr.ListDue(ctx, nil, now, 10)
+	req.NoError(err)
+	req.Len(due, 2)
+
+	req.NoError(r.Delete(ctx, nil, "ci-hook"))
+	all, err := dr.List(ctx, nil, "ci-hook")
+	req.NoError(err)
+	req.Len(all, 0)
+}
+
+func cleanupSubscriptions() error {
+	query, _, err := sq.Delete(postgres.SubscriptionTable).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(query)
+	return err
+}
@@ -0,0 +1,187 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"time"
+)
+
+const (
+	ModelTrainingMetricTable        = "odahu_operator_training_metric"
+	ModelTrainingParamTable         = "odahu_operator_training_param"
+	foreignKeyViolationPostgresCode = pq.ErrorCode("23503") // foreign_key_violation
+)
+
+// Persistence repository of metrics and params which are reported by trainers
+type TrainingMetricsRepo struct {
+	DB *sql.DB
+}
+
+// SaveMetrics inserts metric values and params of the training. Values with the same key and step
+// and params with the same key are overwritten. NotFoundError is returned if the training does not exist
+func (repo TrainingMetricsRepo) SaveMetrics(
+	ctx context.Context, tx *sql.Tx, trainingID string, metrics training.ModelTrainingMetrics) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	if len(metrics.Metrics) > 0 {
+		ib := sq.Insert(ModelTrainingMetricTable).
+			Columns("training_id", "key", "step", "value", "timestamp").
+			Suffix("ON CONFLICT (training_id, key, step) DO UPDATE " +
+				"SET value = EXCLUDED.value, timestamp = EXCLUDED.timestamp").
+			PlaceholderFormat(sq.Dollar)
+		for _, m := range metrics.Metrics {
+			ib = ib.Values(trainingID, m.Key, m.Step, m.Value, m.Timestamp)
+		}
+		if err := execMetricsStatement(ctx, qrr, trainingID, ib); err != nil {
+			return err
+		}
+	}
+
+	if len(metrics.Params) > 0 {
+		ib := sq.Insert(ModelTrainingParamTable).
+			Columns("training_id", "key", "value").
+			Suffix("ON CONFLICT (training_id, key) DO UPDATE SET value = EXCLUDED.value").
+			PlaceholderFormat(sq.Dollar)
+		for key, value := range metrics.Params {
+			ib = ib.Values(trainingID, key, value)
+		}
+		if err := execMetricsStatement(ctx, qrr, trainingID, ib); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMetrics returns metrics and params of the training. If keys are passed then only metrics
+// with these keys are returned
+func (repo TrainingMetricsRepo) GetMetrics(
+	ctx context.Context, tx *sql.Tx, trainingID string, keys []string) (res training.ModelTrainingMetrics, err error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	where := sq.And{sq.Eq{"training_id": trainingID}}
+	if len(keys) > 0 {
+		where = append(where, sq.Eq{"key": keys})
+	}
+	query, args, err := sq.Select("key", "step", "value", "timestamp").
+		From(ModelTrainingMetricTable).
+		Where(where).
+		OrderBy("key", "step").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return res, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, query, args...)
+	if err != nil {
+		return res, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	for rows.Next() {
+		var m training.MetricValue
+		var timestamp time.Time
+		if err := rows.Scan(&m.Key, &m.Step, &m.Value, &timestamp); err != nil {
+			return res, err
+		}
+		m.Timestamp = &timestamp
+		res.Metrics = append(res.Metrics, m)
+	}
+	if err := rows.Err(); err != nil {
+		return res, err
+	}
+
+	res.Params, err = getParams(ctx, qrr, trainingID)
+	return res, err
+}
+
+func getParams(ctx context.Context, qrr utils.Querier, trainingID string) (map[string]string, error) {
+
+	query, args, err := sq.Select("key", "value").
+		From(ModelTrainingParamTable).
+		Where(sq.Eq{"training_id": trainingID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	var params map[string]string
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[key] = value
+	}
+	return params, rows.Err()
+}
+
+func (repo TrainingMetricsRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return repo.DB.BeginTx(ctx, txOptions)
+}
+
+func execMetricsStatement(
+	ctx context.Context, qrr utils.Querier, trainingID string, ib sq.InsertBuilder) error {
+
+	stmt, args, err := ib.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	if pqError, ok := err.(*pq.Error); ok && pqError.Code == foreignKeyViolationPostgresCode {
+		return odahuErrors.NotFoundError{Entity: trainingID}
+	}
+	return err
+}
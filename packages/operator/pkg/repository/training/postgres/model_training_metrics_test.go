@@ -0,0 +1,59 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	postgres_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/training/postgres"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	metricsMtID = "metrics-training"
+)
+
+func TestModelTrainingMetricsRepository(t *testing.T) {
+	req := require.New(t)
+	trainRepo := postgres_repo.TrainingRepo{DB: db}
+	repo := postgres_repo.TrainingMetricsRepo{DB: db}
+	ctx := context.TODO()
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	metrics := training.ModelTrainingMetrics{
+		Metrics: []training.MetricValue{
+			{Key: "loss", Step: 1, Value: 0.5, Timestamp: &now},
+			{Key: "loss", Step: 0, Value: 0.9, Timestamp: &now},
+			{Key: "accuracy", Step: 0, Value: 0.6, Timestamp: &now},
+		},
+		Params: map[string]string{"batch_size": "32"},
+	}
+	req.True(odahuErrors.IsNotFoundError(repo.SaveMetrics(ctx, nil, metricsMtID, metrics)))
+
+	req.NoError(trainRepo.SaveModelTraining(ctx, nil, &training.ModelTraining{ID: metricsMtID}))
+	defer func() {
+		if err := trainRepo.DeleteModelTraining(ctx, nil, metricsMtID); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	req.NoError(repo.SaveMetrics(ctx, nil, metricsMtID, metrics))
+	req.NoError(repo.SaveMetrics(ctx, nil, metricsMtID, training.ModelTrainingMetrics{
+		Metrics: []training.MetricValue{{Key: "loss", Step: 1, Value: 0.4, Timestamp: &now}},
+		Params:  map[string]string{"batch_size": "64"},
+	}))
+
+	fetched, err := repo.GetMetrics(ctx, nil, metricsMtID, nil)
+	req.NoError(err)
+	req.Len(fetched.Metrics, 3)
+	req.Equal("accuracy", fetched.Metrics[0].Key)
+	req.Equal(0.9, fetched.Metrics[1].Value)
+	req.Equal(0.4, fetched.Metrics[2].Value)
+	req.True(now.Equal(*fetched.Metrics[2].Timestamp))
+	req.Equal(map[string]string{"batch_size": "64"}, fetched.Params)
+
+	fetched, err = repo.GetMetrics(ctx, nil, metricsMtID, []string{"accuracy"})
+	req.NoError(err)
+	req.Len(fetched.Metrics, 1)
+}
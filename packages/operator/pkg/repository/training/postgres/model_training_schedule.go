@@ -0,0 +1,249 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+)
+
+const (
+	ModelTrainingScheduleTable = "odahu_operator_training_schedule"
+)
+
+var (
+	MaxScheduleListSize = 500
+	FirstSchedulePage   = 0
+)
+
+// Model training schedule persistence repository
+type TrainingScheduleRepo struct {
+	DB *sql.DB
+}
+
+func (repo TrainingScheduleRepo) Create(
+	ctx context.Context, tx *sql.Tx, mts training.ModelTrainingSchedule) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.
+		Insert(ModelTrainingScheduleTable).
+		Columns("id", "spec", "status", "created", "updated").
+		Values(mts.ID, mts.Spec, mts.Status, mts.CreatedAt, mts.UpdatedAt).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		pqError, ok := err.(*pq.Error)
+		if ok && pqError.Code == uniqueViolationPostgresCode {
+			return odahuErrors.AlreadyExistError{Entity: mts.ID}
+		}
+		return err
+	}
+	return nil
+}
+
+func (repo TrainingScheduleRepo) Get(
+	ctx context.Context, tx *sql.Tx, id string) (res training.ModelTrainingSchedule, err error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	query, args, err := sq.
+		Select("id", "spec", "status", "created", "updated").
+		From(ModelTrainingScheduleTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return res, err
+	}
+
+	err = qrr.QueryRowContext(ctx, query, args...).
+		Scan(&res.ID, &res.Spec, &res.Status, &res.CreatedAt, &res.UpdatedAt)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return res, odahuErrors.NotFoundError{Entity: id}
+	case err != nil:
+		log.Error(err, "error during sql query")
+		return res, err
+	default:
+		return res, nil
+	}
+}
+
+func (repo TrainingScheduleRepo) List(
+	ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []training.ModelTrainingSchedule, err error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	listOptions := &filter.ListOptions{
+		Filter: nil,
+		Page:   &FirstSchedulePage,
+		Size:   &MaxScheduleListSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	offset := *listOptions.Size * (*listOptions.Page)
+
+	stmt, args, err := sq.Select("id", "spec", "status", "created", "updated").
+		From(ModelTrainingScheduleTable).
+		OrderBy("id").
+		Offset(uint64(offset)).
+		Limit(uint64(*listOptions.Size)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	// To avoid nil
+	res = make([]training.ModelTrainingSchedule, 0)
+
+	for rows.Next() {
+		mts := training.ModelTrainingSchedule{}
+		if err := rows.Scan(&mts.ID, &mts.Spec, &mts.Status, &mts.CreatedAt, &mts.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, mts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Update updates spec of the schedule. Status is managed only by UpdateStatus
+func (repo TrainingScheduleRepo) Update(
+	ctx context.Context, tx *sql.Tx, id string, mts training.ModelTrainingSchedule) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(ModelTrainingScheduleTable).
+		Set("spec", mts.Spec).
+		Set("updated", mts.UpdatedAt).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execEntityStatement(ctx, qrr, id, stmt, args)
+}
+
+func (repo TrainingScheduleRepo) UpdateStatus(
+	ctx context.Context, tx *sql.Tx, id string, status training.ModelTrainingScheduleStatus) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(ModelTrainingScheduleTable).
+		Set("status", status).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execEntityStatement(ctx, qrr, id, stmt, args)
+}
+
+func (repo TrainingScheduleRepo) Delete(ctx context.Context, tx *sql.Tx, id string) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Delete(ModelTrainingScheduleTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execEntityStatement(ctx, qrr, id, stmt, args)
+}
+
+func (repo TrainingScheduleRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return repo.DB.BeginTx(ctx, txOptions)
+}
+
+// execEntityStatement executes the statement and returns NotFoundError if no rows were affected
+func execEntityStatement(ctx context.Context, qrr utils.Querier, id string, stmt string, args []interface{}) error {
+	result, err := qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return odahuErrors.NotFoundError{Entity: id}
+	}
+
+	return nil
+}
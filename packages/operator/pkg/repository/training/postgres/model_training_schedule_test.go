@@ -0,0 +1,65 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	postgres_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/training/postgres"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	mtsID = "nightly"
+)
+
+func TestModelTrainingScheduleRepository(t *testing.T) {
+	req := require.New(t)
+	repo := postgres_repo.TrainingScheduleRepo{DB: db}
+	ctx := context.TODO()
+	defer func() {
+		err := repo.Delete(ctx, nil, mtsID)
+		if err != nil && !odahuErrors.IsNotFoundError(err) {
+			t.Fatal(err)
+		}
+	}()
+
+	created := training.ModelTrainingSchedule{
+		ID:        mtsID,
+		CreatedAt: time.Now().Round(time.Microsecond),
+		UpdatedAt: time.Now().Round(time.Microsecond),
+		Spec: training.ModelTrainingScheduleSpec{
+			Schedule: "0 3 * * *",
+			Template: v1alpha1.ModelTrainingSpec{WorkDir: "/foo"},
+		},
+	}
+	req.NoError(repo.Create(ctx, nil, created))
+	req.True(odahuErrors.IsAlreadyExistError(repo.Create(ctx, nil, created)))
+
+	created.Spec.Suspend = true
+	req.NoError(repo.Update(ctx, nil, mtsID, created))
+
+	lastScheduleTime := time.Now().UTC().Round(time.Microsecond)
+	status := training.ModelTrainingScheduleStatus{
+		LastScheduleTime: &lastScheduleTime,
+		Trainings:        []string{created.TrainingID(lastScheduleTime)},
+	}
+	req.NoError(repo.UpdateStatus(ctx, nil, mtsID, status))
+
+	fetched, err := repo.Get(ctx, nil, mtsID)
+	req.NoError(err)
+	req.Equal(created.Spec, fetched.Spec)
+	req.Equal(status.Trainings, fetched.Status.Trainings)
+	req.True(lastScheduleTime.Equal(*fetched.Status.LastScheduleTime))
+
+	list, err := repo.List(ctx, nil)
+	req.NoError(err)
+	req.Len(list, 1)
+
+	req.NoError(repo.Delete(ctx, nil, mtsID))
+	_, err = repo.Get(ctx, nil, mtsID)
+	req.True(odahuErrors.IsNotFoundError(err))
+	req.True(odahuErrors.IsNotFoundError(repo.UpdateStatus(ctx, nil, mtsID, status)))
+}
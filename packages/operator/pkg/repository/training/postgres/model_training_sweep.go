@@ -0,0 +1,217 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	utils "github.com/odahu/odahu-flow/packages/operator/pkg/repository/util/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+)
+
+const (
+	ModelTrainingSweepTable = "odahu_operator_training_sweep"
+)
+
+var (
+	MaxSweepListSize = 500
+	FirstSweepPage   = 0
+)
+
+// Model training sweep persistence repository
+type TrainingSweepRepo struct {
+	DB *sql.DB
+}
+
+func (repo TrainingSweepRepo) Create(
+	ctx context.Context, tx *sql.Tx, mtsw training.ModelTrainingSweep) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.
+		Insert(ModelTrainingSweepTable).
+		Columns("id", "spec", "status", "created", "updated").
+		Values(mtsw.ID, mtsw.Spec, mtsw.Status, mtsw.CreatedAt, mtsw.UpdatedAt).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = qrr.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		pqError, ok := err.(*pq.Error)
+		if ok && pqError.Code == uniqueViolationPostgresCode {
+			return odahuErrors.AlreadyExistError{Entity: mtsw.ID}
+		}
+		return err
+	}
+	return nil
+}
+
+func (repo TrainingSweepRepo) Get(
+	ctx context.Context, tx *sql.Tx, id string) (res training.ModelTrainingSweep, err error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	query, args, err := sq.
+		Select("id", "spec", "status", "created", "updated").
+		From(ModelTrainingSweepTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return res, err
+	}
+
+	err = qrr.QueryRowContext(ctx, query, args...).
+		Scan(&res.ID, &res.Spec, &res.Status, &res.CreatedAt, &res.UpdatedAt)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return res, odahuErrors.NotFoundError{Entity: id}
+	case err != nil:
+		log.Error(err, "error during sql query")
+		return res, err
+	default:
+		return res, nil
+	}
+}
+
+func (repo TrainingSweepRepo) List(
+	ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []training.ModelTrainingSweep, err error) {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	listOptions := &filter.ListOptions{
+		Filter: nil,
+		Page:   &FirstSweepPage,
+		Size:   &MaxSweepListSize,
+	}
+	for _, option := range options {
+		option(listOptions)
+	}
+
+	offset := *listOptions.Size * (*listOptions.Page)
+
+	sb := sq.Select("id", "spec", "status", "created", "updated").
+		From(ModelTrainingSweepTable).
+		OrderBy("id").
+		Offset(uint64(offset)).
+		Limit(uint64(*listOptions.Size)).
+		PlaceholderFormat(sq.Dollar)
+
+	if listOptions.Filter != nil {
+		sweepFilter, ok := listOptions.Filter.(*training.SweepFilter)
+		if !ok {
+			return nil, fmt.Errorf("unexpected filter type: %T", listOptions.Filter)
+		}
+		sb = utils.TransformFilter(sb, sweepFilter)
+	}
+
+	stmt, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qrr.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error(err, "error during rows.Close()")
+		}
+	}()
+
+	// To avoid nil
+	res = make([]training.ModelTrainingSweep, 0)
+
+	for rows.Next() {
+		mtsw := training.ModelTrainingSweep{}
+		if err := rows.Scan(&mtsw.ID, &mtsw.Spec, &mtsw.Status, &mtsw.CreatedAt, &mtsw.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, mtsw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (repo TrainingSweepRepo) UpdateStatus(
+	ctx context.Context, tx *sql.Tx, id string, status training.ModelTrainingSweepStatus) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Update(ModelTrainingSweepTable).
+		Set("status", status).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execEntityStatement(ctx, qrr, id, stmt, args)
+}
+
+func (repo TrainingSweepRepo) Delete(ctx context.Context, tx *sql.Tx, id string) error {
+
+	var qrr utils.Querier
+	qrr = repo.DB
+	if tx != nil {
+		qrr = tx
+	}
+
+	stmt, args, err := sq.Delete(ModelTrainingSweepTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return execEntityStatement(ctx, qrr, id, stmt, args)
+}
+
+func (repo TrainingSweepRepo) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return repo.DB.BeginTx(ctx, txOptions)
+}
@@ -0,0 +1,74 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	postgres_repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/training/postgres"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	mtswID = "wine-sweep"
+)
+
+func TestModelTrainingSweepRepository(t *testing.T) {
+	req := require.New(t)
+	repo := postgres_repo.TrainingSweepRepo{DB: db}
+	ctx := context.TODO()
+	defer func() {
+		err := repo.Delete(ctx, nil, mtswID)
+		if err != nil && !odahuErrors.IsNotFoundError(err) {
+			t.Fatal(err)
+		}
+	}()
+
+	created := training.ModelTrainingSweep{
+		ID:        mtswID,
+		CreatedAt: time.Now().Round(time.Microsecond),
+		UpdatedAt: time.Now().Round(time.Microsecond),
+		Spec: training.ModelTrainingSweepSpec{
+			Strategy:   training.GridSweep,
+			Parameters: []training.SweepParameter{{Name: "alpha", Values: []string{"0.1", "0.5"}}},
+			Objective:  training.SweepObjective{Metric: "accuracy", Goal: training.MaximizeGoal},
+			Template:   v1alpha1.ModelTrainingSpec{WorkDir: "/foo"},
+		},
+		Status: training.ModelTrainingSweepStatus{State: training.SweepRunning},
+	}
+	req.NoError(repo.Create(ctx, nil, created))
+	req.True(odahuErrors.IsAlreadyExistError(repo.Create(ctx, nil, created)))
+
+	metric := 0.9
+	status := training.ModelTrainingSweepStatus{
+		State: training.SweepSucceeded,
+		Trials: []training.SweepTrial{{
+			TrainingID:      created.TrialTrainingID(0),
+			HyperParameters: map[string]string{"alpha": "0.1"},
+			Metric:          &metric,
+		}},
+		BestTrainingID: created.TrialTrainingID(0),
+		BestMetric:     &metric,
+	}
+	req.NoError(repo.UpdateStatus(ctx, nil, mtswID, status))
+
+	fetched, err := repo.Get(ctx, nil, mtswID)
+	req.NoError(err)
+	req.Equal(created.Spec, fetched.Spec)
+	req.Equal(status, fetched.Status)
+
+	list, err := repo.List(ctx, nil, filter.ListFilter(&training.SweepFilter{State: []string{"succeeded"}}))
+	req.NoError(err)
+	req.Len(list, 1)
+
+	list, err = repo.List(ctx, nil, filter.ListFilter(&training.SweepFilter{State: []string{"running"}}))
+	req.NoError(err)
+	req.Len(list, 0)
+
+	req.NoError(repo.Delete(ctx, nil, mtswID))
+	_, err = repo.Get(ctx, nil, mtswID)
+	req.True(odahuErrors.IsNotFoundError(err))
+}
@@ -72,6 +72,18 @@ func NewBaseAPIClient(
 }
 
 func (bec *BaseAPIClient) Do(req *http.Request) (*http.Response, error) {
+	return bec.do(req, http.Client{
+		Timeout: defaultAPIRequestTimeout,
+	})
+}
+
+// DoStream is like Do, but does not limit time of reading the response body, for example of an event stream.
+// Request context must be used to stop the request
+func (bec *BaseAPIClient) DoStream(req *http.Request) (*http.Response, error) {
+	return bec.do(req, http.Client{})
+}
+
+func (bec *BaseAPIClient) do(req *http.Request, apiHTTPClient http.Client) (*http.Response, error) {
 	if len(req.URL.Host) == 0 {
 		apiURLStr := fmt.Sprintf("%s/%s%s", bec.apiURL, bec.apiVersion, req.URL.Path)
 		apiURL, err := url.Parse(apiURLStr)
@@ -94,10 +106,6 @@ func (bec *BaseAPIClient) Do(req *http.Request) (*http.Response, error) {
 		fmt.Sprintf(authorizationHeaderValue, bec.token),
 	}
 
-	apiHTTPClient := http.Client{
-		Timeout: defaultAPIRequestTimeout,
-	}
-
 	// We need store body bytes for retry in case of login
 	var bodyBytes []byte
 	var err error
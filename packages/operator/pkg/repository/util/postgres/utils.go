@@ -11,6 +11,8 @@ import (
 
 const (
 	tagKey = "postgres"
+	// Fields with this tag value are skipped by TransformFilter
+	skipTagValue = "-"
 	deletionMarkColumn = "deletionmark"
 )
 
@@ -36,6 +38,9 @@ func TransformFilter(sqlBuilder sq.SelectBuilder, filter interface{}) sq.SelectB
 		}
 
 		field := elem.Type().Field(i).Tag.Get(tagKey)
+		if field == skipTagValue {
+			continue
+		}
 
 		conditions = append(conditions, sq.Eq{field: value})
 	}
@@ -21,10 +21,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
 	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/batch"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/connection"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	odahuErrors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	hashutil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/hash"
 	"time"
 )
 
@@ -35,6 +39,7 @@ type JobRepository interface {
 	List(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []api_types.InferenceJob, err error)
 	Get(ctx context.Context, tx *sql.Tx, id string) (res api_types.InferenceJob, err error)
 	SetDeletionMark(ctx context.Context, tx *sql.Tx, id string, value bool) error
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
 }
 
 type ServiceRepository interface {
@@ -49,13 +54,20 @@ type JobService struct {
 	repo JobRepository
 	sRepo ServiceRepository
 	connGetter ConnectionGetter
+	eventPub EventPublisher
+	auditRecorder AuditRecorder
 }
 
-func NewJobService(repo JobRepository, sRepo ServiceRepository, connGetter ConnectionGetter) *JobService {
+func NewJobService(
+	repo JobRepository, sRepo ServiceRepository, connGetter ConnectionGetter,
+	eventPub EventPublisher, auditRecorder AuditRecorder,
+) *JobService {
 	return &JobService{
-		repo:       repo,
-		sRepo:      sRepo,
-		connGetter: connGetter,
+		repo:          repo,
+		sRepo:         sRepo,
+		connGetter:    connGetter,
+		eventPub:      eventPub,
+		auditRecorder: auditRecorder,
 	}
 }
 
@@ -98,18 +110,86 @@ func (s *JobService) Create(ctx context.Context, bij *api_types.InferenceJob) (e
 		}
 	}
 
-	err = s.repo.Create(ctx, nil, *bij)
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.Create(ctx, tx, *bij); err != nil {
+		return err
+	}
 
-	return err
+	e := event.Event{
+		EntityID:   bij.ID,
+		EventType:  event.InferenceJobCreatedEventType,
+		EventGroup: event.InferenceJobEventGroup,
+		Payload:    *bij,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.InferenceJobKind,
+		EntityID:   bij.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    bij.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
-func (s *JobService) UpdateStatus(ctx context.Context, id string, status api_types.InferenceJobStatus) error {
+func (s *JobService) UpdateStatus(
+	ctx context.Context, id string, status api_types.InferenceJobStatus) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if hashutil.Equal(old.Status, status) {
+		// Status is not changed. Skip updating in database and publishing event
+		return nil
+	}
+
+	if err = s.repo.UpdateStatus(ctx, tx, id, status); err != nil {
+		return err
+	}
 
-	return s.repo.UpdateStatus(ctx, nil, id, status)
+	updated := old
+	updated.Status = status
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.InferenceJobStatusUpdatedEventType,
+		EventGroup: event.InferenceJobEventGroup,
+		Payload:    updated,
+	}
+	return s.eventPub.PublishEvent(ctx, tx, e)
 }
 
-func (s *JobService) Delete(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, nil, id)
+func (s *JobService) Delete(ctx context.Context, id string) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.InferenceJobDeletedEventType,
+		EventGroup: event.InferenceJobEventGroup,
+	}
+	return s.eventPub.PublishEvent(ctx, tx, e)
 }
 func (s *JobService) List(ctx context.Context, options ...filter.ListOption) ([]api_types.InferenceJob, error) {
 	return s.repo.List(ctx, nil, options...)
@@ -119,6 +199,28 @@ func (s *JobService) Get(ctx context.Context, id string) (api_types.InferenceJob
 	return s.repo.Get(ctx, nil, id)
 }
 
-func (s *JobService) SetDeletionMark(ctx context.Context, id string) error {
-	return s.repo.SetDeletionMark(ctx, nil, id, true)
+// SetDeletionMark is used by users to delete jobs. Therefore it is the deletion in terms of audit
+func (s *JobService) SetDeletionMark(ctx context.Context, id string) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.SetDeletionMark(ctx, tx, id, true); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.InferenceJobKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    old.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
\ No newline at end of file
@@ -20,26 +20,45 @@ package batch
 import (
 	"context"
 	"database/sql"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
 	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/batch"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"time"
 )
 
+var log = logf.Log.WithName("batch-inference--service")
+
 type InferenceServiceRepo interface {
 	Create(ctx context.Context, tx *sql.Tx, bis api_types.InferenceService) (err error)
 	Get(ctx context.Context, tx *sql.Tx, id string) (res api_types.InferenceService, err error)
 	Update(ctx context.Context, tx *sql.Tx, id string, bis api_types.InferenceService) (err error)
 	List(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []api_types.InferenceService, err error)
 	Delete(ctx context.Context, tx *sql.Tx, id string) (err error)
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+}
+
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, tx *sql.Tx, event event.Event) (err error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
 }
 
 type InferenceServiceService struct {
-	repo InferenceServiceRepo
+	repo          InferenceServiceRepo
+	eventPub      EventPublisher
+	auditRecorder AuditRecorder
 }
 
-func NewInferenceServiceService(repo InferenceServiceRepo) *InferenceServiceService {
-	return &InferenceServiceService{repo: repo}
+func NewInferenceServiceService(
+	repo InferenceServiceRepo, eventPub EventPublisher, auditRecorder AuditRecorder,
+) *InferenceServiceService {
+	return &InferenceServiceService{repo: repo, eventPub: eventPub, auditRecorder: auditRecorder}
 }
 
 // Create creates api_types.InferenceService
@@ -63,8 +82,33 @@ func (s *InferenceServiceService) Create(
 		}
 	}
 
-	err = s.repo.Create(ctx, nil, *bis)
-	return err
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.Create(ctx, tx, *bis); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   bis.ID,
+		EventType:  event.InferenceServiceCreatedEventType,
+		EventGroup: event.InferenceServiceEventGroup,
+		Payload:    *bis,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.InferenceServiceKind,
+		EntityID:   bis.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    bis.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 // Update updates api_types.InferenceService
@@ -82,17 +126,74 @@ func (s *InferenceServiceService) Update(
 		}
 	}
 
-	old, err := s.repo.Get(ctx, nil, id)
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
 	if err != nil {
 		return err
 	}
 	bis.CreatedAt = old.CreatedAt
 
-	return s.repo.Update(ctx, nil, id, *bis)
+	if err = s.repo.Update(ctx, tx, id, *bis); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.InferenceServiceUpdatedEventType,
+		EventGroup: event.InferenceServiceEventGroup,
+		Payload:    *bis,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.InferenceServiceKind,
+		EntityID:   id,
+		Operation:  audit.UpdateOperation,
+		OldSpec:    old.Spec,
+		NewSpec:    bis.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s *InferenceServiceService) Delete(ctx context.Context, id string) (err error) {
-	return s.repo.Delete(ctx, nil, id)
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.InferenceServiceDeletedEventType,
+		EventGroup: event.InferenceServiceEventGroup,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.InferenceServiceKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    old.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s *InferenceServiceService) Get(ctx context.Context, id string) (res api_types.InferenceService, err error) {
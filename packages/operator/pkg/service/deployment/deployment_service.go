@@ -19,9 +19,12 @@ package deployment
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/google/uuid"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
@@ -50,17 +53,29 @@ type Service interface {
 		ctx context.Context, id string, status v1alpha1.ModelDeploymentStatus, spec v1alpha1.ModelDeploymentSpec) error
 	CreateModelDeployment(ctx context.Context, mt *deployment.ModelDeployment) error
 	GetDefaultModelRoute(ctx context.Context, mdID string) (*deployment.ModelRoute, error)
+	GetRevision(ctx context.Context, id string, revision int) (*deployment.ModelDeploymentRevision, error)
+	GetRevisionList(
+		ctx context.Context, id string, options ...filter.ListOption) ([]deployment.ModelDeploymentRevision, error)
+	// Compare specs of two revisions. The latest revision is used if "to" is 0
+	DiffRevisions(ctx context.Context, id string, from, to int) (*deployment.ModelDeploymentRevisionDiff, error)
+	// Restore the spec of the revision. The restored spec is saved as a new revision
+	RollbackModelDeployment(ctx context.Context, id string, revision int) (*deployment.ModelDeployment, error)
 }
 
 type EventPublisher interface {
 	PublishEvent(ctx context.Context, tx *sql.Tx, event event.Event) (err error)
 }
 
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
 type serviceImpl struct {
 	// Repository that has "database/sql" underlying storage
-	repo     repo.Repository
-	mrRepo   mrRepo.Repository
-	eventPub EventPublisher
+	repo          repo.Repository
+	mrRepo        mrRepo.Repository
+	eventPub      EventPublisher
+	auditRecorder AuditRecorder
 }
 
 func (s serviceImpl) GetModelDeployment(ctx context.Context, id string) (*deployment.ModelDeployment, error) {
@@ -138,7 +153,7 @@ func (s serviceImpl) DeleteModelDeployment(ctx context.Context, id string) (err
 	return
 }
 
-func (s serviceImpl) SetDeletionMark(ctx context.Context, id string, value bool) error {
+func (s serviceImpl) SetDeletionMark(ctx context.Context, id string, value bool) (err error) {
 
 	tx, err := s.repo.BeginTransaction(ctx)
 	if err != nil {
@@ -146,6 +161,11 @@ func (s serviceImpl) SetDeletionMark(ctx context.Context, id string, value bool)
 	}
 	defer func() { db_utils.FinishTx(tx, err, log) }()
 
+	oldMd, err := s.repo.GetModelDeployment(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
 	e := event.Event{
 		EntityID:   id,
 		EventType:  event.ModelDeploymentDeletionMarkIsSetEventType,
@@ -155,7 +175,21 @@ func (s serviceImpl) SetDeletionMark(ctx context.Context, id string, value bool)
 		return err
 	}
 
-	return s.repo.SetDeletionMark(ctx, tx, id, value)
+	if err = s.repo.SetDeletionMark(ctx, tx, id, value); err != nil {
+		return err
+	}
+
+	if !value {
+		return nil
+	}
+	// Users delete model deployments by the deletion mark. Therefore it is the deletion in terms of audit
+	change := audit.Change{
+		EntityKind: audit.ModelDeploymentKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    oldMd.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s serviceImpl) UpdateModelDeployment(ctx context.Context, md *deployment.ModelDeployment) (err error) {
@@ -171,6 +205,14 @@ func (s serviceImpl) UpdateModelDeployment(ctx context.Context, md *deployment.M
 	if err != nil {
 		return err
 	}
+
+	return s.update(ctx, tx, oldMd, md)
+}
+
+// update saves the new version of the deployment. A new revision is saved if the spec is changed
+func (s serviceImpl) update(
+	ctx context.Context, tx *sql.Tx, oldMd *deployment.ModelDeployment, md *deployment.ModelDeployment) (err error) {
+
 	md.CreatedAt = oldMd.CreatedAt
 	md.DeletionMark = false
 	md.Status = v1alpha1.ModelDeploymentStatus{}
@@ -184,7 +226,137 @@ func (s serviceImpl) UpdateModelDeployment(ctx context.Context, md *deployment.M
 	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
 		return err
 	}
-	return s.repo.UpdateModelDeployment(ctx, tx, md)
+	if err = s.repo.UpdateModelDeployment(ctx, tx, md); err != nil {
+		return err
+	}
+
+	if !hashutil.Equal(oldMd.Spec, md.Spec) {
+		lastRevision, err := s.repo.GetLastRevision(ctx, tx, md.ID)
+		if err != nil {
+			return err
+		}
+		if err = s.saveRevision(ctx, tx, md, lastRevision+1); err != nil {
+			return err
+		}
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelDeploymentKind,
+		EntityID:   md.ID,
+		Operation:  audit.UpdateOperation,
+		OldSpec:    oldMd.Spec,
+		NewSpec:    md.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+func (s serviceImpl) saveRevision(
+	ctx context.Context, tx *sql.Tx, md *deployment.ModelDeployment, revision int) error {
+	return s.repo.SaveRevision(ctx, tx, deployment.ModelDeploymentRevision{
+		DeploymentID: md.ID,
+		Revision:     revision,
+		CreatedAt:    md.UpdatedAt,
+		Spec:         md.Spec,
+	})
+}
+
+func (s serviceImpl) GetRevision(
+	ctx context.Context, id string, revision int) (*deployment.ModelDeploymentRevision, error) {
+
+	rev, err := s.repo.GetRevision(ctx, nil, id, revision)
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func (s serviceImpl) GetRevisionList(
+	ctx context.Context, id string, options ...filter.ListOption) ([]deployment.ModelDeploymentRevision, error) {
+
+	// Deployment without revisions and missed deployment are distinguished for clients
+	if _, err := s.repo.GetModelDeployment(ctx, nil, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRevisionList(ctx, nil, id, options...)
+}
+
+func (s serviceImpl) DiffRevisions(
+	ctx context.Context, id string, from, to int) (*deployment.ModelDeploymentRevisionDiff, error) {
+
+	if to == 0 {
+		lastRevision, err := s.repo.GetLastRevision(ctx, nil, id)
+		if err != nil {
+			return nil, err
+		}
+		if lastRevision == 0 {
+			return nil, odahu_errors.NotFoundError{Entity: id}
+		}
+		to = lastRevision
+	}
+
+	fromRev, err := s.repo.GetRevision(ctx, nil, id, from)
+	if err != nil {
+		return nil, err
+	}
+	toRev, err := s.repo.GetRevision(ctx, nil, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromSpec, err := json.Marshal(fromRev.Spec)
+	if err != nil {
+		return nil, err
+	}
+	toSpec, err := json.Marshal(toRev.Spec)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := jsonpatch.CreateMergePatch(fromSpec, toSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployment.ModelDeploymentRevisionDiff{DeploymentID: id, From: from, To: to, Diff: diff}, nil
+}
+
+func (s serviceImpl) RollbackModelDeployment(
+	ctx context.Context, id string, revision int) (md *deployment.ModelDeployment, err error) {
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	oldMd, err := s.repo.GetModelDeployment(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if oldMd.DeletionMark {
+		return nil, odahu_errors.ExtendedForbiddenError{
+			Message: fmt.Sprintf("unable to rollback model deployment %q, because it is being deleted", id),
+		}
+	}
+
+	rev, err := s.repo.GetRevision(ctx, tx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+	if hashutil.Equal(oldMd.Spec, rev.Spec) {
+		log.Info("Model deployment already has the spec of the revision", "id", id, "revision", revision)
+		return oldMd, nil
+	}
+
+	md = &deployment.ModelDeployment{
+		ID:        id,
+		UpdatedAt: time.Now(),
+		Spec:      rev.Spec,
+	}
+	if err = s.update(ctx, tx, oldMd, md); err != nil {
+		return nil, err
+	}
+	log.Info("Model deployment is rolled back", "id", id, "revision", revision)
+	return md, nil
 }
 
 func (s serviceImpl) UpdateModelDeploymentStatus(
@@ -271,17 +443,10 @@ func (s serviceImpl) CreateModelDeployment(ctx context.Context, md *deployment.M
 	if err != nil {
 		return
 	}
-
-	exists, err := s.mrRepo.DefaultExists(ctx, md.ID, tx)
-	if err != nil || exists {
+	// Revisions of a deleted deployment are deleted together with it, so the history starts again
+	if err = s.saveRevision(ctx, tx, md, 1); err != nil {
 		return err
 	}
-	// Every Model deployment must have a default HTTP route that sends 100% of traffic to the model
-	defRoute := constructDefaultRoute(md.ID)
-	err = s.mrRepo.SaveModelRoute(ctx, tx, &defRoute)
-	if err != nil {
-		return fmt.Errorf("unable to create default ModelRoute: %v", err)
-	}
 
 	e := event.Event{
 		EntityID:   md.ID,
@@ -292,6 +457,26 @@ func (s serviceImpl) CreateModelDeployment(ctx context.Context, md *deployment.M
 	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
 		return err
 	}
+	err = s.auditRecorder.Record(ctx, tx, audit.Change{
+		EntityKind: audit.ModelDeploymentKind,
+		EntityID:   md.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    md.Spec,
+	})
+	if err != nil {
+		return err
+	}
+
+	exists, err := s.mrRepo.DefaultExists(ctx, md.ID, tx)
+	if err != nil || exists {
+		return err
+	}
+	// Every Model deployment must have a default HTTP route that sends 100% of traffic to the model
+	defRoute := constructDefaultRoute(md.ID)
+	err = s.mrRepo.SaveModelRoute(ctx, tx, &defRoute)
+	if err != nil {
+		return fmt.Errorf("unable to create default ModelRoute: %v", err)
+	}
 
 	e = event.Event{
 		EntityID:   defRoute.ID,
@@ -303,9 +488,18 @@ func (s serviceImpl) CreateModelDeployment(ctx context.Context, md *deployment.M
 		return err
 	}
 
+	err = s.auditRecorder.Record(ctx, tx, audit.Change{
+		EntityKind: audit.ModelRouteKind,
+		EntityID:   defRoute.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    defRoute.Spec,
+	})
+
 	return err
 }
 
-func NewService(repo repo.Repository, mrRepo mrRepo.Repository, eventPub EventPublisher) Service {
-	return &serviceImpl{repo: repo, mrRepo: mrRepo, eventPub: eventPub}
+func NewService(
+	repo repo.Repository, mrRepo mrRepo.Repository, eventPub EventPublisher, auditRecorder AuditRecorder,
+) Service {
+	return &serviceImpl{repo: repo, mrRepo: mrRepo, eventPub: eventPub, auditRecorder: auditRecorder}
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/audit"
 	repo_dep "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/postgres"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/outbox"
 	route_interface "github.com/odahu/odahu-flow/packages/operator/pkg/repository/route"
@@ -63,8 +64,9 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	s.repo = repo_dep.DeploymentRepo{DB: s.DB}
 	s.routeRepo = repo_route.RouteRepo{DB: s.DB}
 	s.eventPub = &outbox.EventPublisher{DB: s.DB}
-	s.service = service.NewService(s.repo, s.routeRepo, s.eventPub)
-	s.routeService = route_service.NewService(s.routeRepo, s.eventPub)
+	auditRecorder := audit.Recorder{DB: s.DB}
+	s.service = service.NewService(s.repo, s.routeRepo, s.eventPub, auditRecorder)
+	s.routeService = route_service.NewService(s.routeRepo, s.eventPub, auditRecorder)
 
 	s.routeEventGetter = &outbox.RouteEventGetter{DB: s.DB}
 	s.depEventGetter = &outbox.DeploymentEventGetter{DB: s.DB}
@@ -22,12 +22,14 @@ import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
 	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment/mocks"
 	route_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/repository/route/mocks"
 	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment"
-	event_pub_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment/mocks"
+	service_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment/mocks"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -40,6 +42,8 @@ const (
 	enID = "entity-id"
 )
 
+var roleName = "role"
+
 func TestSuiteRun(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
@@ -48,7 +52,8 @@ type TestSuite struct {
 	suite.Suite
 	mockRepo  *mocks.Repository
 	rMockRepo *route_mocks.Repository
-	eMockPub  *event_pub_mocks.EventPublisher
+	eMockPub  *service_mocks.EventPublisher
+	mockAudit *service_mocks.AuditRecorder
 	service   service.Service
 	db        *sql.DB
 	dbMock    sqlmock.Sqlmock
@@ -67,14 +72,16 @@ func (s *TestSuite) SetupTest() {
 	}
 	mockRepo := &mocks.Repository{}
 	rMockRepo := &route_mocks.Repository{}
-	eMockPub := &event_pub_mocks.EventPublisher{}
+	eMockPub := &service_mocks.EventPublisher{}
+	mockAudit := &service_mocks.AuditRecorder{}
 
 	s.mockRepo = mockRepo
 	s.rMockRepo = rMockRepo
 	s.eMockPub = eMockPub
+	s.mockAudit = mockAudit
 	s.db = db
 	s.dbMock = dbMock
-	s.service = service.NewService(mockRepo, rMockRepo, eMockPub)
+	s.service = service.NewService(mockRepo, rMockRepo, eMockPub, mockAudit)
 }
 
 func (s *TestSuite) TestGetModelDeployment() {
@@ -162,12 +169,21 @@ func (s *TestSuite) TestSetDeletionMark() {
 	mockTx, err := s.db.Begin()
 	as.NoError(err)
 
+	en := newStubMT()
 	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	s.mockRepo.On("GetModelDeployment", ctx, mockTx, enID).Return(en, nil)
 	s.mockRepo.On("SetDeletionMark", ctx, mockTx, enID, true).Return(nil)
 	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockAudit.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelDeploymentKind,
+		EntityID:   enID,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    en.Spec,
+	}).Return(nil)
 
 	as.NoError(s.service.SetDeletionMark(ctx, enID, true))
 	s.mockRepo.AssertExpectations(s.T())
+	s.mockAudit.AssertExpectations(s.T())
 	as.NoError(s.dbMock.ExpectationsWereMet())
 }
 
@@ -186,6 +202,7 @@ func (s *TestSuite) TestUpdateModelDeployment() {
 	s.mockRepo.On("UpdateModelDeployment", ctx, mockTx, en).Return(nil)
 	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
 	s.mockRepo.On("GetModelDeployment", ctx, s.nilTx, en.ID).Return(en, nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	timeBeforeCall := time.Now()
 	as.NoError(s.service.UpdateModelDeployment(ctx, en))
@@ -286,15 +303,21 @@ func (s *TestSuite) TestCreateModelDeployment() {
 	}
 	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
 	s.mockRepo.On("SaveModelDeployment", ctx, mockTx, en).Return(nil)
+	s.mockRepo.On("SaveRevision", ctx, mockTx, mock.MatchedBy(func(rev apis.ModelDeploymentRevision) bool {
+		return rev.DeploymentID == enID && rev.Revision == 1
+	})).Return(nil)
 	s.rMockRepo.On("DefaultExists", ctx, enID, mockTx).Return(false, nil)
 	s.rMockRepo.On("SaveModelRoute", ctx, mockTx, mock.Anything).
 		Return(nil)
 	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	timeBeforeCall := time.Now()
 
 	as.NoError(s.service.CreateModelDeployment(ctx, en))
 	s.mockRepo.AssertExpectations(s.T())
+	// Both deployment and its default route must be recorded
+	s.mockAudit.AssertNumberOfCalls(s.T(), "Record", 2)
 	as.NoError(s.dbMock.ExpectationsWereMet())
 
 	// CreatedAt, UpdatedAt fields must be updated on now during the invocation
@@ -302,6 +325,39 @@ func (s *TestSuite) TestCreateModelDeployment() {
 	as.True(timeBeforeCall.Before(en.UpdatedAt))
 }
 
+func (s *TestSuite) TestCreateModelDeployment_DefaultRouteExists() {
+	as := assert.New(s.T())
+
+	en := newStubMT()
+	ctx := context.Background()
+
+	// Assume transaction commit
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	s.mockRepo.On("SaveModelDeployment", ctx, mockTx, en).Return(nil)
+	s.mockRepo.On("SaveRevision", ctx, mockTx, mock.Anything).Return(nil)
+	s.rMockRepo.On("DefaultExists", ctx, enID, mockTx).Return(true, nil)
+	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
+
+	as.NoError(s.service.CreateModelDeployment(ctx, en))
+	// Default route is not created again, but the deployment itself is recorded
+	s.rMockRepo.AssertNotCalled(s.T(), "SaveModelRoute")
+	s.mockAudit.AssertCalled(s.T(), "Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelDeploymentKind,
+		EntityID:   enID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    en.Spec,
+	})
+	s.mockAudit.AssertNumberOfCalls(s.T(), "Record", 1)
+	as.NoError(s.dbMock.ExpectationsWereMet())
+}
+
 func (s *TestSuite) TestCreateModelDeployment_Error() {
 	as := assert.New(s.T())
 
@@ -327,6 +383,131 @@ func (s *TestSuite) TestCreateModelDeployment_Error() {
 	as.NoError(s.dbMock.ExpectationsWereMet())
 }
 
+func (s *TestSuite) TestUpdateModelDeploymentSavesRevision() {
+	as := assert.New(s.T())
+	ctx := context.Background()
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+	mockTx, err := s.db.Begin()
+	as.NoError(err)
+
+	oldEn := newStubMT()
+	en := newStubMT()
+	en.Spec.Image = "new-image"
+	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	s.mockRepo.On("GetModelDeployment", ctx, s.nilTx, en.ID).Return(oldEn, nil)
+	s.mockRepo.On("UpdateModelDeployment", ctx, mockTx, en).Return(nil)
+	s.mockRepo.On("GetLastRevision", ctx, mockTx, en.ID).Return(2, nil)
+	s.mockRepo.On("SaveRevision", ctx, mockTx, mock.MatchedBy(func(rev apis.ModelDeploymentRevision) bool {
+		return rev.Revision == 3 && rev.Spec.Image == "new-image"
+	})).Return(nil)
+	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
+
+	as.NoError(s.service.UpdateModelDeployment(ctx, en))
+	s.mockRepo.AssertExpectations(s.T())
+	as.NoError(s.dbMock.ExpectationsWereMet())
+}
+
+func (s *TestSuite) TestRollbackModelDeployment() {
+	as := assert.New(s.T())
+	ctx := context.Background()
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+	mockTx, err := s.db.Begin()
+	as.NoError(err)
+
+	current := newStubMT()
+	current.Spec.Image = "broken-image"
+	rev := apis.ModelDeploymentRevision{
+		DeploymentID: enID, Revision: 1, Spec: v1alpha1.ModelDeploymentSpec{Image: "stable-image"},
+	}
+	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	s.mockRepo.On("GetModelDeployment", ctx, mockTx, enID).Return(current, nil)
+	s.mockRepo.On("GetRevision", ctx, mockTx, enID, 1).Return(rev, nil)
+	s.mockRepo.On("UpdateModelDeployment", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockRepo.On("GetLastRevision", ctx, mockTx, enID).Return(2, nil)
+	s.mockRepo.On("SaveRevision", ctx, mockTx, mock.MatchedBy(func(saved apis.ModelDeploymentRevision) bool {
+		return saved.Revision == 3 && saved.Spec.Image == "stable-image"
+	})).Return(nil)
+	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.MatchedBy(func(e event.Event) bool {
+		return e.EventType == event.ModelDeploymentUpdatedEventType
+	})).Return(nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.MatchedBy(func(change audit.Change) bool {
+		return change.Operation == audit.UpdateOperation
+	})).Return(nil)
+
+	md, err := s.service.RollbackModelDeployment(ctx, enID, 1)
+	as.NoError(err)
+	as.Equal("stable-image", md.Spec.Image)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockAudit.AssertExpectations(s.T())
+	as.NoError(s.dbMock.ExpectationsWereMet())
+}
+
+func (s *TestSuite) TestRollbackModelDeploymentSameSpec() {
+	as := assert.New(s.T())
+	ctx := context.Background()
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+	mockTx, err := s.db.Begin()
+	as.NoError(err)
+
+	current := newStubMT()
+	rev := apis.ModelDeploymentRevision{DeploymentID: enID, Revision: 1, Spec: current.Spec}
+	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	s.mockRepo.On("GetModelDeployment", ctx, mockTx, enID).Return(current, nil)
+	s.mockRepo.On("GetRevision", ctx, mockTx, enID, 1).Return(rev, nil)
+
+	md, err := s.service.RollbackModelDeployment(ctx, enID, 1)
+	as.NoError(err)
+	as.Equal(current, md)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateModelDeployment")
+	s.mockRepo.AssertNotCalled(s.T(), "SaveRevision")
+	as.NoError(s.dbMock.ExpectationsWereMet())
+}
+
+func (s *TestSuite) TestRollbackModelDeploymentNotFoundRevision() {
+	as := assert.New(s.T())
+	ctx := context.Background()
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectRollback()
+	mockTx, err := s.db.Begin()
+	as.NoError(err)
+
+	notFound := odahu_errs.NotFoundError{Entity: enID + "/5"}
+	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	s.mockRepo.On("GetModelDeployment", ctx, mockTx, enID).Return(newStubMT(), nil)
+	s.mockRepo.On("GetRevision", ctx, mockTx, enID, 5).Return(apis.ModelDeploymentRevision{}, notFound)
+
+	_, err = s.service.RollbackModelDeployment(ctx, enID, 5)
+	as.Equal(notFound, err)
+	as.NoError(s.dbMock.ExpectationsWereMet())
+}
+
+func (s *TestSuite) TestDiffRevisions() {
+	as := assert.New(s.T())
+	ctx := context.Background()
+
+	s.mockRepo.On("GetLastRevision", ctx, s.nilTx, enID).Return(2, nil)
+	s.mockRepo.On("GetRevision", ctx, s.nilTx, enID, 1).Return(apis.ModelDeploymentRevision{
+		DeploymentID: enID, Revision: 1, Spec: v1alpha1.ModelDeploymentSpec{Image: "image:1", RoleName: &roleName},
+	}, nil)
+	s.mockRepo.On("GetRevision", ctx, s.nilTx, enID, 2).Return(apis.ModelDeploymentRevision{
+		DeploymentID: enID, Revision: 2, Spec: v1alpha1.ModelDeploymentSpec{Image: "image:2"},
+	}, nil)
+
+	diff, err := s.service.DiffRevisions(ctx, enID, 1, 0)
+	as.NoError(err)
+	as.Equal(1, diff.From)
+	as.Equal(2, diff.To)
+	as.JSONEq(`{"image": "image:2", "roleName": null}`, string(diff.Diff))
+}
+
 // Helpers
 
 func newStubFilter() filter.ListOption {
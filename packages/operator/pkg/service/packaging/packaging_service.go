@@ -18,10 +18,14 @@ package packaging
 
 import (
 	"context"
+	"database/sql"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
 	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/packaging"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
 	hashutil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/hash"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -44,9 +48,19 @@ type Service interface {
 	CreateModelPackaging(ctx context.Context, mt *packaging.ModelPackaging) error
 }
 
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, tx *sql.Tx, event event.Event) (err error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
 type serviceImpl struct {
 	// Repository that has "database/sql" underlying storage
-	repo repo.Repository
+	repo          repo.Repository
+	eventPub      EventPublisher
+	auditRecorder AuditRecorder
 }
 
 func (s serviceImpl) GetModelPackaging(ctx context.Context, id string) (*packaging.ModelPackaging, error) {
@@ -59,17 +73,53 @@ func (s serviceImpl) GetModelPackagingList(
 	return s.repo.GetModelPackagingList(ctx, nil, options...)
 }
 
-func (s serviceImpl) DeleteModelPackaging(ctx context.Context, id string) error {
-	return s.repo.DeleteModelPackaging(ctx, nil, id)
+func (s serviceImpl) DeleteModelPackaging(ctx context.Context, id string) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	oldMp, err := s.repo.GetModelPackaging(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.DeleteModelPackaging(ctx, tx, id); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.ModelPackagingDeletedEventType,
+		EventGroup: event.ModelPackagingEventGroup,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelPackagingKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    oldMp.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s serviceImpl) SetDeletionMark(ctx context.Context, id string, value bool) error {
 	return s.repo.SetDeletionMark(ctx, nil, id, value)
 }
 
-func (s serviceImpl) UpdateModelPackaging(ctx context.Context, mp *packaging.ModelPackaging) error {
+func (s serviceImpl) UpdateModelPackaging(ctx context.Context, mp *packaging.ModelPackaging) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
 	mp.UpdatedAt = time.Now()
-	oldMp, err := s.GetModelPackaging(ctx, mp.ID)
+	oldMp, err := s.repo.GetModelPackaging(ctx, tx, mp.ID)
 	if err != nil {
 		return err
 	}
@@ -78,7 +128,28 @@ func (s serviceImpl) UpdateModelPackaging(ctx context.Context, mp *packaging.Mod
 	mp.Status = v1alpha1.ModelPackagingStatus{
 		State: v1alpha1.ModelPackagingUnknown,
 	}
-	return s.repo.UpdateModelPackaging(ctx, nil, mp)
+	if err = s.repo.UpdateModelPackaging(ctx, tx, mp); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   mp.ID,
+		EventType:  event.ModelPackagingUpdatedEventType,
+		EventGroup: event.ModelPackagingEventGroup,
+		Payload:    *mp,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelPackagingKind,
+		EntityID:   mp.ID,
+		Operation:  audit.UpdateOperation,
+		OldSpec:    oldMp.Spec,
+		NewSpec:    mp.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s serviceImpl) UpdateModelPackagingStatus(
@@ -121,24 +192,64 @@ func (s serviceImpl) UpdateModelPackagingStatus(
 		return odahu_errors.SpecWasTouched{Entity: id}
 	}
 
+	if hashutil.Equal(oldMt.Status, status) {
+		// Status is not changed. Skip updating in database and publishing event
+		return nil
+	}
+
 	err = s.repo.UpdateModelPackagingStatus(ctx, tx, id, status)
 	if err != nil {
 		return err
 	}
 
-	return err
+	updated := *oldMt
+	updated.Status = status
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.ModelPackagingStatusUpdatedEventType,
+		EventGroup: event.ModelPackagingEventGroup,
+		Payload:    updated,
+	}
+	return s.eventPub.PublishEvent(ctx, tx, e)
 }
 
-func (s serviceImpl) CreateModelPackaging(ctx context.Context, mp *packaging.ModelPackaging) error {
+func (s serviceImpl) CreateModelPackaging(ctx context.Context, mp *packaging.ModelPackaging) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
 	mp.CreatedAt = time.Now()
 	mp.UpdatedAt = time.Now()
 	mp.DeletionMark = false
 	mp.Status = v1alpha1.ModelPackagingStatus{
 		State: v1alpha1.ModelPackagingUnknown,
 	}
-	return s.repo.SaveModelPackaging(ctx, nil, mp)
+	if err = s.repo.SaveModelPackaging(ctx, tx, mp); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   mp.ID,
+		EventType:  event.ModelPackagingCreatedEventType,
+		EventGroup: event.ModelPackagingEventGroup,
+		Payload:    *mp,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelPackagingKind,
+		EntityID:   mp.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    mp.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
-func NewService(repo repo.Repository) Service {
-	return &serviceImpl{repo: repo}
+func NewService(repo repo.Repository, eventPub EventPublisher, auditRecorder AuditRecorder) Service {
+	return &serviceImpl{repo: repo, eventPub: eventPub, auditRecorder: auditRecorder}
 }
@@ -22,10 +22,13 @@ import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
 	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/packaging/mocks"
 	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/packaging"
+	service_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/packaging/mocks"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -44,12 +47,14 @@ func TestSuiteRun(t *testing.T) {
 
 type TestSuite struct {
 	suite.Suite
-	mockRepo *mocks.Repository
-	service  service.Service
-	db       *sql.DB
-	dbMock   sqlmock.Sqlmock
-	as       *assert.Assertions
-	nilTx    *sql.Tx
+	mockRepo      *mocks.Repository
+	mockRecorder  *service_mocks.AuditRecorder
+	mockPublisher *service_mocks.EventPublisher
+	service       service.Service
+	db            *sql.DB
+	dbMock        sqlmock.Sqlmock
+	as            *assert.Assertions
+	nilTx         *sql.Tx
 }
 
 func (s *TestSuite) SetupSuite() {
@@ -62,11 +67,15 @@ func (s *TestSuite) SetupTest() {
 		s.T().Fatal("Unable initialize sql mock")
 	}
 	mockRepo := &mocks.Repository{}
+	mockRecorder := &service_mocks.AuditRecorder{}
+	mockPublisher := &service_mocks.EventPublisher{}
 
 	s.mockRepo = mockRepo
+	s.mockRecorder = mockRecorder
+	s.mockPublisher = mockPublisher
 	s.db = db
 	s.dbMock = dbMock
-	s.service = service.NewService(mockRepo)
+	s.service = service.NewService(mockRepo, mockPublisher, mockRecorder)
 }
 
 func (s *TestSuite) TestGetModelPackaging() {
@@ -119,10 +128,27 @@ func (s *TestSuite) TestDeleteModelPackaging() {
 	as := assert.New(s.T())
 
 	ctx := context.Background()
-	s.mockRepo.On("DeleteModelPackaging", ctx, s.nilTx, enID).Return(nil)
+	mockTx := s.expectTx(true)
+	en := newStubMT()
+	s.mockRepo.On("GetModelPackaging", ctx, mockTx, enID).Return(en, nil)
+	s.mockRepo.On("DeleteModelPackaging", ctx, mockTx, enID).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, event.Event{
+		EntityID:   enID,
+		EventType:  event.ModelPackagingDeletedEventType,
+		EventGroup: event.ModelPackagingEventGroup,
+	}).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelPackagingKind,
+		EntityID:   enID,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    en.Spec,
+	}).Return(nil)
 
 	as.NoError(s.service.DeleteModelPackaging(ctx, enID))
+	as.NoError(s.dbMock.ExpectationsWereMet())
 	s.mockRepo.AssertExpectations(s.T())
+	s.mockPublisher.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
 }
 
 func (s *TestSuite) TestSetDeletionMark() {
@@ -140,8 +166,13 @@ func (s *TestSuite) TestUpdateModelPackaging() {
 
 	ctx := context.Background()
 	en := newStubMT()
-	s.mockRepo.On("UpdateModelPackaging", ctx, s.nilTx, en).Return(nil)
-	s.mockRepo.On("GetModelPackaging", ctx, s.nilTx, en.ID).Return(en, nil)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("UpdateModelPackaging", ctx, mockTx, en).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.MatchedBy(func(e event.Event) bool {
+		return e.EventType == event.ModelPackagingUpdatedEventType && e.EntityID == en.ID
+	})).Return(nil)
+	s.mockRepo.On("GetModelPackaging", ctx, mockTx, en.ID).Return(en, nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	as.NoError(s.service.UpdateModelPackaging(ctx, en))
 	s.mockRepo.AssertExpectations(s.T())
@@ -152,8 +183,11 @@ func (s *TestSuite) TestUpdateModelPackaging_UpdatedAt() {
 
 	ctx := context.Background()
 	en := newStubMT()
-	s.mockRepo.On("UpdateModelPackaging", ctx, s.nilTx, en).Return(nil)
-	s.mockRepo.On("GetModelPackaging", ctx, s.nilTx, en.ID).Return(en, nil)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("UpdateModelPackaging", ctx, mockTx, en).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.AnythingOfType("event.Event")).Return(nil)
+	s.mockRepo.On("GetModelPackaging", ctx, mockTx, en.ID).Return(en, nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	timeBeforeCall := time.Now()
 	as.NoError(s.service.UpdateModelPackaging(ctx, en))
@@ -188,11 +222,39 @@ func (s *TestSuite) TestUpdateModelPackagingStatus() {
 		On("UpdateModelPackagingStatus", ctx, mockTx, enID, newStatus).
 		Return(nil)
 
+	// Event with the new status must be published in the same transaction
+	updatedEn := *repoEn
+	updatedEn.Status = newStatus
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, event.Event{
+		EntityID:   enID,
+		EventType:  event.ModelPackagingStatusUpdatedEventType,
+		EventGroup: event.ModelPackagingEventGroup,
+		Payload:    updatedEn,
+	}).Return(nil)
+
 	// Call service with the same spec snapshot as in repository and new status
 	specSnapshot := repoEn.Spec
 	as.NoError(s.service.UpdateModelPackagingStatus(ctx, enID, newStatus, specSnapshot))
 	as.NoError(s.dbMock.ExpectationsWereMet())
 	s.mockRepo.AssertExpectations(s.T())
+	s.mockPublisher.AssertExpectations(s.T())
+}
+
+func (s *TestSuite) TestUpdateModelPackagingStatusNotChanged() {
+	as := assert.New(s.T())
+
+	ctx := context.Background()
+	mockTx := s.expectTx(true)
+	repoEn := newStubMT()
+	s.mockRepo.On("GetModelPackaging", ctx, mockTx, enID).Return(repoEn, nil)
+
+	// Call service with the same status as in repository
+	as.NoError(s.service.UpdateModelPackagingStatus(ctx, enID, repoEn.Status, repoEn.Spec))
+	as.NoError(s.dbMock.ExpectationsWereMet())
+	s.mockRepo.AssertExpectations(s.T())
+	// Neither status update nor event must happen
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateModelPackagingStatus")
+	s.mockPublisher.AssertNotCalled(s.T(), "PublishEvent")
 }
 
 func (s *TestSuite) TestUpdateModelPackagingStatusSpecTouched() {
@@ -237,7 +299,18 @@ func (s *TestSuite) TestCreateModelPackaging() {
 
 	en := newStubMT()
 	ctx := context.Background()
-	s.mockRepo.On("SaveModelPackaging", ctx, s.nilTx, en).Return(nil)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("SaveModelPackaging", ctx, mockTx, en).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.MatchedBy(func(e event.Event) bool {
+		return e.EventType == event.ModelPackagingCreatedEventType && e.EventGroup == event.ModelPackagingEventGroup &&
+			e.EntityID == enID
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelPackagingKind,
+		EntityID:   enID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    en.Spec,
+	}).Return(nil)
 
 	as.NoError(s.service.CreateModelPackaging(ctx, en))
 	s.mockRepo.AssertExpectations(s.T())
@@ -247,7 +320,15 @@ func (s *TestSuite) TestCreateModelPackaging_CreatedAt() {
 
 	en := newStubMT()
 	ctx := context.Background()
-	s.mockRepo.On("SaveModelPackaging", ctx, s.nilTx, en).Return(nil)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("SaveModelPackaging", ctx, mockTx, en).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.AnythingOfType("event.Event")).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelPackagingKind,
+		EntityID:   enID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    en.Spec,
+	}).Return(nil)
 
 	timeBeforeCall := time.Now()
 	as.NoError(s.service.CreateModelPackaging(ctx, en))
@@ -263,14 +344,36 @@ func (s *TestSuite) TestCreateModelPackaging_Error() {
 	en := newStubMT()
 	ctx := context.Background()
 	anyError := errors.New("any error")
-	s.mockRepo.On("SaveModelPackaging", ctx, s.nilTx, en).Return(anyError)
+	mockTx := s.expectTx(false)
+	s.mockRepo.On("SaveModelPackaging", ctx, mockTx, en).Return(anyError)
 
 	as.Error(s.service.CreateModelPackaging(ctx, en))
+	as.NoError(s.dbMock.ExpectationsWereMet())
 	s.mockRepo.AssertExpectations(s.T())
+	// Nothing must be recorded if entity was not saved
+	s.mockRecorder.AssertNotCalled(s.T(), "Record")
+	s.mockPublisher.AssertNotCalled(s.T(), "PublishEvent")
 }
 
 // Helpers
 
+// expectTx opens a mock transaction and assumes that service commits or rolls it back
+func (s *TestSuite) expectTx(commit bool) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	if commit {
+		s.dbMock.ExpectCommit()
+	} else {
+		s.dbMock.ExpectRollback()
+	}
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
 func newStubFilter() filter.ListOption {
 	return func(options *filter.ListOptions) {
 	}
@@ -0,0 +1,249 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"time"
+)
+
+const StageEntityAlreadyExistsErrorMessage = "%s with ID %q already exists"
+
+var log = logf.Log.WithName("model-pipeline--service")
+
+type Repository interface {
+	Create(ctx context.Context, tx *sql.Tx, p pipeline.ModelPipeline) error
+	Get(ctx context.Context, tx *sql.Tx, id string) (pipeline.ModelPipeline, error)
+	List(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) ([]pipeline.ModelPipeline, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, id string, status pipeline.ModelPipelineStatus) error
+	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+}
+
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, tx *sql.Tx, event event.Event) (err error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
+type TrainingService interface {
+	GetModelTraining(ctx context.Context, id string) (*training.ModelTraining, error)
+	CreateModelTraining(ctx context.Context, mt *training.ModelTraining) error
+}
+
+type PackagingService interface {
+	GetModelPackaging(ctx context.Context, id string) (*packaging.ModelPackaging, error)
+	CreateModelPackaging(ctx context.Context, mp *packaging.ModelPackaging) error
+}
+
+type DeploymentService interface {
+	GetModelDeployment(ctx context.Context, id string) (*deployment.ModelDeployment, error)
+	CreateModelDeployment(ctx context.Context, md *deployment.ModelDeployment) error
+}
+
+type Service struct {
+	repo          Repository
+	eventPub      EventPublisher
+	auditRecorder AuditRecorder
+	trainService  TrainingService
+	packService   PackagingService
+	depService    DeploymentService
+}
+
+func NewService(
+	repo Repository, eventPub EventPublisher, auditRecorder AuditRecorder,
+	trainService TrainingService, packService PackagingService, depService DeploymentService,
+) *Service {
+	return &Service{
+		repo:          repo,
+		eventPub:      eventPub,
+		auditRecorder: auditRecorder,
+		trainService:  trainService,
+		packService:   packService,
+		depService:    depService,
+	}
+}
+
+// Create creates a pipeline. The training stage is started by the pipeline runner
+func (s *Service) Create(ctx context.Context, p *pipeline.ModelPipeline) (err error) {
+
+	// Set fields that managed by platform. Cannot be overridden by user
+	p.CreatedAt = time.Now().UTC()
+	p.UpdatedAt = time.Now().UTC()
+	p.Status = pipeline.ModelPipelineStatus{
+		State:        pipeline.Running,
+		CurrentStage: pipeline.TrainingStage,
+	}
+
+	SetDefaults(p)
+	if errs := ValidateCreate(*p); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           p.ID,
+			ValidationErrors: errs,
+		}
+	}
+
+	// Stage entities get the pipeline ID, so they must not be created by somebody else
+	if err = s.checkStageEntitiesAbsent(ctx, *p); err != nil {
+		return err
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.Create(ctx, tx, *p); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   p.ID,
+		EventType:  event.ModelPipelineCreatedEventType,
+		EventGroup: event.ModelPipelineEventGroup,
+		Payload:    *p,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelPipelineKind,
+		EntityID:   p.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    p.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+func (s *Service) checkStageEntitiesAbsent(ctx context.Context, p pipeline.ModelPipeline) error {
+	var errs []error
+
+	_, err := s.trainService.GetModelTraining(ctx, p.ID)
+	switch {
+	case err == nil:
+		errs = append(errs, fmt.Errorf(StageEntityAlreadyExistsErrorMessage, "ModelTraining", p.ID))
+	case !odahuErrs.IsNotFoundError(err):
+		return err
+	}
+
+	_, err = s.packService.GetModelPackaging(ctx, p.ID)
+	switch {
+	case err == nil:
+		errs = append(errs, fmt.Errorf(StageEntityAlreadyExistsErrorMessage, "ModelPackaging", p.ID))
+	case !odahuErrs.IsNotFoundError(err):
+		return err
+	}
+
+	if p.Spec.Deployment != nil {
+		_, err = s.depService.GetModelDeployment(ctx, p.ID)
+		switch {
+		case err == nil:
+			errs = append(errs, fmt.Errorf(StageEntityAlreadyExistsErrorMessage, "ModelDeployment", p.ID))
+		case !odahuErrs.IsNotFoundError(err):
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           p.ID,
+			ValidationErrors: errs,
+		}
+	}
+	return nil
+}
+
+// Delete deletes the pipeline. Trainings, packagings and deployments which were created by the pipeline are kept
+func (s *Service) Delete(ctx context.Context, id string) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.ModelPipelineDeletedEventType,
+		EventGroup: event.ModelPipelineEventGroup,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelPipelineKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    old.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+// UpdateStatus saves the status of the pipeline and publishes it as an event
+func (s *Service) UpdateStatus(ctx context.Context, p pipeline.ModelPipeline) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.UpdateStatus(ctx, tx, p.ID, p.Status); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   p.ID,
+		EventType:  event.ModelPipelineStatusUpdatedEventType,
+		EventGroup: event.ModelPipelineEventGroup,
+		Payload:    p,
+	}
+	return s.eventPub.PublishEvent(ctx, tx, e)
+}
+
+func (s *Service) Get(ctx context.Context, id string) (pipeline.ModelPipeline, error) {
+	return s.repo.Get(ctx, nil, id)
+}
+
+func (s *Service) List(ctx context.Context, options ...filter.ListOption) ([]pipeline.ModelPipeline, error) {
+	return s.repo.List(ctx, nil, options...)
+}
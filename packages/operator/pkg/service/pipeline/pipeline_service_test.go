@@ -0,0 +1,190 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/pipeline/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const (
+	pipelineID = "wine"
+)
+
+func TestServiceSuiteRun(t *testing.T) {
+	suite.Run(t, new(ServiceSuite))
+}
+
+type ServiceSuite struct {
+	suite.Suite
+	mockRepo        *mocks.Repository
+	mockPublisher   *mocks.EventPublisher
+	mockRecorder    *mocks.AuditRecorder
+	mockTrainings   *mocks.TrainingService
+	mockPackagings  *mocks.PackagingService
+	mockDeployments *mocks.DeploymentService
+	service         *service.Service
+	db              *sql.DB
+	dbMock          sqlmock.Sqlmock
+}
+
+func (s *ServiceSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockPublisher = &mocks.EventPublisher{}
+	s.mockRecorder = &mocks.AuditRecorder{}
+	s.mockTrainings = &mocks.TrainingService{}
+	s.mockPackagings = &mocks.PackagingService{}
+	s.mockDeployments = &mocks.DeploymentService{}
+	s.db = db
+	s.dbMock = dbMock
+	s.service = service.NewService(
+		s.mockRepo, s.mockPublisher, s.mockRecorder, s.mockTrainings, s.mockPackagings, s.mockDeployments,
+	)
+}
+
+func (s *ServiceSuite) TestCreateSetsDefaults() {
+	ctx := context.Background()
+	p := newStubPipeline()
+	p.Status.State = apis.Succeeded
+	s.expectNoStageEntities(ctx)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Create", ctx, mockTx, mock.MatchedBy(func(created apis.ModelPipeline) bool {
+		return created.Spec.Packaging.ArtifactName == apis.DefaultArtifactNameTemplate &&
+			created.Spec.Deployment.Image == apis.DefaultImageTemplate &&
+			created.Status.State == apis.Running && created.Status.CurrentStage == apis.TrainingStage &&
+			!created.CreatedAt.IsZero()
+	})).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.MatchedBy(func(e event.Event) bool {
+		return e.EventType == event.ModelPipelineCreatedEventType && e.EventGroup == event.ModelPipelineEventGroup
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.MatchedBy(func(change audit.Change) bool {
+		return change.EntityKind == audit.ModelPipelineKind && change.Operation == audit.CreateOperation
+	})).Return(nil)
+
+	err := s.service.Create(ctx, p)
+	s.Assertions.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockPublisher.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestCreateInvalidTemplates() {
+	ctx := context.Background()
+	p := newStubPipeline()
+	p.Spec.Packaging.ArtifactName = "{{ .Training.ArtifactName"
+	p.Spec.Packaging.Arguments = map[string]interface{}{"imageName": "{{ .Training.RunID }", "replicas": 1}
+
+	err := s.service.Create(ctx, p)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.Assertions.Len(err.(odahu_errs.InvalidEntityError).ValidationErrors, 2)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestCreateStageEntityExists() {
+	ctx := context.Background()
+	p := newStubPipeline()
+	s.mockTrainings.On("GetModelTraining", ctx, pipelineID).Return(&training.ModelTraining{ID: pipelineID}, nil)
+	s.mockPackagings.On("GetModelPackaging", ctx, pipelineID).Return(
+		nil, odahu_errs.NotFoundError{Entity: pipelineID},
+	)
+	s.mockDeployments.On("GetModelDeployment", ctx, pipelineID).Return(
+		&deployment.ModelDeployment{ID: pipelineID}, nil,
+	)
+
+	err := s.service.Create(ctx, p)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.Assertions.Len(err.(odahu_errs.InvalidEntityError).ValidationErrors, 2)
+	s.mockRepo.AssertNotCalled(s.T(), "BeginTransaction", mock.Anything)
+}
+
+func (s *ServiceSuite) TestDeleteKeepsStageEntities() {
+	ctx := context.Background()
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Get", ctx, mockTx, pipelineID).Return(*newStubPipeline(), nil)
+	s.mockRepo.On("Delete", ctx, mockTx, pipelineID).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.MatchedBy(func(e event.Event) bool {
+		return e.EventType == event.ModelPipelineDeletedEventType
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
+
+	err := s.service.Delete(ctx, pipelineID)
+	s.Assertions.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockTrainings.AssertNotCalled(s.T(), "DeleteModelTraining", mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) expectNoStageEntities(ctx context.Context) {
+	notFound := odahu_errs.NotFoundError{Entity: pipelineID}
+	s.mockTrainings.On("GetModelTraining", ctx, pipelineID).Return(nil, notFound)
+	s.mockPackagings.On("GetModelPackaging", ctx, pipelineID).Return(nil, notFound)
+	s.mockDeployments.On("GetModelDeployment", ctx, pipelineID).Return(nil, notFound)
+}
+
+func (s *ServiceSuite) expectTx(commit bool) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	if commit {
+		s.dbMock.ExpectCommit()
+	} else {
+		s.dbMock.ExpectRollback()
+	}
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
+func newStubPipeline() *apis.ModelPipeline {
+	return &apis.ModelPipeline{
+		ID: pipelineID,
+		Spec: apis.ModelPipelineSpec{
+			Training: v1alpha1.ModelTrainingSpec{
+				Model:     v1alpha1.ModelIdentity{Name: "wine", Version: "1.0"},
+				Toolchain: "mlflow",
+			},
+			Packaging: packaging.ModelPackagingSpec{
+				IntegrationName: "docker-rest",
+				Image:           "packager:latest",
+			},
+			Deployment: &v1alpha1.ModelDeploymentSpec{
+				Predictor: "odahu-ml-server",
+			},
+		},
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
+	"go.uber.org/multierr"
+)
+
+// SetDefaults fills templated references between stages if they are missed
+func SetDefaults(p *pipeline.ModelPipeline) {
+	if len(p.Spec.Packaging.ArtifactName) == 0 {
+		p.Spec.Packaging.ArtifactName = pipeline.DefaultArtifactNameTemplate
+	}
+	if p.Spec.Deployment != nil && len(p.Spec.Deployment.Image) == 0 {
+		p.Spec.Deployment.Image = pipeline.DefaultImageTemplate
+	}
+}
+
+// ValidateCreate validates the pipeline ID and templates. Stage specifications are validated
+// by the API server together with other trainings, packagings and deployments
+func ValidateCreate(p pipeline.ModelPipeline) (errs []error) {
+
+	var err error
+
+	err = multierr.Append(err, validation.ValidateID(p.ID))
+	for _, templateErr := range ValidateTemplates(p.Spec) {
+		err = multierr.Append(err, templateErr)
+	}
+
+	if err != nil {
+		return multierr.Errors(err)
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"reflect"
+	"time"
+)
+
+const (
+	StageFailedMessage          = "%s stage failed: %s"
+	StageEntityDeletedMessage   = "%s %q was deleted before the stage finished"
+	NoTrainingArtifactsMessage  = "training has not produced any artifact"
+	UnknownStageFailureMessage  = "unknown reason"
+	DeploymentFailedMessage     = "model deployment is failed"
+	TemplateRenderFailedMessage = "%s stage cannot be started: %s"
+)
+
+type outcome int
+
+const (
+	stageRunning outcome = iota
+	stageSucceeded
+	stageFailed
+)
+
+// Current state of the entity created by a stage
+type stageState struct {
+	state   string
+	outcome outcome
+	results map[string]string
+	message string
+}
+
+// Runner starts stages of running pipelines and advances pipelines when stages succeed
+type Runner struct {
+	service *Service
+	now     func() time.Time
+}
+
+func NewRunner(service *Service) *Runner {
+	return &Runner{service: service, now: time.Now}
+}
+
+// NewRunnerWithClock is used in tests to control the current time
+func NewRunnerWithClock(service *Service, now func() time.Time) *Runner {
+	return &Runner{service: service, now: now}
+}
+
+// Advance processes all running pipelines once
+func (r *Runner) Advance(ctx context.Context) error {
+	runningFilter := filter.ListFilter(&pipeline.Filter{State: []string{string(pipeline.Running)}})
+
+	// Pipelines which finish during the processing leave the filtered list and shift pages.
+	// Skipped pipelines are processed by the next call
+	for page := 0; ; page++ {
+		pipelines, err := r.service.List(ctx, runningFilter, filter.Page(page))
+		if err != nil {
+			return err
+		}
+		if len(pipelines) == 0 {
+			return nil
+		}
+
+		for _, p := range pipelines {
+			// A failure of one pipeline must not block others
+			if err := r.process(ctx, p); err != nil {
+				log.Error(err, "Unable to process the model pipeline", "pipeline", p.ID)
+			}
+		}
+	}
+}
+
+func (r *Runner) process(ctx context.Context, p pipeline.ModelPipeline) error {
+	status := copyStatus(p.Status)
+	status.LastError = ""
+
+	if err := r.advance(ctx, p, &status); err != nil {
+		// The attempt is repeated during the next processing
+		status.LastError = err.Error()
+		log.Error(err, "Unable to advance the model pipeline", "pipeline", p.ID)
+	}
+
+	if reflect.DeepEqual(p.Status, status) {
+		return nil
+	}
+	p.Status = status
+	return r.service.UpdateStatus(ctx, p)
+}
+
+// advance starts the current stage if it was not started and moves the pipeline to the next stage
+// if the current one succeeded
+func (r *Runner) advance(ctx context.Context, p pipeline.ModelPipeline, status *pipeline.ModelPipelineStatus) error {
+	if len(status.CurrentStage) == 0 {
+		status.CurrentStage = pipeline.TrainingStage
+	}
+
+	for !status.Finished() {
+		stage := status.CurrentStage
+
+		if status.StageStatus(stage) == nil {
+			err := r.startStage(ctx, p, stage, NewTemplateData(*status))
+			if _, ok := err.(TemplateError); ok {
+				status.State = pipeline.Failed
+				status.Message = fmt.Sprintf(TemplateRenderFailedMessage, stage, err.Error())
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			now := r.now().UTC()
+			status.Stages = append(status.Stages, pipeline.StageStatus{
+				Stage:     stage,
+				EntityID:  p.ID,
+				StartedAt: &now,
+			})
+			log.Info("Stage of the model pipeline is started", "pipeline", p.ID, "stage", stage)
+		}
+		ss := status.StageStatus(stage)
+
+		state, err := r.getStageState(ctx, stage, ss.EntityID)
+		if odahuErrs.IsNotFoundError(err) {
+			state = stageState{
+				outcome: stageFailed,
+				message: fmt.Sprintf(StageEntityDeletedMessage, stage, ss.EntityID),
+			}
+		} else if err != nil {
+			return err
+		}
+		ss.State = state.state
+
+		switch state.outcome {
+		case stageRunning:
+			return nil
+		case stageFailed:
+			now := r.now().UTC()
+			ss.FinishedAt = &now
+			ss.Message = state.message
+			status.State = pipeline.Failed
+			status.Message = fmt.Sprintf(StageFailedMessage, stage, state.message)
+			log.Info("Model pipeline failed", "pipeline", p.ID, "stage", stage, "reason", state.message)
+		case stageSucceeded:
+			now := r.now().UTC()
+			ss.FinishedAt = &now
+			ss.Results = state.results
+			next := nextStage(p.Spec, stage)
+			if len(next) == 0 {
+				status.State = pipeline.Succeeded
+				log.Info("Model pipeline succeeded", "pipeline", p.ID)
+			} else {
+				status.CurrentStage = next
+			}
+		}
+	}
+
+	return nil
+}
+
+// startStage creates the entity of the stage. The entity has the pipeline ID, so an entity which was
+// created before a failed status update is not created twice
+func (r *Runner) startStage(
+	ctx context.Context, p pipeline.ModelPipeline, stage pipeline.Stage, data TemplateData) (err error) {
+
+	switch stage {
+	case pipeline.TrainingStage:
+		mt := &training.ModelTraining{ID: p.ID, Spec: *p.Spec.Training.DeepCopy()}
+		err = r.service.trainService.CreateModelTraining(ctx, mt)
+	case pipeline.PackagingStage:
+		spec, renderErr := RenderPackaging(p.Spec.Packaging, data)
+		if renderErr != nil {
+			return renderErr
+		}
+		err = r.service.packService.CreateModelPackaging(ctx, &packaging.ModelPackaging{ID: p.ID, Spec: spec})
+	case pipeline.DeploymentStage:
+		spec, renderErr := RenderDeployment(*p.Spec.Deployment, data)
+		if renderErr != nil {
+			return renderErr
+		}
+		err = r.service.depService.CreateModelDeployment(ctx, &deployment.ModelDeployment{ID: p.ID, Spec: spec})
+	default:
+		return fmt.Errorf("unknown stage %q", stage)
+	}
+
+	if err != nil && !odahuErrs.IsAlreadyExistError(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *Runner) getStageState(ctx context.Context, stage pipeline.Stage, id string) (stageState, error) {
+	switch stage {
+	case pipeline.TrainingStage:
+		mt, err := r.service.trainService.GetModelTraining(ctx, id)
+		if err != nil {
+			return stageState{}, err
+		}
+		return trainingState(mt.Status), nil
+	case pipeline.PackagingStage:
+		mp, err := r.service.packService.GetModelPackaging(ctx, id)
+		if err != nil {
+			return stageState{}, err
+		}
+		return packagingState(mp.Status), nil
+	case pipeline.DeploymentStage:
+		md, err := r.service.depService.GetModelDeployment(ctx, id)
+		if err != nil {
+			return stageState{}, err
+		}
+		return deploymentState(md.Status), nil
+	default:
+		return stageState{}, fmt.Errorf("unknown stage %q", stage)
+	}
+}
+
+func trainingState(status v1alpha1.ModelTrainingStatus) stageState {
+	state := stageState{state: string(status.State)}
+
+	switch status.State {
+	case v1alpha1.ModelTrainingSucceeded:
+		if len(status.Artifacts) == 0 {
+			state.outcome = stageFailed
+			state.message = NoTrainingArtifactsMessage
+			return state
+		}
+		// The last artifact is produced by the last run
+		artifact := status.Artifacts[len(status.Artifacts)-1]
+		state.outcome = stageSucceeded
+		state.results = map[string]string{
+			ArtifactNameResult: artifact.ArtifactName,
+			RunIDResult:        artifact.RunID,
+			CommitIDResult:     artifact.CommitID,
+		}
+	case v1alpha1.ModelTrainingFailed:
+		state.outcome = stageFailed
+		state.message = failureMessage(status.Message, status.Reason)
+	}
+
+	return state
+}
+
+func packagingState(status v1alpha1.ModelPackagingStatus) stageState {
+	state := stageState{state: string(status.State)}
+
+	switch status.State {
+	case v1alpha1.ModelPackagingSucceeded:
+		state.outcome = stageSucceeded
+		state.results = make(map[string]string, len(status.Results))
+		for _, result := range status.Results {
+			state.results[result.Name] = result.Value
+		}
+	case v1alpha1.ModelPackagingFailed, v1alpha1.ModelPackagingArtifactNotFound:
+		state.outcome = stageFailed
+		state.message = failureMessage(status.Message, status.Reason)
+	}
+
+	return state
+}
+
+func deploymentState(status v1alpha1.ModelDeploymentStatus) stageState {
+	state := stageState{state: string(status.State)}
+
+	switch status.State {
+	case v1alpha1.ModelDeploymentStateReady:
+		state.outcome = stageSucceeded
+		state.results = map[string]string{
+			HostHeaderResult:   status.HostHeader,
+			ModelNameResult:    status.ModelName,
+			ModelVersionResult: status.ModelVersion,
+		}
+	case v1alpha1.ModelDeploymentStateFailed:
+		state.outcome = stageFailed
+		state.message = DeploymentFailedMessage
+	}
+
+	return state
+}
+
+func failureMessage(message *string, reason *string) string {
+	switch {
+	case message != nil && len(*message) > 0:
+		return *message
+	case reason != nil && len(*reason) > 0:
+		return *reason
+	default:
+		return UnknownStageFailureMessage
+	}
+}
+
+// nextStage returns the stage after the given one or an empty string if the stage is the last one
+func nextStage(spec pipeline.ModelPipelineSpec, stage pipeline.Stage) pipeline.Stage {
+	stages := spec.Stages()
+	for i, s := range stages {
+		if s == stage && i+1 < len(stages) {
+			return stages[i+1]
+		}
+	}
+	return ""
+}
+
+// copyStatus makes a deep copy of the status, so changes can be compared with the original
+func copyStatus(status pipeline.ModelPipelineStatus) pipeline.ModelPipelineStatus {
+	res := status
+	res.Stages = nil
+	for _, ss := range status.Stages {
+		if ss.Results != nil {
+			results := make(map[string]string, len(ss.Results))
+			for name, value := range ss.Results {
+				results[name] = value
+			}
+			ss.Results = results
+		}
+		res.Stages = append(res.Stages, ss)
+	}
+	return res
+}
@@ -0,0 +1,245 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/pipeline/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+var runnerNow = time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC)
+
+func TestRunnerSuiteRun(t *testing.T) {
+	suite.Run(t, new(RunnerSuite))
+}
+
+type RunnerSuite struct {
+	suite.Suite
+	mockRepo        *mocks.Repository
+	mockPublisher   *mocks.EventPublisher
+	mockTrainings   *mocks.TrainingService
+	mockPackagings  *mocks.PackagingService
+	mockDeployments *mocks.DeploymentService
+	runner          *service.Runner
+	db              *sql.DB
+	dbMock          sqlmock.Sqlmock
+	nilTx           *sql.Tx
+	ctx             context.Context
+}
+
+func (s *RunnerSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockPublisher = &mocks.EventPublisher{}
+	s.mockTrainings = &mocks.TrainingService{}
+	s.mockPackagings = &mocks.PackagingService{}
+	s.mockDeployments = &mocks.DeploymentService{}
+	s.db = db
+	s.dbMock = dbMock
+	s.ctx = context.Background()
+	pipelineService := service.NewService(
+		s.mockRepo, s.mockPublisher, &mocks.AuditRecorder{},
+		s.mockTrainings, s.mockPackagings, s.mockDeployments,
+	)
+	s.runner = service.NewRunnerWithClock(pipelineService, func() time.Time { return runnerNow })
+}
+
+func (s *RunnerSuite) TestStartsTraining() {
+	p := newRunningPipeline()
+	s.mockTrainings.On("CreateModelTraining", s.ctx, &training.ModelTraining{
+		ID: pipelineID, Spec: p.Spec.Training,
+	}).Return(nil)
+	s.expectTraining(v1alpha1.ModelTrainingScheduling)
+
+	status := s.advance(p)
+
+	s.Assertions.Equal(apis.Running, status.State)
+	s.Assertions.Equal(apis.TrainingStage, status.CurrentStage)
+	s.Assertions.Len(status.Stages, 1)
+	s.Assertions.Equal(string(v1alpha1.ModelTrainingScheduling), status.Stages[0].State)
+	s.Assertions.Equal(runnerNow, *status.Stages[0].StartedAt)
+	s.mockTrainings.AssertExpectations(s.T())
+}
+
+func (s *RunnerSuite) TestStartsPackagingWithTrainingArtifact() {
+	p := newRunningPipeline()
+	p.Spec.Packaging.Arguments = map[string]interface{}{"imageName": "wine:{{ .Training.RunID }}", "port": 5000}
+	p.Status.Stages = []apis.StageStatus{{Stage: apis.TrainingStage, EntityID: pipelineID}}
+	s.expectTraining(v1alpha1.ModelTrainingSucceeded)
+	s.mockPackagings.On("CreateModelPackaging", s.ctx, mock.MatchedBy(func(mp *packaging.ModelPackaging) bool {
+		return mp.ID == pipelineID && mp.Spec.ArtifactName == "wine-artifact" &&
+			mp.Spec.Arguments["imageName"] == "wine:run-1" && mp.Spec.Arguments["port"] == 5000
+	})).Return(nil)
+	s.expectPackaging(v1alpha1.ModelPackagingRunning)
+
+	status := s.advance(p)
+
+	s.Assertions.Equal(apis.Running, status.State)
+	s.Assertions.Equal(apis.PackagingStage, status.CurrentStage)
+	s.Assertions.Equal("wine-artifact", status.Stages[0].Results[service.ArtifactNameResult])
+	s.Assertions.NotNil(status.Stages[0].FinishedAt)
+	s.Assertions.Equal(string(v1alpha1.ModelPackagingRunning), status.Stages[1].State)
+	s.mockPackagings.AssertExpectations(s.T())
+	// Spec of the pipeline must stay templated
+	s.Assertions.Equal(apis.DefaultArtifactNameTemplate, p.Spec.Packaging.ArtifactName)
+}
+
+func (s *RunnerSuite) TestSucceedsAfterDeployment() {
+	p := newRunningPipeline()
+	p.Status.CurrentStage = apis.PackagingStage
+	p.Status.Stages = []apis.StageStatus{
+		{Stage: apis.TrainingStage, EntityID: pipelineID, Results: map[string]string{"artifactName": "wine-artifact"}},
+		{Stage: apis.PackagingStage, EntityID: pipelineID},
+	}
+	s.expectPackaging(v1alpha1.ModelPackagingSucceeded)
+	s.mockDeployments.On("CreateModelDeployment", s.ctx, mock.MatchedBy(func(md *deployment.ModelDeployment) bool {
+		return md.ID == pipelineID && md.Spec.Image == "registry/wine:1.0"
+	})).Return(odahu_errs.AlreadyExistError{Entity: pipelineID})
+	s.mockDeployments.On("GetModelDeployment", s.ctx, pipelineID).Return(&deployment.ModelDeployment{
+		ID:     pipelineID,
+		Status: v1alpha1.ModelDeploymentStatus{State: v1alpha1.ModelDeploymentStateReady, HostHeader: "wine.host"},
+	}, nil)
+
+	status := s.advance(p)
+
+	s.Assertions.Equal(apis.Succeeded, status.State)
+	s.Assertions.Equal(apis.DeploymentStage, status.CurrentStage)
+	s.Assertions.Equal("wine.host", status.Stages[2].Results[service.HostHeaderResult])
+}
+
+func (s *RunnerSuite) TestFailsWhenStageFails() {
+	p := newRunningPipeline()
+	p.Status.Stages = []apis.StageStatus{{Stage: apis.TrainingStage, EntityID: pipelineID}}
+	message := "out of memory"
+	s.mockTrainings.On("GetModelTraining", s.ctx, pipelineID).Return(&training.ModelTraining{
+		ID:     pipelineID,
+		Status: v1alpha1.ModelTrainingStatus{State: v1alpha1.ModelTrainingFailed, Message: &message},
+	}, nil)
+
+	status := s.advance(p)
+
+	s.Assertions.Equal(apis.Failed, status.State)
+	s.Assertions.Equal("training stage failed: out of memory", status.Message)
+	s.Assertions.Equal(message, status.Stages[0].Message)
+	s.mockPackagings.AssertNotCalled(s.T(), "CreateModelPackaging", mock.Anything, mock.Anything)
+}
+
+func (s *RunnerSuite) TestFailsWhenStageEntityDeleted() {
+	p := newRunningPipeline()
+	p.Status.Stages = []apis.StageStatus{{Stage: apis.TrainingStage, EntityID: pipelineID}}
+	s.mockTrainings.On("GetModelTraining", s.ctx, pipelineID).Return(
+		nil, odahu_errs.NotFoundError{Entity: pipelineID},
+	)
+
+	status := s.advance(p)
+
+	s.Assertions.Equal(apis.Failed, status.State)
+}
+
+func (s *RunnerSuite) TestFailsWhenTemplateCannotBeRendered() {
+	p := newRunningPipeline()
+	p.Spec.Packaging.ArtifactName = "{{ .Packaging.Results.missing }}"
+	p.Status.Stages = []apis.StageStatus{{Stage: apis.TrainingStage, EntityID: pipelineID}}
+	s.expectTraining(v1alpha1.ModelTrainingSucceeded)
+
+	status := s.advance(p)
+
+	s.Assertions.Equal(apis.Failed, status.State)
+	s.Assertions.Contains(status.Message, "packaging.artifactName")
+	s.mockPackagings.AssertNotCalled(s.T(), "CreateModelPackaging", mock.Anything, mock.Anything)
+}
+
+func (s *RunnerSuite) TestRetriesTransientError() {
+	p := newRunningPipeline()
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(errors.New("connection refused"))
+
+	status := s.advance(p)
+
+	s.Assertions.Equal(apis.Running, status.State)
+	s.Assertions.Equal("connection refused", status.LastError)
+	s.Assertions.Len(status.Stages, 0)
+}
+
+func (s *RunnerSuite) advance(p apis.ModelPipeline) (status apis.ModelPipelineStatus) {
+	s.mockRepo.On("List", s.ctx, s.nilTx, mock.AnythingOfType("filter.ListOption"),
+		mock.AnythingOfType("filter.ListOption")).Return([]apis.ModelPipeline{p}, nil).Once()
+	s.mockRepo.On("List", s.ctx, s.nilTx, mock.AnythingOfType("filter.ListOption"),
+		mock.AnythingOfType("filter.ListOption")).Return([]apis.ModelPipeline{}, nil).Once()
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", s.ctx).Return(mockTx, nil)
+	s.mockRepo.On("UpdateStatus", s.ctx, mockTx, p.ID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			status = args.Get(3).(apis.ModelPipelineStatus)
+		}).Return(nil)
+	s.mockPublisher.On("PublishEvent", s.ctx, mockTx, mock.AnythingOfType("event.Event")).Return(nil)
+
+	s.Assertions.NoError(s.runner.Advance(s.ctx))
+
+	return status
+}
+
+func (s *RunnerSuite) expectTraining(state v1alpha1.ModelTrainingState) {
+	s.mockTrainings.On("GetModelTraining", s.ctx, pipelineID).Return(&training.ModelTraining{
+		ID: pipelineID,
+		Status: v1alpha1.ModelTrainingStatus{
+			State:     state,
+			Artifacts: []v1alpha1.TrainingResult{{RunID: "run-1", ArtifactName: "wine-artifact"}},
+		},
+	}, nil)
+}
+
+func (s *RunnerSuite) expectPackaging(state v1alpha1.ModelPackagingState) {
+	s.mockPackagings.On("GetModelPackaging", s.ctx, pipelineID).Return(&packaging.ModelPackaging{
+		ID: pipelineID,
+		Status: v1alpha1.ModelPackagingStatus{
+			State:   state,
+			Results: []v1alpha1.ModelPackagingResult{{Name: "image", Value: "registry/wine:1.0"}},
+		},
+	}, nil)
+}
+
+func newRunningPipeline() apis.ModelPipeline {
+	p := newStubPipeline()
+	service.SetDefaults(p)
+	p.Status = apis.ModelPipelineStatus{State: apis.Running, CurrentStage: apis.TrainingStage}
+	return *p
+}
@@ -0,0 +1,170 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"text/template"
+)
+
+const (
+	InvalidTemplateErrorMessage = "invalid template of %s: %s"
+	RenderTemplateErrorMessage  = "unable to render template of %s: %s"
+)
+
+// Names of stage results which are available in templates
+const (
+	ArtifactNameResult = "artifactName"
+	RunIDResult        = "runId"
+	CommitIDResult     = "commitID"
+	HostHeaderResult   = "hostHeader"
+	ModelNameResult    = "modelName"
+	ModelVersionResult = "modelVersion"
+)
+
+// TemplateData contains results of finished stages which can be referenced by templates
+type TemplateData struct {
+	Training  TrainingData
+	Packaging PackagingData
+}
+
+type TrainingData struct {
+	ArtifactName string
+	RunID        string
+	CommitID     string
+}
+
+type PackagingData struct {
+	// Docker image built by the packaging
+	Image string
+	// All results of the packaging by their names
+	Results map[string]string
+}
+
+// NewTemplateData collects results of finished stages from the pipeline status
+func NewTemplateData(status pipeline.ModelPipelineStatus) TemplateData {
+	data := TemplateData{Packaging: PackagingData{Results: map[string]string{}}}
+
+	if ss := status.StageStatus(pipeline.TrainingStage); ss != nil {
+		data.Training = TrainingData{
+			ArtifactName: ss.Results[ArtifactNameResult],
+			RunID:        ss.Results[RunIDResult],
+			CommitID:     ss.Results[CommitIDResult],
+		}
+	}
+	if ss := status.StageStatus(pipeline.PackagingStage); ss != nil {
+		for name, value := range ss.Results {
+			data.Packaging.Results[name] = value
+		}
+		data.Packaging.Image = ss.Results[pipeline.ImagePackagingResult]
+	}
+
+	return data
+}
+
+// TemplateError means that a template of the pipeline cannot be rendered. It is not fixed by retries
+type TemplateError struct {
+	msg string
+}
+
+func (e TemplateError) Error() string {
+	return e.msg
+}
+
+func parseTemplate(field string, text string) (*template.Template, error) {
+	tmpl, err := template.New(field).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf(InvalidTemplateErrorMessage, field, err.Error())
+	}
+	return tmpl, nil
+}
+
+func render(field string, text string, data TemplateData) (string, error) {
+	tmpl, err := parseTemplate(field, text)
+	if err != nil {
+		return "", TemplateError{msg: err.Error()}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", TemplateError{msg: fmt.Sprintf(RenderTemplateErrorMessage, field, err.Error())}
+	}
+	return buf.String(), nil
+}
+
+// ValidateTemplates checks that all templated fields of the pipeline can be parsed
+func ValidateTemplates(spec pipeline.ModelPipelineSpec) (errs []error) {
+	if _, err := parseTemplate("packaging.artifactName", spec.Packaging.ArtifactName); err != nil {
+		errs = append(errs, err)
+	}
+	for name, value := range spec.Packaging.Arguments {
+		if text, ok := value.(string); ok {
+			if _, err := parseTemplate("packaging.arguments."+name, text); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if spec.Deployment != nil {
+		if _, err := parseTemplate("deployment.image", spec.Deployment.Image); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// RenderPackaging returns the packaging spec with rendered artifact name and arguments
+func RenderPackaging(spec packaging.ModelPackagingSpec, data TemplateData) (packaging.ModelPackagingSpec, error) {
+	rendered := spec
+
+	artifactName, err := render("packaging.artifactName", spec.ArtifactName, data)
+	if err != nil {
+		return rendered, err
+	}
+	rendered.ArtifactName = artifactName
+
+	if spec.Arguments != nil {
+		rendered.Arguments = make(map[string]interface{}, len(spec.Arguments))
+		for name, value := range spec.Arguments {
+			if text, ok := value.(string); ok {
+				if value, err = render("packaging.arguments."+name, text, data); err != nil {
+					return rendered, err
+				}
+			}
+			rendered.Arguments[name] = value
+		}
+	}
+
+	return rendered, nil
+}
+
+// RenderDeployment returns the deployment spec with rendered image
+func RenderDeployment(spec v1alpha1.ModelDeploymentSpec, data TemplateData) (v1alpha1.ModelDeploymentSpec, error) {
+	rendered := *spec.DeepCopy()
+
+	image, err := render("deployment.image", spec.Image, data)
+	if err != nil {
+		return rendered, err
+	}
+	rendered.Image = image
+
+	return rendered, nil
+}
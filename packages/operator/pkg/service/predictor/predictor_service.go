@@ -0,0 +1,234 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package predictor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	md_repository "github.com/odahu/odahu-flow/packages/operator/pkg/repository/deployment"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"odahu-commons/predictors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sort"
+	"time"
+)
+
+const (
+	BuiltinPredictorForbiddenMessage = "predictor %q is built-in and can not be changed"
+	PredictorInUseForbiddenMessage   = "predictor %q is used by model deployment %q"
+)
+
+var log = logf.Log.WithName("predictor--service")
+
+type Repository interface {
+	Create(ctx context.Context, tx *sql.Tx, p deployment.Predictor) error
+	Get(ctx context.Context, tx *sql.Tx, id string) (deployment.Predictor, error)
+	List(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) ([]deployment.Predictor, error)
+	Update(ctx context.Context, tx *sql.Tx, p deployment.Predictor) error
+	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+}
+
+type DeploymentRepository interface {
+	GetModelDeploymentList(
+		ctx context.Context, tx *sql.Tx, options ...filter.ListOption) ([]deployment.ModelDeployment, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
+// Service manages predictors. Built-in predictors are compiled into ODAHU and are read-only,
+// other predictors are stored in the repository
+type Service struct {
+	repo           Repository
+	deploymentRepo DeploymentRepository
+	auditRecorder  AuditRecorder
+}
+
+func NewService(repo Repository, deploymentRepo DeploymentRepository, auditRecorder AuditRecorder) *Service {
+	return &Service{repo: repo, deploymentRepo: deploymentRepo, auditRecorder: auditRecorder}
+}
+
+func builtinPredictors() []deployment.Predictor {
+	res := make([]deployment.Predictor, 0, len(predictors.Predictors))
+	for _, p := range predictors.Predictors {
+		res = append(res, deployment.NewBuiltinPredictor(p))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
+
+func checkNotBuiltin(id string) error {
+	if _, ok := predictors.Predictors[id]; ok {
+		return odahuErrs.ExtendedForbiddenError{Message: fmt.Sprintf(BuiltinPredictorForbiddenMessage, id)}
+	}
+	return nil
+}
+
+func (s *Service) Create(ctx context.Context, p *deployment.Predictor) (err error) {
+	if _, ok := predictors.Predictors[p.ID]; ok {
+		return odahuErrs.AlreadyExistError{Entity: p.ID}
+	}
+
+	// Set fields that managed by platform. Cannot be overridden by user
+	p.CreatedAt = time.Now().UTC()
+	p.UpdatedAt = time.Now().UTC()
+	p.Builtin = false
+
+	SetDefaults(p)
+	if errs := ValidateCreateUpdate(*p); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           p.ID,
+			ValidationErrors: errs,
+		}
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.Create(ctx, tx, *p); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.PredictorKind,
+		EntityID:   p.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    p.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+// Update updates the predictor. Running model deployments pick up the change on their next reconciliation
+func (s *Service) Update(ctx context.Context, p *deployment.Predictor) (err error) {
+	if err := checkNotBuiltin(p.ID); err != nil {
+		return err
+	}
+
+	p.UpdatedAt = time.Now().UTC()
+	p.Builtin = false
+
+	SetDefaults(p)
+	if errs := ValidateCreateUpdate(*p); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           p.ID,
+			ValidationErrors: errs,
+		}
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, p.ID)
+	if err != nil {
+		return err
+	}
+	p.CreatedAt = old.CreatedAt
+
+	if err = s.repo.Update(ctx, tx, *p); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.PredictorKind,
+		EntityID:   p.ID,
+		Operation:  audit.UpdateOperation,
+		OldSpec:    old.Spec,
+		NewSpec:    p.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+// Delete deletes the predictor. Predictors used by model deployments can not be deleted
+func (s *Service) Delete(ctx context.Context, id string) (err error) {
+	if err := checkNotBuiltin(id); err != nil {
+		return err
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	mds, err := s.deploymentRepo.GetModelDeploymentList(
+		ctx, tx, filter.ListFilter(&md_repository.MdFilter{Predictor: []string{id}}), filter.Size(1),
+	)
+	if err != nil {
+		return err
+	}
+	if len(mds) > 0 {
+		err = odahuErrs.ExtendedForbiddenError{Message: fmt.Sprintf(PredictorInUseForbiddenMessage, id, mds[0].ID)}
+		return err
+	}
+
+	if err = s.repo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.PredictorKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    old.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+func (s *Service) Get(ctx context.Context, id string) (deployment.Predictor, error) {
+	if p, ok := predictors.Predictors[id]; ok {
+		return deployment.NewBuiltinPredictor(p), nil
+	}
+	return s.repo.Get(ctx, nil, id)
+}
+
+// List returns built-in predictors on the first page followed by predictors from the repository
+func (s *Service) List(ctx context.Context, options ...filter.ListOption) ([]deployment.Predictor, error) {
+	stored, err := s.repo.List(ctx, nil, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	listOptions := &filter.ListOptions{}
+	for _, option := range options {
+		option(listOptions)
+	}
+	if listOptions.Page != nil && *listOptions.Page > 0 {
+		return stored, nil
+	}
+	return append(builtinPredictors(), stored...), nil
+}
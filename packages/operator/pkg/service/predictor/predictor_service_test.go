@@ -0,0 +1,235 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package predictor_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/predictor"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/predictor/mocks"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"odahu-commons/predictors"
+	"testing"
+	"time"
+)
+
+const (
+	predictorID = "torchserve"
+	opaPolicy   = `package odahu.core
+
+import data.odahu.mapper
+
+allow {
+  mapper.action == "POST"
+  re_match("^/predictions/[\\w-]+/?$", mapper.resource)
+  mapper.raw_roles[_] == "{{.Role}}"
+}
+`
+)
+
+func TestServiceSuiteRun(t *testing.T) {
+	suite.Run(t, new(ServiceSuite))
+}
+
+type ServiceSuite struct {
+	suite.Suite
+	mockRepo     *mocks.Repository
+	mockMDRepo   *mocks.DeploymentRepository
+	mockRecorder *mocks.AuditRecorder
+	service      *service.Service
+	db           *sql.DB
+	dbMock       sqlmock.Sqlmock
+}
+
+func (s *ServiceSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockMDRepo = &mocks.DeploymentRepository{}
+	s.mockRecorder = &mocks.AuditRecorder{}
+	s.db = db
+	s.dbMock = dbMock
+	s.service = service.NewService(s.mockRepo, s.mockMDRepo, s.mockRecorder)
+}
+
+func (s *ServiceSuite) TestCreateSetsDefaults() {
+	ctx := context.Background()
+	p := newStubPredictor()
+	p.Builtin = true
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Create", ctx, mockTx, mock.MatchedBy(func(created deployment.Predictor) bool {
+		port := created.Spec.Ports[0]
+		return port.Name == service.DefaultPortName && port.Protocol == corev1.ProtocolTCP &&
+			!created.Builtin && !created.CreatedAt.IsZero()
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.MatchedBy(func(change audit.Change) bool {
+		return change.EntityKind == audit.PredictorKind && change.Operation == audit.CreateOperation
+	})).Return(nil)
+
+	err := s.service.Create(ctx, p)
+	s.Assertions.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestCreateBuiltinID() {
+	p := newStubPredictor()
+	p.ID = predictors.Triton.ID
+
+	err := s.service.Create(context.Background(), p)
+	s.Assertions.True(odahu_errs.IsAlreadyExistError(err))
+	s.mockRepo.AssertNotCalled(s.T(), "BeginTransaction", mock.Anything)
+}
+
+func (s *ServiceSuite) TestCreateInvalid() {
+	p := newStubPredictor()
+	p.Spec.Ports = append(p.Spec.Ports, corev1.ContainerPort{Name: "grpc", ContainerPort: 7070})
+	p.Spec.LivenessProbe = corev1.Probe{}
+	p.Spec.InferenceEndpointRegex = "("
+	p.Spec.Inspector = deployment.PredictorInspector{Type: predictors.OpenAPIInspector}
+	p.Spec.OpaPolicy = "package odahu.models\n{{.Role"
+
+	err := s.service.Create(context.Background(), p)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.Assertions.Len(err.(odahu_errs.InvalidEntityError).ValidationErrors, 5)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestUpdateKeepsCreationTime() {
+	ctx := context.Background()
+	old := *newStubPredictor()
+	old.CreatedAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := newStubPredictor()
+	p.Spec.Inspector.OpenAPIPath = "/api-description"
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Get", ctx, mockTx, predictorID).Return(old, nil)
+	s.mockRepo.On("Update", ctx, mockTx, mock.MatchedBy(func(updated deployment.Predictor) bool {
+		return updated.CreatedAt.Equal(old.CreatedAt) && updated.Spec.Inspector.OpenAPIPath == "/api-description"
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
+
+	err := s.service.Update(ctx, p)
+	s.Assertions.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestBuiltinIsReadOnly() {
+	ctx := context.Background()
+	p := newStubPredictor()
+	p.ID = predictors.OdahuMLServer.ID
+
+	s.Assertions.True(odahu_errs.IsForbiddenError(s.service.Update(ctx, p)))
+	s.Assertions.True(odahu_errs.IsForbiddenError(s.service.Delete(ctx, predictors.OdahuMLServer.ID)))
+	s.mockRepo.AssertNotCalled(s.T(), "BeginTransaction", mock.Anything)
+}
+
+func (s *ServiceSuite) TestDelete() {
+	ctx := context.Background()
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Get", ctx, mockTx, predictorID).Return(*newStubPredictor(), nil)
+	s.mockMDRepo.On("GetModelDeploymentList", ctx, mockTx, mock.Anything, mock.Anything).
+		Return([]deployment.ModelDeployment{}, nil)
+	s.mockRepo.On("Delete", ctx, mockTx, predictorID).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
+
+	s.Assertions.NoError(s.service.Delete(ctx, predictorID))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestDeleteUsedByDeployment() {
+	ctx := context.Background()
+	mockTx := s.expectTx(false)
+	s.mockRepo.On("Get", ctx, mockTx, predictorID).Return(*newStubPredictor(), nil)
+	s.mockMDRepo.On("GetModelDeploymentList", ctx, mockTx, mock.Anything, mock.Anything).
+		Return([]deployment.ModelDeployment{{ID: "wine"}}, nil)
+
+	err := s.service.Delete(ctx, predictorID)
+	s.Assertions.True(odahu_errs.IsForbiddenError(err))
+	s.mockRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+	s.Assertions.NoError(s.dbMock.ExpectationsWereMet())
+}
+
+func (s *ServiceSuite) TestGetBuiltin() {
+	p, err := s.service.Get(context.Background(), predictors.Triton.ID)
+	s.Assertions.NoError(err)
+	s.Assertions.True(p.Builtin)
+	s.Assertions.Equal(predictors.TritonInspector, p.Spec.Inspector.Type)
+	s.Assertions.Equal(predictors.Triton, p.ToPredictor())
+	s.mockRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestList() {
+	ctx := context.Background()
+	s.mockRepo.On("List", ctx, (*sql.Tx)(nil), mock.Anything).Return(
+		[]deployment.Predictor{*newStubPredictor()}, nil,
+	)
+
+	list, err := s.service.List(ctx)
+	s.Assertions.NoError(err)
+	s.Assertions.Len(list, len(predictors.Predictors)+1)
+	s.Assertions.Equal(predictors.OdahuMLServer.ID, list[0].ID)
+	s.Assertions.Equal(predictorID, list[len(list)-1].ID)
+
+	list, err = s.service.List(ctx, filter.Page(1))
+	s.Assertions.NoError(err)
+	s.Assertions.Len(list, 1)
+}
+
+func (s *ServiceSuite) expectTx(commit bool) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	if commit {
+		s.dbMock.ExpectCommit()
+	} else {
+		s.dbMock.ExpectRollback()
+	}
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
+func newStubPredictor() *deployment.Predictor {
+	probe := corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/ping"}}}
+	return &deployment.Predictor{
+		ID: predictorID,
+		Spec: deployment.PredictorSpec{
+			Ports:                  []corev1.ContainerPort{{ContainerPort: 8080}},
+			LivenessProbe:          probe,
+			ReadinessProbe:         probe,
+			InferenceEndpointRegex: ".*/predictions/.*",
+			OpaPolicy:              opaPolicy,
+			Inspector: deployment.PredictorInspector{
+				Type:        predictors.OpenAPIInspector,
+				OpenAPIPath: "/openapi.json",
+			},
+		},
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package predictor
+
+import (
+	"errors"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	"odahu-commons/predictors"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+const (
+	PortsNumberErrorMessage           = "predictor must expose exactly one port, Knative does not support more"
+	InvalidPortErrorMessage           = "port %d must be between 1 and 65535"
+	UnsupportedPortNameErrorMessage   = "port name %q is not supported by Knative. Possible values: %v"
+	ProbeHandlerErrorMessage          = "%s must contain exactly one of httpGet, tcpSocket and exec"
+	EmptyInferenceRegexErrorMessage   = "inferenceEndpointRegex must not be empty"
+	InvalidInferenceRegexErrorMessage = "invalid inferenceEndpointRegex: %s"
+	UnknownInspectorTypeErrorMessage  = "unknown inspector type %q. Possible values: %v"
+	EmptyOpenAPIPathErrorMessage      = "openAPIPath must be set for the %q inspector"
+	InvalidOpenAPIPathErrorMessage    = "openAPIPath %q must be an absolute path"
+	EmptyOpaPolicyErrorMessage        = "opaPolicy must not be empty"
+	InvalidOpaPolicyErrorMessage      = "opaPolicy is not a valid template: %s"
+	OpaPolicyPackageErrorMessage      = "opaPolicy must declare the %q package"
+	DefaultPortName                   = "http1"
+	opaPolicyPackage                  = "odahu.core"
+)
+
+var (
+	// Port names which are recognized by Knative. The name selects the HTTP version of the model server
+	portNames      = []string{"http1", "h2c"}
+	inspectorTypes = []predictors.InspectorType{
+		predictors.OdahuMLServerInspector, predictors.TritonInspector, predictors.OpenAPIInspector,
+		predictors.KServeV2Inspector,
+	}
+	opaPolicyPackageRegex = regexp.MustCompile(`(?m)^\s*package\s+` + regexp.QuoteMeta(opaPolicyPackage) + `\s*$`)
+)
+
+// SetDefaults fills optional fields of the predictor spec
+func SetDefaults(p *deployment.Predictor) {
+	for i := range p.Spec.Ports {
+		if len(p.Spec.Ports[i].Name) == 0 {
+			p.Spec.Ports[i].Name = DefaultPortName
+		}
+		if len(p.Spec.Ports[i].Protocol) == 0 {
+			p.Spec.Ports[i].Protocol = corev1.ProtocolTCP
+		}
+	}
+}
+
+func validatePorts(ports []corev1.ContainerPort) (err error) {
+	if len(ports) != 1 {
+		return errors.New(PortsNumberErrorMessage)
+	}
+
+	port := ports[0]
+	if port.ContainerPort < 1 || port.ContainerPort > 65535 {
+		err = multierr.Append(err, fmt.Errorf(InvalidPortErrorMessage, port.ContainerPort))
+	}
+	isKnownName := false
+	for _, name := range portNames {
+		if name == port.Name {
+			isKnownName = true
+		}
+	}
+	if !isKnownName {
+		err = multierr.Append(err, fmt.Errorf(UnsupportedPortNameErrorMessage, port.Name, portNames))
+	}
+	return err
+}
+
+func validateProbe(name string, probe corev1.Probe) error {
+	handlers := 0
+	if probe.HTTPGet != nil {
+		handlers++
+	}
+	if probe.TCPSocket != nil {
+		handlers++
+	}
+	if probe.Exec != nil {
+		handlers++
+	}
+	if handlers != 1 {
+		return fmt.Errorf(ProbeHandlerErrorMessage, name)
+	}
+	return nil
+}
+
+func validateInspector(inspector deployment.PredictorInspector) error {
+	isKnownType := false
+	for _, t := range inspectorTypes {
+		if t == inspector.Type {
+			isKnownType = true
+		}
+	}
+	if !isKnownType {
+		return fmt.Errorf(UnknownInspectorTypeErrorMessage, inspector.Type, inspectorTypes)
+	}
+
+	if inspector.Type != predictors.OpenAPIInspector {
+		return nil
+	}
+	if len(inspector.OpenAPIPath) == 0 {
+		return fmt.Errorf(EmptyOpenAPIPathErrorMessage, inspector.Type)
+	}
+	if !strings.HasPrefix(inspector.OpenAPIPath, "/") {
+		return fmt.Errorf(InvalidOpenAPIPathErrorMessage, inspector.OpenAPIPath)
+	}
+	return nil
+}
+
+func validateOpaPolicy(policy string) error {
+	if len(policy) == 0 {
+		return errors.New(EmptyOpaPolicyErrorMessage)
+	}
+	if _, err := template.New("_").Parse(policy); err != nil {
+		return fmt.Errorf(InvalidOpaPolicyErrorMessage, err.Error())
+	}
+	// Policies of model servers extend the common authorization rules of the odahu.core package
+	if !opaPolicyPackageRegex.MatchString(policy) {
+		return fmt.Errorf(OpaPolicyPackageErrorMessage, opaPolicyPackage)
+	}
+	return nil
+}
+
+// ValidateCreateUpdate validates a predictor which is registered at runtime
+func ValidateCreateUpdate(p deployment.Predictor) (errs []error) {
+
+	var err error
+
+	err = multierr.Append(err, validation.ValidateID(p.ID))
+	err = multierr.Append(err, validatePorts(p.Spec.Ports))
+	err = multierr.Append(err, validateProbe("livenessProbe", p.Spec.LivenessProbe))
+	err = multierr.Append(err, validateProbe("readinessProbe", p.Spec.ReadinessProbe))
+
+	if len(p.Spec.InferenceEndpointRegex) == 0 {
+		err = multierr.Append(err, errors.New(EmptyInferenceRegexErrorMessage))
+	} else if _, regexErr := regexp.Compile(p.Spec.InferenceEndpointRegex); regexErr != nil {
+		err = multierr.Append(err, fmt.Errorf(InvalidInferenceRegexErrorMessage, regexErr.Error()))
+	}
+
+	err = multierr.Append(err, validateInspector(p.Spec.Inspector))
+	err = multierr.Append(err, validateOpaPolicy(p.Spec.OpaPolicy))
+
+	if err != nil {
+		return multierr.Errors(err)
+	}
+	return nil
+}
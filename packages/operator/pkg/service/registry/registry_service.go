@@ -0,0 +1,163 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/registry"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"time"
+)
+
+const UnknownStageErrorMessage = "unknown stage %q. Possible values: %v"
+
+var log = logf.Log.WithName("model-registry--service")
+
+type Repository interface {
+	GetModel(ctx context.Context, tx *sql.Tx, name string) (registry.Model, error)
+	ListModels(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) ([]registry.Model, error)
+	CreateVersion(ctx context.Context, tx *sql.Tx, mv registry.ModelVersion) error
+	UpdateVersion(ctx context.Context, tx *sql.Tx, mv registry.ModelVersion) error
+	GetVersion(ctx context.Context, tx *sql.Tx, model, version string) (registry.ModelVersion, error)
+	ListVersions(
+		ctx context.Context, tx *sql.Tx, model string, options ...filter.ListOption,
+	) ([]registry.ModelVersion, error)
+	FindVersionsByArtifact(ctx context.Context, tx *sql.Tx, artifactName string) ([]registry.ModelVersion, error)
+	FindVersionsByImage(ctx context.Context, tx *sql.Tx, image string) ([]registry.ModelVersion, error)
+	GetCursor(ctx context.Context, tx *sql.Tx) (int, error)
+	SaveCursor(ctx context.Context, tx *sql.Tx, cursor int) error
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
+type Service struct {
+	repo          Repository
+	auditRecorder AuditRecorder
+}
+
+func NewService(repo Repository, auditRecorder AuditRecorder) *Service {
+	return &Service{repo: repo, auditRecorder: auditRecorder}
+}
+
+func (s *Service) GetModel(ctx context.Context, name string) (registry.Model, error) {
+	return s.repo.GetModel(ctx, nil, name)
+}
+
+func (s *Service) ListModels(ctx context.Context, options ...filter.ListOption) ([]registry.Model, error) {
+	return s.repo.ListModels(ctx, nil, options...)
+}
+
+func (s *Service) GetVersion(ctx context.Context, model, version string) (registry.ModelVersion, error) {
+	return s.repo.GetVersion(ctx, nil, model, version)
+}
+
+// ListVersions returns versions of the model. NotFoundError is returned if the model is missed
+func (s *Service) ListVersions(
+	ctx context.Context, model string, options ...filter.ListOption) ([]registry.ModelVersion, error) {
+	if _, err := s.repo.GetModel(ctx, nil, model); err != nil {
+		return nil, err
+	}
+	return s.repo.ListVersions(ctx, nil, model, options...)
+}
+
+// TransitionStage moves the version to the stage. If archiveExisting is set then other versions
+// of the model in the same stage are archived
+func (s *Service) TransitionStage(
+	ctx context.Context, model, version string, transition registry.StageTransition,
+) (mv registry.ModelVersion, err error) {
+
+	if err := validateStage(transition.Stage); err != nil {
+		return mv, odahuErrs.InvalidEntityError{
+			Entity:           fmt.Sprintf("%s/%s", model, version),
+			ValidationErrors: []error{err},
+		}
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return mv, err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	mv, err = s.repo.GetVersion(ctx, tx, model, version)
+	if err != nil {
+		return mv, err
+	}
+
+	if transition.ArchiveExisting && transition.Stage != registry.ArchivedStage {
+		existing, err := s.repo.ListVersions(ctx, tx, model, filter.ListFilter(&registry.VersionFilter{
+			Stage: []string{string(transition.Stage)},
+		}))
+		if err != nil {
+			return mv, err
+		}
+		for _, other := range existing {
+			if other.Version == version {
+				continue
+			}
+			if err = s.moveToStage(ctx, tx, &other, registry.ArchivedStage); err != nil {
+				return mv, err
+			}
+		}
+	}
+
+	if mv.Stage != transition.Stage {
+		err = s.moveToStage(ctx, tx, &mv, transition.Stage)
+	}
+	return mv, err
+}
+
+func (s *Service) moveToStage(
+	ctx context.Context, tx *sql.Tx, mv *registry.ModelVersion, stage registry.Stage) error {
+
+	oldStage := mv.Stage
+	now := time.Now().UTC()
+	mv.Stage = stage
+	mv.StageUpdatedAt = &now
+	mv.UpdatedAt = now
+	if err := s.repo.UpdateVersion(ctx, tx, *mv); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelVersionKind,
+		EntityID:   fmt.Sprintf("%s/%s", mv.Model, mv.Version),
+		Operation:  audit.UpdateOperation,
+		OldSpec:    registry.StageTransition{Stage: oldStage},
+		NewSpec:    registry.StageTransition{Stage: stage},
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+func validateStage(stage registry.Stage) error {
+	for _, s := range registry.Stages() {
+		if s == stage {
+			return nil
+		}
+	}
+	return fmt.Errorf(UnknownStageErrorMessage, stage, registry.Stages())
+}
@@ -0,0 +1,143 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package registry_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/registry"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/registry"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/registry/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const (
+	modelName = "wine"
+)
+
+func TestServiceSuiteRun(t *testing.T) {
+	suite.Run(t, new(ServiceSuite))
+}
+
+type ServiceSuite struct {
+	suite.Suite
+	mockRepo     *mocks.Repository
+	mockRecorder *mocks.AuditRecorder
+	service      *service.Service
+	db           *sql.DB
+	dbMock       sqlmock.Sqlmock
+}
+
+func (s *ServiceSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockRecorder = &mocks.AuditRecorder{}
+	s.db = db
+	s.dbMock = dbMock
+	s.service = service.NewService(s.mockRepo, s.mockRecorder)
+}
+
+func (s *ServiceSuite) TestTransitionStageArchivesExisting() {
+	ctx := context.Background()
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("GetVersion", ctx, mockTx, modelName, "2").Return(*newStubVersion("2", apis.StagingStage), nil)
+	s.mockRepo.On("ListVersions", ctx, mockTx, modelName, mock.AnythingOfType("filter.ListOption")).Return(
+		[]apis.ModelVersion{*newStubVersion("1", apis.ProductionStage)}, nil,
+	)
+	s.mockRepo.On("UpdateVersion", ctx, mockTx, mock.MatchedBy(func(mv apis.ModelVersion) bool {
+		return mv.Version == "1" && mv.Stage == apis.ArchivedStage && mv.StageUpdatedAt != nil
+	})).Return(nil)
+	s.mockRepo.On("UpdateVersion", ctx, mockTx, mock.MatchedBy(func(mv apis.ModelVersion) bool {
+		return mv.Version == "2" && mv.Stage == apis.ProductionStage && mv.StageUpdatedAt != nil
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.MatchedBy(func(change audit.Change) bool {
+		return change.EntityKind == audit.ModelVersionKind && change.Operation == audit.UpdateOperation
+	})).Return(nil).Times(2)
+
+	mv, err := s.service.TransitionStage(ctx, modelName, "2", apis.StageTransition{
+		Stage:           apis.ProductionStage,
+		ArchiveExisting: true,
+	})
+	s.Assertions.NoError(err)
+	s.Assertions.Equal(apis.ProductionStage, mv.Stage)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestTransitionStageInvalid() {
+	_, err := s.service.TransitionStage(context.Background(), modelName, "1", apis.StageTransition{
+		Stage: "retired",
+	})
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.mockRepo.AssertNotCalled(s.T(), "BeginTransaction", mock.Anything)
+}
+
+func (s *ServiceSuite) TestTransitionStageNotFound() {
+	ctx := context.Background()
+	mockTx := s.expectTx(false)
+	s.mockRepo.On("GetVersion", ctx, mockTx, modelName, "1").Return(
+		apis.ModelVersion{}, odahu_errs.NotFoundError{Entity: modelName},
+	)
+
+	_, err := s.service.TransitionStage(ctx, modelName, "1", apis.StageTransition{Stage: apis.StagingStage})
+	s.Assertions.True(odahu_errs.IsNotFoundError(err))
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestListVersionsModelNotFound() {
+	ctx := context.Background()
+	s.mockRepo.On("GetModel", ctx, (*sql.Tx)(nil), modelName).Return(
+		apis.Model{}, odahu_errs.NotFoundError{Entity: modelName},
+	)
+
+	_, err := s.service.ListVersions(ctx, modelName)
+	s.Assertions.True(odahu_errs.IsNotFoundError(err))
+}
+
+func (s *ServiceSuite) expectTx(commit bool) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	if commit {
+		s.dbMock.ExpectCommit()
+	} else {
+		s.dbMock.ExpectRollback()
+	}
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
+func newStubVersion(version string, stage apis.Stage) *apis.ModelVersion {
+	return &apis.ModelVersion{
+		Model:   modelName,
+		Version: version,
+		Stage:   stage,
+	}
+}
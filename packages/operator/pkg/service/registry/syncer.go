@@ -0,0 +1,254 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/pipeline"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/registry"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"time"
+)
+
+// Names and versions of models are stored in columns of this size. Longer ones are not registered
+const maxNameLength = 256
+
+type EventReader interface {
+	GetAfter(ctx context.Context, cursor int, limit int) (records []event.Record, err error)
+}
+
+// Syncer populates the registry from the event log. Succeeded trainings register model versions,
+// succeeded packagings of their artifacts and deployments of the built images are linked to the versions
+type Syncer struct {
+	repo        Repository
+	eventReader EventReader
+	batchSize   int
+	now         func() time.Time
+}
+
+func NewSyncer(repo Repository, eventReader EventReader, batchSize int) *Syncer {
+	return &Syncer{repo: repo, eventReader: eventReader, batchSize: batchSize, now: time.Now}
+}
+
+// NewSyncerWithClock is used in tests to control the current time
+func NewSyncerWithClock(repo Repository, eventReader EventReader, batchSize int, now func() time.Time) *Syncer {
+	return &Syncer{repo: repo, eventReader: eventReader, batchSize: batchSize, now: now}
+}
+
+// Sync applies all events which were raised after the previous sync
+func (s *Syncer) Sync(ctx context.Context) error {
+	for {
+		processed, err := s.syncBatch(ctx)
+		if err != nil || processed < s.batchSize {
+			return err
+		}
+	}
+}
+
+// syncBatch applies the next batch of events. Changes of the registry and the new cursor are saved
+// in the same transaction, so every event is applied once
+func (s *Syncer) syncBatch(ctx context.Context) (processed int, err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	cursor, err := s.repo.GetCursor(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := s.eventReader.GetAfter(ctx, cursor, s.batchSize)
+	if odahuErrs.IsCursorCompactedError(err) {
+		// Events which were not applied yet are lost. Registration is idempotent, so the registry is synced
+		// again from the beginning of the compacted log, that contains the last event of each entity
+		log.Info("Events after the registry cursor were compacted. Registry is synced from the beginning",
+			"cursor", cursor)
+		records, err = s.eventReader.GetAfter(ctx, 0, s.batchSize)
+	}
+	if err != nil || len(records) == 0 {
+		return 0, err
+	}
+
+	for _, r := range records {
+		if err = s.apply(ctx, tx, r); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = s.repo.SaveCursor(ctx, tx, records[len(records)-1].ID); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+func (s *Syncer) apply(ctx context.Context, tx *sql.Tx, r event.Record) error {
+	switch r.EventType {
+	case event.ModelTrainingStatusUpdatedEventType:
+		var mt training.ModelTraining
+		if !decode(r, &mt) || mt.Status.State != v1alpha1.ModelTrainingSucceeded {
+			return nil
+		}
+		return s.registerTraining(ctx, tx, mt)
+	case event.ModelPackagingStatusUpdatedEventType:
+		var mp packaging.ModelPackaging
+		if !decode(r, &mp) || mp.Status.State != v1alpha1.ModelPackagingSucceeded {
+			return nil
+		}
+		return s.linkPackaging(ctx, tx, mp)
+	case event.ModelDeploymentCreatedEventType, event.ModelDeploymentUpdatedEventType:
+		var md deployment.ModelDeployment
+		if !decode(r, &md) {
+			return nil
+		}
+		return s.linkDeployment(ctx, tx, md)
+	}
+	return nil
+}
+
+// decode unmarshals the event payload. Broken payloads are skipped, because they can not be fixed by retries
+func decode(r event.Record, payload interface{}) bool {
+	if err := json.Unmarshal(r.Payload, payload); err != nil {
+		log.Error(err, "Unable to decode the event payload, event is skipped", "event", r.ID, "type", r.EventType)
+		return false
+	}
+	return true
+}
+
+// registerTraining creates the version of the trained model or links the version to the training
+func (s *Syncer) registerTraining(ctx context.Context, tx *sql.Tx, mt training.ModelTraining) error {
+	name, version := mt.Spec.Model.Name, mt.Spec.Model.Version
+	if len(name) == 0 || len(version) == 0 || len(name) > maxNameLength || len(version) > maxNameLength {
+		log.Info("Training has no valid model name or version, it is not registered", "training", mt.ID)
+		return nil
+	}
+
+	var result v1alpha1.TrainingResult
+	if len(mt.Status.Artifacts) > 0 {
+		result = mt.Status.Artifacts[len(mt.Status.Artifacts)-1]
+	}
+
+	now := s.now().UTC()
+	mv, err := s.repo.GetVersion(ctx, tx, name, version)
+	switch {
+	case odahuErrs.IsNotFoundError(err):
+		mv = registry.ModelVersion{
+			Model:     name,
+			Version:   version,
+			Stage:     registry.NoneStage,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		setTrainingLineage(&mv.Lineage, mt.ID, result)
+		log.Info("Model version is registered", "model", name, "version", version, "training", mt.ID)
+		return s.repo.CreateVersion(ctx, tx, mv)
+	case err != nil:
+		return err
+	}
+
+	if mv.Lineage.TrainingID == mt.ID && mv.Lineage.ArtifactName == result.ArtifactName {
+		return nil
+	}
+	// Packagings and deployments of the previous artifact stay linked to the version
+	setTrainingLineage(&mv.Lineage, mt.ID, result)
+	mv.UpdatedAt = now
+	return s.repo.UpdateVersion(ctx, tx, mv)
+}
+
+func setTrainingLineage(lineage *registry.Lineage, trainingID string, result v1alpha1.TrainingResult) {
+	lineage.TrainingID = trainingID
+	lineage.ArtifactName = result.ArtifactName
+	lineage.RunID = result.RunID
+	lineage.CommitID = result.CommitID
+	lineage.Metrics = result.Metrics
+}
+
+// linkPackaging links the packaging and its image to versions which were trained into the packaged artifact
+func (s *Syncer) linkPackaging(ctx context.Context, tx *sql.Tx, mp packaging.ModelPackaging) error {
+	if len(mp.Spec.ArtifactName) == 0 {
+		return nil
+	}
+	versions, err := s.repo.FindVersionsByArtifact(ctx, tx, mp.Spec.ArtifactName)
+	if err != nil {
+		return err
+	}
+
+	var image string
+	for _, result := range mp.Status.Results {
+		if result.Name == pipeline.ImagePackagingResult {
+			image = result.Value
+		}
+	}
+
+	for _, mv := range versions {
+		changed := addUnique(&mv.Lineage.PackagingIDs, mp.ID)
+		if len(image) > 0 && addUnique(&mv.Lineage.Images, image) {
+			changed = true
+		}
+		if err := s.updateIfChanged(ctx, tx, mv, changed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkDeployment links the deployment to versions whose packagings built the deployed image
+func (s *Syncer) linkDeployment(ctx context.Context, tx *sql.Tx, md deployment.ModelDeployment) error {
+	if len(md.Spec.Image) == 0 {
+		return nil
+	}
+	versions, err := s.repo.FindVersionsByImage(ctx, tx, md.Spec.Image)
+	if err != nil {
+		return err
+	}
+
+	for _, mv := range versions {
+		if err := s.updateIfChanged(ctx, tx, mv, addUnique(&mv.Lineage.DeploymentIDs, md.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Syncer) updateIfChanged(ctx context.Context, tx *sql.Tx, mv registry.ModelVersion, changed bool) error {
+	if !changed {
+		return nil
+	}
+	mv.UpdatedAt = s.now().UTC()
+	return s.repo.UpdateVersion(ctx, tx, mv)
+}
+
+// addUnique appends the value if it is missed. It returns true if the value was appended
+func addUnique(values *[]string, value string) bool {
+	for _, v := range *values {
+		if v == value {
+			return false
+		}
+	}
+	*values = append(*values, value)
+	return true
+}
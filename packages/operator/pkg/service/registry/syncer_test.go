@@ -0,0 +1,198 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package registry_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/packaging"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/registry"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/registry"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/registry/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+const (
+	batchSize    = 10
+	artifactName = "wine-1.0-20210101.zip"
+	image        = "registry/wine:1.0"
+)
+
+func TestSyncerSuiteRun(t *testing.T) {
+	suite.Run(t, new(SyncerSuite))
+}
+
+type SyncerSuite struct {
+	suite.Suite
+	mockRepo   *mocks.Repository
+	mockReader *mocks.EventReader
+	syncer     *service.Syncer
+	db         *sql.DB
+	dbMock     sqlmock.Sqlmock
+	now        time.Time
+}
+
+func (s *SyncerSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockReader = &mocks.EventReader{}
+	s.db = db
+	s.dbMock = dbMock
+	s.now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.syncer = service.NewSyncerWithClock(s.mockRepo, s.mockReader, batchSize, func() time.Time { return s.now })
+}
+
+func (s *SyncerSuite) TestCompactedCursorSyncsFromBeginning() {
+	ctx := context.Background()
+
+	mockTx := s.expectTx()
+	s.mockRepo.On("GetCursor", ctx, mockTx).Return(5, nil)
+	s.mockReader.On("GetAfter", ctx, 5, batchSize).Return(nil, odahu_errs.CursorCompactedError{Cursor: 5})
+	s.mockReader.On("GetAfter", ctx, 0, batchSize).Return([]event.Record{
+		s.record(8, event.ModelRouteCreatedEventType, deployment.ModelRoute{}),
+	}, nil)
+	s.mockRepo.On("SaveCursor", ctx, mockTx, 8).Return(nil)
+
+	s.Assert().NoError(s.syncer.Sync(ctx))
+	s.mockRepo.AssertExpectations(s.T())
+	s.Assert().NoError(s.dbMock.ExpectationsWereMet())
+}
+
+func (s *SyncerSuite) TestSucceededTrainingRegistersVersion() {
+	ctx := context.Background()
+	mt := training.ModelTraining{
+		ID: "wine-training",
+		Spec: v1alpha1.ModelTrainingSpec{
+			Model: v1alpha1.ModelIdentity{Name: modelName, Version: "1.0"},
+		},
+		Status: v1alpha1.ModelTrainingStatus{
+			State: v1alpha1.ModelTrainingSucceeded,
+			Artifacts: []v1alpha1.TrainingResult{
+				{ArtifactName: artifactName, RunID: "run", Metrics: map[string]float64{"accuracy": 0.9}},
+			},
+		},
+	}
+	runningMt := mt
+	runningMt.Status = v1alpha1.ModelTrainingStatus{State: v1alpha1.ModelTrainingRunning}
+
+	mockTx := s.expectTx()
+	s.mockRepo.On("GetCursor", ctx, mockTx).Return(5, nil)
+	s.mockReader.On("GetAfter", ctx, 5, batchSize).Return([]event.Record{
+		s.record(6, event.ModelTrainingStatusUpdatedEventType, runningMt),
+		s.record(7, event.ModelTrainingStatusUpdatedEventType, mt),
+	}, nil)
+	s.mockRepo.On("GetVersion", ctx, mockTx, modelName, "1.0").Return(
+		apis.ModelVersion{}, odahu_errs.NotFoundError{Entity: modelName},
+	)
+	s.mockRepo.On("CreateVersion", ctx, mockTx, mock.MatchedBy(func(mv apis.ModelVersion) bool {
+		return mv.Stage == apis.NoneStage && mv.Lineage.TrainingID == mt.ID &&
+			mv.Lineage.ArtifactName == artifactName && mv.Lineage.Metrics["accuracy"] == 0.9 &&
+			mv.CreatedAt.Equal(s.now)
+	})).Return(nil)
+	s.mockRepo.On("SaveCursor", ctx, mockTx, 7).Return(nil)
+
+	s.Assertions.NoError(s.syncer.Sync(ctx))
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRepo.AssertNumberOfCalls(s.T(), "GetVersion", 1)
+}
+
+func (s *SyncerSuite) TestPackagingAndDeploymentAreLinked() {
+	ctx := context.Background()
+	mp := packaging.ModelPackaging{
+		ID:   "wine-packaging",
+		Spec: packaging.ModelPackagingSpec{ArtifactName: artifactName},
+		Status: v1alpha1.ModelPackagingStatus{
+			State:   v1alpha1.ModelPackagingSucceeded,
+			Results: []v1alpha1.ModelPackagingResult{{Name: "image", Value: image}},
+		},
+	}
+	md := deployment.ModelDeployment{
+		ID:   "wine-deployment",
+		Spec: v1alpha1.ModelDeploymentSpec{Image: image},
+	}
+	mv := *newStubVersion("1.0", apis.StagingStage)
+	mv.Lineage.ArtifactName = artifactName
+	linked := mv
+	linked.Lineage.PackagingIDs = []string{mp.ID}
+	linked.Lineage.Images = []string{image}
+
+	mockTx := s.expectTx()
+	s.mockRepo.On("GetCursor", ctx, mockTx).Return(0, nil)
+	s.mockReader.On("GetAfter", ctx, 0, batchSize).Return([]event.Record{
+		s.record(1, event.ModelPackagingStatusUpdatedEventType, mp),
+		{ID: 2, EventType: event.ModelDeploymentCreatedEventType, Payload: json.RawMessage("{broken")},
+		s.record(3, event.ModelDeploymentCreatedEventType, md),
+	}, nil)
+	s.mockRepo.On("FindVersionsByArtifact", ctx, mockTx, artifactName).Return([]apis.ModelVersion{mv}, nil)
+	s.mockRepo.On("UpdateVersion", ctx, mockTx, mock.MatchedBy(func(updated apis.ModelVersion) bool {
+		return len(updated.Lineage.PackagingIDs) == 1 && len(updated.Lineage.Images) == 1 &&
+			len(updated.Lineage.DeploymentIDs) == 0
+	})).Return(nil).Once()
+	s.mockRepo.On("FindVersionsByImage", ctx, mockTx, image).Return([]apis.ModelVersion{linked}, nil)
+	s.mockRepo.On("UpdateVersion", ctx, mockTx, mock.MatchedBy(func(updated apis.ModelVersion) bool {
+		return len(updated.Lineage.DeploymentIDs) == 1 && updated.Lineage.DeploymentIDs[0] == md.ID
+	})).Return(nil).Once()
+	s.mockRepo.On("SaveCursor", ctx, mockTx, 3).Return(nil)
+
+	s.Assertions.NoError(s.syncer.Sync(ctx))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *SyncerSuite) TestNoNewEvents() {
+	ctx := context.Background()
+	mockTx := s.expectTx()
+	s.mockRepo.On("GetCursor", ctx, mockTx).Return(3, nil)
+	s.mockReader.On("GetAfter", ctx, 3, batchSize).Return([]event.Record{}, nil)
+
+	s.Assertions.NoError(s.syncer.Sync(ctx))
+	s.mockRepo.AssertNotCalled(s.T(), "SaveCursor", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *SyncerSuite) expectTx() *sql.Tx {
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
+func (s *SyncerSuite) record(id int, eventType event.Type, payload interface{}) event.Record {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	return event.Record{ID: id, EventType: eventType, Payload: b}
+}
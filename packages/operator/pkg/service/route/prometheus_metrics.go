@@ -0,0 +1,145 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package route
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const prometheusQueryPath = "/api/v1/query"
+
+// PrometheusMetrics reads metrics of canary Model Deployments from Istio telemetry which is collected by Prometheus
+type PrometheusMetrics struct {
+	client    *http.Client
+	config    config.RolloutConfig
+	namespace string
+}
+
+func NewPrometheusMetrics(cfg config.RolloutConfig, namespace string) *PrometheusMetrics {
+	return &PrometheusMetrics{
+		client:    &http.Client{Timeout: cfg.Timeout},
+		config:    cfg,
+		namespace: namespace,
+	}
+}
+
+type queryValues struct {
+	Namespace  string
+	Deployment string
+	Window     string
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Result []struct {
+			// Pair of a timestamp and a string value
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (p *PrometheusMetrics) CanaryMetrics(
+	ctx context.Context, deploymentID string, window time.Duration) (metrics RolloutMetrics, err error) {
+
+	if len(p.config.PrometheusURL) == 0 {
+		return metrics, errors.New("prometheus URL is not configured, canary metrics are unavailable")
+	}
+
+	values := queryValues{
+		Namespace:  p.namespace,
+		Deployment: deploymentID,
+		// Prometheus does not accept Go durations with several units, for example 5m0s
+		Window: fmt.Sprintf("%ds", int64(window.Seconds())),
+	}
+	if metrics.ErrorRate, err = p.query(ctx, p.config.ErrorRateQuery, values); err != nil {
+		return metrics, err
+	}
+	if metrics.LatencyMs, err = p.query(ctx, p.config.LatencyQuery, values); err != nil {
+		return metrics, err
+	}
+	return metrics, nil
+}
+
+// query returns the value of the first sample of the instant query result.
+// nil is returned if there is no sample or the value is not a number, for example if the canary got no requests
+func (p *PrometheusMetrics) query(ctx context.Context, queryTemplate string, values queryValues) (*float64, error) {
+	tmpl, err := template.New("query").Parse(queryTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var query bytes.Buffer
+	if err := tmpl.Execute(&query, values); err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimSuffix(p.config.PrometheusURL, "/") + prometheusQueryPath + "?" +
+		url.Values{"query": []string{query.String()}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Error(err, "Unable to close the Prometheus response body")
+		}
+	}()
+
+	var result prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("unable to decode the Prometheus response with status %d: %s",
+			resp.StatusCode, err.Error())
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("prometheus query %q failed: %s", query.String(), result.Error)
+	}
+
+	if len(result.Data.Result) == 0 || len(result.Data.Result[0].Value) != 2 {
+		return nil, nil
+	}
+	raw, ok := result.Data.Result[0].Value[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value of the Prometheus sample: %v", result.Data.Result[0].Value[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, nil
+	}
+	return &value, nil
+}
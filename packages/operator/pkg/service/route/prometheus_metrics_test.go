@@ -0,0 +1,74 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package route_test
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+		query := r.URL.Query().Get("query")
+		queries = append(queries, query)
+		if query == "latency" {
+			// The canary got no requests
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector",` +
+			`"result":[{"metric":{},"value":[1609502400,"0.25"]}]}}`))
+	}))
+	defer server.Close()
+
+	cfg := config.NewDefaultRolloutConfig()
+	cfg.PrometheusURL = server.URL
+	cfg.ErrorRateQuery = "errors{ns={{ .Namespace }},md={{ .Deployment }}}[{{ .Window }}]"
+	cfg.LatencyQuery = "latency"
+	metrics, err := service.NewPrometheusMetrics(cfg, "odahu-flow-deployment").
+		CanaryMetrics(context.Background(), "wine", 5*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, *metrics.ErrorRate)
+	assert.Nil(t, metrics.LatencyMs)
+	assert.Equal(t, []string{"errors{ns=odahu-flow-deployment,md=wine}[300s]", "latency"}, queries)
+}
+
+func TestPrometheusMetricsQueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+	}))
+	defer server.Close()
+
+	cfg := config.NewDefaultRolloutConfig()
+	cfg.PrometheusURL = server.URL
+	_, err := service.NewPrometheusMetrics(cfg, "odahu-flow-deployment").
+		CanaryMetrics(context.Background(), "wine", time.Minute)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "parse error")
+}
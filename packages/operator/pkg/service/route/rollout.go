@@ -0,0 +1,214 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package route
+
+import (
+	"context"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	route "github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/route"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	hashutil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/hash"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"time"
+)
+
+const (
+	ErrorRateExceededMessage = "canary error rate %.4f exceeds the limit %.4f at weight %d"
+	LatencyExceededMessage   = "canary latency %.1fms exceeds the limit %.1fms at weight %d"
+)
+
+// RolloutMetrics are measured for the canary during the last step. Missed values mean that there was no traffic
+type RolloutMetrics struct {
+	ErrorRate *float64
+	LatencyMs *float64
+}
+
+type MetricsProvider interface {
+	CanaryMetrics(ctx context.Context, deploymentID string, window time.Duration) (RolloutMetrics, error)
+}
+
+// Roller advances progressive rollouts of model routes. It changes weights of route targets in the storage,
+// and the route worker delivers them to the runtime
+type Roller struct {
+	repo     repo.Repository
+	eventPub EventPublisher
+	metrics  MetricsProvider
+	now      func() time.Time
+}
+
+func NewRoller(repo repo.Repository, eventPub EventPublisher, metrics MetricsProvider) *Roller {
+	return &Roller{repo: repo, eventPub: eventPub, metrics: metrics, now: time.Now}
+}
+
+// NewRollerWithClock is used in tests to control the current time
+func NewRollerWithClock(
+	repo repo.Repository, eventPub EventPublisher, metrics MetricsProvider, now func() time.Time) *Roller {
+	return &Roller{repo: repo, eventPub: eventPub, metrics: metrics, now: now}
+}
+
+// Rollout processes progressing rollouts of all routes once
+func (r *Roller) Rollout(ctx context.Context) error {
+	for page := 0; ; page++ {
+		routes, err := r.repo.GetModelRouteList(ctx, nil, filter.Page(page))
+		if err != nil {
+			return err
+		}
+		if len(routes) == 0 {
+			return nil
+		}
+
+		for _, mr := range routes {
+			// A failure of one route must not block others
+			if err := r.process(ctx, mr); err != nil {
+				log.Error(err, "Unable to advance the rollout", "route", mr.ID)
+			}
+		}
+	}
+}
+
+func (r *Roller) process(ctx context.Context, mr route.ModelRoute) error {
+	policy := mr.Spec.Rollout
+	if policy == nil || mr.DeletionMark {
+		return nil
+	}
+	status := mr.Status.Rollout
+	if status != nil && status.Phase != v1alpha1.RolloutProgressing {
+		return nil
+	}
+	if errs := ValidateRollout(mr.Spec); len(errs) > 0 {
+		log.Info("Rollout policy is invalid, the route is skipped", "route", mr.ID, "errors", errs)
+		return nil
+	}
+	interval, _ := time.ParseDuration(policy.StepInterval)
+
+	now := r.now().UTC()
+	updated := mr
+	updated.Spec = *mr.Spec.DeepCopy()
+
+	if status == nil || len(status.Steps) == 0 {
+		updated.Status.Rollout = &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutProgressing}
+		startStep(&updated, 0, now)
+		return r.save(ctx, mr, updated)
+	}
+	updated.Status.Rollout = status.DeepCopy()
+	rollout := updated.Status.Rollout
+
+	step := &rollout.Steps[len(rollout.Steps)-1]
+	if now.Before(step.StartedAt.Add(interval)) {
+		return nil
+	}
+
+	if policy.MaxErrorRate != nil || policy.MaxLatencyMs != nil {
+		metrics, err := r.metrics.CanaryMetrics(ctx, policy.Canary, interval)
+		if err != nil {
+			// The step is analyzed again during the next processing
+			rollout.Message = err.Error()
+			return r.save(ctx, mr, updated)
+		}
+		step.ErrorRate = metrics.ErrorRate
+		step.LatencyMs = metrics.LatencyMs
+
+		if reason := violation(*policy, *step); len(reason) > 0 {
+			log.Info("Rollout is aborted", "route", mr.ID, "reason", reason)
+			rollout.Phase = v1alpha1.RolloutAborted
+			rollout.Message = reason
+			SetRolloutWeights(&updated.Spec, 0)
+			return r.save(ctx, mr, updated)
+		}
+	}
+
+	rollout.Message = ""
+	if rollout.CurrentStep >= len(policy.StepWeights)-1 {
+		log.Info("Rollout is succeeded", "route", mr.ID)
+		rollout.Phase = v1alpha1.RolloutSucceeded
+		return r.save(ctx, mr, updated)
+	}
+	startStep(&updated, rollout.CurrentStep+1, now)
+	return r.save(ctx, mr, updated)
+}
+
+func startStep(mr *route.ModelRoute, index int, now time.Time) {
+	weight := mr.Spec.Rollout.StepWeights[index]
+	SetRolloutWeights(&mr.Spec, weight)
+	mr.Status.Rollout.CurrentStep = index
+	mr.Status.Rollout.Steps = append(mr.Status.Rollout.Steps, v1alpha1.RolloutStep{
+		Weight:    weight,
+		StartedAt: metav1.NewTime(now),
+	})
+	log.Info("Rollout step is started", "route", mr.ID, "step", index, "weight", weight)
+}
+
+// violation returns the reason to abort the rollout or an empty string if metrics of the step are within limits
+func violation(policy v1alpha1.RolloutPolicy, step v1alpha1.RolloutStep) string {
+	if policy.MaxErrorRate != nil && step.ErrorRate != nil && *step.ErrorRate > *policy.MaxErrorRate {
+		return fmt.Sprintf(ErrorRateExceededMessage, *step.ErrorRate, *policy.MaxErrorRate, step.Weight)
+	}
+	if policy.MaxLatencyMs != nil && step.LatencyMs != nil && *step.LatencyMs > *policy.MaxLatencyMs {
+		return fmt.Sprintf(LatencyExceededMessage, *step.LatencyMs, *policy.MaxLatencyMs, step.Weight)
+	}
+	return ""
+}
+
+// save stores the new weights and the rollout status if the route was not changed since it was read
+func (r *Roller) save(ctx context.Context, old route.ModelRoute, updated route.ModelRoute) (err error) {
+	specChanged := !reflect.DeepEqual(old.Spec, updated.Spec)
+	if !specChanged && reflect.DeepEqual(old.Status, updated.Status) {
+		return nil
+	}
+
+	tx, err := r.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	current, err := r.repo.GetModelRoute(ctx, tx, old.ID)
+	if err != nil {
+		return err
+	}
+	if !hashutil.Equal(current.Spec, old.Spec) || !reflect.DeepEqual(current.Status.Rollout, old.Status.Rollout) {
+		// The route was updated by a user, so the rollout is started again during the next processing
+		return nil
+	}
+
+	eventType := event.ModelRouteStatusUpdatedEventType
+	if specChanged {
+		updated.UpdatedAt = r.now().UTC()
+		// Runtime state of the route is not actual until the new weights are applied
+		updated.Status.State = ""
+		err = r.repo.UpdateModelRoute(ctx, tx, &updated)
+		eventType = event.ModelRouteUpdatedEventType
+	} else {
+		err = r.repo.UpdateModelRouteStatus(ctx, tx, updated.ID, updated.Status)
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.eventPub.PublishEvent(ctx, tx, event.Event{
+		EntityID:   updated.ID,
+		EventType:  eventType,
+		EventGroup: event.ModelRouteEventGroup,
+		Payload:    updated,
+	})
+}
@@ -0,0 +1,272 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package route_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/route/mocks"
+	event_pub_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment/mocks"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
+	metrics_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/route/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+const (
+	mrID     = "wine-canary"
+	stableMD = "wine-1"
+	canaryMD = "wine-2"
+)
+
+func TestRollerSuiteRun(t *testing.T) {
+	suite.Run(t, new(RollerSuite))
+}
+
+type RollerSuite struct {
+	suite.Suite
+	mockRepo    *mocks.Repository
+	mockPub     *event_pub_mocks.EventPublisher
+	mockMetrics *metrics_mocks.MetricsProvider
+	roller      *service.Roller
+	db          *sql.DB
+	dbMock      sqlmock.Sqlmock
+	now         time.Time
+}
+
+func (s *RollerSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockPub = &event_pub_mocks.EventPublisher{}
+	s.mockMetrics = &metrics_mocks.MetricsProvider{}
+	s.db = db
+	s.dbMock = dbMock
+	s.now = time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.roller = service.NewRollerWithClock(s.mockRepo, s.mockPub, s.mockMetrics, func() time.Time { return s.now })
+}
+
+func (s *RollerSuite) TestFirstStepIsStarted() {
+	ctx := context.Background()
+	mr := newStubRolloutRoute()
+	s.expectList(mr)
+	mockTx := s.expectTx(mr)
+	s.mockRepo.On("UpdateModelRoute", ctx, mockTx, mock.MatchedBy(func(updated *apis.ModelRoute) bool {
+		return weightOf(updated, canaryMD) == 10 && weightOf(updated, stableMD) == 90 &&
+			updated.Status.Rollout.Phase == v1alpha1.RolloutProgressing && len(updated.Status.Rollout.Steps) == 1
+	})).Return(nil)
+	s.expectEvent(mockTx, event.ModelRouteUpdatedEventType)
+
+	s.Assertions.NoError(s.roller.Rollout(ctx))
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockPub.AssertExpectations(s.T())
+}
+
+func (s *RollerSuite) TestStepIsNotFinished() {
+	mr := newStubRolloutRoute()
+	mr.Status.Rollout = s.progressingStatus(0, s.now.Add(-time.Minute))
+	s.expectList(mr)
+
+	s.Assertions.NoError(s.roller.Rollout(context.Background()))
+	s.mockRepo.AssertNotCalled(s.T(), "BeginTransaction", mock.Anything)
+	s.mockMetrics.AssertNotCalled(s.T(), "CanaryMetrics", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *RollerSuite) TestNextStepIsStarted() {
+	ctx := context.Background()
+	mr := newStubRolloutRoute()
+	mr.Status.Rollout = s.progressingStatus(0, s.now.Add(-10*time.Minute))
+	service.SetRolloutWeights(&mr.Spec, 10)
+	s.expectList(mr)
+	errorRate := 0.01
+	s.mockMetrics.On("CanaryMetrics", ctx, canaryMD, 5*time.Minute).
+		Return(service.RolloutMetrics{ErrorRate: &errorRate}, nil)
+	mockTx := s.expectTx(mr)
+	s.mockRepo.On("UpdateModelRoute", ctx, mockTx, mock.MatchedBy(func(updated *apis.ModelRoute) bool {
+		rollout := updated.Status.Rollout
+		return weightOf(updated, canaryMD) == 50 && rollout.CurrentStep == 1 && len(rollout.Steps) == 2 &&
+			*rollout.Steps[0].ErrorRate == errorRate
+	})).Return(nil)
+	s.expectEvent(mockTx, event.ModelRouteUpdatedEventType)
+
+	s.Assertions.NoError(s.roller.Rollout(ctx))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *RollerSuite) TestRolloutIsAborted() {
+	ctx := context.Background()
+	mr := newStubRolloutRoute()
+	mr.Status.Rollout = s.progressingStatus(1, s.now.Add(-10*time.Minute))
+	service.SetRolloutWeights(&mr.Spec, 50)
+	s.expectList(mr)
+	latency := 900.0
+	s.mockMetrics.On("CanaryMetrics", ctx, canaryMD, 5*time.Minute).
+		Return(service.RolloutMetrics{LatencyMs: &latency}, nil)
+	mockTx := s.expectTx(mr)
+	s.mockRepo.On("UpdateModelRoute", ctx, mockTx, mock.MatchedBy(func(updated *apis.ModelRoute) bool {
+		return weightOf(updated, canaryMD) == 0 && weightOf(updated, stableMD) == 100 &&
+			updated.Status.Rollout.Phase == v1alpha1.RolloutAborted && len(updated.Status.Rollout.Message) > 0
+	})).Return(nil)
+	s.expectEvent(mockTx, event.ModelRouteUpdatedEventType)
+
+	s.Assertions.NoError(s.roller.Rollout(ctx))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *RollerSuite) TestLastStepSucceeds() {
+	ctx := context.Background()
+	mr := newStubRolloutRoute()
+	mr.Status.Rollout = s.progressingStatus(2, s.now.Add(-10*time.Minute))
+	service.SetRolloutWeights(&mr.Spec, 100)
+	s.expectList(mr)
+	s.mockMetrics.On("CanaryMetrics", ctx, canaryMD, 5*time.Minute).Return(service.RolloutMetrics{}, nil)
+	mockTx := s.expectTx(mr)
+	s.mockRepo.On("UpdateModelRouteStatus", ctx, mockTx, mrID,
+		mock.MatchedBy(func(status v1alpha1.ModelRouteStatus) bool {
+			return status.Rollout.Phase == v1alpha1.RolloutSucceeded
+		}),
+	).Return(nil)
+	s.expectEvent(mockTx, event.ModelRouteStatusUpdatedEventType)
+
+	s.Assertions.NoError(s.roller.Rollout(ctx))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *RollerSuite) TestRouteWasUpdatedByUser() {
+	ctx := context.Background()
+	mr := newStubRolloutRoute()
+	s.expectList(mr)
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	current := newStubRolloutRoute()
+	current.Spec.URLPrefix = "/custom/changed"
+	s.mockRepo.On("GetModelRoute", ctx, mockTx, mrID).Return(current, nil)
+
+	s.Assertions.NoError(s.roller.Rollout(ctx))
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateModelRoute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *RollerSuite) TestValidateRollout() {
+	spec := newStubRolloutRoute().Spec
+	spec.Rollout.Canary = "unknown"
+	spec.Rollout.StepWeights = []int32{50, 20}
+	spec.Rollout.StepInterval = "soon"
+	errs := service.ValidateRollout(spec)
+	s.Assertions.Len(errs, 3)
+
+	spec = newStubRolloutRoute().Spec
+	spec.Rollout.StepInterval = ""
+	service.SetRolloutDefaults(&spec)
+	s.Assertions.Equal(service.DefaultStepInterval, spec.Rollout.StepInterval)
+	s.Assertions.Empty(service.ValidateRollout(spec))
+}
+
+func (s *RollerSuite) TestSetRolloutWeightsSplitsRest() {
+	spec := newStubRolloutRoute().Spec
+	spec.ModelDeploymentTargets = append(spec.ModelDeploymentTargets, v1alpha1.ModelDeploymentTarget{Name: "wine-0"})
+	service.SetRolloutWeights(&spec, 25)
+
+	mr := &apis.ModelRoute{Spec: spec}
+	s.Assertions.Equal(int32(38), weightOf(mr, stableMD))
+	s.Assertions.Equal(int32(25), weightOf(mr, canaryMD))
+	s.Assertions.Equal(int32(37), weightOf(mr, "wine-0"))
+}
+
+func (s *RollerSuite) progressingStatus(step int, startedAt time.Time) *v1alpha1.RolloutStatus {
+	weights := newStubRolloutRoute().Spec.Rollout.StepWeights
+	status := &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutProgressing, CurrentStep: step}
+	for i := 0; i <= step; i++ {
+		status.Steps = append(status.Steps, v1alpha1.RolloutStep{
+			Weight: weights[i], StartedAt: metav1.NewTime(startedAt),
+		})
+	}
+	return status
+}
+
+func (s *RollerSuite) expectList(mr *apis.ModelRoute) {
+	ctx := context.Background()
+	s.mockRepo.On("GetModelRouteList", ctx, (*sql.Tx)(nil), mock.AnythingOfType("filter.ListOption")).
+		Return([]apis.ModelRoute{*mr}, nil).Once()
+	s.mockRepo.On("GetModelRouteList", ctx, (*sql.Tx)(nil), mock.AnythingOfType("filter.ListOption")).
+		Return([]apis.ModelRoute{}, nil).Once()
+}
+
+func (s *RollerSuite) expectTx(current *apis.ModelRoute) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	s.mockRepo.On("GetModelRoute", context.Background(), mockTx, mrID).Return(current, nil)
+	return mockTx
+}
+
+func (s *RollerSuite) expectEvent(tx *sql.Tx, eventType event.Type) {
+	s.mockPub.On("PublishEvent", context.Background(), tx, mock.MatchedBy(func(e event.Event) bool {
+		return e.EntityID == mrID && e.EventType == eventType
+	})).Return(nil)
+}
+
+func weightOf(mr *apis.ModelRoute, name string) int32 {
+	for _, target := range mr.Spec.ModelDeploymentTargets {
+		if target.Name == name && target.Weight != nil {
+			return *target.Weight
+		}
+	}
+	return -1
+}
+
+func newStubRolloutRoute() *apis.ModelRoute {
+	maxLatency := 500.0
+	stableWeight := int32(100)
+	canaryWeight := int32(0)
+	return &apis.ModelRoute{
+		ID: mrID,
+		Spec: v1alpha1.ModelRouteSpec{
+			URLPrefix: "/custom/wine",
+			ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{
+				{Name: stableMD, Weight: &stableWeight},
+				{Name: canaryMD, Weight: &canaryWeight},
+			},
+			Rollout: &v1alpha1.RolloutPolicy{
+				Canary:       canaryMD,
+				StepWeights:  []int32{10, 50, 100},
+				StepInterval: "5m",
+				MaxLatencyMs: &maxLatency,
+			},
+		},
+	}
+}
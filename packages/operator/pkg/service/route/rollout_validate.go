@@ -0,0 +1,117 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package route
+
+import (
+	"errors"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"time"
+)
+
+const (
+	UnknownCanaryErrorMessage       = "rollout canary %q must be one of the model deployment targets"
+	RolloutTargetsErrorMessage      = "rollout requires at least two model deployment targets"
+	EmptyStepWeightsErrorMessage    = "rollout step weights must not be empty"
+	InvalidStepWeightsErrorMessage  = "rollout step weights must increase and be between 1 and 100"
+	InvalidStepIntervalErrorMessage = "invalid rollout step interval %q"
+	InvalidMaxErrorRateErrorMessage = "rollout maxErrorRate must be between 0 and 1"
+	InvalidMaxLatencyErrorMessage   = "rollout maxLatencyMs must be positive"
+)
+
+var DefaultStepInterval = "5m"
+
+// SetRolloutDefaults fills optional fields of the rollout policy. Weights of targets are managed by the rollout,
+// so they are reset: the canary gets no traffic before the first step
+func SetRolloutDefaults(spec *v1alpha1.ModelRouteSpec) {
+	if spec.Rollout == nil {
+		return
+	}
+	if len(spec.Rollout.StepInterval) == 0 {
+		spec.Rollout.StepInterval = DefaultStepInterval
+	}
+	if len(spec.ModelDeploymentTargets) > 1 && hasTarget(*spec, spec.Rollout.Canary) {
+		SetRolloutWeights(spec, 0)
+	}
+}
+
+// ValidateRollout validates the rollout policy of the route spec if it is set
+func ValidateRollout(spec v1alpha1.ModelRouteSpec) (errs []error) {
+	policy := spec.Rollout
+	if policy == nil {
+		return nil
+	}
+
+	if len(spec.ModelDeploymentTargets) < 2 {
+		errs = append(errs, errors.New(RolloutTargetsErrorMessage))
+	}
+	if !hasTarget(spec, policy.Canary) {
+		errs = append(errs, fmt.Errorf(UnknownCanaryErrorMessage, policy.Canary))
+	}
+
+	if len(policy.StepWeights) == 0 {
+		errs = append(errs, errors.New(EmptyStepWeightsErrorMessage))
+	}
+	for i, weight := range policy.StepWeights {
+		if weight < 1 || weight > 100 || (i > 0 && weight <= policy.StepWeights[i-1]) {
+			errs = append(errs, errors.New(InvalidStepWeightsErrorMessage))
+			break
+		}
+	}
+
+	if interval, err := time.ParseDuration(policy.StepInterval); err != nil || interval <= 0 {
+		errs = append(errs, fmt.Errorf(InvalidStepIntervalErrorMessage, policy.StepInterval))
+	}
+	if policy.MaxErrorRate != nil && (*policy.MaxErrorRate < 0 || *policy.MaxErrorRate > 1) {
+		errs = append(errs, errors.New(InvalidMaxErrorRateErrorMessage))
+	}
+	if policy.MaxLatencyMs != nil && *policy.MaxLatencyMs <= 0 {
+		errs = append(errs, errors.New(InvalidMaxLatencyErrorMessage))
+	}
+
+	return errs
+}
+
+// SetRolloutWeights gives the weight to the canary and splits the rest of the traffic equally between other targets
+func SetRolloutWeights(spec *v1alpha1.ModelRouteSpec, canaryWeight int32) {
+	others := int32(len(spec.ModelDeploymentTargets) - 1)
+	rest := 100 - canaryWeight
+
+	var i int32
+	for idx := range spec.ModelDeploymentTargets {
+		weight := canaryWeight
+		if spec.ModelDeploymentTargets[idx].Name != spec.Rollout.Canary {
+			// The remainder goes to the first targets
+			weight = rest / others
+			if i < rest%others {
+				weight++
+			}
+			i++
+		}
+		spec.ModelDeploymentTargets[idx].Weight = &weight
+	}
+}
+
+func hasTarget(spec v1alpha1.ModelRouteSpec, name string) bool {
+	for _, target := range spec.ModelDeploymentTargets {
+		if target.Name == name {
+			return true
+		}
+	}
+	return false
+}
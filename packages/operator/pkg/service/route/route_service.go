@@ -21,6 +21,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
 	route "github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
@@ -52,10 +53,15 @@ type EventPublisher interface {
 	PublishEvent(ctx context.Context, tx *sql.Tx, event event.Event) (err error)
 }
 
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
 type serviceImpl struct {
 	// Repository that has "database/sql" underlying storage
-	repo     repo.Repository
-	eventPub EventPublisher
+	repo          repo.Repository
+	eventPub      EventPublisher
+	auditRecorder AuditRecorder
 }
 
 func (s serviceImpl) GetModelRoute(ctx context.Context, id string) (*route.ModelRoute, error) {
@@ -88,13 +94,28 @@ func (s serviceImpl) DeleteModelRoute(ctx context.Context, id string) (err error
 		}
 	}
 
+	oldMr, err := s.repo.GetModelRoute(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
 	e := event.Event{EntityID: id, EventType: event.ModelRouteDeletedEventType,
 		EventGroup: event.ModelRouteEventGroup, Payload: nil}
 	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
 		return
 	}
 
-	return s.repo.DeleteModelRoute(ctx, tx, id)
+	if err = s.repo.DeleteModelRoute(ctx, tx, id); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelRouteKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    oldMr.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s serviceImpl) SetDeletionMark(ctx context.Context, id string, value bool) (err error) {
@@ -155,7 +176,18 @@ func (s serviceImpl) UpdateModelRoute(ctx context.Context, md *route.ModelRoute)
 	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
 		return err
 	}
-	return s.repo.UpdateModelRoute(ctx, tx, md)
+	if err = s.repo.UpdateModelRoute(ctx, tx, md); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelRouteKind,
+		EntityID:   md.ID,
+		Operation:  audit.UpdateOperation,
+		OldSpec:    oldMd.Spec,
+		NewSpec:    md.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s serviceImpl) UpdateModelRouteStatus(
@@ -180,6 +212,9 @@ func (s serviceImpl) UpdateModelRouteStatus(
 		return odahu_errors.SpecWasTouched{Entity: id}
 	}
 
+	// Rollout progress is managed by the rollout controller, runtime does not report it
+	status.Rollout = oldRoute.Status.Rollout
+
 	if hashutil.Equal(oldRoute.Status, status) {
 		// Status is not changed. Skip updating in database and publishing event
 		return nil
@@ -237,9 +272,19 @@ func (s serviceImpl) CreateModelRoute(ctx context.Context, md *route.ModelRoute)
 		return err
 	}
 
-	return s.repo.SaveModelRoute(ctx, tx, md)
+	if err = s.repo.SaveModelRoute(ctx, tx, md); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelRouteKind,
+		EntityID:   md.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    md.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
-func NewService(repo repo.Repository, eventPub EventPublisher) Service {
-	return &serviceImpl{repo: repo, eventPub: eventPub}
+func NewService(repo repo.Repository, eventPub EventPublisher, auditRecorder AuditRecorder) Service {
+	return &serviceImpl{repo: repo, eventPub: eventPub, auditRecorder: auditRecorder}
 }
@@ -22,11 +22,13 @@ import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
 	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/deployment"
 	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/route/mocks"
 	event_pub_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/deployment/mocks"
 	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
+	audit_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/route/mocks"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -45,13 +47,14 @@ func TestSuiteRun(t *testing.T) {
 
 type TestSuite struct {
 	suite.Suite
-	mockRepo *mocks.Repository
-	service  service.Service
-	db       *sql.DB
-	dbMock   sqlmock.Sqlmock
-	as       *assert.Assertions
-	nilTx    *sql.Tx
-	eMockPub *event_pub_mocks.EventPublisher
+	mockRepo  *mocks.Repository
+	service   service.Service
+	db        *sql.DB
+	dbMock    sqlmock.Sqlmock
+	as        *assert.Assertions
+	nilTx     *sql.Tx
+	eMockPub  *event_pub_mocks.EventPublisher
+	mockAudit *audit_mocks.AuditRecorder
 }
 
 func (s *TestSuite) SetupSuite() {
@@ -65,12 +68,14 @@ func (s *TestSuite) SetupTest() {
 	}
 	mockRepo := &mocks.Repository{}
 	eMockPub := &event_pub_mocks.EventPublisher{}
+	mockAudit := &audit_mocks.AuditRecorder{}
 
 	s.mockRepo = mockRepo
 	s.db = db
 	s.dbMock = dbMock
 	s.eMockPub = eMockPub
-	s.service = service.NewService(mockRepo, eMockPub)
+	s.mockAudit = mockAudit
+	s.service = service.NewService(mockRepo, eMockPub, mockAudit)
 }
 
 func (s *TestSuite) TestGetModelRoute() {
@@ -133,11 +138,20 @@ func (s *TestSuite) TestDeleteModelRoute() {
 	}
 	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
 	s.mockRepo.On("IsDefault", ctx, enID, mockTx).Return(false, nil)
+	en := newStubMT()
+	s.mockRepo.On("GetModelRoute", ctx, mockTx, enID).Return(en, nil)
 	s.mockRepo.On("DeleteModelRoute", ctx, mockTx, enID).Return(nil)
 	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockAudit.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelRouteKind,
+		EntityID:   enID,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    en.Spec,
+	}).Return(nil)
 
 	as.NoError(s.service.DeleteModelRoute(ctx, enID))
 	s.mockRepo.AssertExpectations(s.T())
+	s.mockAudit.AssertExpectations(s.T())
 	as.NoError(s.dbMock.ExpectationsWereMet())
 }
 
@@ -178,6 +192,7 @@ func (s *TestSuite) TestUpdateModelRoute() {
 	s.mockRepo.On("UpdateModelRoute", ctx, mockTx, en).Return(nil)
 	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
 	s.mockRepo.On("GetModelRoute", ctx, s.nilTx, en.ID).Return(en, nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	as.NoError(s.service.UpdateModelRoute(ctx, en))
 	s.mockRepo.AssertExpectations(s.T())
@@ -202,6 +217,7 @@ func (s *TestSuite) TestUpdateModelRoute_UpdatedAt() {
 	s.mockRepo.On("UpdateModelRoute", ctx, mockTx, en).Return(nil)
 	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
 	s.mockRepo.On("GetModelRoute", ctx, s.nilTx, en.ID).Return(en, nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	timeBeforeCall := time.Now()
 	as.NoError(s.service.UpdateModelRoute(ctx, en))
@@ -245,6 +261,35 @@ func (s *TestSuite) TestUpdateModelRouteStatus() {
 	s.mockRepo.AssertExpectations(s.T())
 }
 
+func (s *TestSuite) TestUpdateModelRouteStatusKeepsRollout() {
+	as := assert.New(s.T())
+
+	s.dbMock.ExpectBegin()
+	s.dbMock.ExpectCommit()
+
+	ctx := context.Background()
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	repoEn := newStubMT()
+	repoEn.Status.Rollout = &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutProgressing, CurrentStep: 1}
+	s.mockRepo.On("GetModelRoute", ctx, mockTx, enID).Return(repoEn, nil)
+	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
+
+	// Runtime does not report the rollout
+	newStatus := v1alpha1.ModelRouteStatus{EdgeURL: "new", State: v1alpha1.ModelRouteStateReady}
+	s.mockRepo.
+		On("UpdateModelRouteStatus", ctx, mockTx, enID, mock.MatchedBy(func(status v1alpha1.ModelRouteStatus) bool {
+			return status.EdgeURL == "new" && status.Rollout != nil && status.Rollout.CurrentStep == 1
+		})).
+		Return(nil)
+
+	as.NoError(s.service.UpdateModelRouteStatus(ctx, enID, newStatus, repoEn.Spec))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
 func (s *TestSuite) TestUpdateModelRouteStatusSpecTouched() {
 	as := assert.New(s.T())
 
@@ -296,6 +341,7 @@ func (s *TestSuite) TestCreateModelRoute() {
 	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
 	s.mockRepo.On("SaveModelRoute", ctx, mockTx, en).Return(nil)
 	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	as.NoError(s.service.CreateModelRoute(ctx, en))
 	s.mockRepo.AssertExpectations(s.T())
@@ -316,6 +362,7 @@ func (s *TestSuite) TestCreateModelRoute_CreatedAt() {
 	s.mockRepo.On("BeginTransaction", ctx).Return(mockTx, nil)
 	s.mockRepo.On("SaveModelRoute", ctx, mockTx, en).Return(nil)
 	s.eMockPub.On("PublishEvent", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockAudit.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	timeBeforeCall := time.Now()
 	as.NoError(s.service.CreateModelRoute(ctx, en))
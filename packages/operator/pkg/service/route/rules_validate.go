@@ -0,0 +1,196 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package route
+
+import (
+	"errors"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"regexp"
+	"strings"
+)
+
+const (
+	EmptyRuleNameErrorMessage      = "rule name must not be empty"
+	DuplicatedRuleNameErrorMessage = "rule name %q is duplicated"
+	EmptyRuleMatchErrorMessage     = "rule %q must contain at least one match condition"
+	EmptyRuleTargetsErrorMessage   = "rule %q must contain at least one model deployment target"
+	UnknownMatchTypeErrorMessage   = "rule %q: unknown match type %q. Possible values: %v"
+	EmptyMatchNameErrorMessage     = "rule %q: name of the %s match must not be empty"
+	MatchValueErrorMessage         = "rule %q: exactly one of exact, prefix and regex must be set for the %s %q"
+	QueryParamPrefixErrorMessage   = "rule %q: prefix match is not supported for the query parameter %q"
+	InvalidMatchRegexErrorMessage  = "rule %q: invalid regex of the %s %q: %s"
+	DuplicatedMatchErrorMessage    = "rule %q: %s %q is matched more than once"
+	CookieHeaderMatchErrorMessage  = "rule %q: cookie header can not be matched together with cookies"
+	TooManyCookiesErrorMessage     = "rule %q: at most %d cookies can be matched"
+	OverlappingRulesErrorMessage   = "rules %q and %q overlap: a request can match both of them"
+	cookieHeader                   = "cookie"
+	// Every order of cookies is a separate alternative of the cookie header regex,
+	// so the regex grows as the factorial of the number of cookies
+	MaxRuleCookies = 3
+)
+
+var matchTypes = []v1alpha1.RouteMatchType{
+	v1alpha1.HeaderRouteMatch, v1alpha1.QueryParamRouteMatch, v1alpha1.CookieRouteMatch,
+}
+
+// ValidateRules validates match conditions of route rules and checks that rules do not overlap.
+// Targets of rules are validated by the API server together with other targets of the route
+func ValidateRules(spec v1alpha1.ModelRouteSpec) (errs []error) {
+	names := make(map[string]bool, len(spec.Rules))
+	for _, rule := range spec.Rules {
+		if len(rule.Name) == 0 {
+			errs = append(errs, errors.New(EmptyRuleNameErrorMessage))
+		} else if names[rule.Name] {
+			errs = append(errs, fmt.Errorf(DuplicatedRuleNameErrorMessage, rule.Name))
+		}
+		names[rule.Name] = true
+
+		if len(rule.ModelDeploymentTargets) == 0 {
+			errs = append(errs, fmt.Errorf(EmptyRuleTargetsErrorMessage, rule.Name))
+		}
+		errs = append(errs, validateMatch(rule)...)
+	}
+
+	// Overlaps are searched only among valid rules, otherwise errors of conditions are reported twice
+	if len(errs) > 0 {
+		return errs
+	}
+	for i := range spec.Rules {
+		for j := i + 1; j < len(spec.Rules); j++ {
+			if rulesOverlap(spec.Rules[i], spec.Rules[j]) {
+				errs = append(errs, fmt.Errorf(OverlappingRulesErrorMessage, spec.Rules[i].Name, spec.Rules[j].Name))
+			}
+		}
+	}
+	return errs
+}
+
+func validateMatch(rule v1alpha1.RouteRule) (errs []error) {
+	if len(rule.Match) == 0 {
+		return []error{fmt.Errorf(EmptyRuleMatchErrorMessage, rule.Name)}
+	}
+
+	keys := make(map[string]bool, len(rule.Match))
+	for _, m := range rule.Match {
+		if !isKnownMatchType(m.Type) {
+			errs = append(errs, fmt.Errorf(UnknownMatchTypeErrorMessage, rule.Name, m.Type, matchTypes))
+			continue
+		}
+		if len(m.Name) == 0 {
+			errs = append(errs, fmt.Errorf(EmptyMatchNameErrorMessage, rule.Name, m.Type))
+			continue
+		}
+
+		values := 0
+		for _, v := range []string{m.Exact, m.Prefix, m.Regex} {
+			if len(v) > 0 {
+				values++
+			}
+		}
+		if values != 1 {
+			errs = append(errs, fmt.Errorf(MatchValueErrorMessage, rule.Name, m.Type, m.Name))
+		}
+		if m.Type == v1alpha1.QueryParamRouteMatch && len(m.Prefix) > 0 {
+			errs = append(errs, fmt.Errorf(QueryParamPrefixErrorMessage, rule.Name, m.Name))
+		}
+		if len(m.Regex) > 0 {
+			if _, err := regexp.Compile(m.Regex); err != nil {
+				errs = append(errs, fmt.Errorf(InvalidMatchRegexErrorMessage, rule.Name, m.Type, m.Name, err.Error()))
+			}
+		}
+
+		key := matchKey(m)
+		if keys[key] {
+			errs = append(errs, fmt.Errorf(DuplicatedMatchErrorMessage, rule.Name, m.Type, m.Name))
+		}
+		keys[key] = true
+	}
+
+	// Cookies are matched by the cookie header, so they can not be combined with a condition on the header itself
+	cookies := 0
+	for _, m := range rule.Match {
+		if m.Type == v1alpha1.CookieRouteMatch {
+			cookies++
+		}
+	}
+	if cookies > MaxRuleCookies {
+		errs = append(errs, fmt.Errorf(TooManyCookiesErrorMessage, rule.Name, MaxRuleCookies))
+	}
+	if cookies > 0 && keys[matchKey(v1alpha1.RouteMatch{Type: v1alpha1.HeaderRouteMatch, Name: cookieHeader})] {
+		errs = append(errs, fmt.Errorf(CookieHeaderMatchErrorMessage, rule.Name))
+	}
+
+	return errs
+}
+
+func isKnownMatchType(t v1alpha1.RouteMatchType) bool {
+	for _, known := range matchTypes {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
+
+// matchKey identifies the matched value of the request. Header names are case-insensitive
+func matchKey(m v1alpha1.RouteMatch) string {
+	name := m.Name
+	if m.Type == v1alpha1.HeaderRouteMatch {
+		name = strings.ToLower(name)
+	}
+	return string(m.Type) + "/" + name
+}
+
+// rulesOverlap returns false only if both rules match the same value with conditions
+// which can not be met simultaneously. Two regexes or a regex and a prefix are considered overlapping,
+// because their intersection can not be checked
+func rulesOverlap(a, b v1alpha1.RouteRule) bool {
+	for _, ma := range a.Match {
+		for _, mb := range b.Match {
+			if matchKey(ma) == matchKey(mb) && valuesDisjoint(ma, mb) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func valuesDisjoint(a, b v1alpha1.RouteMatch) bool {
+	switch {
+	case len(a.Exact) > 0 && len(b.Exact) > 0:
+		return a.Exact != b.Exact
+	case len(a.Exact) > 0 && len(b.Prefix) > 0:
+		return !strings.HasPrefix(a.Exact, b.Prefix)
+	case len(a.Prefix) > 0 && len(b.Exact) > 0:
+		return !strings.HasPrefix(b.Exact, a.Prefix)
+	case len(a.Prefix) > 0 && len(b.Prefix) > 0:
+		return !strings.HasPrefix(a.Prefix, b.Prefix) && !strings.HasPrefix(b.Prefix, a.Prefix)
+	case len(a.Exact) > 0 && len(b.Regex) > 0:
+		return !fullMatch(b.Regex, a.Exact)
+	case len(a.Regex) > 0 && len(b.Exact) > 0:
+		return !fullMatch(a.Regex, b.Exact)
+	}
+	return false
+}
+
+// fullMatch reports whether the regex matches the whole value as Istio does
+func fullMatch(regex, value string) bool {
+	re, err := regexp.Compile("^(?:" + regex + ")$")
+	return err == nil && re.MatchString(value)
+}
@@ -0,0 +1,142 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package route_test
+
+import (
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/route"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newStubRule(name string, match ...v1alpha1.RouteMatch) v1alpha1.RouteRule {
+	return v1alpha1.RouteRule{
+		Name:                   name,
+		Match:                  match,
+		ModelDeploymentTargets: []v1alpha1.ModelDeploymentTarget{{Name: canaryMD}},
+	}
+}
+
+func header(name, exact string) v1alpha1.RouteMatch {
+	return v1alpha1.RouteMatch{Type: v1alpha1.HeaderRouteMatch, Name: name, Exact: exact}
+}
+
+func cookie(name, exact string) v1alpha1.RouteMatch {
+	return v1alpha1.RouteMatch{Type: v1alpha1.CookieRouteMatch, Name: name, Exact: exact}
+}
+
+func TestValidateRulesValid(t *testing.T) {
+	spec := v1alpha1.ModelRouteSpec{Rules: []v1alpha1.RouteRule{
+		newStubRule("group-a", header("X-Experiment", "a")),
+		newStubRule("group-b", header("x-experiment", "b"), v1alpha1.RouteMatch{
+			Type: v1alpha1.CookieRouteMatch, Name: "tester", Regex: "true|yes",
+		}),
+		newStubRule("beta", v1alpha1.RouteMatch{Type: v1alpha1.HeaderRouteMatch, Name: "X-Experiment", Prefix: "beta-"}),
+		newStubRule("regex", header("X-Experiment", "c"), v1alpha1.RouteMatch{
+			Type: v1alpha1.QueryParamRouteMatch, Name: "group", Regex: "c[0-9]",
+		}),
+	}}
+
+	assert.Empty(t, service.ValidateRules(spec))
+}
+
+func TestValidateRulesOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a    v1alpha1.RouteRule
+		b    v1alpha1.RouteRule
+	}{
+		{
+			name: "same value",
+			a:    newStubRule("a", header("X-Experiment", "a")),
+			b:    newStubRule("b", header("X-Experiment", "a")),
+		},
+		{
+			name: "different keys",
+			a:    newStubRule("a", header("X-Experiment", "a")),
+			b:    newStubRule("b", header("X-Tester", "true")),
+		},
+		{
+			name: "exact matches prefix",
+			a:    newStubRule("a", header("X-Experiment", "beta-1")),
+			b: newStubRule("b", v1alpha1.RouteMatch{
+				Type: v1alpha1.HeaderRouteMatch, Name: "X-Experiment", Prefix: "beta-",
+			}),
+		},
+		{
+			name: "exact matches regex",
+			a:    newStubRule("a", header("X-Experiment", "c1")),
+			b: newStubRule("b", v1alpha1.RouteMatch{
+				Type: v1alpha1.HeaderRouteMatch, Name: "X-Experiment", Regex: "c[0-9]",
+			}),
+		},
+		{
+			name: "two regexes",
+			a: newStubRule("a", v1alpha1.RouteMatch{
+				Type: v1alpha1.QueryParamRouteMatch, Name: "group", Regex: "a.*",
+			}),
+			b: newStubRule("b", v1alpha1.RouteMatch{
+				Type: v1alpha1.QueryParamRouteMatch, Name: "group", Regex: "b.*",
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := service.ValidateRules(v1alpha1.ModelRouteSpec{Rules: []v1alpha1.RouteRule{tt.a, tt.b}})
+			assert.Len(t, errs, 1)
+			assert.EqualError(t, errs[0], fmt.Sprintf(service.OverlappingRulesErrorMessage, "a", "b"))
+		})
+	}
+}
+
+func TestValidateRulesInvalidMatch(t *testing.T) {
+	spec := v1alpha1.ModelRouteSpec{Rules: []v1alpha1.RouteRule{
+		newStubRule("no-match"),
+		newStubRule("no-value", v1alpha1.RouteMatch{Type: v1alpha1.HeaderRouteMatch, Name: "X-Experiment"}),
+		newStubRule("query-prefix", v1alpha1.RouteMatch{
+			Type: v1alpha1.QueryParamRouteMatch, Name: "group", Prefix: "a",
+		}),
+		newStubRule("bad-regex", v1alpha1.RouteMatch{Type: v1alpha1.CookieRouteMatch, Name: "group", Regex: "("}),
+		newStubRule("unknown", v1alpha1.RouteMatch{Type: "path", Name: "group", Exact: "a"}),
+		newStubRule("duplicated", header("X-Experiment", "a"), header("x-experiment", "b")),
+		newStubRule("cookie-header", header("Cookie", "a=b"), v1alpha1.RouteMatch{
+			Type: v1alpha1.CookieRouteMatch, Name: "group", Exact: "a",
+		}),
+		newStubRule("many-cookies", cookie("a", "1"), cookie("b", "1"), cookie("c", "1"), cookie("d", "1")),
+		{Name: "no-targets", Match: []v1alpha1.RouteMatch{header("X-Experiment", "z")}},
+		newStubRule("no-targets", header("X-Experiment", "y")),
+	}}
+
+	assert.ElementsMatch(t, []error{
+		fmt.Errorf(service.EmptyRuleMatchErrorMessage, "no-match"),
+		fmt.Errorf(service.MatchValueErrorMessage, "no-value", v1alpha1.HeaderRouteMatch, "X-Experiment"),
+		fmt.Errorf(service.QueryParamPrefixErrorMessage, "query-prefix", "group"),
+		fmt.Errorf(service.InvalidMatchRegexErrorMessage, "bad-regex", v1alpha1.CookieRouteMatch, "group",
+			"error parsing regexp: missing closing ): `(`"),
+		fmt.Errorf(service.UnknownMatchTypeErrorMessage, "unknown", "path", []v1alpha1.RouteMatchType{
+			v1alpha1.HeaderRouteMatch, v1alpha1.QueryParamRouteMatch, v1alpha1.CookieRouteMatch,
+		}),
+		fmt.Errorf(service.DuplicatedMatchErrorMessage, "duplicated", v1alpha1.HeaderRouteMatch, "x-experiment"),
+		fmt.Errorf(service.CookieHeaderMatchErrorMessage, "cookie-header"),
+		fmt.Errorf(service.TooManyCookiesErrorMessage, "many-cookies", service.MaxRuleCookies),
+		fmt.Errorf(service.EmptyRuleTargetsErrorMessage, "no-targets"),
+		fmt.Errorf(service.DuplicatedRuleNameErrorMessage, "no-targets"),
+	}, service.ValidateRules(spec))
+}
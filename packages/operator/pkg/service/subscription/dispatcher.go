@@ -0,0 +1,286 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+type EventReader interface {
+	GetAfter(ctx context.Context, cursor int, limit int) (records []event.Record, err error)
+}
+
+// Dispatcher delivers events of the event log to webhook endpoints of subscriptions.
+// Events are delivered at least once and can be delivered out of order, because
+// failed deliveries are retried independently. Receivers can use event ID to order events.
+type Dispatcher struct {
+	repo         Repository
+	deliveryRepo DeliveryRepository
+	eventReader  EventReader
+	client       *http.Client
+	config       config.SubscriptionConfig
+}
+
+func NewDispatcher(
+	repo Repository, deliveryRepo DeliveryRepository, eventReader EventReader, cfg config.SubscriptionConfig,
+) *Dispatcher {
+	return &Dispatcher{
+		repo:         repo,
+		deliveryRepo: deliveryRepo,
+		eventReader:  eventReader,
+		client:       newWebhookClient(cfg),
+		config:       cfg,
+	}
+}
+
+// newWebhookClient returns a client which refuses to connect to internal addresses of not allowed hosts.
+// Addresses are checked after DNS resolution, so host names pointing to internal services are refused too
+func newWebhookClient(cfg config.SubscriptionConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	guardedDialer := &net.Dialer{
+		Timeout: cfg.Timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isInternalIP(ip) {
+				return fmt.Errorf("webhooks to internal address %s are not allowed", host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				host, _, err := net.SplitHostPort(addr)
+				if err != nil {
+					return nil, err
+				}
+				if isAllowedHost(host, cfg.AllowedHosts) {
+					return dialer.DialContext(ctx, network, addr)
+				}
+				return guardedDialer.DialContext(ctx, network, addr)
+			},
+		},
+	}
+}
+
+// Dispatch creates deliveries for new events of all subscriptions and makes due delivery attempts
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	subs, err := d.listSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		// A failure of one subscription, for example its concurrent deletion, must not block others
+		if err := d.enqueue(ctx, sub); err != nil {
+			log.Error(err, "Unable to enqueue deliveries", "subscription", sub.ID)
+		}
+	}
+
+	return d.deliver(ctx, subs)
+}
+
+func (d *Dispatcher) listSubscriptions(ctx context.Context) (map[string]*api_types.Subscription, error) {
+	subs := make(map[string]*api_types.Subscription)
+	for page := 0; ; page++ {
+		res, err := d.repo.List(ctx, nil, filter.Page(page))
+		if err != nil {
+			return nil, err
+		}
+		if len(res) == 0 {
+			return subs, nil
+		}
+		for i := range res {
+			subs[res[i].ID] = &res[i]
+		}
+	}
+}
+
+// enqueue creates pending deliveries for matching events after the subscription cursor.
+// Deliveries and the new cursor are saved in the same transaction, so every event is enqueued once
+func (d *Dispatcher) enqueue(ctx context.Context, sub *api_types.Subscription) (err error) {
+	records, err := d.eventReader.GetAfter(ctx, sub.Status.Cursor, d.config.BatchSize)
+	if compacted, ok := err.(odahuErrs.CursorCompactedError); ok && compacted.CompactedUntil > sub.Status.Cursor {
+		return d.skipCompacted(ctx, sub, compacted.CompactedUntil)
+	}
+	if err != nil || len(records) == 0 {
+		return err
+	}
+
+	now := time.Now().UTC()
+	deliveries := make([]api_types.Delivery, 0, len(records))
+	for _, r := range records {
+		if !sub.Spec.Matches(r) {
+			continue
+		}
+		deliveries = append(deliveries, api_types.Delivery{
+			SubscriptionID:  sub.ID,
+			State:           api_types.DeliveryPending,
+			NextAttemptTime: now,
+			CreatedAt:       now,
+			Event:           r,
+		})
+	}
+
+	tx, err := d.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = d.deliveryRepo.Create(ctx, tx, deliveries); err != nil {
+		return err
+	}
+
+	status := sub.Status
+	status.Cursor = records[len(records)-1].ID
+	if err = d.repo.UpdateStatus(ctx, tx, sub.ID, status); err != nil {
+		return err
+	}
+	sub.Status = status
+
+	return nil
+}
+
+// skipCompacted moves the cursor of the subscription past events which were removed by compaction
+// before they were enqueued. These events can not be delivered, so the loss is reported in the status
+func (d *Dispatcher) skipCompacted(ctx context.Context, sub *api_types.Subscription, compactedUntil int) error {
+	lastError := fmt.Sprintf("events %d-%d were compacted before they were enqueued for delivery",
+		sub.Status.Cursor+1, compactedUntil)
+	log.Info("Subscription skips compacted events", "subscription", sub.ID, "error", lastError)
+
+	status := sub.Status
+	status.Cursor = compactedUntil
+	status.LastError = lastError
+	if err := d.repo.UpdateStatus(ctx, nil, sub.ID, status); err != nil {
+		return err
+	}
+	sub.Status = status
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, subs map[string]*api_types.Subscription) error {
+	due, err := d.deliveryRepo.ListDue(ctx, nil, time.Now().UTC(), d.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range due {
+		sub, ok := subs[delivery.SubscriptionID]
+		if !ok {
+			// Subscription was created after the listing. Delivery will be attempted during the next dispatch
+			continue
+		}
+
+		now := time.Now().UTC()
+		if sendErr := d.send(ctx, sub.Spec, delivery); sendErr != nil {
+			log.Info("Delivery attempt failed", "subscription", sub.ID, "delivery", delivery.ID,
+				"error", sendErr.Error())
+
+			delivery.Attempts++
+			delivery.LastError = sendErr.Error()
+			delivery.NextAttemptTime = now.Add(d.backoff(delivery.Attempts))
+			if delivery.Attempts >= d.config.MaxAttempts {
+				delivery.State = api_types.DeliveryDeadLetter
+			}
+			// NotFoundError means that the subscription was deleted during the attempt
+			if err := d.deliveryRepo.Update(ctx, nil, delivery); err != nil && !odahuErrs.IsNotFoundError(err) {
+				return err
+			}
+			sub.Status.LastError = sendErr.Error()
+		} else {
+			if err := d.deliveryRepo.Delete(ctx, nil, delivery.ID); err != nil && !odahuErrs.IsNotFoundError(err) {
+				return err
+			}
+			sub.Status.LastDeliveryTime = &now
+		}
+
+		if err := d.repo.UpdateStatus(ctx, nil, sub.ID, sub.Status); err != nil && !odahuErrs.IsNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backoff returns the delay before the next attempt after the number of failed attempts
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.InitialBackoff
+	for i := 1; i < attempts && delay < d.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.config.MaxBackoff {
+		delay = d.config.MaxBackoff
+	}
+	return delay
+}
+
+func (d *Dispatcher) send(ctx context.Context, spec api_types.SubscriptionSpec, delivery api_types.Delivery) error {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api_types.EventTypeHeader, string(delivery.Event.EventType))
+	req.Header.Set(api_types.DeliveryHeader, strconv.Itoa(delivery.ID))
+	if len(spec.Secret) != 0 {
+		req.Header.Set(api_types.SignatureHeader, api_types.Sign(spec.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Body is drained to reuse the connection
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook endpoint responded with %d status code", resp.StatusCode)
+	}
+
+	return nil
+}
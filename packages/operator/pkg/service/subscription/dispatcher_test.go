@@ -0,0 +1,220 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package subscription_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/subscription/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type dispatcherFixture struct {
+	repo         *mocks.Repository
+	deliveryRepo *mocks.DeliveryRepository
+	eventReader  *mocks.EventReader
+	dispatcher   *service.Dispatcher
+	cfg          config.SubscriptionConfig
+	nilTx        *sql.Tx
+}
+
+func newDispatcherFixture() dispatcherFixture {
+	f := dispatcherFixture{
+		repo:         &mocks.Repository{},
+		deliveryRepo: &mocks.DeliveryRepository{},
+		eventReader:  &mocks.EventReader{},
+		cfg:          config.NewDefaultSubscriptionConfig(),
+	}
+	f.cfg.MaxAttempts = 2
+	// Test webhook endpoints listen on the loopback address
+	f.cfg.AllowedHosts = []string{"127.0.0.1"}
+	f.dispatcher = service.NewDispatcher(f.repo, f.deliveryRepo, f.eventReader, f.cfg)
+	return f
+}
+
+func (f dispatcherFixture) expectSubscriptions(subs ...apis.Subscription) {
+	f.repo.On("List", mock.Anything, f.nilTx, mock.AnythingOfType("filter.ListOption")).Return(subs, nil).Once()
+	f.repo.On("List", mock.Anything, f.nilTx, mock.AnythingOfType("filter.ListOption")).
+		Return([]apis.Subscription{}, nil).Once()
+}
+
+func TestDispatchEnqueuesMatchingEvents(t *testing.T) {
+	f := newDispatcherFixture()
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbMock.ExpectBegin()
+	dbMock.ExpectCommit()
+	mockTx, _ := db.Begin()
+
+	sub := apis.Subscription{
+		ID:     "sub",
+		Spec:   apis.SubscriptionSpec{URL: "http://localhost", EventGroups: []event.Group{event.ModelTrainingEventGroup}},
+		Status: apis.SubscriptionStatus{Cursor: 5},
+	}
+	f.expectSubscriptions(sub)
+	f.eventReader.On("GetAfter", mock.Anything, 5, f.cfg.BatchSize).Return([]event.Record{
+		{ID: 6, EntityID: "md", EventType: event.ModelDeploymentCreatedEventType, EventGroup: event.ModelDeploymentEventGroup},
+		{ID: 7, EntityID: "mt", EventType: event.ModelTrainingCreatedEventType, EventGroup: event.ModelTrainingEventGroup},
+	}, nil)
+	f.repo.On("BeginTransaction", mock.Anything).Return(mockTx, nil)
+	f.deliveryRepo.On("Create", mock.Anything, mockTx, mock.MatchedBy(func(deliveries []apis.Delivery) bool {
+		return len(deliveries) == 1 && deliveries[0].Event.ID == 7 && deliveries[0].State == apis.DeliveryPending
+	})).Return(nil)
+	f.repo.On("UpdateStatus", mock.Anything, mockTx, "sub", apis.SubscriptionStatus{Cursor: 7}).Return(nil)
+	f.deliveryRepo.On("ListDue", mock.Anything, f.nilTx, mock.AnythingOfType("time.Time"), f.cfg.BatchSize).
+		Return([]apis.Delivery{}, nil)
+
+	assert.NoError(t, f.dispatcher.Dispatch(context.Background()))
+	f.deliveryRepo.AssertExpectations(t)
+	f.repo.AssertExpectations(t)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestDispatchSkipsCompactedEvents(t *testing.T) {
+	f := newDispatcherFixture()
+
+	sub := apis.Subscription{ID: "sub", Spec: apis.SubscriptionSpec{URL: "http://localhost"},
+		Status: apis.SubscriptionStatus{Cursor: 5}}
+	f.expectSubscriptions(sub)
+	f.eventReader.On("GetAfter", mock.Anything, 5, f.cfg.BatchSize).
+		Return(nil, odahu_errors.CursorCompactedError{Cursor: 5, CompactedUntil: 9})
+	// Subscription resumes after compacted events and reports the loss
+	f.repo.On("UpdateStatus", mock.Anything, f.nilTx, "sub", mock.MatchedBy(func(status apis.SubscriptionStatus) bool {
+		return status.Cursor == 9 && status.LastError != ""
+	})).Return(nil)
+	f.deliveryRepo.On("ListDue", mock.Anything, f.nilTx, mock.AnythingOfType("time.Time"), f.cfg.BatchSize).
+		Return([]apis.Delivery{}, nil)
+
+	assert.NoError(t, f.dispatcher.Dispatch(context.Background()))
+	f.repo.AssertExpectations(t)
+}
+
+func TestDispatchDeliversSignedEvent(t *testing.T) {
+	f := newDispatcherFixture()
+
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sub := apis.Subscription{ID: "sub", Spec: apis.SubscriptionSpec{URL: server.URL, Secret: "secret"}}
+	delivery := apis.Delivery{
+		ID: 3, SubscriptionID: "sub", State: apis.DeliveryPending,
+		Event: event.Record{ID: 7, EntityID: "mt", EventType: event.ModelTrainingStatusUpdatedEventType},
+	}
+	f.expectSubscriptions(sub)
+	f.eventReader.On("GetAfter", mock.Anything, 0, f.cfg.BatchSize).Return(nil, nil)
+	f.deliveryRepo.On("ListDue", mock.Anything, f.nilTx, mock.AnythingOfType("time.Time"), f.cfg.BatchSize).
+		Return([]apis.Delivery{delivery}, nil)
+	f.deliveryRepo.On("Delete", mock.Anything, f.nilTx, 3).Return(nil)
+	f.repo.On("UpdateStatus", mock.Anything, f.nilTx, "sub", mock.MatchedBy(func(status apis.SubscriptionStatus) bool {
+		return status.LastDeliveryTime != nil && status.LastError == ""
+	})).Return(nil)
+
+	assert.NoError(t, f.dispatcher.Dispatch(context.Background()))
+	f.deliveryRepo.AssertExpectations(t)
+	f.repo.AssertExpectations(t)
+
+	var received event.Record
+	assert.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, delivery.Event.ID, received.ID)
+	assert.Equal(t, apis.Sign("secret", body), header.Get(apis.SignatureHeader))
+	assert.Equal(t, string(event.ModelTrainingStatusUpdatedEventType), header.Get(apis.EventTypeHeader))
+	assert.Equal(t, "3", header.Get(apis.DeliveryHeader))
+}
+
+func TestDispatchRetriesAndDeadLetters(t *testing.T) {
+	f := newDispatcherFixture()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sub := apis.Subscription{ID: "sub", Spec: apis.SubscriptionSpec{URL: server.URL}}
+	first := apis.Delivery{ID: 1, SubscriptionID: "sub", State: apis.DeliveryPending}
+	last := apis.Delivery{ID: 2, SubscriptionID: "sub", State: apis.DeliveryPending, Attempts: f.cfg.MaxAttempts - 1}
+	f.expectSubscriptions(sub)
+	f.eventReader.On("GetAfter", mock.Anything, 0, f.cfg.BatchSize).Return(nil, nil)
+	f.deliveryRepo.On("ListDue", mock.Anything, f.nilTx, mock.AnythingOfType("time.Time"), f.cfg.BatchSize).
+		Return([]apis.Delivery{first, last}, nil)
+
+	start := time.Now()
+	f.deliveryRepo.On("Update", mock.Anything, f.nilTx, mock.MatchedBy(func(d apis.Delivery) bool {
+		return d.ID == 1 && d.Attempts == 1 && d.State == apis.DeliveryPending &&
+			!d.NextAttemptTime.Before(start.Add(f.cfg.InitialBackoff)) && d.LastError != ""
+	})).Return(nil)
+	f.deliveryRepo.On("Update", mock.Anything, f.nilTx, mock.MatchedBy(func(d apis.Delivery) bool {
+		return d.ID == 2 && d.Attempts == f.cfg.MaxAttempts && d.State == apis.DeliveryDeadLetter
+	})).Return(nil)
+	f.repo.On("UpdateStatus", mock.Anything, f.nilTx, "sub", mock.MatchedBy(func(status apis.SubscriptionStatus) bool {
+		return status.LastError != "" && status.LastDeliveryTime == nil
+	})).Return(nil)
+
+	assert.NoError(t, f.dispatcher.Dispatch(context.Background()))
+	f.deliveryRepo.AssertExpectations(t)
+	f.repo.AssertExpectations(t)
+}
+
+func TestDispatchRefusesInternalAddresses(t *testing.T) {
+	f := newDispatcherFixture()
+	f.cfg.AllowedHosts = nil
+	f.dispatcher = service.NewDispatcher(f.repo, f.deliveryRepo, f.eventReader, f.cfg)
+
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sub := apis.Subscription{ID: "sub", Spec: apis.SubscriptionSpec{URL: server.URL}}
+	delivery := apis.Delivery{ID: 1, SubscriptionID: "sub", State: apis.DeliveryPending}
+	f.expectSubscriptions(sub)
+	f.eventReader.On("GetAfter", mock.Anything, 0, f.cfg.BatchSize).Return(nil, nil)
+	f.deliveryRepo.On("ListDue", mock.Anything, f.nilTx, mock.AnythingOfType("time.Time"), f.cfg.BatchSize).
+		Return([]apis.Delivery{delivery}, nil)
+	f.deliveryRepo.On("Update", mock.Anything, f.nilTx, mock.MatchedBy(func(d apis.Delivery) bool {
+		return d.ID == 1 && d.Attempts == 1 && strings.Contains(d.LastError, "internal address")
+	})).Return(nil)
+	f.repo.On("UpdateStatus", mock.Anything, f.nilTx, "sub", mock.Anything).Return(nil)
+
+	assert.NoError(t, f.dispatcher.Dispatch(context.Background()))
+	f.deliveryRepo.AssertExpectations(t)
+	assert.False(t, requested)
+}
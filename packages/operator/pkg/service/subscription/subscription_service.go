@@ -0,0 +1,223 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"time"
+)
+
+var log = logf.Log.WithName("subscription--service")
+
+type Repository interface {
+	Create(ctx context.Context, tx *sql.Tx, s api_types.Subscription) (err error)
+	Get(ctx context.Context, tx *sql.Tx, id string) (res api_types.Subscription, err error)
+	Update(ctx context.Context, tx *sql.Tx, id string, s api_types.Subscription) (err error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, id string, status api_types.SubscriptionStatus) (err error)
+	List(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) (res []api_types.Subscription, err error)
+	Delete(ctx context.Context, tx *sql.Tx, id string) (err error)
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+}
+
+type DeliveryRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, deliveries []api_types.Delivery) (err error)
+	Update(ctx context.Context, tx *sql.Tx, d api_types.Delivery) (err error)
+	Delete(ctx context.Context, tx *sql.Tx, id int) (err error)
+	Requeue(ctx context.Context, tx *sql.Tx, subscriptionID string, id int, nextAttempt time.Time) (err error)
+	ListDue(ctx context.Context, tx *sql.Tx, now time.Time, limit int) (res []api_types.Delivery, err error)
+	List(
+		ctx context.Context, tx *sql.Tx, subscriptionID string, options ...filter.ListOption,
+	) (res []api_types.Delivery, err error)
+}
+
+type EventLog interface {
+	LastID(ctx context.Context) (id int, err error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
+type Service struct {
+	repo          Repository
+	deliveryRepo  DeliveryRepository
+	eventLog      EventLog
+	auditRecorder AuditRecorder
+	allowedHosts  []string
+}
+
+func NewService(
+	repo Repository, deliveryRepo DeliveryRepository, eventLog EventLog, auditRecorder AuditRecorder,
+	allowedHosts []string,
+) *Service {
+	return &Service{
+		repo:          repo,
+		deliveryRepo:  deliveryRepo,
+		eventLog:      eventLog,
+		auditRecorder: auditRecorder,
+		allowedHosts:  allowedHosts,
+	}
+}
+
+// Create creates a subscription. Only events raised after the creation are delivered to it
+func (s *Service) Create(ctx context.Context, sub *api_types.Subscription) (err error) {
+
+	// Set fields that managed by platform. Cannot be overridden by user
+	sub.CreatedAt = time.Now().UTC()
+	sub.UpdatedAt = time.Now().UTC()
+	sub.Status = api_types.SubscriptionStatus{}
+
+	if errs := ValidateCreateUpdate(*sub, s.allowedHosts); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           sub.ID,
+			ValidationErrors: errs,
+		}
+	}
+
+	if sub.Status.Cursor, err = s.eventLog.LastID(ctx); err != nil {
+		return err
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.Create(ctx, tx, *sub); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.SubscriptionKind,
+		EntityID:   sub.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    sub.DeleteSensitiveData().Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+// Update updates spec of the subscription. The old secret is kept if the masked secret is passed
+func (s *Service) Update(ctx context.Context, id string, sub *api_types.Subscription) (err error) {
+
+	sub.UpdatedAt = time.Now().UTC()
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	sub.CreatedAt = old.CreatedAt
+	sub.Status = old.Status
+	if sub.Spec.Secret == api_types.SecretMask {
+		sub.Spec.Secret = old.Spec.Secret
+	}
+
+	if errs := ValidateCreateUpdate(*sub, s.allowedHosts); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           sub.ID,
+			ValidationErrors: errs,
+		}
+	}
+
+	if err = s.repo.Update(ctx, tx, id, *sub); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.SubscriptionKind,
+		EntityID:   id,
+		Operation:  audit.UpdateOperation,
+		OldSpec:    old.DeleteSensitiveData().Spec,
+		NewSpec:    sub.DeleteSensitiveData().Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+// Delete deletes the subscription with all its deliveries
+func (s *Service) Delete(ctx context.Context, id string) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.SubscriptionKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    old.DeleteSensitiveData().Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+// Get returns the subscription with masked secret
+func (s *Service) Get(ctx context.Context, id string) (res api_types.Subscription, err error) {
+	res, err = s.repo.Get(ctx, nil, id)
+	if err != nil {
+		return res, err
+	}
+	return *res.DeleteSensitiveData(), nil
+}
+
+// List returns subscriptions with masked secrets
+func (s *Service) List(ctx context.Context, options ...filter.ListOption) (res []api_types.Subscription, err error) {
+	res, err = s.repo.List(ctx, nil, options...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].DeleteSensitiveData()
+	}
+	return res, nil
+}
+
+// ListDeliveries returns pending and dead-lettered deliveries of the subscription
+func (s *Service) ListDeliveries(
+	ctx context.Context, id string, options ...filter.ListOption) (res []api_types.Delivery, err error) {
+	if _, err = s.repo.Get(ctx, nil, id); err != nil {
+		return nil, err
+	}
+	return s.deliveryRepo.List(ctx, nil, id, options...)
+}
+
+// RequeueDelivery schedules a new series of attempts for the delivery, for example for the dead-lettered one
+func (s *Service) RequeueDelivery(ctx context.Context, id string, deliveryID int) (err error) {
+	return s.deliveryRepo.Requeue(ctx, nil, id, deliveryID, time.Now().UTC())
+}
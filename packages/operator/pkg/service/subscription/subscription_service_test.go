@@ -0,0 +1,209 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package subscription_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/subscription/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const (
+	subID     = "ci-hook"
+	subURL    = "https://ci.example.com/hook"
+	subSecret = "secret"
+)
+
+func TestSuiteRun(t *testing.T) {
+	suite.Run(t, new(TestSuite))
+}
+
+type TestSuite struct {
+	suite.Suite
+	mockRepo         *mocks.Repository
+	mockDeliveryRepo *mocks.DeliveryRepository
+	mockEventLog     *mocks.EventLog
+	mockRecorder     *mocks.AuditRecorder
+	service          *service.Service
+	db               *sql.DB
+	dbMock           sqlmock.Sqlmock
+	nilTx            *sql.Tx
+}
+
+func (s *TestSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockDeliveryRepo = &mocks.DeliveryRepository{}
+	s.mockEventLog = &mocks.EventLog{}
+	s.mockRecorder = &mocks.AuditRecorder{}
+	s.db = db
+	s.dbMock = dbMock
+	s.service = service.NewService(
+		s.mockRepo, s.mockDeliveryRepo, s.mockEventLog, s.mockRecorder, []string{"localhost"},
+	)
+}
+
+func (s *TestSuite) TestCreate() {
+	ctx := context.Background()
+	sub := newStubSubscription()
+	mockTx := s.expectTx(true)
+	s.mockEventLog.On("LastID", ctx).Return(42, nil)
+	s.mockRepo.On("Create", ctx, mockTx, mock.MatchedBy(func(created apis.Subscription) bool {
+		// Secret is stored as is and events raised before the creation are skipped
+		return created.Spec.Secret == subSecret && created.Status.Cursor == 42 && !created.CreatedAt.IsZero()
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.MatchedBy(func(change audit.Change) bool {
+		spec := change.NewSpec.(apis.SubscriptionSpec)
+		return change.Operation == audit.CreateOperation && spec.Secret == apis.SecretMask
+	})).Return(nil)
+
+	err := s.service.Create(ctx, sub)
+	s.Assertions.NoError(err)
+	s.Assertions.Equal(apis.SecretMask, sub.Spec.Secret)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
+}
+
+func (s *TestSuite) TestCreateInvalidURL() {
+	ctx := context.Background()
+	sub := newStubSubscription()
+	sub.Spec.URL = "ftp://ci.example.com"
+
+	err := s.service.Create(ctx, sub)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuite) TestCreateInternalURL() {
+	ctx := context.Background()
+
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest", "http://10.0.0.5/hook", "http://[::1]/hook",
+	} {
+		sub := newStubSubscription()
+		sub.Spec.URL = url
+		err := s.service.Create(ctx, sub)
+		s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err, url)
+	}
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+
+	// Hosts of the allowed list can be internal
+	mockTx := s.expectTx(true)
+	s.mockEventLog.On("LastID", ctx).Return(42, nil)
+	s.mockRepo.On("Create", ctx, mockTx, mock.Anything).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.Anything).Return(nil)
+	sub := newStubSubscription()
+	sub.Spec.URL = "http://localhost:8080/hook"
+	s.Assertions.NoError(s.service.Create(ctx, sub))
+}
+
+func (s *TestSuite) TestUpdateKeepsMaskedSecret() {
+	ctx := context.Background()
+	old := *newStubSubscription()
+	old.Status.Cursor = 10
+	sub := newStubSubscription()
+	sub.Spec.Secret = apis.SecretMask
+	sub.Spec.EventGroups = []event.Group{event.ModelTrainingEventGroup}
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Get", ctx, mockTx, subID).Return(old, nil)
+	s.mockRepo.On("Update", ctx, mockTx, subID, mock.MatchedBy(func(updated apis.Subscription) bool {
+		return updated.Spec.Secret == subSecret && updated.Status.Cursor == 10 &&
+			len(updated.Spec.EventGroups) == 1
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
+
+	err := s.service.Update(ctx, subID, sub)
+	s.Assertions.NoError(err)
+	s.Assertions.Equal(apis.SecretMask, sub.Spec.Secret)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *TestSuite) TestGetMasksSecret() {
+	ctx := context.Background()
+	s.mockRepo.On("Get", ctx, s.nilTx, subID).Return(*newStubSubscription(), nil)
+
+	sub, err := s.service.Get(ctx, subID)
+	s.Assertions.NoError(err)
+	s.Assertions.Equal(apis.SecretMask, sub.Spec.Secret)
+}
+
+func (s *TestSuite) TestDelete() {
+	ctx := context.Background()
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Get", ctx, mockTx, subID).Return(*newStubSubscription(), nil)
+	s.mockRepo.On("Delete", ctx, mockTx, subID).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.SubscriptionKind,
+		EntityID:   subID,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    apis.SubscriptionSpec{URL: subURL, Secret: apis.SecretMask},
+	}).Return(nil)
+
+	err := s.service.Delete(ctx, subID)
+	s.Assertions.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
+}
+
+func (s *TestSuite) TestListDeliveriesNotFound() {
+	ctx := context.Background()
+	s.mockRepo.On("Get", ctx, s.nilTx, subID).Return(apis.Subscription{}, odahu_errs.NotFoundError{Entity: subID})
+
+	_, err := s.service.ListDeliveries(ctx, subID)
+	s.Assertions.True(odahu_errs.IsNotFoundError(err))
+	s.mockDeliveryRepo.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TestSuite) expectTx(commit bool) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	if commit {
+		s.dbMock.ExpectCommit()
+	} else {
+		s.dbMock.ExpectRollback()
+	}
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
+func newStubSubscription() *apis.Subscription {
+	return &apis.Subscription{
+		ID: subID,
+		Spec: apis.SubscriptionSpec{
+			URL:    subURL,
+			Secret: subSecret,
+		},
+	}
+}
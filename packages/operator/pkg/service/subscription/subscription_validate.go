@@ -0,0 +1,110 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package subscription
+
+import (
+	"fmt"
+	api_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/subscription"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
+	"go.uber.org/multierr"
+	"net"
+	"net/url"
+	"strings"
+)
+
+const (
+	EmptySpecFieldErrorMessage = "%s must be non-empty"
+	InvalidURLErrorMessage     = "url must be an absolute http or https URL: %s"
+	InternalURLErrorMessage    = "url must not point to a loopback, link-local or private address " +
+		"unless its host is in the allowed hosts of the subscription config: %s"
+)
+
+// Private networks which are not covered by net.IP methods
+var privateNetworks = []*net.IPNet{
+	mustParseCIDR("10.0.0.0/8"),
+	mustParseCIDR("172.16.0.0/12"),
+	mustParseCIDR("192.168.0.0/16"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("fc00::/7"),
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// isInternalIP returns true if the address can belong to a service inside the cluster or on the node
+func isInternalIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAllowedHost(host string, allowedHosts []string) bool {
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateURL rejects hosts which are internal addresses. Host names are checked again when
+// a webhook is sent, because they can resolve to an internal address
+func validateURL(rawURL string, allowedHosts []string) error {
+	if len(rawURL) == 0 {
+		return fmt.Errorf(EmptySpecFieldErrorMessage, "url")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf(InvalidURLErrorMessage, rawURL)
+	}
+
+	host := u.Hostname()
+	if isAllowedHost(host, allowedHosts) {
+		return nil
+	}
+	if ip := net.ParseIP(host); (ip != nil && isInternalIP(ip)) || strings.EqualFold(host, "localhost") {
+		return fmt.Errorf(InternalURLErrorMessage, rawURL)
+	}
+
+	return nil
+}
+
+func ValidateCreateUpdate(sub api_types.Subscription, allowedHosts []string) (errs []error) {
+
+	var err error
+
+	err = multierr.Append(err, validation.ValidateID(sub.ID))
+	err = multierr.Append(err, validateURL(sub.Spec.URL, allowedHosts))
+
+	if err != nil {
+		return multierr.Errors(err)
+	}
+	return nil
+}
@@ -18,10 +18,14 @@ package training
 
 import (
 	"context"
+	"database/sql"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
 	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	repo "github.com/odahu/odahu-flow/packages/operator/pkg/repository/training"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
 	hashutil "github.com/odahu/odahu-flow/packages/operator/pkg/utils/hash"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -44,9 +48,19 @@ type Service interface {
 	CreateModelTraining(ctx context.Context, mt *training.ModelTraining) error
 }
 
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, tx *sql.Tx, event event.Event) (err error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
 type serviceImpl struct {
 	// Repository that has "database/sql" underlying storage
-	repo repo.Repository
+	repo          repo.Repository
+	eventPub      EventPublisher
+	auditRecorder AuditRecorder
 }
 
 func (s serviceImpl) GetModelTraining(ctx context.Context, id string) (*training.ModelTraining, error) {
@@ -59,17 +73,53 @@ func (s serviceImpl) GetModelTrainingList(
 	return s.repo.GetModelTrainingList(ctx, nil, options...)
 }
 
-func (s serviceImpl) DeleteModelTraining(ctx context.Context, id string) error {
-	return s.repo.DeleteModelTraining(ctx, nil, id)
+func (s serviceImpl) DeleteModelTraining(ctx context.Context, id string) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	oldMt, err := s.repo.GetModelTraining(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.DeleteModelTraining(ctx, tx, id); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.ModelTrainingDeletedEventType,
+		EventGroup: event.ModelTrainingEventGroup,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelTrainingKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    oldMt.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s serviceImpl) SetDeletionMark(ctx context.Context, id string, value bool) error {
 	return s.repo.SetDeletionMark(ctx, nil, id, value)
 }
 
-func (s serviceImpl) UpdateModelTraining(ctx context.Context, mt *training.ModelTraining) error {
+func (s serviceImpl) UpdateModelTraining(ctx context.Context, mt *training.ModelTraining) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
 	mt.UpdatedAt = time.Now()
-	oldMt, err := s.GetModelTraining(ctx, mt.ID)
+	oldMt, err := s.repo.GetModelTraining(ctx, tx, mt.ID)
 	if err != nil {
 		return err
 	}
@@ -78,7 +128,28 @@ func (s serviceImpl) UpdateModelTraining(ctx context.Context, mt *training.Model
 	mt.Status = v1alpha1.ModelTrainingStatus{
 		State: v1alpha1.ModelTrainingUnknown,
 	}
-	return s.repo.UpdateModelTraining(ctx, nil, mt)
+	if err = s.repo.UpdateModelTraining(ctx, tx, mt); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   mt.ID,
+		EventType:  event.ModelTrainingUpdatedEventType,
+		EventGroup: event.ModelTrainingEventGroup,
+		Payload:    *mt,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelTrainingKind,
+		EntityID:   mt.ID,
+		Operation:  audit.UpdateOperation,
+		OldSpec:    oldMt.Spec,
+		NewSpec:    mt.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
 func (s serviceImpl) UpdateModelTrainingStatus(
@@ -121,24 +192,64 @@ func (s serviceImpl) UpdateModelTrainingStatus(
 		return odahu_errors.SpecWasTouched{Entity: id}
 	}
 
+	if hashutil.Equal(oldMt.Status, status) {
+		// Status is not changed. Skip updating in database and publishing event
+		return nil
+	}
+
 	err = s.repo.UpdateModelTrainingStatus(ctx, tx, id, status)
 	if err != nil {
 		return err
 	}
 
-	return err
+	updated := *oldMt
+	updated.Status = status
+
+	e := event.Event{
+		EntityID:   id,
+		EventType:  event.ModelTrainingStatusUpdatedEventType,
+		EventGroup: event.ModelTrainingEventGroup,
+		Payload:    updated,
+	}
+	return s.eventPub.PublishEvent(ctx, tx, e)
 }
 
-func (s serviceImpl) CreateModelTraining(ctx context.Context, mt *training.ModelTraining) error {
+func (s serviceImpl) CreateModelTraining(ctx context.Context, mt *training.ModelTraining) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
 	mt.CreatedAt = time.Now()
 	mt.UpdatedAt = time.Now()
 	mt.DeletionMark = false
 	mt.Status = v1alpha1.ModelTrainingStatus{
 		State: v1alpha1.ModelTrainingUnknown,
 	}
-	return s.repo.SaveModelTraining(ctx, nil, mt)
+	if err = s.repo.SaveModelTraining(ctx, tx, mt); err != nil {
+		return err
+	}
+
+	e := event.Event{
+		EntityID:   mt.ID,
+		EventType:  event.ModelTrainingCreatedEventType,
+		EventGroup: event.ModelTrainingEventGroup,
+		Payload:    *mt,
+	}
+	if err = s.eventPub.PublishEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelTrainingKind,
+		EntityID:   mt.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    mt.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
 }
 
-func NewService(repo repo.Repository) Service {
-	return &serviceImpl{repo: repo}
+func NewService(repo repo.Repository, eventPub EventPublisher, auditRecorder AuditRecorder) Service {
+	return &serviceImpl{repo: repo, eventPub: eventPub, auditRecorder: auditRecorder}
 }
@@ -22,10 +22,13 @@ import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
 	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/repository/training/mocks"
 	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training"
+	service_mocks "github.com/odahu/odahu-flow/packages/operator/pkg/service/training/mocks"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -44,12 +47,14 @@ func TestSuiteRun(t *testing.T) {
 
 type TestSuite struct {
 	suite.Suite
-	mockRepo *mocks.Repository
-	service  service.Service
-	db       *sql.DB
-	dbMock   sqlmock.Sqlmock
-	as       *assert.Assertions
-	nilTx    *sql.Tx
+	mockRepo      *mocks.Repository
+	mockRecorder  *service_mocks.AuditRecorder
+	mockPublisher *service_mocks.EventPublisher
+	service       service.Service
+	db            *sql.DB
+	dbMock        sqlmock.Sqlmock
+	as            *assert.Assertions
+	nilTx         *sql.Tx
 }
 
 func (s *TestSuite) SetupSuite() {
@@ -62,11 +67,15 @@ func (s *TestSuite) SetupTest() {
 		s.T().Fatal("Unable initialize sql mock")
 	}
 	mockRepo := &mocks.Repository{}
+	mockRecorder := &service_mocks.AuditRecorder{}
+	mockPublisher := &service_mocks.EventPublisher{}
 
 	s.mockRepo = mockRepo
+	s.mockRecorder = mockRecorder
+	s.mockPublisher = mockPublisher
 	s.db = db
 	s.dbMock = dbMock
-	s.service = service.NewService(mockRepo)
+	s.service = service.NewService(mockRepo, mockPublisher, mockRecorder)
 }
 
 func (s *TestSuite) TestGetModelTraining() {
@@ -119,10 +128,27 @@ func (s *TestSuite) TestDeleteModelTraining() {
 	as := assert.New(s.T())
 
 	ctx := context.Background()
-	s.mockRepo.On("DeleteModelTraining", ctx, s.nilTx, enID).Return(nil)
+	mockTx := s.expectTx(true)
+	en := newStubMT()
+	s.mockRepo.On("GetModelTraining", ctx, mockTx, enID).Return(en, nil)
+	s.mockRepo.On("DeleteModelTraining", ctx, mockTx, enID).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, event.Event{
+		EntityID:   enID,
+		EventType:  event.ModelTrainingDeletedEventType,
+		EventGroup: event.ModelTrainingEventGroup,
+	}).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelTrainingKind,
+		EntityID:   enID,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    en.Spec,
+	}).Return(nil)
 
 	as.NoError(s.service.DeleteModelTraining(ctx, enID))
+	as.NoError(s.dbMock.ExpectationsWereMet())
 	s.mockRepo.AssertExpectations(s.T())
+	s.mockPublisher.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
 }
 
 func (s *TestSuite) TestSetDeletionMark() {
@@ -140,8 +166,13 @@ func (s *TestSuite) TestUpdateModelTraining() {
 
 	ctx := context.Background()
 	en := newStubMT()
-	s.mockRepo.On("UpdateModelTraining", ctx, s.nilTx, en).Return(nil)
-	s.mockRepo.On("GetModelTraining", ctx, s.nilTx, en.ID).Return(en, nil)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("UpdateModelTraining", ctx, mockTx, en).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.MatchedBy(func(e event.Event) bool {
+		return e.EventType == event.ModelTrainingUpdatedEventType && e.EntityID == en.ID
+	})).Return(nil)
+	s.mockRepo.On("GetModelTraining", ctx, mockTx, en.ID).Return(en, nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	as.NoError(s.service.UpdateModelTraining(ctx, en))
 	s.mockRepo.AssertExpectations(s.T())
@@ -153,8 +184,11 @@ func (s *TestSuite) TestUpdateModelTraining_UpdatedAt() {
 	ctx := context.Background()
 	en := newStubMT()
 
-	s.mockRepo.On("UpdateModelTraining", ctx, s.nilTx, en).Return(nil)
-	s.mockRepo.On("GetModelTraining", ctx, s.nilTx, en.ID).Return(en, nil)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("UpdateModelTraining", ctx, mockTx, en).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.AnythingOfType("event.Event")).Return(nil)
+	s.mockRepo.On("GetModelTraining", ctx, mockTx, en.ID).Return(en, nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
 
 	timeBeforeCall := time.Now()
 	as.NoError(s.service.UpdateModelTraining(ctx, en))
@@ -192,11 +226,39 @@ func (s *TestSuite) TestUpdateModelTrainingStatus() {
 		On("UpdateModelTrainingStatus", ctx, mockTx, enID, newStatus).
 		Return(nil)
 
+	// Event with the new status must be published in the same transaction
+	updatedEn := *repoEn
+	updatedEn.Status = newStatus
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, event.Event{
+		EntityID:   enID,
+		EventType:  event.ModelTrainingStatusUpdatedEventType,
+		EventGroup: event.ModelTrainingEventGroup,
+		Payload:    updatedEn,
+	}).Return(nil)
+
 	// Call service with the same spec snapshot as in repository and new status
 	specSnapshot := repoEn.Spec
 	as.NoError(s.service.UpdateModelTrainingStatus(ctx, enID, newStatus, specSnapshot))
 	as.NoError(s.dbMock.ExpectationsWereMet())
 	s.mockRepo.AssertExpectations(s.T())
+	s.mockPublisher.AssertExpectations(s.T())
+}
+
+func (s *TestSuite) TestUpdateModelTrainingStatusNotChanged() {
+	as := assert.New(s.T())
+
+	ctx := context.Background()
+	mockTx := s.expectTx(true)
+	repoEn := newStubMT()
+	s.mockRepo.On("GetModelTraining", ctx, mockTx, enID).Return(repoEn, nil)
+
+	// Call service with the same status as in repository
+	as.NoError(s.service.UpdateModelTrainingStatus(ctx, enID, repoEn.Status, repoEn.Spec))
+	as.NoError(s.dbMock.ExpectationsWereMet())
+	s.mockRepo.AssertExpectations(s.T())
+	// Neither status update nor event must happen
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateModelTrainingStatus")
+	s.mockPublisher.AssertNotCalled(s.T(), "PublishEvent")
 }
 
 func (s *TestSuite) TestUpdateModelTrainingStatusSpecTouched() {
@@ -241,7 +303,18 @@ func (s *TestSuite) TestCreateModelTraining() {
 
 	en := newStubMT()
 	ctx := context.Background()
-	s.mockRepo.On("SaveModelTraining", ctx, s.nilTx, en).Return(nil)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("SaveModelTraining", ctx, mockTx, en).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.MatchedBy(func(e event.Event) bool {
+		return e.EventType == event.ModelTrainingCreatedEventType && e.EventGroup == event.ModelTrainingEventGroup &&
+			e.EntityID == enID
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelTrainingKind,
+		EntityID:   enID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    en.Spec,
+	}).Return(nil)
 	as.NoError(s.service.CreateModelTraining(ctx, en))
 	s.mockRepo.AssertExpectations(s.T())
 }
@@ -251,7 +324,15 @@ func (s *TestSuite) TestCreateModelTraining_CreatedAt() {
 
 	en := newStubMT()
 	ctx := context.Background()
-	s.mockRepo.On("SaveModelTraining", ctx, s.nilTx, en).Return(nil)
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("SaveModelTraining", ctx, mockTx, en).Return(nil)
+	s.mockPublisher.On("PublishEvent", ctx, mockTx, mock.AnythingOfType("event.Event")).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, audit.Change{
+		EntityKind: audit.ModelTrainingKind,
+		EntityID:   enID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    en.Spec,
+	}).Return(nil)
 	timeBeforeCall := time.Now()
 	as.NoError(s.service.CreateModelTraining(ctx, en))
 	// CreatedAt, UpdatedAt fields must be updated on now during the invocation
@@ -266,14 +347,36 @@ func (s *TestSuite) TestCreateModelTraining_Error() {
 	en := newStubMT()
 	ctx := context.Background()
 	anyError := errors.New("any error")
-	s.mockRepo.On("SaveModelTraining", ctx, s.nilTx, en).Return(anyError)
+	mockTx := s.expectTx(false)
+	s.mockRepo.On("SaveModelTraining", ctx, mockTx, en).Return(anyError)
 
 	as.Error(s.service.CreateModelTraining(ctx, en))
+	as.NoError(s.dbMock.ExpectationsWereMet())
 	s.mockRepo.AssertExpectations(s.T())
+	// Nothing must be recorded if entity was not saved
+	s.mockRecorder.AssertNotCalled(s.T(), "Record")
+	s.mockPublisher.AssertNotCalled(s.T(), "PublishEvent")
 }
 
 // Helpers
 
+// expectTx opens a mock transaction and assumes that service commits or rolls it back
+func (s *TestSuite) expectTx(commit bool) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	if commit {
+		s.dbMock.ExpectCommit()
+	} else {
+		s.dbMock.ExpectRollback()
+	}
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
 func newStubFilter() filter.ListOption {
 	return func(options *filter.ListOptions) {
 	}
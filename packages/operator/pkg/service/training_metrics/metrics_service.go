@@ -0,0 +1,136 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_metrics //nolint
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"go.uber.org/multierr"
+	"time"
+)
+
+const (
+	EmptyMetricsErrorMessage     = "at least one metric or param must be passed"
+	TooManyMetricsErrorMessage   = "no more than %d metrics and params can be passed at once"
+	EmptyKeyErrorMessage         = "metric and param keys must not be empty"
+	TooLongKeyErrorMessage       = "key %q must be no more than %d characters long"
+	NegativeStepErrorMessage     = "step of metric %q must not be negative"
+	DuplicatedMetricErrorMessage = "metric %q is passed more than once for step %d"
+)
+
+type Repository interface {
+	SaveMetrics(ctx context.Context, tx *sql.Tx, trainingID string, metrics training.ModelTrainingMetrics) error
+	GetMetrics(
+		ctx context.Context, tx *sql.Tx, trainingID string, keys []string,
+	) (training.ModelTrainingMetrics, error)
+}
+
+type TrainingGetter interface {
+	GetModelTraining(ctx context.Context, id string) (*training.ModelTraining, error)
+}
+
+type Service struct {
+	repo         Repository
+	trainService TrainingGetter
+}
+
+func NewService(repo Repository, trainService TrainingGetter) *Service {
+	return &Service{repo: repo, trainService: trainService}
+}
+
+// Save stores metrics and params of the training. Missed timestamps are set to the current time
+func (s *Service) Save(ctx context.Context, trainingID string, metrics *training.ModelTrainingMetrics) error {
+	now := time.Now().UTC()
+	for i := range metrics.Metrics {
+		if metrics.Metrics[i].Timestamp == nil {
+			metrics.Metrics[i].Timestamp = &now
+		}
+	}
+
+	if errs := Validate(*metrics); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           trainingID,
+			ValidationErrors: errs,
+		}
+	}
+
+	return s.repo.SaveMetrics(ctx, nil, trainingID, *metrics)
+}
+
+// Get returns metrics and params of the training. Only metrics with the keys are returned if keys are passed
+func (s *Service) Get(ctx context.Context, trainingID string, keys []string) (training.ModelTrainingMetrics, error) {
+	// Metrics of a missed training are empty, so the training is checked to respond with not found error
+	if _, err := s.trainService.GetModelTraining(ctx, trainingID); err != nil {
+		return training.ModelTrainingMetrics{}, err
+	}
+
+	return s.repo.GetMetrics(ctx, nil, trainingID, keys)
+}
+
+// Validate checks metrics before they are saved
+func Validate(metrics training.ModelTrainingMetrics) (errs []error) {
+	var err error
+
+	total := len(metrics.Metrics) + len(metrics.Params)
+	switch {
+	case total == 0:
+		err = multierr.Append(err, errors.New(EmptyMetricsErrorMessage))
+	case total > training.MaxMetricsPerRequest:
+		err = multierr.Append(err, fmt.Errorf(TooManyMetricsErrorMessage, training.MaxMetricsPerRequest))
+	}
+
+	type metricStep struct {
+		key  string
+		step int64
+	}
+	steps := make(map[metricStep]bool, len(metrics.Metrics))
+	for _, m := range metrics.Metrics {
+		err = multierr.Append(err, validateKey(m.Key))
+		if m.Step < 0 {
+			err = multierr.Append(err, fmt.Errorf(NegativeStepErrorMessage, m.Key))
+		}
+
+		// Postgres cannot update the same row twice in one statement
+		if steps[metricStep{m.Key, m.Step}] {
+			err = multierr.Append(err, fmt.Errorf(DuplicatedMetricErrorMessage, m.Key, m.Step))
+		}
+		steps[metricStep{m.Key, m.Step}] = true
+	}
+	for key := range metrics.Params {
+		err = multierr.Append(err, validateKey(key))
+	}
+
+	if err != nil {
+		return multierr.Errors(err)
+	}
+	return nil
+}
+
+func validateKey(key string) error {
+	switch {
+	case len(key) == 0:
+		return errors.New(EmptyKeyErrorMessage)
+	case len(key) > training.MaxMetricKeyLength:
+		return fmt.Errorf(TooLongKeyErrorMessage, key, training.MaxMetricKeyLength)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_metrics_test
+
+import (
+	"context"
+	"database/sql"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_metrics"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/training_metrics/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+const (
+	mtID = "wine-training"
+)
+
+func TestServiceSuiteRun(t *testing.T) {
+	suite.Run(t, new(ServiceSuite))
+}
+
+type ServiceSuite struct {
+	suite.Suite
+	mockRepo      *mocks.Repository
+	mockTrainings *mocks.TrainingGetter
+	service       *service.Service
+	nilTx         *sql.Tx
+	ctx           context.Context
+}
+
+func (s *ServiceSuite) SetupTest() {
+	s.mockRepo = &mocks.Repository{}
+	s.mockTrainings = &mocks.TrainingGetter{}
+	s.service = service.NewService(s.mockRepo, s.mockTrainings)
+	s.ctx = context.Background()
+}
+
+func (s *ServiceSuite) TestSaveSetsTimestamps() {
+	metrics := &apis.ModelTrainingMetrics{
+		Metrics: []apis.MetricValue{{Key: "loss", Value: 0.3}, {Key: "loss", Step: 1, Value: 0.2}},
+		Params:  map[string]string{"batch_size": "32"},
+	}
+	s.mockRepo.On("SaveMetrics", s.ctx, s.nilTx, mtID, mock.MatchedBy(func(m apis.ModelTrainingMetrics) bool {
+		return m.Metrics[0].Timestamp != nil && m.Metrics[1].Timestamp != nil
+	})).Return(nil)
+
+	s.Assertions.NoError(s.service.Save(s.ctx, mtID, metrics))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestSaveInvalid() {
+	metrics := &apis.ModelTrainingMetrics{
+		Metrics: []apis.MetricValue{
+			{Key: "", Value: 0.3},
+			{Key: "loss", Step: -1},
+			{Key: "acc", Step: 2},
+			{Key: "acc", Step: 2},
+		},
+		Params: map[string]string{strings.Repeat("a", apis.MaxMetricKeyLength+1): "32"},
+	}
+
+	err := s.service.Save(s.ctx, mtID, metrics)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.Assertions.Len(err.(odahu_errs.InvalidEntityError).ValidationErrors, 4)
+	s.mockRepo.AssertNotCalled(s.T(), "SaveMetrics", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestSaveEmpty() {
+	err := s.service.Save(s.ctx, mtID, &apis.ModelTrainingMetrics{})
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+}
+
+func (s *ServiceSuite) TestGetMissedTraining() {
+	s.mockTrainings.On("GetModelTraining", s.ctx, mtID).Return(nil, odahu_errs.NotFoundError{Entity: mtID})
+
+	_, err := s.service.Get(s.ctx, mtID, nil)
+	s.Assertions.True(odahu_errs.IsNotFoundError(err))
+	s.mockRepo.AssertNotCalled(s.T(), "GetMetrics", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestGet() {
+	expected := apis.ModelTrainingMetrics{Metrics: []apis.MetricValue{{Key: "loss", Value: 0.3}}}
+	s.mockTrainings.On("GetModelTraining", s.ctx, mtID).Return(&apis.ModelTraining{ID: mtID}, nil)
+	s.mockRepo.On("GetMetrics", s.ctx, s.nilTx, mtID, []string{"loss"}).Return(expected, nil)
+
+	metrics, err := s.service.Get(s.ctx, mtID, []string{"loss"})
+	s.Assertions.NoError(err)
+	s.Assertions.Equal(expected, metrics)
+}
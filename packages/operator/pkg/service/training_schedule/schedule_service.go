@@ -0,0 +1,164 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_schedule //nolint
+
+import (
+	"context"
+	"database/sql"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"time"
+)
+
+var log = logf.Log.WithName("model-training-schedule--service")
+
+type Repository interface {
+	Create(ctx context.Context, tx *sql.Tx, mts training.ModelTrainingSchedule) error
+	Get(ctx context.Context, tx *sql.Tx, id string) (training.ModelTrainingSchedule, error)
+	List(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) ([]training.ModelTrainingSchedule, error)
+	Update(ctx context.Context, tx *sql.Tx, id string, mts training.ModelTrainingSchedule) error
+	UpdateStatus(ctx context.Context, tx *sql.Tx, id string, status training.ModelTrainingScheduleStatus) error
+	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
+type Service struct {
+	repo          Repository
+	auditRecorder AuditRecorder
+}
+
+func NewService(repo Repository, auditRecorder AuditRecorder) *Service {
+	return &Service{repo: repo, auditRecorder: auditRecorder}
+}
+
+// Create creates a schedule. The first training is started at the first scheduled time after the creation
+func (s *Service) Create(ctx context.Context, mts *training.ModelTrainingSchedule) (err error) {
+
+	// Set fields that managed by platform. Cannot be overridden by user
+	mts.CreatedAt = time.Now().UTC()
+	mts.UpdatedAt = time.Now().UTC()
+	mts.Status = training.ModelTrainingScheduleStatus{}
+
+	SetDefaults(mts)
+	if errs := ValidateCreateUpdate(*mts); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           mts.ID,
+			ValidationErrors: errs,
+		}
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.Create(ctx, tx, *mts); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelTrainingScheduleKind,
+		EntityID:   mts.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    mts.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+// Update updates spec of the schedule. Status, including already started trainings, is kept
+func (s *Service) Update(ctx context.Context, mts *training.ModelTrainingSchedule) (err error) {
+
+	mts.UpdatedAt = time.Now().UTC()
+
+	SetDefaults(mts)
+	if errs := ValidateCreateUpdate(*mts); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           mts.ID,
+			ValidationErrors: errs,
+		}
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, mts.ID)
+	if err != nil {
+		return err
+	}
+	mts.CreatedAt = old.CreatedAt
+	mts.Status = old.Status
+
+	if err = s.repo.Update(ctx, tx, mts.ID, *mts); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelTrainingScheduleKind,
+		EntityID:   mts.ID,
+		Operation:  audit.UpdateOperation,
+		OldSpec:    old.Spec,
+		NewSpec:    mts.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+// Delete deletes the schedule. Trainings which were started by the schedule are kept
+func (s *Service) Delete(ctx context.Context, id string) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelTrainingScheduleKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    old.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+func (s *Service) Get(ctx context.Context, id string) (training.ModelTrainingSchedule, error) {
+	return s.repo.Get(ctx, nil, id)
+}
+
+func (s *Service) List(ctx context.Context, options ...filter.ListOption) ([]training.ModelTrainingSchedule, error) {
+	return s.repo.List(ctx, nil, options...)
+}
@@ -0,0 +1,158 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_schedule_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_schedule"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/training_schedule/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	mtsID       = "nightly"
+	mtsSchedule = "0 3 * * *"
+)
+
+func TestServiceSuiteRun(t *testing.T) {
+	suite.Run(t, new(ServiceSuite))
+}
+
+type ServiceSuite struct {
+	suite.Suite
+	mockRepo     *mocks.Repository
+	mockRecorder *mocks.AuditRecorder
+	service      *service.Service
+	db           *sql.DB
+	dbMock       sqlmock.Sqlmock
+}
+
+func (s *ServiceSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockRecorder = &mocks.AuditRecorder{}
+	s.db = db
+	s.dbMock = dbMock
+	s.service = service.NewService(s.mockRepo, s.mockRecorder)
+}
+
+func (s *ServiceSuite) TestCreateSetsDefaults() {
+	ctx := context.Background()
+	mts := newStubSchedule()
+	mts.Status.Trainings = []string{"forged"}
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Create", ctx, mockTx, mock.MatchedBy(func(created apis.ModelTrainingSchedule) bool {
+		return created.Spec.TimeZone == "UTC" && created.Spec.ConcurrencyPolicy == apis.AllowConcurrent &&
+			*created.Spec.SuccessfulHistoryLimit == service.DefaultSuccessfulHistoryLimit &&
+			*created.Spec.FailedHistoryLimit == service.DefaultFailedHistoryLimit &&
+			len(created.Status.Trainings) == 0 && !created.CreatedAt.IsZero()
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.MatchedBy(func(change audit.Change) bool {
+		return change.EntityKind == audit.ModelTrainingScheduleKind && change.Operation == audit.CreateOperation
+	})).Return(nil)
+
+	err := s.service.Create(ctx, mts)
+	s.Assertions.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestCreateInvalid() {
+	ctx := context.Background()
+	negativeLimit := -1
+	mts := newStubSchedule()
+	mts.ID = strings.Repeat("a", apis.MaxScheduleIDLength+1)
+	mts.Spec.Schedule = "0 3 * *"
+	mts.Spec.TimeZone = "Mars/Olympus"
+	mts.Spec.ConcurrencyPolicy = "Sometimes"
+	mts.Spec.FailedHistoryLimit = &negativeLimit
+
+	err := s.service.Create(ctx, mts)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.Assertions.Len(err.(odahu_errs.InvalidEntityError).ValidationErrors, 5)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestUpdateKeepsStatus() {
+	ctx := context.Background()
+	old := *newStubSchedule()
+	old.CreatedAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	old.Status.Trainings = []string{"nightly-202101020300"}
+	mts := newStubSchedule()
+	mts.Spec.Suspend = true
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Get", ctx, mockTx, mtsID).Return(old, nil)
+	s.mockRepo.On("Update", ctx, mockTx, mtsID, mock.MatchedBy(func(updated apis.ModelTrainingSchedule) bool {
+		return updated.Spec.Suspend && updated.CreatedAt.Equal(old.CreatedAt) && len(updated.Status.Trainings) == 1
+	})).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.AnythingOfType("audit.Change")).Return(nil)
+
+	err := s.service.Update(ctx, mts)
+	s.Assertions.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestDeleteNotFound() {
+	ctx := context.Background()
+	mockTx := s.expectTx(false)
+	s.mockRepo.On("Get", ctx, mockTx, mtsID).Return(
+		apis.ModelTrainingSchedule{}, odahu_errs.NotFoundError{Entity: mtsID},
+	)
+
+	err := s.service.Delete(ctx, mtsID)
+	s.Assertions.True(odahu_errs.IsNotFoundError(err))
+	s.mockRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) expectTx(commit bool) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	if commit {
+		s.dbMock.ExpectCommit()
+	} else {
+		s.dbMock.ExpectRollback()
+	}
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
+func newStubSchedule() *apis.ModelTrainingSchedule {
+	return &apis.ModelTrainingSchedule{
+		ID: mtsID,
+		Spec: apis.ModelTrainingScheduleSpec{
+			Schedule: mtsSchedule,
+		},
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_schedule //nolint
+
+import (
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/multierr"
+	"time"
+)
+
+const (
+	TooLongIDErrorMessage            = "schedule ID must be no more than %d characters long"
+	InvalidScheduleErrorMessage      = "invalid cron schedule %q: %s"
+	InvalidTimeZoneErrorMessage      = "invalid time zone %q: %s"
+	UnknownConcurrencyPolicyMessage  = "unknown concurrency policy %q. Possible values: %v"
+	NegativeHistoryLimitErrorMessage = "%s must not be negative"
+)
+
+var (
+	DefaultSuccessfulHistoryLimit = 3
+	DefaultFailedHistoryLimit     = 1
+
+	concurrencyPolicies = []training.ConcurrencyPolicy{
+		training.AllowConcurrent, training.ForbidConcurrent, training.ReplaceConcurrent,
+	}
+)
+
+// ParseSchedule returns the parsed cron schedule and the location in which it must be evaluated
+func ParseSchedule(spec training.ModelTrainingScheduleSpec) (cron.Schedule, *time.Location, error) {
+	schedule, err := parseCron(spec.Schedule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	location, err := loadLocation(spec.TimeZone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schedule, location, nil
+}
+
+func parseCron(expression string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, fmt.Errorf(InvalidScheduleErrorMessage, expression, err.Error())
+	}
+	return schedule, nil
+}
+
+func loadLocation(timeZone string) (*time.Location, error) {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf(InvalidTimeZoneErrorMessage, timeZone, err.Error())
+	}
+	return location, nil
+}
+
+// SetDefaults fills optional fields of the schedule spec
+func SetDefaults(mts *training.ModelTrainingSchedule) {
+	if len(mts.Spec.TimeZone) == 0 {
+		mts.Spec.TimeZone = time.UTC.String()
+	}
+	if len(mts.Spec.ConcurrencyPolicy) == 0 {
+		mts.Spec.ConcurrencyPolicy = training.AllowConcurrent
+	}
+	if mts.Spec.SuccessfulHistoryLimit == nil {
+		limit := DefaultSuccessfulHistoryLimit
+		mts.Spec.SuccessfulHistoryLimit = &limit
+	}
+	if mts.Spec.FailedHistoryLimit == nil {
+		limit := DefaultFailedHistoryLimit
+		mts.Spec.FailedHistoryLimit = &limit
+	}
+}
+
+func validateConcurrencyPolicy(policy training.ConcurrencyPolicy) error {
+	for _, p := range concurrencyPolicies {
+		if p == policy {
+			return nil
+		}
+	}
+	return fmt.Errorf(UnknownConcurrencyPolicyMessage, policy, concurrencyPolicies)
+}
+
+func validateHistoryLimit(name string, limit *int) error {
+	if limit != nil && *limit < 0 {
+		return fmt.Errorf(NegativeHistoryLimitErrorMessage, name)
+	}
+	return nil
+}
+
+// ValidateCreateUpdate validates the schedule. Template is validated by the API server together with other trainings
+func ValidateCreateUpdate(mts training.ModelTrainingSchedule) (errs []error) {
+
+	var err error
+
+	err = multierr.Append(err, validation.ValidateID(mts.ID))
+	if len(mts.ID) > training.MaxScheduleIDLength {
+		err = multierr.Append(err, fmt.Errorf(TooLongIDErrorMessage, training.MaxScheduleIDLength))
+	}
+	if _, cronErr := parseCron(mts.Spec.Schedule); cronErr != nil {
+		err = multierr.Append(err, cronErr)
+	}
+	if _, locationErr := loadLocation(mts.Spec.TimeZone); locationErr != nil {
+		err = multierr.Append(err, locationErr)
+	}
+	err = multierr.Append(err, validateConcurrencyPolicy(mts.Spec.ConcurrencyPolicy))
+	err = multierr.Append(err, validateHistoryLimit("successfulHistoryLimit", mts.Spec.SuccessfulHistoryLimit))
+	err = multierr.Append(err, validateHistoryLimit("failedHistoryLimit", mts.Spec.FailedHistoryLimit))
+
+	if err != nil {
+		return multierr.Errors(err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_schedule //nolint
+
+import (
+	"context"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"github.com/robfig/cron/v3"
+	"reflect"
+	"time"
+)
+
+// If more runs were missed since the last processed one, for example because the controller was down,
+// they are skipped without starting a training
+const maxMissedRuns = 1000
+
+type TrainingService interface {
+	GetModelTraining(ctx context.Context, id string) (*training.ModelTraining, error)
+	CreateModelTraining(ctx context.Context, mt *training.ModelTraining) error
+	DeleteModelTraining(ctx context.Context, id string) error
+}
+
+// Scheduler starts trainings of schedules when they are due and deletes finished trainings
+// which exceed history limits
+type Scheduler struct {
+	repo         Repository
+	trainService TrainingService
+	now          func() time.Time
+}
+
+func NewScheduler(repo Repository, trainService TrainingService) *Scheduler {
+	return &Scheduler{repo: repo, trainService: trainService, now: time.Now}
+}
+
+// NewSchedulerWithClock is used in tests to control the current time
+func NewSchedulerWithClock(repo Repository, trainService TrainingService, now func() time.Time) *Scheduler {
+	return &Scheduler{repo: repo, trainService: trainService, now: now}
+}
+
+// Schedule processes all schedules once. Only the latest due run of a schedule is started,
+// older missed runs are skipped
+func (s *Scheduler) Schedule(ctx context.Context) error {
+	for page := 0; ; page++ {
+		schedules, err := s.repo.List(ctx, nil, filter.Page(page))
+		if err != nil {
+			return err
+		}
+		if len(schedules) == 0 {
+			return nil
+		}
+
+		for _, mts := range schedules {
+			// A failure of one schedule must not block others
+			if err := s.process(ctx, mts); err != nil {
+				log.Error(err, "Unable to process the training schedule", "schedule", mts.ID)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) process(ctx context.Context, mts training.ModelTrainingSchedule) error {
+	status := mts.Status
+	status.LastError = ""
+
+	schedule, location, err := ParseSchedule(mts.Spec)
+	if err != nil {
+		status.LastError = err.Error()
+		return s.saveStatus(ctx, mts, status)
+	}
+
+	now := s.now().UTC()
+
+	if err := s.refreshTrainings(ctx, mts, &status); err != nil {
+		return err
+	}
+
+	since := mts.CreatedAt
+	if status.LastScheduleTime != nil {
+		since = *status.LastScheduleTime
+	}
+	run, missed := latestRun(schedule, location, since, now)
+	switch {
+	case missed:
+		log.Info("Too many runs were missed, they are skipped", "schedule", mts.ID)
+		status.LastScheduleTime = &now
+	case run == nil:
+	case mts.Spec.Suspend:
+		log.Info("Run of the suspended schedule is skipped", "schedule", mts.ID, "time", *run)
+		status.LastScheduleTime = run
+	default:
+		if err := s.startTraining(ctx, mts, *run, &status); err != nil {
+			// The run is retried during the next processing, because the last schedule time is not moved
+			status.LastError = err.Error()
+			log.Error(err, "Unable to start the scheduled training", "schedule", mts.ID, "time", *run)
+		} else {
+			status.LastScheduleTime = run
+		}
+	}
+
+	next := schedule.Next(now.In(location)).UTC()
+	status.NextScheduleTime = &next
+
+	return s.saveStatus(ctx, mts, status)
+}
+
+// refreshTrainings forgets deleted trainings, finds active ones and deletes the oldest finished trainings
+// which exceed history limits
+func (s *Scheduler) refreshTrainings(
+	ctx context.Context, mts training.ModelTrainingSchedule, status *training.ModelTrainingScheduleStatus) error {
+
+	var existing, active, succeeded, failed []string
+	for _, id := range status.Trainings {
+		mt, err := s.trainService.GetModelTraining(ctx, id)
+		if odahuErrs.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		existing = append(existing, id)
+		switch mt.Status.State {
+		case v1alpha1.ModelTrainingSucceeded:
+			succeeded = append(succeeded, id)
+		case v1alpha1.ModelTrainingFailed:
+			failed = append(failed, id)
+		default:
+			active = append(active, id)
+		}
+	}
+
+	var deleted []string
+	for _, id := range exceedingLimit(succeeded, mts.Spec.SuccessfulHistoryLimit, DefaultSuccessfulHistoryLimit) {
+		if err := s.deleteTraining(ctx, id); err != nil {
+			return err
+		}
+		deleted = append(deleted, id)
+	}
+	for _, id := range exceedingLimit(failed, mts.Spec.FailedHistoryLimit, DefaultFailedHistoryLimit) {
+		if err := s.deleteTraining(ctx, id); err != nil {
+			return err
+		}
+		deleted = append(deleted, id)
+	}
+
+	status.Trainings = without(existing, deleted)
+	status.Active = active
+
+	return nil
+}
+
+// startTraining creates the training of the run according to the concurrency policy
+func (s *Scheduler) startTraining(
+	ctx context.Context, mts training.ModelTrainingSchedule,
+	run time.Time, status *training.ModelTrainingScheduleStatus) error {
+
+	switch mts.Spec.ConcurrencyPolicy {
+	case training.ForbidConcurrent:
+		if len(status.Active) > 0 {
+			log.Info("Run is skipped, because previous trainings are still active",
+				"schedule", mts.ID, "time", run, "active", status.Active)
+			return nil
+		}
+	case training.ReplaceConcurrent:
+		for _, id := range status.Active {
+			log.Info("Active training is replaced by the new run", "schedule", mts.ID, "training", id)
+			if err := s.deleteTraining(ctx, id); err != nil {
+				return err
+			}
+		}
+		status.Trainings = without(status.Trainings, status.Active)
+		status.Active = nil
+	}
+
+	mt := &training.ModelTraining{
+		ID:   mts.TrainingID(run),
+		Spec: *mts.Spec.Template.DeepCopy(),
+	}
+	// Training ID is derived from the run time, so a training which was created before
+	// a failed status update is not created twice
+	err := s.trainService.CreateModelTraining(ctx, mt)
+	if odahuErrs.IsAlreadyExistError(err) {
+		adopted, adoptErr := s.createdBySchedule(ctx, mts, mt.ID, run)
+		if adoptErr != nil {
+			return adoptErr
+		}
+		if !adopted {
+			// A foreign training must not be counted by the concurrency policy or deleted by history limits
+			log.Info("Run is skipped, because a training with the same ID was not created by the schedule",
+				"schedule", mts.ID, "time", run, "training", mt.ID)
+			return nil
+		}
+	} else if err != nil {
+		return err
+	}
+	log.Info("Scheduled training is started", "schedule", mts.ID, "training", mt.ID)
+
+	if !contains(status.Trainings, mt.ID) {
+		status.Trainings = append(status.Trainings, mt.ID)
+		status.Active = append(status.Active, mt.ID)
+	}
+
+	return nil
+}
+
+// createdBySchedule checks whether the existing training with the run ID was created by the schedule
+func (s *Scheduler) createdBySchedule(
+	ctx context.Context, mts training.ModelTrainingSchedule, id string, run time.Time) (bool, error) {
+
+	mt, err := s.trainService.GetModelTraining(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return !mt.CreatedAt.Before(run) && reflect.DeepEqual(mt.Spec, mts.Spec.Template), nil
+}
+
+func (s *Scheduler) deleteTraining(ctx context.Context, id string) error {
+	if err := s.trainService.DeleteModelTraining(ctx, id); err != nil && !odahuErrs.IsNotFoundError(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *Scheduler) saveStatus(
+	ctx context.Context, mts training.ModelTrainingSchedule, status training.ModelTrainingScheduleStatus) error {
+	if reflect.DeepEqual(mts.Status, status) {
+		return nil
+	}
+	return s.repo.UpdateStatus(ctx, nil, mts.ID, status)
+}
+
+// latestRun returns the latest scheduled time after since which is not after now.
+// missed is true if there are too many scheduled times to iterate over
+func latestRun(schedule cron.Schedule, location *time.Location, since, now time.Time) (run *time.Time, missed bool) {
+	for i := 0; i < maxMissedRuns; i++ {
+		next := schedule.Next(since.In(location))
+		if next.IsZero() || next.After(now) {
+			return run, false
+		}
+		next = next.UTC()
+		run = &next
+		since = next
+	}
+	return nil, true
+}
+
+// exceedingLimit returns the oldest IDs which do not fit into the limit
+func exceedingLimit(ids []string, limit *int, defaultLimit int) []string {
+	keep := defaultLimit
+	if limit != nil {
+		keep = *limit
+	}
+	if len(ids) <= keep {
+		return nil
+	}
+	return ids[:len(ids)-keep]
+}
+
+func without(ids []string, excluded []string) (res []string) {
+	for _, id := range ids {
+		if !contains(excluded, id) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
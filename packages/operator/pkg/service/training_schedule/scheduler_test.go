@@ -0,0 +1,247 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_schedule_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_schedule"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/training_schedule/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+var (
+	scheduleCreatedAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	scheduleTemplate  = v1alpha1.ModelTrainingSpec{
+		Model:     v1alpha1.ModelIdentity{Name: "wine", Version: "1.0"},
+		Toolchain: "mlflow",
+	}
+)
+
+func TestSchedulerSuiteRun(t *testing.T) {
+	suite.Run(t, new(SchedulerSuite))
+}
+
+type SchedulerSuite struct {
+	suite.Suite
+	mockRepo      *mocks.Repository
+	mockTrainings *mocks.TrainingService
+	nilTx         *sql.Tx
+	ctx           context.Context
+}
+
+func (s *SchedulerSuite) SetupTest() {
+	s.mockRepo = &mocks.Repository{}
+	s.mockTrainings = &mocks.TrainingService{}
+	s.ctx = context.Background()
+}
+
+func (s *SchedulerSuite) TestStartsDueRun() {
+	mts := newScheduledStub()
+	s.mockTrainings.On("CreateModelTraining", s.ctx, &apis.ModelTraining{
+		ID: "nightly-202101020300", Spec: scheduleTemplate,
+	}).Return(nil)
+
+	status := s.schedule(mts, time.Date(2021, 1, 2, 3, 0, 30, 0, time.UTC))
+
+	s.Assertions.Equal(time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC), *status.LastScheduleTime)
+	s.Assertions.Equal(time.Date(2021, 1, 3, 3, 0, 0, 0, time.UTC), *status.NextScheduleTime)
+	s.Assertions.Equal([]string{"nightly-202101020300"}, status.Trainings)
+	s.Assertions.Equal([]string{"nightly-202101020300"}, status.Active)
+	s.mockTrainings.AssertExpectations(s.T())
+}
+
+func (s *SchedulerSuite) TestStartsOnlyLatestMissedRun() {
+	mts := newScheduledStub()
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(nil).Once()
+
+	status := s.schedule(mts, time.Date(2021, 1, 5, 3, 10, 0, 0, time.UTC))
+
+	s.Assertions.Equal([]string{"nightly-202101050300"}, status.Trainings)
+}
+
+func (s *SchedulerSuite) TestEvaluatesScheduleInTimeZone() {
+	mts := newScheduledStub()
+	mts.Spec.TimeZone = "Europe/Berlin"
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(nil)
+
+	// 03:00 in Berlin is 02:00 in UTC during winter
+	status := s.schedule(mts, time.Date(2021, 1, 2, 2, 30, 0, 0, time.UTC))
+
+	s.Assertions.Equal([]string{"nightly-202101020200"}, status.Trainings)
+}
+
+func (s *SchedulerSuite) TestNotDue() {
+	mts := newScheduledStub()
+	mts.Status.LastScheduleTime = timePtr(time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC))
+
+	status := s.schedule(mts, time.Date(2021, 1, 2, 20, 0, 0, 0, time.UTC))
+
+	s.Assertions.Equal(time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC), *status.LastScheduleTime)
+	s.mockTrainings.AssertNotCalled(s.T(), "CreateModelTraining", mock.Anything, mock.Anything)
+}
+
+func (s *SchedulerSuite) TestSuspendedSkipsRun() {
+	mts := newScheduledStub()
+	mts.Spec.Suspend = true
+
+	status := s.schedule(mts, time.Date(2021, 1, 2, 3, 0, 30, 0, time.UTC))
+
+	// The skipped run is not started after resuming
+	s.Assertions.Equal(time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC), *status.LastScheduleTime)
+	s.mockTrainings.AssertNotCalled(s.T(), "CreateModelTraining", mock.Anything, mock.Anything)
+}
+
+func (s *SchedulerSuite) TestForbidSkipsRunWhileActive() {
+	mts := newScheduledStub()
+	mts.Spec.ConcurrencyPolicy = apis.ForbidConcurrent
+	mts.Status.Trainings = []string{"nightly-202101010300"}
+	s.expectTraining("nightly-202101010300", v1alpha1.ModelTrainingRunning)
+
+	status := s.schedule(mts, time.Date(2021, 1, 2, 3, 0, 30, 0, time.UTC))
+
+	s.Assertions.Equal(time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC), *status.LastScheduleTime)
+	s.Assertions.Equal([]string{"nightly-202101010300"}, status.Active)
+	s.mockTrainings.AssertNotCalled(s.T(), "CreateModelTraining", mock.Anything, mock.Anything)
+}
+
+func (s *SchedulerSuite) TestReplaceDeletesActiveTrainings() {
+	mts := newScheduledStub()
+	mts.Spec.ConcurrencyPolicy = apis.ReplaceConcurrent
+	mts.Status.Trainings = []string{"nightly-202101010300"}
+	s.expectTraining("nightly-202101010300", v1alpha1.ModelTrainingRunning)
+	s.mockTrainings.On("DeleteModelTraining", s.ctx, "nightly-202101010300").Return(nil)
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(nil)
+
+	status := s.schedule(mts, time.Date(2021, 1, 2, 3, 0, 30, 0, time.UTC))
+
+	s.Assertions.Equal([]string{"nightly-202101020300"}, status.Trainings)
+	s.Assertions.Equal([]string{"nightly-202101020300"}, status.Active)
+	s.mockTrainings.AssertExpectations(s.T())
+}
+
+func (s *SchedulerSuite) TestHistoryLimits() {
+	mts := newScheduledStub()
+	mts.Status.LastScheduleTime = timePtr(time.Date(2021, 1, 5, 3, 0, 0, 0, time.UTC))
+	mts.Status.Trainings = []string{"succeeded-1", "failed-1", "deleted", "succeeded-2", "failed-2", "running"}
+	succeededLimit, failedLimit := 1, 1
+	mts.Spec.SuccessfulHistoryLimit = &succeededLimit
+	mts.Spec.FailedHistoryLimit = &failedLimit
+	s.expectTraining("succeeded-1", v1alpha1.ModelTrainingSucceeded)
+	s.expectTraining("succeeded-2", v1alpha1.ModelTrainingSucceeded)
+	s.expectTraining("failed-1", v1alpha1.ModelTrainingFailed)
+	s.expectTraining("failed-2", v1alpha1.ModelTrainingFailed)
+	s.expectTraining("running", v1alpha1.ModelTrainingRunning)
+	s.mockTrainings.On("GetModelTraining", s.ctx, "deleted").Return(nil, odahu_errs.NotFoundError{})
+	s.mockTrainings.On("DeleteModelTraining", s.ctx, "succeeded-1").Return(nil)
+	s.mockTrainings.On("DeleteModelTraining", s.ctx, "failed-1").Return(odahu_errs.NotFoundError{})
+
+	status := s.schedule(mts, time.Date(2021, 1, 5, 4, 0, 0, 0, time.UTC))
+
+	s.Assertions.Equal([]string{"succeeded-2", "failed-2", "running"}, status.Trainings)
+	s.Assertions.Equal([]string{"running"}, status.Active)
+	s.mockTrainings.AssertExpectations(s.T())
+}
+
+func (s *SchedulerSuite) TestFailedRunIsRetried() {
+	mts := newScheduledStub()
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(errors.New("database is unavailable"))
+
+	status := s.schedule(mts, time.Date(2021, 1, 2, 3, 0, 30, 0, time.UTC))
+
+	s.Assertions.Nil(status.LastScheduleTime)
+	s.Assertions.Equal("database is unavailable", status.LastError)
+	s.Assertions.Empty(status.Trainings)
+}
+
+func (s *SchedulerSuite) TestAlreadyCreatedTrainingIsTracked() {
+	mts := newScheduledStub()
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(odahu_errs.AlreadyExistError{})
+	// The training was created by the previous attempt, that failed to save the status
+	s.mockTrainings.On("GetModelTraining", s.ctx, "nightly-202101020300").Return(&apis.ModelTraining{
+		ID: "nightly-202101020300", Spec: scheduleTemplate, CreatedAt: time.Date(2021, 1, 2, 3, 0, 10, 0, time.UTC),
+	}, nil)
+
+	status := s.schedule(mts, time.Date(2021, 1, 2, 3, 0, 30, 0, time.UTC))
+
+	s.Assertions.Equal([]string{"nightly-202101020300"}, status.Trainings)
+	s.Assertions.Equal([]string{"nightly-202101020300"}, status.Active)
+	s.Assertions.Empty(status.LastError)
+}
+
+func (s *SchedulerSuite) TestForeignTrainingIsNotAdopted() {
+	mts := newScheduledStub()
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(odahu_errs.AlreadyExistError{})
+	// The training with the run ID was created by a user before the run
+	foreignSpec := *scheduleTemplate.DeepCopy()
+	foreignSpec.Toolchain = "custom"
+	s.mockTrainings.On("GetModelTraining", s.ctx, "nightly-202101020300").Return(&apis.ModelTraining{
+		ID: "nightly-202101020300", Spec: foreignSpec, CreatedAt: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, nil)
+
+	status := s.schedule(mts, time.Date(2021, 1, 2, 3, 0, 30, 0, time.UTC))
+
+	s.Assertions.Equal(time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC), *status.LastScheduleTime)
+	s.Assertions.Empty(status.Trainings)
+	s.Assertions.Empty(status.Active)
+}
+
+// schedule processes the schedule at the time and returns its saved status
+func (s *SchedulerSuite) schedule(
+	mts apis.ModelTrainingSchedule, now time.Time) (status apis.ModelTrainingScheduleStatus) {
+
+	s.mockRepo.On("List", s.ctx, s.nilTx, mock.AnythingOfType("filter.ListOption")).
+		Return([]apis.ModelTrainingSchedule{mts}, nil).Once()
+	s.mockRepo.On("List", s.ctx, s.nilTx, mock.AnythingOfType("filter.ListOption")).
+		Return([]apis.ModelTrainingSchedule{}, nil).Once()
+	s.mockRepo.On("UpdateStatus", s.ctx, s.nilTx, mts.ID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			status = args.Get(3).(apis.ModelTrainingScheduleStatus)
+		}).Return(nil)
+
+	scheduler := service.NewSchedulerWithClock(s.mockRepo, s.mockTrainings, func() time.Time { return now })
+	s.Assertions.NoError(scheduler.Schedule(s.ctx))
+
+	return status
+}
+
+func (s *SchedulerSuite) expectTraining(id string, state v1alpha1.ModelTrainingState) {
+	s.mockTrainings.On("GetModelTraining", s.ctx, id).Return(&apis.ModelTraining{
+		ID:     id,
+		Status: v1alpha1.ModelTrainingStatus{State: state},
+	}, nil)
+}
+
+func newScheduledStub() apis.ModelTrainingSchedule {
+	mts := newStubSchedule()
+	mts.CreatedAt = scheduleCreatedAt
+	mts.Spec.Template = scheduleTemplate
+	service.SetDefaults(mts)
+	return *mts
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
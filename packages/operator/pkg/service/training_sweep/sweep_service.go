@@ -0,0 +1,158 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_sweep //nolint
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	db_utils "github.com/odahu/odahu-flow/packages/operator/pkg/utils/db"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"time"
+)
+
+var log = logf.Log.WithName("model-training-sweep--service")
+
+const TrialTrainingAlreadyExistsErrorMessage = "ModelTraining with ID %q of a trial already exists"
+
+type Repository interface {
+	Create(ctx context.Context, tx *sql.Tx, mtsw training.ModelTrainingSweep) error
+	Get(ctx context.Context, tx *sql.Tx, id string) (training.ModelTrainingSweep, error)
+	List(ctx context.Context, tx *sql.Tx, options ...filter.ListOption) ([]training.ModelTrainingSweep, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, id string, status training.ModelTrainingSweepStatus) error
+	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, tx *sql.Tx, change audit.Change) error
+}
+
+type Service struct {
+	repo          Repository
+	auditRecorder AuditRecorder
+	trainService  TrainingService
+}
+
+func NewService(repo Repository, auditRecorder AuditRecorder, trainService TrainingService) *Service {
+	return &Service{repo: repo, auditRecorder: auditRecorder, trainService: trainService}
+}
+
+// Create creates a sweep with all its trials. Trainings of trials are started by the Sweeper
+func (s *Service) Create(ctx context.Context, mtsw *training.ModelTrainingSweep) (err error) {
+
+	// Set fields that managed by platform. Cannot be overridden by user
+	mtsw.CreatedAt = time.Now().UTC()
+	mtsw.UpdatedAt = time.Now().UTC()
+
+	SetDefaults(mtsw)
+	if errs := ValidateCreate(*mtsw); len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           mtsw.ID,
+			ValidationErrors: errs,
+		}
+	}
+
+	mtsw.Status = training.ModelTrainingSweepStatus{
+		State:  training.SweepRunning,
+		Trials: GenerateTrials(*mtsw),
+	}
+
+	// The Sweeper adopts existing trainings with trial IDs, so they must not exist beforehand
+	if err := s.checkTrialTrainingsAbsent(ctx, *mtsw); err != nil {
+		return err
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	if err = s.repo.Create(ctx, tx, *mtsw); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelTrainingSweepKind,
+		EntityID:   mtsw.ID,
+		Operation:  audit.CreateOperation,
+		NewSpec:    mtsw.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+func (s *Service) checkTrialTrainingsAbsent(ctx context.Context, mtsw training.ModelTrainingSweep) error {
+	var errs []error
+
+	for _, trial := range mtsw.Status.Trials {
+		_, err := s.trainService.GetModelTraining(ctx, trial.TrainingID)
+		switch {
+		case err == nil:
+			errs = append(errs, fmt.Errorf(TrialTrainingAlreadyExistsErrorMessage, trial.TrainingID))
+		case !odahuErrs.IsNotFoundError(err):
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return odahuErrs.InvalidEntityError{
+			Entity:           mtsw.ID,
+			ValidationErrors: errs,
+		}
+	}
+	return nil
+}
+
+// Delete deletes the sweep. Trainings of its trials are kept
+func (s *Service) Delete(ctx context.Context, id string) (err error) {
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { db_utils.FinishTx(tx, err, log) }()
+
+	old, err := s.repo.Get(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+
+	change := audit.Change{
+		EntityKind: audit.ModelTrainingSweepKind,
+		EntityID:   id,
+		Operation:  audit.DeleteOperation,
+		OldSpec:    old.Spec,
+	}
+	return s.auditRecorder.Record(ctx, tx, change)
+}
+
+func (s *Service) Get(ctx context.Context, id string) (training.ModelTrainingSweep, error) {
+	return s.repo.Get(ctx, nil, id)
+}
+
+func (s *Service) List(ctx context.Context, options ...filter.ListOption) ([]training.ModelTrainingSweep, error) {
+	return s.repo.List(ctx, nil, options...)
+}
@@ -0,0 +1,225 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_sweep_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/audit"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_sweep"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/training_sweep/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+const (
+	mtswID = "wine-sweep"
+)
+
+func TestServiceSuiteRun(t *testing.T) {
+	suite.Run(t, new(ServiceSuite))
+}
+
+type ServiceSuite struct {
+	suite.Suite
+	mockRepo     *mocks.Repository
+	mockRecorder *mocks.AuditRecorder
+	mockTraining *mocks.TrainingService
+	service      *service.Service
+	db           *sql.DB
+	dbMock       sqlmock.Sqlmock
+}
+
+func (s *ServiceSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatal("Unable initialize sql mock")
+	}
+
+	s.mockRepo = &mocks.Repository{}
+	s.mockRecorder = &mocks.AuditRecorder{}
+	s.mockTraining = &mocks.TrainingService{}
+	s.db = db
+	s.dbMock = dbMock
+	s.service = service.NewService(s.mockRepo, s.mockRecorder, s.mockTraining)
+}
+
+func (s *ServiceSuite) TestCreateGeneratesGridTrials() {
+	ctx := context.Background()
+	mtsw := newStubSweep()
+	mtsw.Status.BestTrainingID = "forged"
+	s.expectNoTrainings()
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Create", ctx, mockTx, mock.AnythingOfType("training.ModelTrainingSweep")).Return(nil)
+	s.mockRecorder.On("Record", ctx, mockTx, mock.MatchedBy(func(change audit.Change) bool {
+		return change.EntityKind == audit.ModelTrainingSweepKind && change.Operation == audit.CreateOperation
+	})).Return(nil)
+
+	err := s.service.Create(ctx, mtsw)
+	s.Assertions.NoError(err)
+	s.Assertions.Equal(service.DefaultParallelism, mtsw.Spec.Parallelism)
+	s.Assertions.Equal(apis.SweepRunning, mtsw.Status.State)
+	s.Assertions.Empty(mtsw.Status.BestTrainingID)
+	s.Assertions.Equal([]apis.SweepTrial{
+		{TrainingID: "wine-sweep-0", HyperParameters: map[string]string{"alpha": "0.1", "l1_ratio": "0.1"}},
+		{TrainingID: "wine-sweep-1", HyperParameters: map[string]string{"alpha": "0.1", "l1_ratio": "0.5"}},
+		{TrainingID: "wine-sweep-2", HyperParameters: map[string]string{"alpha": "0.5", "l1_ratio": "0.1"}},
+		{TrainingID: "wine-sweep-3", HyperParameters: map[string]string{"alpha": "0.5", "l1_ratio": "0.5"}},
+	}, mtsw.Status.Trials)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecorder.AssertExpectations(s.T())
+}
+
+func (s *ServiceSuite) TestCreateTruncatesGrid() {
+	mtsw := newStubSweep()
+	mtsw.Spec.MaxTrials = 3
+	s.expectNoTrainings()
+	mockTx := s.expectTx(true)
+	s.mockRepo.On("Create", context.Background(), mockTx, mock.Anything).Return(nil)
+	s.mockRecorder.On("Record", context.Background(), mockTx, mock.Anything).Return(nil)
+
+	s.Assertions.NoError(s.service.Create(context.Background(), mtsw))
+	s.Assertions.Len(mtsw.Status.Trials, 3)
+}
+
+func (s *ServiceSuite) TestRandomTrialsDependOnSeed() {
+	minValue, maxValue := 0.001, 1.0
+	minDepth, maxDepth := 2.0, 8.0
+	seed := int64(42)
+	mtsw := newStubSweep()
+	mtsw.Spec.Strategy = apis.RandomSweep
+	mtsw.Spec.MaxTrials = 20
+	mtsw.Spec.Seed = &seed
+	mtsw.Spec.Parameters = []apis.SweepParameter{
+		{Name: "alpha", Distribution: apis.LogUniformDistribution, Min: &minValue, Max: &maxValue},
+		{Name: "depth", Distribution: apis.IntUniformDistribution, Min: &minDepth, Max: &maxDepth},
+		{Name: "solver", Values: []string{"lbfgs", "adam"}},
+	}
+	service.SetDefaults(mtsw)
+	s.Assertions.Empty(service.ValidateCreate(*mtsw))
+
+	trials := service.GenerateTrials(*mtsw)
+	s.Assertions.Len(trials, 20)
+	s.Assertions.Equal(trials, service.GenerateTrials(*mtsw))
+	for _, trial := range trials {
+		s.Assertions.Contains([]string{"2", "3", "4", "5", "6", "7", "8"}, trial.HyperParameters["depth"])
+		s.Assertions.Contains([]string{"lbfgs", "adam"}, trial.HyperParameters["solver"])
+	}
+}
+
+func (s *ServiceSuite) TestCreateInvalid() {
+	ctx := context.Background()
+	minValue, maxValue := 1.0, 0.0
+	mtsw := newStubSweep()
+	mtsw.ID = strings.Repeat("a", apis.MaxSweepIDLength+1)
+	mtsw.Spec.Strategy = apis.RandomSweep
+	mtsw.Spec.Parameters = []apis.SweepParameter{
+		{Name: "alpha", Distribution: apis.UniformDistribution, Min: &minValue, Max: &maxValue},
+		{Name: "alpha", Distribution: "normal"},
+	}
+	mtsw.Spec.Objective = apis.SweepObjective{Goal: "best"}
+
+	err := s.service.Create(ctx, mtsw)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	// Too long ID, invalid bounds, unknown distribution, missed maxTrials, duplicated parameter,
+	// empty metric and unknown goal
+	s.Assertions.Len(err.(odahu_errs.InvalidEntityError).ValidationErrors, 7)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestCreateTooLargeGrid() {
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = strings.Repeat("1", i+1)
+	}
+	mtsw := newStubSweep()
+	mtsw.Spec.Parameters = []apis.SweepParameter{{Name: "a", Values: values}, {Name: "b", Values: values}}
+
+	err := s.service.Create(context.Background(), mtsw)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.Assertions.Len(err.(odahu_errs.InvalidEntityError).ValidationErrors, 1)
+}
+
+func (s *ServiceSuite) TestCreateTrialTrainingExists() {
+	ctx := context.Background()
+	mtsw := newStubSweep()
+	s.mockTraining.On("GetModelTraining", ctx, "wine-sweep-2").Return(&apis.ModelTraining{ID: "wine-sweep-2"}, nil)
+	s.expectNoTrainings()
+
+	err := s.service.Create(ctx, mtsw)
+	s.Assertions.IsType(odahu_errs.InvalidEntityError{}, err)
+	s.Assertions.Equal(
+		[]error{fmt.Errorf(service.TrialTrainingAlreadyExistsErrorMessage, "wine-sweep-2")},
+		err.(odahu_errs.InvalidEntityError).ValidationErrors,
+	)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) TestDeleteNotFound() {
+	ctx := context.Background()
+	mockTx := s.expectTx(false)
+	s.mockRepo.On("Get", ctx, mockTx, mtswID).Return(
+		apis.ModelTrainingSweep{}, odahu_errs.NotFoundError{Entity: mtswID},
+	)
+
+	err := s.service.Delete(ctx, mtswID)
+	s.Assertions.True(odahu_errs.IsNotFoundError(err))
+	s.mockRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceSuite) expectNoTrainings() {
+	s.mockTraining.On("GetModelTraining", context.Background(), mock.AnythingOfType("string")).Return(
+		nil, odahu_errs.NotFoundError{},
+	)
+}
+
+func (s *ServiceSuite) expectTx(commit bool) *sql.Tx {
+	s.dbMock.ExpectBegin()
+	if commit {
+		s.dbMock.ExpectCommit()
+	} else {
+		s.dbMock.ExpectRollback()
+	}
+
+	mockTx, err := s.db.Begin()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockRepo.On("BeginTransaction", context.Background()).Return(mockTx, nil)
+	return mockTx
+}
+
+func newStubSweep() *apis.ModelTrainingSweep {
+	return &apis.ModelTrainingSweep{
+		ID: mtswID,
+		Spec: apis.ModelTrainingSweepSpec{
+			Strategy: apis.GridSweep,
+			Parameters: []apis.SweepParameter{
+				{Name: "alpha", Values: []string{"0.1", "0.5"}},
+				{Name: "l1_ratio", Values: []string{"0.1", "0.5"}},
+			},
+			Objective: apis.SweepObjective{Metric: "accuracy", Goal: apis.MaximizeGoal},
+		},
+	}
+}
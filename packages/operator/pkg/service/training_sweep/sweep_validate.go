@@ -0,0 +1,279 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_sweep //nolint
+
+import (
+	"errors"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/validation"
+	"go.uber.org/multierr"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+const (
+	TooLongIDErrorMessage            = "sweep ID must be no more than %d characters long"
+	UnknownStrategyErrorMessage      = "unknown strategy %q. Possible values: %v"
+	EmptyParametersErrorMessage      = "at least one parameter must be swept"
+	EmptyParameterNameErrorMessage   = "parameter name must not be empty"
+	DuplicatedParameterErrorMessage  = "parameter %q is declared more than once"
+	EmptyValuesErrorMessage          = "values of parameter %q must not be empty"
+	UnknownDistributionErrorMessage  = "unknown distribution %q of parameter %q. Possible values: %v"
+	MissedBoundsErrorMessage         = "min and max of parameter %q must be set"
+	InvalidBoundsErrorMessage        = "min of parameter %q must not be greater than max"
+	NonPositiveLogMinErrorMessage    = "min of parameter %q must be positive for the logUniform distribution"
+	InvalidMaxTrialsErrorMessage     = "maxTrials must be between 1 and %d"
+	TooManyGridTrialsErrorMessage    = "grid has more than %d trials, reduce values or set maxTrials"
+	NonPositiveParallelismMessage    = "parallelism must be positive"
+	EmptyObjectiveMetricErrorMessage = "objective metric must not be empty"
+	UnknownGoalErrorMessage          = "unknown objective goal %q. Possible values: %v"
+)
+
+var (
+	DefaultParallelism = 1
+
+	strategies    = []training.SweepStrategy{training.GridSweep, training.RandomSweep}
+	distributions = []training.Distribution{
+		training.ChoiceDistribution, training.UniformDistribution,
+		training.LogUniformDistribution, training.IntUniformDistribution,
+	}
+	goals = []training.SweepGoal{training.MaximizeGoal, training.MinimizeGoal}
+)
+
+// SetDefaults fills optional fields of the sweep spec
+func SetDefaults(mtsw *training.ModelTrainingSweep) {
+	if mtsw.Spec.Parallelism == 0 {
+		mtsw.Spec.Parallelism = DefaultParallelism
+	}
+	if mtsw.Spec.Strategy == training.RandomSweep {
+		if mtsw.Spec.Seed == nil {
+			seed := time.Now().UnixNano()
+			mtsw.Spec.Seed = &seed
+		}
+		for i := range mtsw.Spec.Parameters {
+			if len(mtsw.Spec.Parameters[i].Distribution) == 0 {
+				mtsw.Spec.Parameters[i].Distribution = training.ChoiceDistribution
+			}
+		}
+	}
+}
+
+// ValidateCreate validates the sweep. Template is validated by the API server together with other trainings
+func ValidateCreate(mtsw training.ModelTrainingSweep) (errs []error) {
+
+	var err error
+
+	err = multierr.Append(err, validation.ValidateID(mtsw.ID))
+	if len(mtsw.ID) > training.MaxSweepIDLength {
+		err = multierr.Append(err, fmt.Errorf(TooLongIDErrorMessage, training.MaxSweepIDLength))
+	}
+
+	switch mtsw.Spec.Strategy {
+	case training.GridSweep:
+		err = multierr.Append(err, validateGrid(mtsw.Spec))
+	case training.RandomSweep:
+		err = multierr.Append(err, validateRandom(mtsw.Spec))
+	default:
+		err = multierr.Append(err, fmt.Errorf(UnknownStrategyErrorMessage, mtsw.Spec.Strategy, strategies))
+	}
+	err = multierr.Append(err, validateParameterNames(mtsw.Spec.Parameters))
+
+	if mtsw.Spec.Parallelism < 1 {
+		err = multierr.Append(err, errors.New(NonPositiveParallelismMessage))
+	}
+	if len(mtsw.Spec.Objective.Metric) == 0 {
+		err = multierr.Append(err, errors.New(EmptyObjectiveMetricErrorMessage))
+	}
+	if !containsGoal(mtsw.Spec.Objective.Goal) {
+		err = multierr.Append(err, fmt.Errorf(UnknownGoalErrorMessage, mtsw.Spec.Objective.Goal, goals))
+	}
+
+	if err != nil {
+		return multierr.Errors(err)
+	}
+	return nil
+}
+
+func validateParameterNames(parameters []training.SweepParameter) (err error) {
+	if len(parameters) == 0 {
+		return errors.New(EmptyParametersErrorMessage)
+	}
+
+	names := make(map[string]bool, len(parameters))
+	for _, p := range parameters {
+		switch {
+		case len(p.Name) == 0:
+			err = multierr.Append(err, errors.New(EmptyParameterNameErrorMessage))
+		case names[p.Name]:
+			err = multierr.Append(err, fmt.Errorf(DuplicatedParameterErrorMessage, p.Name))
+		}
+		names[p.Name] = true
+	}
+	return err
+}
+
+func validateGrid(spec training.ModelTrainingSweepSpec) (err error) {
+	for _, p := range spec.Parameters {
+		if len(p.Values) == 0 {
+			err = multierr.Append(err, fmt.Errorf(EmptyValuesErrorMessage, p.Name))
+		}
+	}
+	if spec.MaxTrials < 0 || spec.MaxTrials > training.MaxSweepTrials {
+		err = multierr.Append(err, fmt.Errorf(InvalidMaxTrialsErrorMessage, training.MaxSweepTrials))
+	}
+	if err == nil && spec.MaxTrials == 0 && gridSize(spec.Parameters) > training.MaxSweepTrials {
+		err = fmt.Errorf(TooManyGridTrialsErrorMessage, training.MaxSweepTrials)
+	}
+	return err
+}
+
+func validateRandom(spec training.ModelTrainingSweepSpec) (err error) {
+	for _, p := range spec.Parameters {
+		switch p.Distribution {
+		case training.ChoiceDistribution:
+			if len(p.Values) == 0 {
+				err = multierr.Append(err, fmt.Errorf(EmptyValuesErrorMessage, p.Name))
+			}
+		case training.UniformDistribution, training.IntUniformDistribution, training.LogUniformDistribution:
+			err = multierr.Append(err, validateBounds(p))
+		default:
+			err = multierr.Append(err, fmt.Errorf(UnknownDistributionErrorMessage, p.Distribution, p.Name, distributions))
+		}
+	}
+	if spec.MaxTrials < 1 || spec.MaxTrials > training.MaxSweepTrials {
+		err = multierr.Append(err, fmt.Errorf(InvalidMaxTrialsErrorMessage, training.MaxSweepTrials))
+	}
+	return err
+}
+
+func validateBounds(p training.SweepParameter) error {
+	switch {
+	case p.Min == nil || p.Max == nil:
+		return fmt.Errorf(MissedBoundsErrorMessage, p.Name)
+	case *p.Min > *p.Max:
+		return fmt.Errorf(InvalidBoundsErrorMessage, p.Name)
+	case p.Distribution == training.LogUniformDistribution && *p.Min <= 0:
+		return fmt.Errorf(NonPositiveLogMinErrorMessage, p.Name)
+	}
+	return nil
+}
+
+// gridSize returns number of combinations of parameter values. Counting stops after MaxSweepTrials
+func gridSize(parameters []training.SweepParameter) int {
+	size := 1
+	for _, p := range parameters {
+		size *= len(p.Values)
+		if size > training.MaxSweepTrials {
+			return size
+		}
+	}
+	return size
+}
+
+// GenerateTrials returns all trials of a valid sweep. Trials of the random strategy depend only on the seed
+func GenerateTrials(mtsw training.ModelTrainingSweep) []training.SweepTrial {
+	var combinations []map[string]string
+	if mtsw.Spec.Strategy == training.GridSweep {
+		combinations = gridCombinations(mtsw.Spec.Parameters, mtsw.Spec.MaxTrials)
+	} else {
+		combinations = randomCombinations(mtsw.Spec.Parameters, mtsw.Spec.MaxTrials, *mtsw.Spec.Seed)
+	}
+
+	trials := make([]training.SweepTrial, 0, len(combinations))
+	for i, hyperParameters := range combinations {
+		trials = append(trials, training.SweepTrial{
+			TrainingID:      mtsw.TrialTrainingID(i),
+			HyperParameters: hyperParameters,
+		})
+	}
+	return trials
+}
+
+// gridCombinations enumerates combinations, the last parameter changes first
+func gridCombinations(parameters []training.SweepParameter, maxTrials int) (res []map[string]string) {
+	indexes := make([]int, len(parameters))
+	for {
+		if maxTrials > 0 && len(res) == maxTrials {
+			return res
+		}
+
+		combination := make(map[string]string, len(parameters))
+		for i, p := range parameters {
+			combination[p.Name] = p.Values[indexes[i]]
+		}
+		res = append(res, combination)
+
+		i := len(parameters) - 1
+		for ; i >= 0; i-- {
+			indexes[i]++
+			if indexes[i] < len(parameters[i].Values) {
+				break
+			}
+			indexes[i] = 0
+		}
+		if i < 0 {
+			return res
+		}
+	}
+}
+
+func randomCombinations(parameters []training.SweepParameter, trials int, seed int64) (res []map[string]string) {
+	rnd := rand.New(rand.NewSource(seed)) //nolint:gosec
+	for t := 0; t < trials; t++ {
+		combination := make(map[string]string, len(parameters))
+		for _, p := range parameters {
+			combination[p.Name] = sample(rnd, p)
+		}
+		res = append(res, combination)
+	}
+	return res
+}
+
+func sample(rnd *rand.Rand, p training.SweepParameter) string {
+	switch p.Distribution {
+	case training.UniformDistribution:
+		return formatFloat(*p.Min + rnd.Float64()*(*p.Max-*p.Min))
+	case training.LogUniformDistribution:
+		logMin, logMax := math.Log(*p.Min), math.Log(*p.Max)
+		return formatFloat(math.Exp(logMin + rnd.Float64()*(logMax-logMin)))
+	case training.IntUniformDistribution:
+		low, high := int64(math.Ceil(*p.Min)), int64(math.Floor(*p.Max))
+		if high < low {
+			return strconv.FormatInt(low, 10)
+		}
+		return strconv.FormatInt(low+rnd.Int63n(high-low+1), 10)
+	default:
+		return p.Values[rnd.Intn(len(p.Values))]
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func containsGoal(goal training.SweepGoal) bool {
+	for _, g := range goals {
+		if g == goal {
+			return true
+		}
+	}
+	return false
+}
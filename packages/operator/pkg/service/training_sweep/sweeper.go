@@ -0,0 +1,283 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_sweep //nolint
+
+import (
+	"context"
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahuErrs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/filter"
+	"reflect"
+	"time"
+)
+
+const (
+	deletedTrainingMessage  = "training was deleted"
+	foreignTrainingMessage  = "training with the same ID was not created by the sweep"
+	missedMetricMessage     = "training did not report the %q metric"
+	noMetricsMessage        = "no trial reported the %q metric"
+	noSucceededTrialMessage = "all trials failed"
+)
+
+type TrainingService interface {
+	GetModelTraining(ctx context.Context, id string) (*training.ModelTraining, error)
+	CreateModelTraining(ctx context.Context, mt *training.ModelTraining) error
+}
+
+// Sweeper starts trainings of pending trials, collects results of finished ones
+// and chooses the best trial of every running sweep
+type Sweeper struct {
+	repo         Repository
+	trainService TrainingService
+	now          func() time.Time
+}
+
+func NewSweeper(repo Repository, trainService TrainingService) *Sweeper {
+	return &Sweeper{repo: repo, trainService: trainService, now: time.Now}
+}
+
+// NewSweeperWithClock is used in tests to control the current time
+func NewSweeperWithClock(repo Repository, trainService TrainingService, now func() time.Time) *Sweeper {
+	return &Sweeper{repo: repo, trainService: trainService, now: now}
+}
+
+// Sweep processes all running sweeps once
+func (s *Sweeper) Sweep(ctx context.Context) error {
+	for page := 0; ; page++ {
+		sweeps, err := s.repo.List(ctx, nil, filter.ListFilter(&training.SweepFilter{
+			State: []string{string(training.SweepRunning)},
+		}), filter.Page(page))
+		if err != nil {
+			return err
+		}
+		if len(sweeps) == 0 {
+			return nil
+		}
+
+		for _, mtsw := range sweeps {
+			// A failure of one sweep must not block others
+			if err := s.process(ctx, mtsw); err != nil {
+				log.Error(err, "Unable to process the training sweep", "sweep", mtsw.ID)
+			}
+		}
+	}
+}
+
+func (s *Sweeper) process(ctx context.Context, mtsw training.ModelTrainingSweep) error {
+	status := copyStatus(mtsw.Status)
+	status.LastError = ""
+
+	if err := s.refreshTrials(ctx, mtsw, &status); err != nil {
+		status.LastError = err.Error()
+		return s.saveStatus(ctx, mtsw, status)
+	}
+	if err := s.startTrials(ctx, mtsw, &status); err != nil {
+		// Pending trials are started during the next processing
+		status.LastError = err.Error()
+		log.Error(err, "Unable to start the trial training", "sweep", mtsw.ID)
+	}
+
+	chooseBest(mtsw.Spec.Objective, &status)
+	if allFinished(status.Trials) {
+		if status.BestTrainingID != "" {
+			status.State = training.SweepSucceeded
+		} else {
+			status.State = training.SweepFailed
+			status.Message = failureMessage(mtsw.Spec.Objective, status.Trials)
+		}
+		log.Info("Training sweep is finished", "sweep", mtsw.ID, "state", status.State,
+			"best training", status.BestTrainingID)
+	}
+
+	return s.saveStatus(ctx, mtsw, status)
+}
+
+// refreshTrials updates states of started trials and reads the objective metric of succeeded ones
+func (s *Sweeper) refreshTrials(
+	ctx context.Context, mtsw training.ModelTrainingSweep, status *training.ModelTrainingSweepStatus) error {
+
+	for i := range status.Trials {
+		trial := &status.Trials[i]
+		if trial.StartedAt == nil || trialFinished(*trial) {
+			continue
+		}
+
+		mt, err := s.trainService.GetModelTraining(ctx, trial.TrainingID)
+		if odahuErrs.IsNotFoundError(err) {
+			trial.State = string(v1alpha1.ModelTrainingFailed)
+			trial.Message = deletedTrainingMessage
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		trial.State = string(mt.Status.State)
+		if mt.Status.State == v1alpha1.ModelTrainingSucceeded {
+			trial.Metric = objectiveMetric(mt.Status, mtsw.Spec.Objective.Metric)
+			if trial.Metric == nil {
+				trial.Message = fmt.Sprintf(missedMetricMessage, mtsw.Spec.Objective.Metric)
+			}
+		}
+	}
+	return nil
+}
+
+// startTrials starts pending trials in order while the number of unfinished trainings is less than parallelism
+func (s *Sweeper) startTrials(
+	ctx context.Context, mtsw training.ModelTrainingSweep, status *training.ModelTrainingSweepStatus) error {
+
+	active := 0
+	for _, trial := range status.Trials {
+		if trial.StartedAt != nil && !trialFinished(trial) {
+			active++
+		}
+	}
+
+	for i := range status.Trials {
+		if active >= mtsw.Spec.Parallelism {
+			return nil
+		}
+		trial := &status.Trials[i]
+		if trial.StartedAt != nil {
+			continue
+		}
+
+		spec := *mtsw.Spec.Template.DeepCopy()
+		if spec.HyperParameters == nil {
+			spec.HyperParameters = make(map[string]string, len(trial.HyperParameters))
+		}
+		for name, value := range trial.HyperParameters {
+			spec.HyperParameters[name] = value
+		}
+
+		now := s.now().UTC()
+
+		// Training ID is derived from the trial index, so a training which was created before
+		// a failed status update is not created twice
+		err := s.trainService.CreateModelTraining(ctx, &training.ModelTraining{ID: trial.TrainingID, Spec: spec})
+		if odahuErrs.IsAlreadyExistError(err) {
+			adopted, adoptErr := s.createdBySweep(ctx, mtsw, trial.TrainingID, spec)
+			if adoptErr != nil {
+				return adoptErr
+			}
+			if !adopted {
+				// Metrics of a foreign training must not get into the sweep result
+				trial.StartedAt = &now
+				trial.State = string(v1alpha1.ModelTrainingFailed)
+				trial.Message = foreignTrainingMessage
+				continue
+			}
+		} else if err != nil {
+			return err
+		}
+		log.Info("Trial training is started", "sweep", mtsw.ID, "training", trial.TrainingID)
+
+		trial.StartedAt = &now
+		trial.State = string(v1alpha1.ModelTrainingScheduling)
+		active++
+	}
+	return nil
+}
+
+// createdBySweep checks whether the existing training with the trial ID was created by the sweep
+func (s *Sweeper) createdBySweep(
+	ctx context.Context, mtsw training.ModelTrainingSweep, id string, spec v1alpha1.ModelTrainingSpec) (bool, error) {
+
+	mt, err := s.trainService.GetModelTraining(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return !mt.CreatedAt.Before(mtsw.CreatedAt) && reflect.DeepEqual(mt.Spec.HyperParameters, spec.HyperParameters), nil
+}
+
+func (s *Sweeper) saveStatus(
+	ctx context.Context, mtsw training.ModelTrainingSweep, status training.ModelTrainingSweepStatus) error {
+	if reflect.DeepEqual(mtsw.Status, status) {
+		return nil
+	}
+	return s.repo.UpdateStatus(ctx, nil, mtsw.ID, status)
+}
+
+// objectiveMetric returns the metric of the latest training result
+func objectiveMetric(status v1alpha1.ModelTrainingStatus, metric string) *float64 {
+	if len(status.Artifacts) == 0 {
+		return nil
+	}
+	value, ok := status.Artifacts[len(status.Artifacts)-1].Metrics[metric]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// chooseBest sets the trial with the best objective metric among finished trials
+func chooseBest(objective training.SweepObjective, status *training.ModelTrainingSweepStatus) {
+	status.BestTrainingID = ""
+	status.BestMetric = nil
+
+	for _, trial := range status.Trials {
+		if trial.Metric == nil {
+			continue
+		}
+		if status.BestMetric == nil || better(objective.Goal, *trial.Metric, *status.BestMetric) {
+			metric := *trial.Metric
+			status.BestTrainingID = trial.TrainingID
+			status.BestMetric = &metric
+		}
+	}
+}
+
+func better(goal training.SweepGoal, value, best float64) bool {
+	if goal == training.MinimizeGoal {
+		return value < best
+	}
+	return value > best
+}
+
+func failureMessage(objective training.SweepObjective, trials []training.SweepTrial) string {
+	for _, trial := range trials {
+		if trial.State == string(v1alpha1.ModelTrainingSucceeded) {
+			return fmt.Sprintf(noMetricsMessage, objective.Metric)
+		}
+	}
+	return noSucceededTrialMessage
+}
+
+func trialFinished(trial training.SweepTrial) bool {
+	return trial.State == string(v1alpha1.ModelTrainingSucceeded) || trial.State == string(v1alpha1.ModelTrainingFailed)
+}
+
+func allFinished(trials []training.SweepTrial) bool {
+	for _, trial := range trials {
+		if !trialFinished(trial) {
+			return false
+		}
+	}
+	return true
+}
+
+// copyStatus makes a copy which can be changed without changing the original status
+func copyStatus(status training.ModelTrainingSweepStatus) training.ModelTrainingSweepStatus {
+	res := status
+	res.Trials = make([]training.SweepTrial, len(status.Trials))
+	copy(res.Trials, status.Trials)
+	return res
+}
@@ -0,0 +1,232 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package training_sweep_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
+	apis "github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	odahu_errs "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
+	service "github.com/odahu/odahu-flow/packages/operator/pkg/service/training_sweep"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/service/training_sweep/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+var (
+	sweepNow      = time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC)
+	sweepTemplate = v1alpha1.ModelTrainingSpec{
+		Model:           v1alpha1.ModelIdentity{Name: "wine", Version: "1.0"},
+		Toolchain:       "mlflow",
+		HyperParameters: map[string]string{"alpha": "1.0", "epochs": "10"},
+	}
+)
+
+func TestSweeperSuiteRun(t *testing.T) {
+	suite.Run(t, new(SweeperSuite))
+}
+
+type SweeperSuite struct {
+	suite.Suite
+	mockRepo      *mocks.Repository
+	mockTrainings *mocks.TrainingService
+	nilTx         *sql.Tx
+	ctx           context.Context
+}
+
+func (s *SweeperSuite) SetupTest() {
+	s.mockRepo = &mocks.Repository{}
+	s.mockTrainings = &mocks.TrainingService{}
+	s.ctx = context.Background()
+}
+
+func (s *SweeperSuite) TestStartsTrialsUpToParallelism() {
+	mtsw := newRunningSweep(2)
+	s.mockTrainings.On("CreateModelTraining", s.ctx, &apis.ModelTraining{
+		ID: "wine-sweep-0",
+		Spec: v1alpha1.ModelTrainingSpec{
+			Model:           sweepTemplate.Model,
+			Toolchain:       sweepTemplate.Toolchain,
+			HyperParameters: map[string]string{"alpha": "0.1", "l1_ratio": "0.1", "epochs": "10"},
+		},
+	}).Return(nil)
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(nil).Once()
+
+	status := s.sweep(mtsw)
+
+	s.Assertions.Equal(apis.SweepRunning, status.State)
+	s.Assertions.Equal(sweepNow, *status.Trials[0].StartedAt)
+	s.Assertions.NotNil(status.Trials[1].StartedAt)
+	s.Assertions.Nil(status.Trials[2].StartedAt)
+	s.Assertions.Equal("1.0", sweepTemplate.HyperParameters["alpha"])
+	s.mockTrainings.AssertNumberOfCalls(s.T(), "CreateModelTraining", 2)
+}
+
+func (s *SweeperSuite) TestCollectsMetricsAndStartsNextTrial() {
+	mtsw := newRunningSweep(1)
+	mtsw.Status.Trials[0].StartedAt = &sweepNow
+	s.expectTraining("wine-sweep-0", v1alpha1.ModelTrainingSucceeded, map[string]float64{"accuracy": 0.8})
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.MatchedBy(func(mt *apis.ModelTraining) bool {
+		return mt.ID == "wine-sweep-1"
+	})).Return(nil)
+
+	status := s.sweep(mtsw)
+
+	s.Assertions.Equal(0.8, *status.Trials[0].Metric)
+	s.Assertions.Equal("wine-sweep-0", status.BestTrainingID)
+	s.Assertions.Equal(apis.SweepRunning, status.State)
+	s.mockTrainings.AssertExpectations(s.T())
+}
+
+func (s *SweeperSuite) TestChoosesBestTrial() {
+	mtsw := newRunningSweep(4)
+	for i := range mtsw.Status.Trials {
+		mtsw.Status.Trials[i].StartedAt = &sweepNow
+	}
+	mtsw.Spec.Objective.Goal = apis.MinimizeGoal
+	s.expectTraining("wine-sweep-0", v1alpha1.ModelTrainingSucceeded, map[string]float64{"accuracy": 0.8})
+	s.expectTraining("wine-sweep-1", v1alpha1.ModelTrainingSucceeded, map[string]float64{"accuracy": 0.6})
+	s.expectTraining("wine-sweep-2", v1alpha1.ModelTrainingSucceeded, map[string]float64{"loss": 0.1})
+	s.mockTrainings.On("GetModelTraining", s.ctx, "wine-sweep-3").Return(
+		nil, odahu_errs.NotFoundError{Entity: "wine-sweep-3"},
+	)
+
+	status := s.sweep(mtsw)
+
+	s.Assertions.Equal(apis.SweepSucceeded, status.State)
+	s.Assertions.Equal("wine-sweep-1", status.BestTrainingID)
+	s.Assertions.Equal(0.6, *status.BestMetric)
+	s.Assertions.Nil(status.Trials[2].Metric)
+	s.Assertions.NotEmpty(status.Trials[2].Message)
+	s.Assertions.Equal(string(v1alpha1.ModelTrainingFailed), status.Trials[3].State)
+}
+
+func (s *SweeperSuite) TestFailsWithoutMetrics() {
+	mtsw := newRunningSweep(4)
+	mtsw.Status.Trials = mtsw.Status.Trials[:1]
+	mtsw.Status.Trials[0].StartedAt = &sweepNow
+	s.expectTraining("wine-sweep-0", v1alpha1.ModelTrainingFailed, nil)
+
+	status := s.sweep(mtsw)
+
+	s.Assertions.Equal(apis.SweepFailed, status.State)
+	s.Assertions.NotEmpty(status.Message)
+	s.Assertions.Empty(status.BestTrainingID)
+}
+
+func (s *SweeperSuite) TestFailedStartIsRetried() {
+	mtsw := newRunningSweep(1)
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(errors.New("k8s is down"))
+
+	status := s.sweep(mtsw)
+
+	s.Assertions.Nil(status.Trials[0].StartedAt)
+	s.Assertions.Equal("k8s is down", status.LastError)
+	s.Assertions.Equal(apis.SweepRunning, status.State)
+}
+
+func (s *SweeperSuite) TestAlreadyCreatedTrainingIsAdopted() {
+	mtsw := newRunningSweep(1)
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(
+		odahu_errs.AlreadyExistError{Entity: "wine-sweep-0"},
+	)
+	s.mockTrainings.On("GetModelTraining", s.ctx, "wine-sweep-0").Return(&apis.ModelTraining{
+		ID:        "wine-sweep-0",
+		CreatedAt: mtsw.CreatedAt.Add(time.Minute),
+		Spec: v1alpha1.ModelTrainingSpec{
+			HyperParameters: map[string]string{"alpha": "0.1", "l1_ratio": "0.1", "epochs": "10"},
+		},
+	}, nil)
+
+	status := s.sweep(mtsw)
+
+	s.Assertions.NotNil(status.Trials[0].StartedAt)
+	s.Assertions.Equal(string(v1alpha1.ModelTrainingScheduling), status.Trials[0].State)
+	s.Assertions.Empty(status.LastError)
+}
+
+func (s *SweeperSuite) TestForeignTrainingIsNotAdopted() {
+	mtsw := newRunningSweep(1)
+	mtsw.Status.Trials = mtsw.Status.Trials[:2]
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(
+		odahu_errs.AlreadyExistError{Entity: "wine-sweep-0"},
+	).Once()
+	s.mockTrainings.On("CreateModelTraining", s.ctx, mock.Anything).Return(nil).Once()
+	// The training was created before the sweep
+	s.mockTrainings.On("GetModelTraining", s.ctx, "wine-sweep-0").Return(&apis.ModelTraining{
+		ID:        "wine-sweep-0",
+		CreatedAt: mtsw.CreatedAt.Add(-time.Minute),
+		Spec: v1alpha1.ModelTrainingSpec{
+			HyperParameters: map[string]string{"alpha": "0.1", "l1_ratio": "0.1", "epochs": "10"},
+		},
+	}, nil)
+
+	status := s.sweep(mtsw)
+
+	s.Assertions.Equal(string(v1alpha1.ModelTrainingFailed), status.Trials[0].State)
+	s.Assertions.NotEmpty(status.Trials[0].Message)
+	s.Assertions.Nil(status.Trials[0].Metric)
+	// The failed trial does not occupy the parallelism slot
+	s.Assertions.Equal(string(v1alpha1.ModelTrainingScheduling), status.Trials[1].State)
+	s.mockTrainings.AssertNumberOfCalls(s.T(), "CreateModelTraining", 2)
+}
+
+// sweep processes the sweep and returns its saved status
+func (s *SweeperSuite) sweep(mtsw apis.ModelTrainingSweep) (status apis.ModelTrainingSweepStatus) {
+	s.mockRepo.On("List", s.ctx, s.nilTx,
+		mock.AnythingOfType("filter.ListOption"), mock.AnythingOfType("filter.ListOption")).
+		Return([]apis.ModelTrainingSweep{mtsw}, nil).Once()
+	s.mockRepo.On("List", s.ctx, s.nilTx,
+		mock.AnythingOfType("filter.ListOption"), mock.AnythingOfType("filter.ListOption")).
+		Return([]apis.ModelTrainingSweep{}, nil).Once()
+	s.mockRepo.On("UpdateStatus", s.ctx, s.nilTx, mtsw.ID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			status = args.Get(3).(apis.ModelTrainingSweepStatus)
+		}).Return(nil)
+
+	sweeper := service.NewSweeperWithClock(s.mockRepo, s.mockTrainings, func() time.Time { return sweepNow })
+	s.Assertions.NoError(sweeper.Sweep(s.ctx))
+
+	return status
+}
+
+func (s *SweeperSuite) expectTraining(id string, state v1alpha1.ModelTrainingState, metrics map[string]float64) {
+	s.mockTrainings.On("GetModelTraining", s.ctx, id).Return(&apis.ModelTraining{
+		ID: id,
+		Status: v1alpha1.ModelTrainingStatus{
+			State:     state,
+			Artifacts: []v1alpha1.TrainingResult{{Metrics: metrics}},
+		},
+	}, nil)
+}
+
+func newRunningSweep(parallelism int) apis.ModelTrainingSweep {
+	mtsw := newStubSweep()
+	mtsw.CreatedAt = sweepNow.Add(-time.Hour)
+	mtsw.Spec.Parallelism = parallelism
+	mtsw.Spec.Template = sweepTemplate
+	mtsw.Status = apis.ModelTrainingSweepStatus{
+		State:  apis.SweepRunning,
+		Trials: service.GenerateTrials(*mtsw),
+	}
+	return *mtsw
+}
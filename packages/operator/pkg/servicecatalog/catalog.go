@@ -22,8 +22,10 @@ import (
 	model_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
 	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"go.uber.org/zap"
+	"sort"
 	"sync"
 	"text/template"
+	"time"
 )
 
 type ModelRouteCatalog struct {
@@ -31,17 +33,79 @@ type ModelRouteCatalog struct {
 	routeMap map[string]Route
 	// Key Deployment ID
 	modelsMap map[string]model_types.DeployedModel
+	// Key Route ID. Lowercase texts of served models for a free-text search
+	searchIndex map[string][]string
 	log *zap.SugaredLogger
+	// If store is set, every change of the catalog is persisted
+	store  StateStore
+	cursor int
 }
 
 func NewModelRouteCatalog(log *zap.SugaredLogger) *ModelRouteCatalog {
 	return &ModelRouteCatalog{
 		routeMap: map[string]Route{},
 		modelsMap: map[string]model_types.DeployedModel{},
+		searchIndex: map[string][]string{},
 		log: log,
 	}
 }
 
+// NewPersistentModelRouteCatalog creates catalog which is restored from the store and persists changes into it
+func NewPersistentModelRouteCatalog(log *zap.SugaredLogger, store StateStore) (*ModelRouteCatalog, error) {
+	state, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	mdc := NewModelRouteCatalog(log)
+	mdc.store = store
+	mdc.cursor = state.Cursor
+	for _, route := range state.Routes {
+		// Routes persisted before health tracking was introduced have never failed an inspection
+		if route.Model.Health.LastInspected.IsZero() {
+			route.Model.Health.Healthy = true
+		}
+		mdc.routeMap[route.ID] = route
+		mdc.modelsMap[route.Model.DeploymentID] = route.Model
+		mdc.searchIndex[route.ID] = buildSearchTexts(route.Model.ServedModels)
+	}
+	log.Infow("Catalog was restored", "cursor", state.Cursor, "routes", len(state.Routes))
+
+	return mdc, nil
+}
+
+// Cursor returns the cursor of events which are reflected in the catalog
+func (mdc *ModelRouteCatalog) Cursor() int {
+	mdc.RLock()
+	defer mdc.RUnlock()
+	return mdc.cursor
+}
+
+// SaveCursor persists the cursor of events which are reflected in the catalog
+func (mdc *ModelRouteCatalog) SaveCursor(cursor int) error {
+	mdc.Lock()
+	defer mdc.Unlock()
+	mdc.cursor = cursor
+	return mdc.persist()
+}
+
+// persist saves the catalog state if the catalog has a store. Must be called under the lock
+func (mdc *ModelRouteCatalog) persist() error {
+	if mdc.store == nil {
+		return nil
+	}
+
+	state := CatalogState{Cursor: mdc.cursor, Routes: make([]Route, 0, len(mdc.routeMap))}
+	for _, route := range mdc.routeMap {
+		state.Routes = append(state.Routes, route)
+	}
+	sort.Slice(state.Routes, func(i, j int) bool {
+		return state.Routes[i].ID < state.Routes[j].ID
+	})
+
+	return mdc.store.Save(state)
+}
+
 // PrefixSwaggerUrls prefix all URLs in swagger using prefix
 func PrefixSwaggerUrls(prefix string, swagger model_types.Swagger2) (result model_types.Swagger2, err error) {
 
@@ -94,20 +158,88 @@ func TagSwaggerMethods(tags []string, swagger model_types.Swagger2) (result mode
 }
 
 // CreateOrUpdate create or update route in catalog
-// All URLs of original swagger of model behind the route will be prefixed by route.Prefix
+// All URLs of original swagger of models behind the route will be prefixed by route.Prefix.
+// Models of the route are considered healthy because they were just inspected
 func (mdc *ModelRouteCatalog) CreateOrUpdate(route Route) error {
 
 	mdc.Lock()
 	defer mdc.Unlock()
 
-	prefixedSwagger, err := PrefixSwaggerUrls(route.Prefix, route.Model.ServedModel.Swagger)
+	servedModels := route.Model.ServedModels
+	if len(servedModels) == 0 {
+		servedModels = []model_types.ServedModel{route.Model.ServedModel}
+	}
+
+	prefixedModels, err := prefixServedModels(route.Prefix, servedModels)
 	if err != nil {
 		return err
 	}
-	route.Model.ServedModel.Swagger = prefixedSwagger
+	route.Model.ServedModels = prefixedModels
+	route.Model.ServedModel = prefixedModels[0]
+	route.Model.Health = model_types.ModelHealth{Healthy: true, LastInspected: time.Now()}
+	mdc.routeMap[route.ID] = route
+	mdc.modelsMap[route.Model.DeploymentID] = route.Model
+	mdc.searchIndex[route.ID] = buildSearchTexts(route.Model.ServedModels)
+	return mdc.persist()
+}
+
+// UpdateInspection sets the result of the route re-inspection. If models is empty (the inspection failed),
+// the previously inspected models are kept. The result is ignored if the route was deleted
+// or changed since the inspected snapshot was taken
+func (mdc *ModelRouteCatalog) UpdateInspection(
+	inspected Route, models []model_types.ServedModel, health model_types.ModelHealth,
+) error {
+	mdc.Lock()
+	defer mdc.Unlock()
+
+	route, ok := mdc.routeMap[inspected.ID]
+	if !ok || route.Prefix != inspected.Prefix || route.Model.DeploymentID != inspected.Model.DeploymentID {
+		return nil
+	}
+
+	if len(models) > 0 {
+		prefixedModels, err := prefixServedModels(route.Prefix, models)
+		if err != nil {
+			return err
+		}
+		route.Model.ServedModels = prefixedModels
+		route.Model.ServedModel = prefixedModels[0]
+		mdc.searchIndex[route.ID] = buildSearchTexts(route.Model.ServedModels)
+	}
+	route.Model.Health = health
 	mdc.routeMap[route.ID] = route
 	mdc.modelsMap[route.Model.DeploymentID] = route.Model
-	return nil
+	return mdc.persist()
+}
+
+// Routes returns all routes of the catalog sorted by ID
+func (mdc *ModelRouteCatalog) Routes() []Route {
+	mdc.RLock()
+	defer mdc.RUnlock()
+
+	routes := make([]Route, 0, len(mdc.routeMap))
+	for _, route := range mdc.routeMap {
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].ID < routes[j].ID
+	})
+	return routes
+}
+
+func prefixServedModels(
+	prefix string, servedModels []model_types.ServedModel,
+) ([]model_types.ServedModel, error) {
+	prefixedModels := make([]model_types.ServedModel, 0, len(servedModels))
+	for _, servedModel := range servedModels {
+		prefixedSwagger, err := PrefixSwaggerUrls(prefix, servedModel.Swagger)
+		if err != nil {
+			return nil, err
+		}
+		servedModel.Swagger = prefixedSwagger
+		prefixedModels = append(prefixedModels, servedModel)
+	}
+	return prefixedModels, nil
 }
 
 // Delete delete route from catalog
@@ -117,7 +249,11 @@ func (mdc *ModelRouteCatalog) Delete(routeID string, log *zap.SugaredLogger) {
 	_, ok := mdc.routeMap[routeID]
 	if ok {
 		delete(mdc.routeMap, routeID)
+		delete(mdc.searchIndex, routeID)
 		log.Info("Model route was deleted")
+		if err := mdc.persist(); err != nil {
+			log.Errorw("Unable to persist catalog state", zap.Error(err))
+		}
 	}
 }
 
@@ -133,31 +269,42 @@ func (mdc *ModelRouteCatalog) GetDeployedModel(deploymentID string) (model_types
 }
 
 // ProcessSwaggerJSON combine URLs of all models in catalog. It separates endpoints by tagging them using
-// route.ID
+// route.ID. Routes with unhealthy models are excluded
 func (mdc *ModelRouteCatalog) ProcessSwaggerJSON() (string, error) {
 	mdc.RLock()
 	defer mdc.RUnlock()
 	allURLs := map[string]interface{}{}
 
 	for _, route := range mdc.routeMap {
-
-		logger := mdc.log.With("route.id", route.ID)
-
-		taggedSwagger, err := TagSwaggerMethods([]string{route.ID}, route.Model.ServedModel.Swagger)
-		if err != nil {
-			logger.Errorw("Unable to tag route swagger urls", err)
+		if !route.Model.Health.Healthy {
 			continue
 		}
-		swaggerMap := map[string]interface{}{}
-		if err := json.Unmarshal(taggedSwagger.Raw, &swaggerMap); err != nil {
-			logger.Errorw("Unable to unmarshall route swagger", err)
-			continue
-		}
-
-		paths := swaggerMap["paths"].(map[string]interface{})
-
-		for url, data := range paths {
-			allURLs[url] = data
+		for _, servedModel := range route.Model.ServedModels {
+
+			logger := mdc.log.With("route.id", route.ID, "model.name", servedModel.Metadata.ModelName)
+
+			// Endpoints of a route which serves several models are grouped by models
+			tag := route.ID
+			if len(route.Model.ServedModels) > 1 {
+				tag = route.ID + "/" + servedModel.Metadata.ModelName
+			}
+
+			taggedSwagger, err := TagSwaggerMethods([]string{tag}, servedModel.Swagger)
+			if err != nil {
+				logger.Errorw("Unable to tag route swagger urls", err)
+				continue
+			}
+			swaggerMap := map[string]interface{}{}
+			if err := json.Unmarshal(taggedSwagger.Raw, &swaggerMap); err != nil {
+				logger.Errorw("Unable to unmarshall route swagger", err)
+				continue
+			}
+
+			paths := swaggerMap["paths"].(map[string]interface{})
+
+			for url, data := range paths {
+				allURLs[url] = data
+			}
 		}
 	}
 
@@ -175,6 +322,72 @@ func (mdc *ModelRouteCatalog) ProcessSwaggerJSON() (string, error) {
 	return buff.String(), nil
 }
 
+// ProcessOpenAPI3JSON combines URLs and schemas of all models in catalog into OpenAPI 3.0 document.
+// Endpoints are separated by tags like in ProcessSwaggerJSON. Schemas are prefixed by route.ID
+// (and model name if the route serves several models) to avoid name collisions. Routes with unhealthy models
+// are excluded
+func (mdc *ModelRouteCatalog) ProcessOpenAPI3JSON(serverURL string) (string, error) {
+	mdc.RLock()
+	defer mdc.RUnlock()
+	allURLs := map[string]interface{}{}
+	allSchemas := map[string]interface{}{}
+
+	for _, route := range mdc.routeMap {
+		if !route.Model.Health.Healthy {
+			continue
+		}
+		for _, servedModel := range route.Model.ServedModels {
+
+			logger := mdc.log.With("route.id", route.ID, "model.name", servedModel.Metadata.ModelName)
+
+			tag := route.ID
+			if len(route.Model.ServedModels) > 1 {
+				tag = route.ID + "/" + servedModel.Metadata.ModelName
+			}
+
+			taggedSwagger, err := TagSwaggerMethods([]string{tag}, servedModel.Swagger)
+			if err != nil {
+				logger.Errorw("Unable to tag route swagger urls", err)
+				continue
+			}
+			fragment, err := ConvertSwagger2ToOpenAPI3(taggedSwagger.Raw, tag)
+			if err != nil {
+				logger.Errorw("Unable to convert route swagger to OpenAPI 3", err)
+				continue
+			}
+
+			for url, data := range fragment.Paths {
+				allURLs[url] = data
+			}
+			for name, schema := range fragment.Schemas {
+				allSchemas[name] = schema
+			}
+		}
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"description": "Catalog of model services",
+			"title":       "Service Catalog",
+			"license": map[string]interface{}{
+				"name": "Apache 2.0",
+				"url":  "http://www.apache.org/licenses/LICENSE-2.0.html",
+			},
+			"version": "1.0",
+		},
+		"servers":    []interface{}{map[string]interface{}{"url": serverURL}},
+		"paths":      allURLs,
+		"components": map[string]interface{}{"schemas": allSchemas},
+	}
+
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(documentBytes), nil
+}
+
 func init() {
 	tmpl, err := template.New("swagger template").Parse(templateStr)
 	if err != nil {
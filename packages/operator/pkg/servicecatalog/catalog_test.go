@@ -81,3 +81,46 @@ func TestProcessSwaggerJSON(t *testing.T) {
 
 	assertJSONEqualFile(t, []byte(combinedSwagger), "testdata/combined_swagger.json")
 }
+
+func TestCreateOrUpdate_MultipleModels(t *testing.T) {
+	log, err := zap.NewDevelopment()
+	assert.NoError(t, err)
+	catalog := servicecatalog.NewModelRouteCatalog(log.Sugar())
+
+	modelSwagger := func(name string) model.Swagger2 {
+		return model.Swagger2{Raw: []byte(`{"paths": {"/v2/models/` + name + `/infer": {"post": {}}}}`)}
+	}
+
+	assert.NoError(t, catalog.CreateOrUpdate(servicecatalog.Route{
+		ID:     "triton",
+		Prefix: "/model/triton",
+		Model: model.DeployedModel{
+			DeploymentID: "triton",
+			ServedModels: []model.ServedModel{
+				{Metadata: model.Metadata{ModelName: "first"}, Swagger: modelSwagger("first")},
+				{Metadata: model.Metadata{ModelName: "second"}, Swagger: modelSwagger("second")},
+			},
+		},
+	}))
+
+	deployedModel, err := catalog.GetDeployedModel("triton")
+	assert.NoError(t, err)
+	assert.Len(t, deployedModel.ServedModels, 2)
+	assert.Equal(t, "first", deployedModel.ServedModel.Metadata.ModelName)
+	assert.Contains(t, string(deployedModel.ServedModels[1].Swagger.Raw), "/model/triton/v2/models/second/infer")
+
+	combinedSwagger, err := catalog.ProcessSwaggerJSON()
+	assert.NoError(t, err)
+
+	swagger := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(combinedSwagger), &swagger))
+	paths := swagger["paths"].(map[string]interface{})
+	assert.Equal(t,
+		map[string]interface{}{"post": map[string]interface{}{"tags": []interface{}{"triton/first"}}},
+		paths["/model/triton/v2/models/first/infer"],
+	)
+	assert.Equal(t,
+		map[string]interface{}{"post": map[string]interface{}{"tags": []interface{}{"triton/second"}}},
+		paths["/model/triton/v2/models/second/infer"],
+	)
+}
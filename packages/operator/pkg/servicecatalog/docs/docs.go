@@ -69,6 +69,79 @@ var doc = `{
                     }
                 }
             }
+        },
+        "/service-catalog/models": {
+            "get": {
+                "description": "List models served behind model routes. Every filter parameter can be repeated to match any of values",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List models of the catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model version",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Predictor of the deployment",
+                        "name": "predictor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prefix of the route URL prefix",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text in the title, description or operation summaries of the model swagger",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entities in a response",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of a page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CatalogModel"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/HTTPResult"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -81,6 +154,35 @@ var doc = `{
                 }
             }
         },
+        "CatalogModel": {
+            "type": "object",
+            "properties": {
+                "deploymentID": {
+                    "description": "deploymentID is ModelDeployment that deploys this model",
+                    "type": "string"
+                },
+                "health": {
+                    "type": "object",
+                    "$ref": "#/definitions/ModelHealth"
+                },
+                "metadata": {
+                    "type": "object",
+                    "$ref": "#/definitions/Metadata"
+                },
+                "predictor": {
+                    "description": "predictor of the deployment",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "prefix is URL prefix of the ModelRoute",
+                    "type": "string"
+                },
+                "routeID": {
+                    "description": "routeID is ModelRoute that exposes the model",
+                    "type": "string"
+                }
+            }
+        },
         "DeployedModel": {
             "type": "object",
             "properties": {
@@ -88,9 +190,25 @@ var doc = `{
                     "description": "deploymentID is ModelDeployment that deploys this model",
                     "type": "string"
                 },
+                "health": {
+                    "type": "object",
+                    "$ref": "#/definitions/ModelHealth"
+                },
+                "predictor": {
+                    "description": "predictor of the deployment",
+                    "type": "string"
+                },
                 "servedModel": {
+                    "description": "servedModel is the first of servedModels. Kept for clients which support only one model per deployment",
                     "type": "object",
                     "$ref": "#/definitions/ServedModel"
+                },
+                "servedModels": {
+                    "description": "servedModels are all models served behind the deployment",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ServedModel"
+                    }
                 }
             }
         },
@@ -112,6 +230,28 @@ var doc = `{
                 }
             }
         },
+        "ModelHealth": {
+            "type": "object",
+            "properties": {
+                "consecutiveFailures": {
+                    "description": "consecutiveFailures is the number of failed inspections since the last successful one",
+                    "type": "integer"
+                },
+                "healthy": {
+                    "description": "healthy is false if the last inspections failed consecutiveFailures times in a row",
+                    "type": "boolean"
+                },
+                "lastError": {
+                    "description": "lastError is the error of the last inspection if it failed",
+                    "type": "string"
+                },
+                "lastInspected": {
+                    "description": "lastInspected is the time of the last inspection",
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
         "ServedModel": {
             "type": "object",
             "properties": {
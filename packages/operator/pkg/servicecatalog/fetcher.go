@@ -1,6 +1,7 @@
 package servicecatalog
 
 import (
+	"context"
 	event_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/event"
 )
 
@@ -8,6 +9,7 @@ import (
 
 type RouteEventsAPIClient interface {
 	GetLastEvents(cursor int) (event_types.LatestRouteEvents, error)
+	GetSnapshot() (event_types.LatestRouteEvents, error)
 }
 
 type RouteEventFetcher struct {
@@ -21,6 +23,20 @@ func (d RouteEventFetcher) GetLastEvents(cursor int) (LatestGenericEvents, error
 	if err != nil {
 		return generic, err
 	}
+
+	return toGenericEvents(routeEvents), nil
+}
+
+func (d RouteEventFetcher) GetSnapshot() (LatestGenericEvents, error) {
+	routeEvents, err := d.APIClient.GetSnapshot()
+	if err != nil {
+		return LatestGenericEvents{}, err
+	}
+
+	return toGenericEvents(routeEvents), nil
+}
+
+func toGenericEvents(routeEvents event_types.LatestRouteEvents) (generic LatestGenericEvents) {
 	generic.Cursor = routeEvents.Cursor
 	for _, de := range routeEvents.Events {
 		generic.Events = append(generic.Events, GenericEvent{
@@ -29,6 +45,32 @@ func (d RouteEventFetcher) GetLastEvents(cursor int) (LatestGenericEvents, error
 		})
 	}
 
-	return generic, nil
+	return generic
 }
 
+type RouteEventsStreamAPIClient interface {
+	RouteEventsAPIClient
+	StreamEvents(ctx context.Context, cursor int, handle func(events event_types.LatestRouteEvents) error) error
+}
+
+// StreamingRouteEventFetcher is RouteEventFetcher that receives new events from the API server
+// event stream instead of polling
+type StreamingRouteEventFetcher struct {
+	RouteEventFetcher
+	StreamClient RouteEventsStreamAPIClient
+}
+
+func NewStreamingRouteEventFetcher(client RouteEventsStreamAPIClient) StreamingRouteEventFetcher {
+	return StreamingRouteEventFetcher{
+		RouteEventFetcher: RouteEventFetcher{APIClient: client},
+		StreamClient:      client,
+	}
+}
+
+func (d StreamingRouteEventFetcher) Stream(
+	ctx context.Context, cursor int, handle func(events LatestGenericEvents)) error {
+	return d.StreamClient.StreamEvents(ctx, cursor, func(routeEvents event_types.LatestRouteEvents) error {
+		handle(toGenericEvents(routeEvents))
+		return nil
+	})
+}
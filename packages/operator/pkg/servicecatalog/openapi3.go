@@ -0,0 +1,331 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const (
+	swagger2RefPrefix       = "#/definitions/"
+	openAPI3SchemaRefPrefix = "#/components/schemas/"
+	defaultMediaType        = "application/json"
+)
+
+var (
+	swagger2Methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+	// Component names must match ^[a-zA-Z0-9\.\-_]+$
+	invalidComponentNameChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]`)
+	responseCodeRegex         = regexp.MustCompile(`^([1-5][0-9X]{2}|default)$`)
+)
+
+// OpenAPI3Fragment is a part of OpenAPI 3.0 document converted from a Swagger 2.0 document
+type OpenAPI3Fragment struct {
+	Paths   map[string]interface{}
+	Schemas map[string]interface{}
+}
+
+// ConvertSwagger2ToOpenAPI3 converts paths and definitions of Swagger 2.0 document to OpenAPI 3.0.
+// Definitions become component schemas, names of the schemas and operation IDs are prefixed
+// by schemaPrefix to avoid collisions with fragments of other documents
+func ConvertSwagger2ToOpenAPI3(raw []byte, schemaPrefix string) (fragment OpenAPI3Fragment, err error) {
+	swagger := map[string]interface{}{}
+	if err = json.Unmarshal(raw, &swagger); err != nil {
+		return fragment, err
+	}
+
+	schemaPrefix = invalidComponentNameChars.ReplaceAllString(schemaPrefix, "_")
+	refPrefix := openAPI3SchemaRefPrefix + schemaPrefix + "_"
+
+	fragment.Schemas = map[string]interface{}{}
+	definitions, _ := swagger["definitions"].(map[string]interface{})
+	for name, definition := range definitions {
+		schemaName := schemaPrefix + "_" + invalidComponentNameChars.ReplaceAllString(name, "_")
+		fragment.Schemas[schemaName] = convertSchema(definition, refPrefix)
+	}
+
+	consumes := stringList(swagger["consumes"], []string{defaultMediaType})
+	produces := stringList(swagger["produces"], []string{defaultMediaType})
+
+	fragment.Paths = map[string]interface{}{}
+	paths, _ := swagger["paths"].(map[string]interface{})
+	for url, pathItemValue := range paths {
+		pathItem, ok := pathItemValue.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		convertedPathItem := map[string]interface{}{}
+		if parameters, ok := pathItem["parameters"].([]interface{}); ok {
+			convertedPathItem["parameters"], _, _ = convertParameters(parameters, consumes, refPrefix)
+		}
+		for _, method := range swagger2Methods {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			convertedPathItem[method] = convertOperation(operation, consumes, produces, schemaPrefix, refPrefix)
+		}
+		fragment.Paths[url] = convertedPathItem
+	}
+
+	return fragment, nil
+}
+
+func convertOperation(
+	operation map[string]interface{}, consumes, produces []string, schemaPrefix, refPrefix string,
+) map[string]interface{} {
+	converted := map[string]interface{}{}
+	for _, key := range []string{"summary", "description", "tags", "deprecated", "externalDocs"} {
+		if value, ok := operation[key]; ok {
+			converted[key] = value
+		}
+	}
+	if operationID, ok := operation["operationId"].(string); ok {
+		converted["operationId"] = schemaPrefix + "_" + operationID
+	}
+
+	consumes = stringList(operation["consumes"], consumes)
+	produces = stringList(operation["produces"], produces)
+
+	if parameters, ok := operation["parameters"].([]interface{}); ok {
+		params, requestBody, hasBody := convertParameters(parameters, consumes, refPrefix)
+		if len(params) > 0 {
+			converted["parameters"] = params
+		}
+		if hasBody {
+			converted["requestBody"] = requestBody
+		}
+	}
+
+	responses := map[string]interface{}{}
+	swaggerResponses, _ := operation["responses"].(map[string]interface{})
+	for code, responseValue := range swaggerResponses {
+		response, ok := responseValue.(map[string]interface{})
+		if !ok || !responseCodeRegex.MatchString(code) {
+			continue
+		}
+		responses[code] = convertResponse(response, produces, refPrefix)
+	}
+	if len(responses) == 0 {
+		responses["default"] = map[string]interface{}{"description": ""}
+	}
+	converted["responses"] = responses
+
+	return converted
+}
+
+// convertParameters converts body and formData parameters to the request body and the others to parameters
+func convertParameters(
+	parameters []interface{}, consumes []string, refPrefix string,
+) (converted []interface{}, requestBody map[string]interface{}, hasBody bool) {
+	converted = []interface{}{}
+	formProperties := map[string]interface{}{}
+	var formRequired []string
+	hasFile := false
+
+	for _, parameterValue := range parameters {
+		parameter, ok := parameterValue.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if ref, ok := parameter["$ref"].(string); ok {
+			converted = append(converted, map[string]interface{}{"$ref": ref})
+			continue
+		}
+
+		name, _ := parameter["name"].(string)
+		required, _ := parameter["required"].(bool)
+
+		switch parameter["in"] {
+		case "body":
+			requestBody = map[string]interface{}{
+				"required": required,
+				"content":  mediaTypes(consumes, convertSchema(parameter["schema"], refPrefix)),
+			}
+			if description, ok := parameter["description"]; ok {
+				requestBody["description"] = description
+			}
+			hasBody = true
+		case "formData":
+			schema := parameterSchema(parameter, refPrefix)
+			if schema["type"] == "string" && schema["format"] == "binary" {
+				hasFile = true
+			}
+			formProperties[name] = schema
+			if required {
+				formRequired = append(formRequired, name)
+			}
+		default:
+			convertedParameter := map[string]interface{}{
+				"name":   name,
+				"in":     parameter["in"],
+				"schema": parameterSchema(parameter, refPrefix),
+			}
+			if required || parameter["in"] == "path" {
+				convertedParameter["required"] = true
+			}
+			if description, ok := parameter["description"]; ok {
+				convertedParameter["description"] = description
+			}
+			converted = append(converted, convertedParameter)
+		}
+	}
+
+	if len(formProperties) > 0 && !hasBody {
+		formSchema := map[string]interface{}{"type": "object", "properties": formProperties}
+		if len(formRequired) > 0 {
+			formSchema["required"] = formRequired
+		}
+		mediaType := "application/x-www-form-urlencoded"
+		if hasFile {
+			mediaType = "multipart/form-data"
+		}
+		requestBody = map[string]interface{}{
+			"content": map[string]interface{}{mediaType: map[string]interface{}{"schema": formSchema}},
+		}
+		hasBody = true
+	}
+
+	return converted, requestBody, hasBody
+}
+
+func convertResponse(response map[string]interface{}, produces []string, refPrefix string) map[string]interface{} {
+	description, _ := response["description"].(string)
+	converted := map[string]interface{}{"description": description}
+
+	schema, hasSchema := response["schema"]
+	if !hasSchema {
+		// Some model servers describe response objects inline without the schema keyword
+		if properties, ok := response["properties"]; ok {
+			schema = map[string]interface{}{"type": "object", "properties": properties}
+			hasSchema = true
+		}
+	}
+	if hasSchema {
+		converted["content"] = mediaTypes(produces, convertSchema(schema, refPrefix))
+	}
+
+	if headers, ok := response["headers"].(map[string]interface{}); ok {
+		convertedHeaders := map[string]interface{}{}
+		for name, headerValue := range headers {
+			header, ok := headerValue.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			convertedHeader := map[string]interface{}{"schema": parameterSchema(header, refPrefix)}
+			if headerDescription, ok := header["description"]; ok {
+				convertedHeader["description"] = headerDescription
+			}
+			convertedHeaders[name] = convertedHeader
+		}
+		converted["headers"] = convertedHeaders
+	}
+
+	return converted
+}
+
+// parameterSchema builds a schema from a non-body parameter, which holds the schema keywords itself
+func parameterSchema(parameter map[string]interface{}, refPrefix string) map[string]interface{} {
+	schema := map[string]interface{}{}
+	for _, key := range []string{
+		"type", "format", "items", "enum", "default", "minimum", "maximum", "minLength", "maxLength", "pattern",
+	} {
+		if value, ok := parameter[key]; ok {
+			schema[key] = value
+		}
+	}
+	converted, _ := convertSchema(schema, refPrefix).(map[string]interface{})
+	return converted
+}
+
+// convertSchema rewrites references to definitions and replaces Swagger 2.0 specific keywords
+func convertSchema(schema interface{}, refPrefix string) interface{} {
+	switch typed := schema.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, value := range typed {
+			switch key {
+			case "$ref":
+				ref, _ := value.(string)
+				if strings.HasPrefix(ref, swagger2RefPrefix) {
+					name := strings.TrimPrefix(ref, swagger2RefPrefix)
+					value = refPrefix + invalidComponentNameChars.ReplaceAllString(name, "_")
+				}
+				converted[key] = value
+			case "x-nullable":
+				converted["nullable"] = value
+			case "type":
+				if value == "file" {
+					converted["type"] = "string"
+					converted["format"] = "binary"
+				} else {
+					converted[key] = value
+				}
+			case "example", "enum", "default", "required":
+				converted[key] = value
+			case "properties":
+				// Keys of properties are names, not keywords
+				properties, _ := value.(map[string]interface{})
+				convertedProperties := make(map[string]interface{}, len(properties))
+				for name, property := range properties {
+					convertedProperties[name] = convertSchema(property, refPrefix)
+				}
+				converted[key] = convertedProperties
+			default:
+				converted[key] = convertSchema(value, refPrefix)
+			}
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, 0, len(typed))
+		for _, item := range typed {
+			converted = append(converted, convertSchema(item, refPrefix))
+		}
+		return converted
+	default:
+		return schema
+	}
+}
+
+func mediaTypes(types []string, schema interface{}) map[string]interface{} {
+	content := make(map[string]interface{}, len(types))
+	for _, mediaType := range types {
+		content[mediaType] = map[string]interface{}{"schema": schema}
+	}
+	return content
+}
+
+// stringList returns the list of strings or defaults if the value is not a non-empty list
+func stringList(value interface{}, defaults []string) []string {
+	values, ok := value.([]interface{})
+	if !ok || len(values) == 0 {
+		return defaults
+	}
+
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog_test
+
+import (
+	"encoding/json"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/servicecatalog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"testing"
+)
+
+const swaggerWithDefinitions = `{
+	"swagger": "2.0",
+	"consumes": ["application/json"],
+	"produces": ["application/json"],
+	"paths": {
+		"/predict/{version}": {
+			"post": {
+				"operationId": "predict",
+				"parameters": [
+					{"in": "path", "name": "version", "type": "string"},
+					{"in": "query", "name": "verbose", "type": "boolean", "required": false},
+					{"in": "body", "name": "body", "required": true, "schema": {"$ref": "#/definitions/Request"}}
+				],
+				"responses": {
+					"200": {"description": "Prediction", "schema": {"$ref": "#/definitions/Response"}}
+				}
+			}
+		},
+		"/upload": {
+			"post": {
+				"consumes": ["multipart/form-data"],
+				"parameters": [{"in": "formData", "name": "file", "type": "file", "required": true}],
+				"responses": {"204": {"description": "Uploaded"}}
+			}
+		}
+	},
+	"definitions": {
+		"Request": {
+			"type": "object",
+			"properties": {
+				"type": {"$ref": "#/definitions/Tensor"},
+				"comment": {"type": "string", "x-nullable": true}
+			}
+		},
+		"Tensor": {"type": "array", "items": {"type": "number"}},
+		"Response": {"type": "object", "properties": {"result": {"$ref": "#/definitions/Tensor"}}}
+	}
+}`
+
+func toJSONMap(t *testing.T, value interface{}) map[string]interface{} {
+	raw, err := json.Marshal(value)
+	assert.NoError(t, err)
+	result := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(raw, &result))
+	return result
+}
+
+func TestConvertSwagger2ToOpenAPI3(t *testing.T) {
+	fragment, err := servicecatalog.ConvertSwagger2ToOpenAPI3([]byte(swaggerWithDefinitions), "my route")
+	assert.NoError(t, err)
+
+	assert.Len(t, fragment.Schemas, 3)
+	schemas := toJSONMap(t, fragment.Schemas)
+	request := schemas["my_route_Request"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/my_route_Tensor"}, request["type"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "nullable": true}, request["comment"])
+
+	paths := toJSONMap(t, fragment.Paths)
+	predict := paths["/predict/{version}"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "my_route_predict", predict["operationId"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name": "version", "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"},
+		},
+		map[string]interface{}{
+			"name": "verbose", "in": "query", "schema": map[string]interface{}{"type": "boolean"},
+		},
+	}, predict["parameters"])
+	assert.Equal(t, map[string]interface{}{
+		"required": true,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/my_route_Request"},
+			},
+		},
+	}, predict["requestBody"])
+	assert.Equal(t, map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "Prediction",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/my_route_Response"},
+				},
+			},
+		},
+	}, predict["responses"])
+
+	upload := paths["/upload"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"content": map[string]interface{}{
+			"multipart/form-data": map[string]interface{}{
+				"schema": map[string]interface{}{
+					"type":       "object",
+					"required":   []interface{}{"file"},
+					"properties": map[string]interface{}{"file": map[string]interface{}{"type": "string", "format": "binary"}},
+				},
+			},
+		},
+	}, upload["requestBody"])
+}
+
+func TestConvertSwagger2ToOpenAPI3_InlineResponse(t *testing.T) {
+	raw, err := ioutil.ReadFile("testdata/swagger.json")
+	assert.NoError(t, err)
+
+	fragment, err := servicecatalog.ConvertSwagger2ToOpenAPI3(raw, "simple")
+	assert.NoError(t, err)
+
+	paths := toJSONMap(t, fragment.Paths)
+	responses := paths["/api/model/invoke"].(map[string]interface{})["post"].(map[string]interface{})["responses"]
+	// Not a response code
+	assert.NotContains(t, responses, "type")
+	content := responses.(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})
+	schema := content["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.Equal(t, "object", schema["type"])
+	assert.Contains(t, schema["properties"], "prediction")
+}
+
+func TestProcessOpenAPI3JSON(t *testing.T) {
+	log, err := zap.NewDevelopment()
+	assert.NoError(t, err)
+	catalog := servicecatalog.NewModelRouteCatalog(log.Sugar())
+
+	for _, id := range []string{"first", "second"} {
+		assert.NoError(t, catalog.CreateOrUpdate(servicecatalog.Route{
+			ID:     id,
+			Prefix: "/model/" + id,
+			Model: model.DeployedModel{
+				DeploymentID: id,
+				ServedModel:  model.ServedModel{Swagger: model.Swagger2{Raw: []byte(swaggerWithDefinitions)}},
+			},
+		}))
+	}
+
+	document, err := catalog.ProcessOpenAPI3JSON("https://edge.example.com")
+	assert.NoError(t, err)
+
+	openAPI := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(document), &openAPI))
+
+	assert.Equal(t, "3.0.3", openAPI["openapi"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://edge.example.com"}}, openAPI["servers"])
+
+	// Definitions with the same names do not collide
+	schemas := openAPI["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assert.Len(t, schemas, 6)
+	assert.Contains(t, schemas, "first_Request")
+	assert.Contains(t, schemas, "second_Request")
+
+	paths := openAPI["paths"].(map[string]interface{})
+	assert.Len(t, paths, 4)
+	predict := paths["/model/second/predict/{version}"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"second"}, predict["tags"])
+	assert.Equal(t, "second_predict", predict["operationId"])
+}
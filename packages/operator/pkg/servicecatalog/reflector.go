@@ -3,6 +3,7 @@ package servicecatalog
 import (
 	"context"
 	"github.com/google/uuid"
+	odahu_errors "github.com/odahu/odahu-flow/packages/operator/pkg/errors"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"k8s.io/client-go/util/workqueue"
@@ -17,6 +18,26 @@ type EventFetcher interface {
 	// If returned error implements interface with func Temporary() and Temporary() == true
 	// then attempt to fetch events will be retried
 	GetLastEvents(cursor int) (LatestGenericEvents, error)
+	// GetSnapshot get the last event of each entity and the cursor to continue fetching from.
+	// It is used when GetLastEvents returns CursorCompactedError, because the event source already
+	// removed some events after the cursor
+	GetSnapshot() (LatestGenericEvents, error)
+}
+
+// EventStreamer is EventFetcher that can push new events instead of being polled.
+// Reflector uses streaming if its EventFetcher implements this interface
+type EventStreamer interface {
+	// Stream blocks and calls handle for each batch of new events after the cursor
+	// until ctx is done or the stream is closed. Errors are treated in the same way as GetLastEvents errors.
+	// CursorCompactedError is returned if the event source already removed some events after the cursor
+	Stream(ctx context.Context, cursor int, handle func(events LatestGenericEvents)) error
+}
+
+// CursorStore persists the cursor of handled events. Reflector resumes fetching from the saved cursor
+// after restart, so only events that happened after it are handled again
+type CursorStore interface {
+	Cursor() int
+	SaveCursor(cursor int) error
 }
 
 // EventHandler process event somehow
@@ -55,14 +76,32 @@ type Reflector struct {
 	workersCount int
 	queue        workqueue.RateLimitingInterface
 	eventCache   *eventCache
+	cursorStore  CursorStore
+	progress     *progress
+}
+
+// progress tracks the cursor of handled events and whether reflector caught up with the event source
+type progress struct {
+	mu *sync.Mutex
+	// Cursor of the last fetched events
+	fetched int
+	// Cursor which was saved into CursorStore
+	saved int
+	// Reflector fetched all events which happened before its start
+	caughtUp bool
+	// Entities of events fetched during catching up that were not processed yet
+	pending map[interface{}]struct{}
 }
 
 // ReflectorOpts are options for Reflector
 type ReflectorOpts struct {
 	// How many concurrent workers will process event using EventHandler.Handle
 	WorkersCount int
-	// How often EventFetcher will fetch new events from source
+	// How often EventFetcher will fetch new events from source.
+	// If EventFetcher is EventStreamer then it is a delay before reopening of a closed stream
 	FetchTimeout time.Duration
+	// If CursorStore is set, reflector starts from the saved cursor and saves the cursor of handled events
+	CursorStore CursorStore
 }
 
 func NewReflector(log *zap.SugaredLogger, handler EventHandler, fetcher EventFetcher,
@@ -79,6 +118,11 @@ func NewReflector(log *zap.SugaredLogger, handler EventHandler, fetcher EventFet
 		},
 		workersCount: 1,
 		fetchTimeout: 10 * time.Second,
+		cursorStore:  opts.CursorStore,
+		progress: &progress{
+			mu:      &sync.Mutex{},
+			pending: map[interface{}]struct{}{},
+		},
 	}
 
 	if opts.WorkersCount != 0 {
@@ -161,6 +205,7 @@ func (r Reflector) runProcessor(log *zap.SugaredLogger) {
 		log.Info("Start processing event")
 		err := r.handler.Handle(event.event, log)
 		r.queue.Done(EntityID)
+		r.markProcessed(EntityID)
 		if err != nil {
 			log.Errorf("Error during processing event: %s. Retry", err.Error())
 			r.queue.AddRateLimited(EntityID)
@@ -169,11 +214,138 @@ func (r Reflector) runProcessor(log *zap.SugaredLogger) {
 		log.Info("Event was processed successfully")
 		r.queue.Forget(EntityID)
 		r.eventCache.delete(EntityID, event.version)
+		r.saveCursor(log)
+	}
+}
+
+// Ready returns true if reflector caught up with the event source after start
+// and every event fetched during catching up was processed at least once
+func (r Reflector) Ready() bool {
+	r.progress.mu.Lock()
+	defer r.progress.mu.Unlock()
+	return r.progress.caughtUp && len(r.progress.pending) == 0
+}
+
+func (r Reflector) markProcessed(entityID interface{}) {
+	r.progress.mu.Lock()
+	defer r.progress.mu.Unlock()
+	delete(r.progress.pending, entityID)
+}
+
+// saveCursor saves the cursor before the oldest event that is not handled yet
+func (r Reflector) saveCursor(log *zap.SugaredLogger) {
+	if r.cursorStore == nil {
+		return
+	}
+
+	r.progress.mu.Lock()
+	defer r.progress.mu.Unlock()
+
+	cursor := r.progress.fetched
+	if version, ok := r.eventCache.minVersion(); ok && version < cursor {
+		cursor = version
+	}
+	if cursor <= r.progress.saved {
+		return
+	}
+
+	if err := r.cursorStore.SaveCursor(cursor); err != nil {
+		log.Errorw("Unable to save cursor", "cursor", cursor, zap.Error(err))
+		return
+	}
+	r.progress.saved = cursor
+}
+
+// startCursor returns the cursor to start fetching from
+func (r Reflector) startCursor() int {
+	if r.cursorStore == nil {
+		return 0
+	}
+
+	cursor := r.cursorStore.Cursor()
+	r.progress.mu.Lock()
+	defer r.progress.mu.Unlock()
+	r.progress.fetched = cursor
+	r.progress.saved = cursor
+	return cursor
+}
+
+// fetch gets events after the cursor. It resumes from snapshot if events after the cursor were compacted
+func (r Reflector) fetch(cursor int, log *zap.SugaredLogger) (LatestGenericEvents, error) {
+	lastEvents, err := r.fetcher.GetLastEvents(cursor)
+	if odahu_errors.IsCursorCompactedError(err) {
+		log.Warnw("Events after cursor were compacted. Resume from snapshot",
+			"cursor", cursor, zap.Error(err))
+		lastEvents, err = r.fetcher.GetSnapshot()
+	}
+	return lastEvents, err
+}
+
+// catchUp fetches all events which happened before the reflector start and returns the cursor after them
+func (r Reflector) catchUp(ctx context.Context, cursor int) (int, error) {
+	log := r.log.With("Component", "catchUp")
+	log.Infow("Catch up with the event source", "cursor", cursor)
+
+	for {
+		lastEvents, err := r.fetch(cursor, log)
+		if err != nil {
+			log.Errorw("Unable to get last events", zap.Error(err))
+			if !IsTemporary(err) {
+				return cursor, err
+			}
+
+			select {
+			case <-ctx.Done():
+				return cursor, nil
+			case <-time.After(r.fetchTimeout):
+			}
+			continue
+		}
+
+		if lastEvents.Cursor <= cursor {
+			break
+		}
+
+		r.progress.mu.Lock()
+		for _, event := range lastEvents.Events {
+			r.progress.pending[event.EntityID] = struct{}{}
+		}
+		r.progress.mu.Unlock()
+
+		cursor = r.moveCursor(lastEvents, cursor, log)
 	}
+
+	r.progress.mu.Lock()
+	r.progress.caughtUp = true
+	r.progress.mu.Unlock()
+	log.Infow("Reflector caught up with the event source", "cursor", cursor)
+
+	return cursor, nil
+}
+
+// moveCursor enqueues events and returns the new cursor
+func (r Reflector) moveCursor(lastEvents LatestGenericEvents, cursor int, log *zap.SugaredLogger) int {
+	r.enqueue(lastEvents, cursor, log)
+
+	r.progress.mu.Lock()
+	r.progress.fetched = lastEvents.Cursor
+	r.progress.mu.Unlock()
+	r.saveCursor(log)
+
+	log.Infow("Move cursor further", "oldCursor", cursor, "cursor", lastEvents.Cursor)
+	return lastEvents.Cursor
 }
 
 func (r Reflector) runFetcher(ctx context.Context) error {
-	var cursor int
+	cursor, err := r.catchUp(ctx, r.startCursor())
+	if err != nil {
+		return err
+	}
+
+	if streamer, ok := r.fetcher.(EventStreamer); ok {
+		return r.runStreamer(ctx, streamer, cursor)
+	}
+
 	t := time.NewTicker(r.fetchTimeout)
 
 	for {
@@ -184,7 +356,7 @@ func (r Reflector) runFetcher(ctx context.Context) error {
 		case  <-t.C:
 			fetchingJobID := uuid.New().String()
 			log := r.log.With("FetchingJobID", fetchingJobID, "Component", "runFetcher")
-			lastEvents, err := r.fetcher.GetLastEvents(cursor)
+			lastEvents, err := r.fetch(cursor, log)
 			if err != nil {
 				log.Errorw("Unable to get last events", zap.Error(err))
 
@@ -200,22 +372,63 @@ func (r Reflector) runFetcher(ctx context.Context) error {
 				continue
 			}
 
-			for _, event := range lastEvents.Events {
-				log := log.With("EntityID", event.EntityID)
-				r.queue.Add(event.EntityID)
-				log.Info("Event's EntityID is added to queue")
-				r.eventCache.put(event.EntityID, versionedEvent{
-					event:   event.Event,
-					version: cursor,
-				})
-				log.Info("Event added to event cache", "GenericEvent", event)
+			cursor = r.moveCursor(lastEvents, cursor, log)
+		}
+
+	}
+}
+
+func (r Reflector) runStreamer(ctx context.Context, streamer EventStreamer, cursor int) error {
+	for {
+		streamingJobID := uuid.New().String()
+		log := r.log.With("StreamingJobID", streamingJobID, "Component", "runStreamer")
+		moveCursor := func(lastEvents LatestGenericEvents) {
+			if lastEvents.Cursor <= cursor {
+				return
+			}
+			cursor = r.moveCursor(lastEvents, cursor, log)
+		}
+
+		log.Infow("Open event stream", "cursor", cursor)
+		err := streamer.Stream(ctx, cursor, moveCursor)
+		if odahu_errors.IsCursorCompactedError(err) {
+			log.Warnw("Events after cursor were compacted. Resume from snapshot",
+				"cursor", cursor, zap.Error(err))
+			var snapshot LatestGenericEvents
+			if snapshot, err = r.fetcher.GetSnapshot(); err == nil {
+				moveCursor(snapshot)
+				continue
+			}
+		}
+		if err != nil {
+			log.Errorw("Unable to stream events", zap.Error(err))
+
+			if !IsTemporary(err) {
+				return err
 			}
 
-			oldCursor := cursor
-			cursor = lastEvents.Cursor
-			log.Infow("Move cursor further", "oldCursor", oldCursor, "cursor", cursor)
+			log.Warnw("Temporary error during event streaming.", zap.Error(err))
+		}
+
+		// Stream was closed by the event source or failed temporary. It is reopened after a pause
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.fetchTimeout):
 		}
+	}
+}
 
+func (r Reflector) enqueue(lastEvents LatestGenericEvents, version int, log *zap.SugaredLogger) {
+	for _, event := range lastEvents.Events {
+		log := log.With("EntityID", event.EntityID)
+		r.queue.Add(event.EntityID)
+		log.Info("Event's EntityID is added to queue")
+		r.eventCache.put(event.EntityID, versionedEvent{
+			event:   event.Event,
+			version: version,
+		})
+		log.Info("Event added to event cache", "GenericEvent", event)
 	}
 }
 
@@ -241,6 +454,19 @@ func (e *eventCache) put(key interface{}, obj versionedEvent) {
 	e.store[key] = obj
 }
 
+// minVersion returns the lowest version of cached events
+func (e *eventCache) minVersion() (version int, ok bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for _, event := range e.store {
+		if !ok || event.version < version {
+			version = event.version
+			ok = true
+		}
+	}
+	return version, ok
+}
+
 // delete happens only if passed version equals to version of event in cache
 // To avoid situations when we delete
 func (e *eventCache) delete(key interface{}, version int) {
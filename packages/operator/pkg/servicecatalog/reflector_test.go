@@ -0,0 +1,156 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog_test
+
+import (
+	"context"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/servicecatalog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventLog returns events after the cursor. Cursor is a number of events
+type eventLog struct {
+	mu       sync.Mutex
+	entities []string
+	cursors  []int
+}
+
+func (l *eventLog) GetLastEvents(cursor int) (events servicecatalog.LatestGenericEvents, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cursors = append(l.cursors, cursor)
+
+	events.Cursor = len(l.entities)
+	for i := cursor; i < len(l.entities); i++ {
+		events.Events = append(events.Events, servicecatalog.GenericEvent{EntityID: l.entities[i], Event: l.entities[i]})
+	}
+	return events, nil
+}
+
+func (l *eventLog) GetSnapshot() (servicecatalog.LatestGenericEvents, error) {
+	return l.GetLastEvents(0)
+}
+
+func (l *eventLog) firstCursor() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cursors[0]
+}
+
+type recordingHandler struct {
+	mu      sync.Mutex
+	handled []string
+	failing map[string]bool
+}
+
+func (h *recordingHandler) Handle(event interface{}, _ *zap.SugaredLogger) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handled = append(h.handled, event.(string))
+	if h.failing[event.(string)] {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+func (h *recordingHandler) handledEvents() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string{}, h.handled...)
+}
+
+type memoryCursorStore struct {
+	mu     sync.Mutex
+	cursor int
+}
+
+func (s *memoryCursorStore) Cursor() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor
+}
+
+func (s *memoryCursorStore) SaveCursor(cursor int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursor = cursor
+	return nil
+}
+
+func runReflector(t *testing.T, reflector servicecatalog.Reflector) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, reflector.Run(ctx))
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestReflector_WarmRestart(t *testing.T) {
+	log, err := zap.NewDevelopment()
+	assert.NoError(t, err)
+
+	fetcher := &eventLog{entities: []string{"a", "b", "c"}}
+	handler := &recordingHandler{}
+	cursorStore := &memoryCursorStore{cursor: 2}
+
+	reflector := servicecatalog.NewReflector(log.Sugar(), handler, fetcher, servicecatalog.ReflectorOpts{
+		FetchTimeout: 10 * time.Millisecond,
+		CursorStore:  cursorStore,
+	})
+	stop := runReflector(t, reflector)
+	defer stop()
+
+	assert.Eventually(t, reflector.Ready, time.Second, 5*time.Millisecond)
+	// Only the delta after the saved cursor is reconciled
+	assert.Equal(t, 2, fetcher.firstCursor())
+	assert.Equal(t, []string{"c"}, handler.handledEvents())
+	assert.Eventually(t, func() bool { return cursorStore.Cursor() == 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestReflector_CursorIsNotSavedBeforeFailedEvent(t *testing.T) {
+	log, err := zap.NewDevelopment()
+	assert.NoError(t, err)
+
+	fetcher := &eventLog{entities: []string{"a", "b"}}
+	handler := &recordingHandler{failing: map[string]bool{"b": true}}
+	cursorStore := &memoryCursorStore{}
+
+	reflector := servicecatalog.NewReflector(log.Sugar(), handler, fetcher, servicecatalog.ReflectorOpts{
+		FetchTimeout: 10 * time.Millisecond,
+		CursorStore:  cursorStore,
+	})
+	assert.False(t, reflector.Ready())
+
+	stop := runReflector(t, reflector)
+	defer stop()
+
+	// Reflector is ready when every event was processed once even if some of them failed
+	assert.Eventually(t, reflector.Ready, time.Second, 5*time.Millisecond)
+	// Both events were fetched in one batch after the 0 cursor, so the failed one holds the cursor
+	assert.Equal(t, 0, cursorStore.Cursor())
+}
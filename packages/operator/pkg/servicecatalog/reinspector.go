@@ -0,0 +1,120 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog
+
+import (
+	"context"
+	model_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"go.uber.org/zap"
+	"time"
+)
+
+// RouteInspector inspects models served behind a route of the catalog
+type RouteInspector interface {
+	InspectRoute(route Route, log *zap.SugaredLogger) ([]model_types.ServedModel, error)
+}
+
+// InspectedCatalog is a catalog which routes are inspected again by Reinspector
+type InspectedCatalog interface {
+	Routes() []Route
+	UpdateInspection(route Route, models []model_types.ServedModel, health model_types.ModelHealth) error
+}
+
+// Reinspector periodically inspects models of all routes in the catalog. Routes are inspected only on
+// route events otherwise, so changes of a model server schema or its failures would never be reflected.
+//
+// Successful inspection refreshes models of the route and resets its health.
+// Route becomes unhealthy after unhealthyThreshold failed inspections in a row,
+// but its previously inspected models are kept until the route is inspected successfully again
+type Reinspector struct {
+	log                *zap.SugaredLogger
+	inspector          RouteInspector
+	catalog            InspectedCatalog
+	period             time.Duration
+	unhealthyThreshold int
+}
+
+func NewReinspector(
+	log *zap.SugaredLogger, inspector RouteInspector, catalog InspectedCatalog,
+	period time.Duration, unhealthyThreshold int,
+) Reinspector {
+	if unhealthyThreshold < 1 {
+		unhealthyThreshold = 1
+	}
+	return Reinspector{
+		log:                log.With("component", "reinspector"),
+		inspector:          inspector,
+		catalog:            catalog,
+		period:             period,
+		unhealthyThreshold: unhealthyThreshold,
+	}
+}
+
+// Run inspects all routes every period until ctx is done
+func (r Reinspector) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, route := range r.catalog.Routes() {
+				if ctx.Err() != nil {
+					return
+				}
+				r.reinspect(route)
+			}
+		}
+	}
+}
+
+func (r Reinspector) reinspect(route Route) {
+	log := r.log.With("route_id", route.ID, "prefix", route.Prefix)
+
+	models, err := r.inspector.InspectRoute(route, log)
+	health := nextHealth(route.Model.Health, err, r.unhealthyThreshold)
+	if err != nil {
+		log.Warnw("Route inspection failed", "failures", health.ConsecutiveFailures, zap.Error(err))
+		if route.Model.Health.Healthy && !health.Healthy {
+			log.Errorw("Route became unhealthy", "failures", health.ConsecutiveFailures)
+		}
+	}
+
+	if err := r.catalog.UpdateInspection(route, models, health); err != nil {
+		log.Errorw("Unable to update route inspection", zap.Error(err))
+	}
+}
+
+// nextHealth returns the route health after the inspection which finished with inspectErr
+func nextHealth(
+	health model_types.ModelHealth, inspectErr error, unhealthyThreshold int,
+) model_types.ModelHealth {
+	if inspectErr == nil {
+		return model_types.ModelHealth{Healthy: true, LastInspected: time.Now()}
+	}
+
+	health.LastInspected = time.Now()
+	health.LastError = inspectErr.Error()
+	health.ConsecutiveFailures++
+	if health.ConsecutiveFailures >= unhealthyThreshold {
+		health.Healthy = false
+	}
+	return health
+}
@@ -0,0 +1,152 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog_test
+
+import (
+	"context"
+	"errors"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/servicecatalog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// switchableInspector returns models or fails depending on the current state
+type switchableInspector struct {
+	lock   sync.Mutex
+	err    error
+	path   string
+	called int
+}
+
+func (i *switchableInspector) InspectRoute(_ servicecatalog.Route, _ *zap.SugaredLogger) ([]model.ServedModel, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.called++
+	if i.err != nil {
+		return nil, i.err
+	}
+	return []model.ServedModel{{
+		Metadata: model.Metadata{ModelName: "model"},
+		Swagger:  model.Swagger2{Raw: []byte(`{"paths": {"` + i.path + `": {}}}`)},
+	}}, nil
+}
+
+func (i *switchableInspector) set(path string, err error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.path, i.err, i.called = path, err, 0
+}
+
+func (i *switchableInspector) calls() int {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.called
+}
+
+func TestReinspector(t *testing.T) {
+	log, err := zap.NewDevelopment()
+	assert.NoError(t, err)
+	catalog := servicecatalog.NewModelRouteCatalog(log.Sugar())
+	assert.NoError(t, catalog.CreateOrUpdate(servicecatalog.Route{
+		ID:     "route",
+		Prefix: "/model/route",
+		Model: model.DeployedModel{
+			DeploymentID: "route",
+			ServedModel:  model.ServedModel{Swagger: model.Swagger2{Raw: []byte(`{"paths": {"/predict": {}}}`)}},
+		},
+	}))
+
+	inspector := &switchableInspector{}
+	inspector.set("/predict", errors.New("connection refused"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reinspector := servicecatalog.NewReinspector(log.Sugar(), inspector, catalog, 10*time.Millisecond, 2)
+	go reinspector.Run(ctx)
+
+	health := func() model.ModelHealth {
+		deployedModel, err := catalog.GetDeployedModel("route")
+		assert.NoError(t, err)
+		return deployedModel.Health
+	}
+	swagger := func() string {
+		swagger, err := catalog.ProcessSwaggerJSON()
+		assert.NoError(t, err)
+		return swagger
+	}
+
+	// Route becomes unhealthy after two failures in a row and is excluded from the aggregated swagger
+	assert.Eventually(t, func() bool { return !health().Healthy }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "connection refused", health().LastError)
+	assert.GreaterOrEqual(t, health().ConsecutiveFailures, 2)
+	assert.NotContains(t, swagger(), "/model/route/predict")
+
+	// Previously inspected models are still in the catalog
+	models := catalog.ListModels(model.CatalogModelFilter{}, 0, 10)
+	assert.Len(t, models, 1)
+	assert.False(t, models[0].Health.Healthy)
+
+	// Successful inspection refreshes models and resets health
+	inspector.set("/v2/predict", nil)
+	assert.Eventually(t, func() bool { return health().Healthy }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, model.ModelHealth{Healthy: true, LastInspected: health().LastInspected}, health())
+	assert.Contains(t, swagger(), "/model/route/v2/predict")
+	assert.False(t, strings.Contains(swagger(), `"/model/route/predict"`))
+
+	// Deleted route is not inspected and not restored
+	catalog.Delete("route", log.Sugar())
+	time.Sleep(30 * time.Millisecond)
+	inspector.set("/v2/predict", nil)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 0, inspector.calls())
+	assert.Empty(t, catalog.Routes())
+}
+
+func TestUpdateInspection_ChangedRoute(t *testing.T) {
+	log, err := zap.NewDevelopment()
+	assert.NoError(t, err)
+	catalog := servicecatalog.NewModelRouteCatalog(log.Sugar())
+
+	route := servicecatalog.Route{
+		ID:     "route",
+		Prefix: "/model/route",
+		Model: model.DeployedModel{
+			DeploymentID: "route",
+			ServedModel:  model.ServedModel{Swagger: model.Swagger2{Raw: []byte(`{"paths": {"/predict": {}}}`)}},
+		},
+	}
+	assert.NoError(t, catalog.CreateOrUpdate(route))
+	inspected := catalog.Routes()[0]
+
+	// Route was updated by an event while it was inspected
+	route.Prefix = "/model/new-route"
+	assert.NoError(t, catalog.CreateOrUpdate(route))
+
+	assert.NoError(t, catalog.UpdateInspection(inspected, nil, model.ModelHealth{ConsecutiveFailures: 3}))
+
+	routes := catalog.Routes()
+	assert.Len(t, routes, 1)
+	assert.Equal(t, "/model/new-route", routes[0].Prefix)
+	assert.True(t, routes[0].Model.Health.Healthy)
+	assert.Equal(t, 0, routes[0].Model.Health.ConsecutiveFailures)
+}
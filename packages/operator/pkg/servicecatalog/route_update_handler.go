@@ -1,7 +1,6 @@
 package servicecatalog
 
 import (
-	"errors"
 	"fmt"
 	odahuflowv1alpha1 "github.com/odahu/odahu-flow/packages/operator/api/v1alpha1"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apiclient/deployment"
@@ -12,8 +11,13 @@ import (
 	"go.uber.org/zap"
 )
 
+// InspectorGetter returns a model server inspector for a predictor
+type InspectorGetter interface {
+	GetInspector(predictorID string) (inspectors.ModelServerInspector, error)
+}
+
 type UpdateHandler struct {
-	Inspectors       map[string]inspectors.ModelServerInspector
+	Inspectors       InspectorGetter
 	Catalog          Catalog
 	DeploymentClient deployment.Client
 }
@@ -26,18 +30,48 @@ func extractModelDeploymentID(modelRoute deployment_types.ModelRoute) string {
 	return ""
 }
 
-func (r UpdateHandler) inspectModel(
-	prefix string, predictor string, log *zap.SugaredLogger) (model model_types.ServedModel, err error) {
-	log.Debugw("Inspecting deployed model...", "predictor", predictor, "prefix", prefix)
+// inspectModels returns every model served behind the prefix
+func (r UpdateHandler) inspectModels(
+	prefix string, predictor string, log *zap.SugaredLogger) ([]model_types.ServedModel, error) {
+	log.Debugw("Inspecting deployed models...", "predictor", predictor, "prefix", prefix)
 
-	inspector, ok := r.Inspectors[predictor]
-	if !ok {
-		errorStr := "no inspector for predictor: " + predictor
-		log.Error(errorStr)
-		return model, errors.New(errorStr)
+	inspector, err := r.Inspectors.GetInspector(predictor)
+	if err != nil {
+		log.Errorw("No inspector for predictor", "predictor", predictor, zap.Error(err))
+		return nil, err
+	}
+
+	if multiModelInspector, ok := inspector.(inspectors.MultiModelServerInspector); ok {
+		return multiModelInspector.InspectAll(prefix, "", log)
+	}
+
+	model, err := inspector.Inspect(prefix, "", log)
+	if err != nil {
+		return nil, err
+	}
+	return []model_types.ServedModel{model}, nil
+}
+
+// InspectRoute inspects models served behind the route which is already in the catalog
+func (r UpdateHandler) InspectRoute(route Route, log *zap.SugaredLogger) ([]model_types.ServedModel, error) {
+	predictor := route.Model.Predictor
+	// Routes persisted before the predictor was stored in the catalog
+	if len(predictor) == 0 {
+		md, err := r.DeploymentClient.GetModelDeployment(route.Model.DeploymentID)
+		if err != nil {
+			return nil, err
+		}
+		predictor = md.Spec.Predictor
 	}
 
-	return inspector.Inspect(prefix, "", log)
+	servedModels, err := r.inspectModels(route.Prefix, predictor, log)
+	if err != nil {
+		return nil, err
+	}
+	if len(servedModels) == 0 {
+		return nil, fmt.Errorf("no models are served behind the %s prefix", route.Prefix)
+	}
+	return servedModels, nil
 }
 
 func (r UpdateHandler) Handle(object interface{}, log *zap.SugaredLogger) (err error) {
@@ -77,18 +111,22 @@ func (r UpdateHandler) Handle(object interface{}, log *zap.SugaredLogger) (err e
 		return err
 	}
 
-	var servedModel model_types.ServedModel
-	servedModel, err = r.inspectModel(route.Prefix, md.Spec.Predictor, log)
+	servedModels, err := r.inspectModels(route.Prefix, md.Spec.Predictor, log)
 	if err != nil {
 		return err
 	}
+	if len(servedModels) == 0 {
+		return fmt.Errorf("no models are served behind the %s prefix", route.Prefix)
+	}
 	deployedModel := model_types.DeployedModel{
 		DeploymentID: mdID,
-		ServedModel:  servedModel,
+		ServedModel:  servedModels[0],
+		ServedModels: servedModels,
+		Predictor:    md.Spec.Predictor,
 	}
 	route.Model = deployedModel
 
-	log.Infow("Adding a model to catalog", "route_id", route.ID)
+	log.Infow("Adding models to catalog", "route_id", route.ID, "models", len(servedModels))
 	if err != r.Catalog.CreateOrUpdate(route) {
 		log.Error(err, "adding to catalog")
 		return err
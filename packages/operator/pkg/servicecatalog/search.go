@@ -0,0 +1,143 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog
+
+import (
+	"encoding/json"
+	model_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"sort"
+	"strings"
+)
+
+// ListModels returns a page of models which match the filter.
+// Models are sorted by route ID, models of a route are in the order of serving
+func (mdc *ModelRouteCatalog) ListModels(
+	filter model_types.CatalogModelFilter, page int, size int,
+) []model_types.CatalogModel {
+	mdc.RLock()
+	defer mdc.RUnlock()
+
+	routeIDs := make([]string, 0, len(mdc.routeMap))
+	for id := range mdc.routeMap {
+		routeIDs = append(routeIDs, id)
+	}
+	sort.Strings(routeIDs)
+
+	searches := make([]string, 0, len(filter.Search))
+	for _, search := range filter.Search {
+		searches = append(searches, strings.ToLower(search))
+	}
+
+	models := make([]model_types.CatalogModel, 0)
+	for _, id := range routeIDs {
+		route := mdc.routeMap[id]
+		if !matchAny(filter.Prefix, route.Prefix, strings.HasPrefix) ||
+			!matchAny(filter.Predictor, route.Model.Predictor, equal) {
+			continue
+		}
+
+		texts := mdc.searchIndex[id]
+		for i, servedModel := range route.Model.ServedModels {
+			if !matchAny(filter.Name, servedModel.Metadata.ModelName, equal) ||
+				!matchAny(filter.Version, servedModel.Metadata.ModelVersion, equal) {
+				continue
+			}
+			var text string
+			if i < len(texts) {
+				text = texts[i]
+			}
+			if !matchAny(searches, text, strings.Contains) {
+				continue
+			}
+
+			models = append(models, model_types.CatalogModel{
+				RouteID:      route.ID,
+				Prefix:       route.Prefix,
+				DeploymentID: route.Model.DeploymentID,
+				Predictor:    route.Model.Predictor,
+				Metadata:     servedModel.Metadata,
+				Health:       route.Model.Health,
+			})
+		}
+	}
+
+	start := page * size
+	if start >= len(models) || start < 0 || size <= 0 {
+		return []model_types.CatalogModel{}
+	}
+	end := start + size
+	if end > len(models) {
+		end = len(models)
+	}
+	return models[start:end]
+}
+
+func equal(value, filterValue string) bool {
+	return value == filterValue
+}
+
+// matchAny returns true if there are no filter values or the value matches any of them
+func matchAny(filterValues []string, value string, match func(value, filterValue string) bool) bool {
+	if len(filterValues) == 0 {
+		return true
+	}
+	for _, filterValue := range filterValues {
+		if match(value, filterValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSearchTexts returns lowercase texts of models for a free-text search
+func buildSearchTexts(models []model_types.ServedModel) []string {
+	texts := make([]string, 0, len(models))
+	for _, servedModel := range models {
+		texts = append(texts, buildSearchText(servedModel))
+	}
+	return texts
+}
+
+func buildSearchText(servedModel model_types.ServedModel) string {
+	parts := []string{servedModel.Metadata.ModelName}
+
+	swagger := struct {
+		Info struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"info"`
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}{}
+	// Search falls back to the model name if the swagger is malformed
+	if err := json.Unmarshal(servedModel.Swagger.Raw, &swagger); err == nil {
+		parts = append(parts, swagger.Info.Title, swagger.Info.Description)
+		for _, pathItem := range swagger.Paths {
+			for _, rawOperation := range pathItem {
+				operation := struct {
+					Summary     string `json:"summary"`
+					Description string `json:"description"`
+				}{}
+				if err := json.Unmarshal(rawOperation, &operation); err == nil {
+					parts = append(parts, operation.Summary, operation.Description)
+				}
+			}
+		}
+	}
+
+	return strings.ToLower(strings.Join(parts, "\n"))
+}
@@ -0,0 +1,172 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog_test
+
+import (
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/servicecatalog"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"testing"
+)
+
+type listModelsSuite struct {
+	suite.Suite
+	catalog *servicecatalog.ModelRouteCatalog
+}
+
+func TestListModelsSuite(t *testing.T) {
+	suite.Run(t, new(listModelsSuite))
+}
+
+func servedModel(name, version, summary string) model.ServedModel {
+	return model.ServedModel{
+		Metadata: model.Metadata{ModelName: name, ModelVersion: version},
+		Swagger: model.Swagger2{Raw: []byte(`{
+			"info": {"title": "` + name + `", "description": "Model API"},
+			"paths": {"/predict": {"post": {"summary": "` + summary + `"}}}
+		}`)},
+	}
+}
+
+func (s *listModelsSuite) SetupTest() {
+	log, err := zap.NewDevelopment()
+	s.Require().NoError(err)
+	s.catalog = servicecatalog.NewModelRouteCatalog(log.Sugar())
+
+	routes := []servicecatalog.Route{
+		{
+			ID:     "wine",
+			Prefix: "/model/wine",
+			Model: model.DeployedModel{
+				DeploymentID: "wine",
+				Predictor:    "odahu-ml-server",
+				ServedModels: []model.ServedModel{servedModel("wine", "1.0", "Predict wine quality")},
+			},
+		},
+		{
+			ID:     "triton",
+			Prefix: "/model/triton",
+			Model: model.DeployedModel{
+				DeploymentID: "triton",
+				Predictor:    "triton",
+				ServedModels: []model.ServedModel{
+					servedModel("resnet", "1", "Classify images"),
+					servedModel("bert", "2", "Answer questions"),
+				},
+			},
+		},
+		{
+			ID:     "wine-canary",
+			Prefix: "/model/experiments/wine",
+			Model: model.DeployedModel{
+				DeploymentID: "wine-canary",
+				Predictor:    "odahu-ml-server",
+				ServedModels: []model.ServedModel{servedModel("wine", "2.0", "Predict wine quality")},
+			},
+		},
+	}
+	for _, route := range routes {
+		s.Require().NoError(s.catalog.CreateOrUpdate(route))
+	}
+}
+
+func (s *listModelsSuite) modelIDs(filter model.CatalogModelFilter, page int, size int) []string {
+	var ids []string
+	for _, m := range s.catalog.ListModels(filter, page, size) {
+		ids = append(ids, m.RouteID+"/"+m.Metadata.ModelName)
+	}
+	return ids
+}
+
+func (s *listModelsSuite) TestAll() {
+	models := s.catalog.ListModels(model.CatalogModelFilter{}, 0, 10)
+	for i := range models {
+		s.Assertions.True(models[i].Health.Healthy)
+		s.Assertions.False(models[i].Health.LastInspected.IsZero())
+		models[i].Health = model.ModelHealth{}
+	}
+	s.Assertions.Equal([]model.CatalogModel{
+		{
+			RouteID: "triton", Prefix: "/model/triton", DeploymentID: "triton", Predictor: "triton",
+			Metadata: model.Metadata{ModelName: "resnet", ModelVersion: "1"},
+		},
+		{
+			RouteID: "triton", Prefix: "/model/triton", DeploymentID: "triton", Predictor: "triton",
+			Metadata: model.Metadata{ModelName: "bert", ModelVersion: "2"},
+		},
+		{
+			RouteID: "wine", Prefix: "/model/wine", DeploymentID: "wine", Predictor: "odahu-ml-server",
+			Metadata: model.Metadata{ModelName: "wine", ModelVersion: "1.0"},
+		},
+		{
+			RouteID: "wine-canary", Prefix: "/model/experiments/wine", DeploymentID: "wine-canary",
+			Predictor: "odahu-ml-server", Metadata: model.Metadata{ModelName: "wine", ModelVersion: "2.0"},
+		},
+	}, models)
+}
+
+func (s *listModelsSuite) TestFilters() {
+	s.Assertions.Equal(
+		[]string{"wine/wine", "wine-canary/wine"},
+		s.modelIDs(model.CatalogModelFilter{Name: []string{"wine"}}, 0, 10),
+	)
+	s.Assertions.Equal(
+		[]string{"wine-canary/wine"},
+		s.modelIDs(model.CatalogModelFilter{Name: []string{"wine"}, Version: []string{"2.0"}}, 0, 10),
+	)
+	s.Assertions.Equal(
+		[]string{"triton/resnet", "triton/bert"},
+		s.modelIDs(model.CatalogModelFilter{Predictor: []string{"triton"}}, 0, 10),
+	)
+	s.Assertions.Equal(
+		[]string{"wine-canary/wine"},
+		s.modelIDs(model.CatalogModelFilter{Prefix: []string{"/model/experiments"}}, 0, 10),
+	)
+	s.Assertions.Equal(
+		[]string{"triton/resnet", "wine/wine"},
+		s.modelIDs(model.CatalogModelFilter{Version: []string{"1", "1.0"}}, 0, 10),
+	)
+	s.Assertions.Empty(s.modelIDs(model.CatalogModelFilter{Name: []string{"unknown"}}, 0, 10))
+}
+
+func (s *listModelsSuite) TestSearch() {
+	s.Assertions.Equal(
+		[]string{"triton/bert"},
+		s.modelIDs(model.CatalogModelFilter{Search: []string{"QUESTIONS"}}, 0, 10),
+	)
+	s.Assertions.Equal(
+		[]string{"triton/resnet", "triton/bert", "wine/wine", "wine-canary/wine"},
+		s.modelIDs(model.CatalogModelFilter{Search: []string{"model api"}}, 0, 10),
+	)
+
+	// Search index is updated when the route is deleted
+	log, err := zap.NewDevelopment()
+	s.Require().NoError(err)
+	s.catalog.Delete("wine", log.Sugar())
+	s.Assertions.Equal(
+		[]string{"wine-canary/wine"},
+		s.modelIDs(model.CatalogModelFilter{Search: []string{"wine quality"}}, 0, 10),
+	)
+}
+
+func (s *listModelsSuite) TestPagination() {
+	s.Assertions.Equal([]string{"triton/resnet", "triton/bert"}, s.modelIDs(model.CatalogModelFilter{}, 0, 2))
+	s.Assertions.Equal([]string{"wine/wine", "wine-canary/wine"}, s.modelIDs(model.CatalogModelFilter{}, 1, 2))
+	s.Assertions.Empty(s.modelIDs(model.CatalogModelFilter{}, 2, 2))
+}
@@ -35,6 +35,7 @@ import (
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 func SetUPMainServer(
 	mrc *ModelRouteCatalog,
+	ready func() bool,
 	config config.ServiceCatalog,
 ) (*http.Server, error) {
 	staticFS, err := fs.New()
@@ -46,9 +47,14 @@ func SetUPMainServer(
 	rootRouteGroup := router.Group(config.BaseURL)
 
 	servicecatalogroutes.SetUpCatalogSwagger(rootRouteGroup, staticFS, mrc.ProcessSwaggerJSON)
+	servicecatalogroutes.SetUpCatalogOpenAPI3(rootRouteGroup, func() (string, error) {
+		return mrc.ProcessOpenAPI3JSON(config.EdgeURL)
+	})
 	servicecatalogroutes.SetUpSwagger(rootRouteGroup, staticFS)
 	servicecatalogroutes.SetupDeployedModelRoute(rootRouteGroup, mrc.GetDeployedModel)
+	servicecatalogroutes.SetupModelsRoute(rootRouteGroup, mrc.ListModels)
 	servicecatalogroutes.SetUpHealthCheck(router)
+	servicecatalogroutes.SetUpReadinessCheck(router, ready)
 
 
 	server := &http.Server{
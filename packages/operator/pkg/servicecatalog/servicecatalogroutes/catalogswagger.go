@@ -17,6 +17,7 @@
 package servicecatalogroutes
 
 import (
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/swagger"
 	"net/http"
 
@@ -28,3 +29,17 @@ import (
 func SetUpCatalogSwagger(rg *gin.RouterGroup, apiStaticFS http.FileSystem, reader swagger.DefinitionReader) {
 	rg.GET("/catalog/*any", swagger.Handler(apiStaticFS, reader))
 }
+
+// CatalogOpenAPI3URL serves OpenAPI 3.0 document of the catalog
+const CatalogOpenAPI3URL = "/openapi3/catalog.json"
+
+func SetUpCatalogOpenAPI3(rg *gin.RouterGroup, reader swagger.DefinitionReader) {
+	rg.GET(CatalogOpenAPI3URL, func(c *gin.Context) {
+		document, err := reader()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, httputil.HTTPResult{Message: err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "application/json", []byte(document))
+	})
+}
@@ -86,3 +86,21 @@ func (s *SwaggerRouteSuite) TestAPISwaggerDefinition() {
 	// Verify random data from API swagger definition
 	s.g.Expect(w.Body.String()).Should(Equal(testSwaggerDefinition))
 }
+
+func TestCatalogOpenAPI3(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := gin.Default()
+	servicecatalogroutes.SetUpCatalogOpenAPI3(server.Group(pathPrefix), func() (string, error) {
+		return `{"openapi": "3.0.3"}`, nil
+	})
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, path.Join(pathPrefix, servicecatalogroutes.CatalogOpenAPI3URL), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	server.ServeHTTP(w, req)
+
+	g.Expect(w.Code).Should(Equal(http.StatusOK))
+	g.Expect(w.Header().Get(swagger.ContentTypeHeaderKey)).Should(ContainSubstring("application/json"))
+	g.Expect(w.Body.String()).Should(Equal(`{"openapi": "3.0.3"}`))
+}
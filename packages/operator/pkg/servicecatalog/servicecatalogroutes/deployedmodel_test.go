@@ -1,6 +1,7 @@
 package servicecatalogroutes_test
 
 import (
+	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/servicecatalog"
@@ -45,4 +46,9 @@ func TestDeployedModelHandler(t *testing.T) {
 
 	assert.Equal(t, w.Code, http.StatusOK)
 
+	var deployedModel model.DeployedModel
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &deployedModel))
+	assert.Equal(t, "simple-model", deployedModel.DeploymentID)
+	assert.Len(t, deployedModel.ServedModels, 1)
+
 }
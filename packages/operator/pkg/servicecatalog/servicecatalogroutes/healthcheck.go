@@ -23,6 +23,7 @@ import (
 
 const (
 	HealthCheckURL = "/health"
+	ReadinessURL   = "/ready"
 )
 
 func healthCheck(c *gin.Context) {
@@ -32,3 +33,14 @@ func healthCheck(c *gin.Context) {
 func SetUpHealthCheck(server *gin.Engine) {
 	server.GET(HealthCheckURL, healthCheck)
 }
+
+// SetUpReadinessCheck responds with 503 status code until ready returns true
+func SetUpReadinessCheck(server *gin.Engine, ready func() bool) {
+	server.GET(ReadinessURL, func(c *gin.Context) {
+		if !ready() {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusOK, gin.H{})
+	})
+}
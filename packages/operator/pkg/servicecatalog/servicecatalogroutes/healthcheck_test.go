@@ -31,11 +31,15 @@ type HealthCheckSuite struct {
 	suite.Suite
 	g      *GomegaWithT
 	server *gin.Engine
+	ready  bool
 }
 
 func (s *HealthCheckSuite) SetupSuite() {
 	s.server = gin.Default()
 	servicecatalogroutes.SetUpHealthCheck(s.server)
+	servicecatalogroutes.SetUpReadinessCheck(s.server, func() bool {
+		return s.ready
+	})
 }
 
 func (s *HealthCheckSuite) SetupTest() {
@@ -59,3 +63,20 @@ func (s *HealthCheckSuite) TestHealthCheck() {
 	s.g.Expect(w.Code).Should(Equal(http.StatusOK))
 	s.g.Expect(response).Should(Equal(make(map[string]string)))
 }
+
+func (s *HealthCheckSuite) TestReadinessCheck() {
+	for _, ready := range []bool{false, true} {
+		s.ready = ready
+
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, servicecatalogroutes.ReadinessURL, nil)
+		s.g.Expect(err).NotTo(HaveOccurred())
+		s.server.ServeHTTP(w, req)
+
+		if ready {
+			s.g.Expect(w.Code).Should(Equal(http.StatusOK))
+		} else {
+			s.g.Expect(w.Code).Should(Equal(http.StatusServiceUnavailable))
+		}
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalogroutes
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	model_types "github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/utils/httputil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+const (
+	ListModelsURL = "/models"
+	sizeParamName = "size"
+	pageParamName = "page"
+	defaultSize   = 500
+	// Tag of model_types.CatalogModelFilter fields which contains the name of URL parameter
+	filterTagKey = "name"
+)
+
+var (
+	modelFilterFields = map[string]int{}
+)
+
+func init() {
+	elem := reflect.TypeOf(&model_types.CatalogModelFilter{}).Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		modelFilterFields[elem.Field(i).Tag.Get(filterTagKey)] = i
+	}
+}
+
+type ListModelsFunc func(filter model_types.CatalogModelFilter, page int, size int) []model_types.CatalogModel
+
+type ModelsHandler struct {
+	ListModels ListModelsFunc
+}
+
+// @Summary List models of the catalog
+// @Description List models served behind model routes. Every filter parameter can be repeated to match any of values
+// @Accept  json
+// @Produce  json
+// @Param name query string false "Model name"
+// @Param version query string false "Model version"
+// @Param predictor query string false "Predictor of the deployment"
+// @Param prefix query string false "Prefix of the route URL prefix"
+// @Param search query string false "Text in the title, description or operation summaries of the model swagger"
+// @Param size query int false "Number of entities in a response"
+// @Param page query int false "Number of a page"
+// @Success 200 {array} model.CatalogModel
+// @Failure 400 {object} httputil.HTTPResult
+// @Router /service-catalog/models [get]
+func (h *ModelsHandler) Handle(c *gin.Context) {
+	f := &model_types.CatalogModelFilter{}
+	size, page, err := parseModelsQuery(c.Request.URL.Query(), f)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, httputil.HTTPResult{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.ListModels(*f, page, size))
+}
+
+// parseModelsQuery fills the filter from URL parameters the same way as list endpoints of the API server.
+// The API server routes package is not imported because it registers own swagger docs
+func parseModelsQuery(params url.Values, filter *model_types.CatalogModelFilter) (size int, page int, err error) {
+	size = defaultSize
+
+	for name, value := range params {
+		switch name {
+		case sizeParamName, pageParamName:
+			if len(value) > 1 {
+				return size, page, fmt.Errorf("the %s URL parameter must be only one", name)
+			}
+			number, err := strconv.Atoi(value[0])
+			if err != nil {
+				return size, page, err
+			}
+			if name == sizeParamName {
+				size = number
+			} else {
+				page = number
+			}
+		default:
+			fieldNumber, ok := modelFilterFields[name]
+			if !ok {
+				return size, page, fmt.Errorf("cannot find %s url parameter", name)
+			}
+
+			reflect.ValueOf(filter).Elem().Field(fieldNumber).Set(reflect.ValueOf(value))
+		}
+	}
+
+	return size, page, nil
+}
+
+func SetupModelsRoute(rg *gin.RouterGroup, lister ListModelsFunc) {
+	handler := ModelsHandler{ListModels: lister}
+	rg.GET(ListModelsURL, handler.Handle)
+}
@@ -0,0 +1,80 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalogroutes_test
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/servicecatalog/servicecatalogroutes"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestModelsHandler(t *testing.T) {
+	var (
+		actualFilter model.CatalogModelFilter
+		actualPage   int
+		actualSize   int
+	)
+	engine := gin.Default()
+	servicecatalogroutes.SetupModelsRoute(engine.Group(""),
+		func(filter model.CatalogModelFilter, page int, size int) []model.CatalogModel {
+			actualFilter, actualPage, actualSize = filter, page, size
+			return []model.CatalogModel{{RouteID: "wine", Metadata: model.Metadata{ModelName: "wine"}}}
+		},
+	)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		http.MethodGet, "/models?name=wine&name=beer&predictor=triton&search=quality&page=1&size=5", nil,
+	)
+	assert.NoError(t, err)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, model.CatalogModelFilter{
+		Name:      []string{"wine", "beer"},
+		Predictor: []string{"triton"},
+		Search:    []string{"quality"},
+	}, actualFilter)
+	assert.Equal(t, 1, actualPage)
+	assert.Equal(t, 5, actualSize)
+
+	var models []model.CatalogModel
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &models))
+	assert.Len(t, models, 1)
+}
+
+func TestModelsHandler_UnknownParameter(t *testing.T) {
+	engine := gin.Default()
+	servicecatalogroutes.SetupModelsRoute(engine.Group(""),
+		func(filter model.CatalogModelFilter, page int, size int) []model.CatalogModel {
+			return nil
+		},
+	)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/models?unknown=value", nil)
+	assert.NoError(t, err)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
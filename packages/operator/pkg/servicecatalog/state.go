@@ -0,0 +1,75 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// CatalogState is a snapshot of the catalog which is used to restore the catalog after restart
+type CatalogState struct {
+	// Cursor of events which are reflected in the routes
+	Cursor int     `json:"cursor"`
+	Routes []Route `json:"routes"`
+}
+
+// StateStore persists the catalog state
+type StateStore interface {
+	// Load returns the last saved state or an empty state if nothing was saved
+	Load() (CatalogState, error)
+	Save(state CatalogState) error
+}
+
+// FileStateStore keeps the catalog state in a local JSON file
+type FileStateStore struct {
+	Path string
+}
+
+func (f FileStateStore) Load() (state CatalogState, err error) {
+	raw, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	err = json.Unmarshal(raw, &state)
+	return state, err
+}
+
+// Save writes the state to a temporary file and renames it, so a crash never leaves a partially written state
+func (f FileStateStore) Save(state CatalogState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0750); err != nil {
+		return err
+	}
+
+	tmpPath := f.Path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, raw, 0640); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, f.Path)
+}
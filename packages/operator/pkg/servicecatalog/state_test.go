@@ -0,0 +1,97 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package servicecatalog_test
+
+import (
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/model"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/servicecatalog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStateStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "service-catalog")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := servicecatalog.FileStateStore{Path: filepath.Join(dir, "nested", "state.json")}
+
+	// Nothing was saved yet
+	state, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, servicecatalog.CatalogState{}, state)
+
+	expected := servicecatalog.CatalogState{
+		Cursor: 42,
+		Routes: []servicecatalog.Route{{ID: "route", Prefix: "/model/route", IsDefault: true}},
+	}
+	assert.NoError(t, store.Save(expected))
+
+	state, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, state)
+}
+
+func TestPersistentModelRouteCatalog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "service-catalog")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log, err := zap.NewDevelopment()
+	assert.NoError(t, err)
+	store := servicecatalog.FileStateStore{Path: filepath.Join(dir, "state.json")}
+
+	catalog, err := servicecatalog.NewPersistentModelRouteCatalog(log.Sugar(), store)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, catalog.Cursor())
+
+	for _, id := range []string{"first", "second"} {
+		assert.NoError(t, catalog.CreateOrUpdate(servicecatalog.Route{
+			ID:     id,
+			Prefix: "/model/" + id,
+			Model: model.DeployedModel{
+				DeploymentID: id,
+				ServedModel: model.ServedModel{
+					Metadata: model.Metadata{ModelName: id},
+					Swagger:  model.Swagger2{Raw: []byte(`{"paths": {"/predict": {}}}`)},
+				},
+			},
+		}))
+	}
+	catalog.Delete("second", log.Sugar())
+	assert.NoError(t, catalog.SaveCursor(7))
+
+	// Restart
+	restored, err := servicecatalog.NewPersistentModelRouteCatalog(log.Sugar(), store)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, restored.Cursor())
+
+	deployedModel, err := restored.GetDeployedModel("first")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", deployedModel.ServedModel.Metadata.ModelName)
+	// Swagger URLs are not prefixed twice
+	assert.JSONEq(t, `{"paths": {"/model/first/predict": {}}}`, string(deployedModel.ServedModels[0].Swagger.Raw))
+
+	state, err := store.Load()
+	assert.NoError(t, err)
+	assert.Len(t, state.Routes, 1)
+}
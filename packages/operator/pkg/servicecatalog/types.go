@@ -15,12 +15,12 @@ func IsTemporary(err error) bool {
 }
 
 type Route struct {
-	ID     string
-	Prefix string
+	ID     string `json:"id"`
+	Prefix string `json:"prefix"`
 
-	IsDefault bool
-	// If route serves more than one model than model is chosen randomly
-	Model model_types.DeployedModel
+	IsDefault bool `json:"isDefault"`
+	// Models served behind the default deployment of the route
+	Model model_types.DeployedModel `json:"model"`
 }
 
 type GenericEvent struct {
@@ -26,6 +26,10 @@ func (t TIStubClient) SaveModelTrainingResult(id string, result *v1alpha1.Traini
 	panic("implement me")
 }
 
+func (t TIStubClient) SaveModelTrainingMetrics(id string, metrics *training.ModelTrainingMetrics) error {
+	panic("implement me")
+}
+
 func (t TIStubClient) GetToolchainIntegration(name string) (*training.ToolchainIntegration, error) {
 	entity, ok := t.db[name]
 	if !ok {
@@ -0,0 +1,47 @@
+/*
+ *
+ *     Copyright 2021 EPAM Systems
+ *
+ *     Licensed under the Apache License, Version 2.0 (the "License");
+ *     you may not use this file except in compliance with the License.
+ *     You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *     Unless required by applicable law or agreed to in writing, software
+ *     distributed under the License is distributed on an "AS IS" BASIS,
+ *     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *     See the License for the specific language governing permissions and
+ *     limitations under the License.
+ */
+
+package trainer
+
+import (
+	"fmt"
+	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/training"
+	"strconv"
+)
+
+// PushMetrics sends metrics and params of the training to the API server. Metric values must be numbers.
+// It can be called from the training container many times, for example after every epoch.
+func (mt *ModelTrainer) PushMetrics(metrics map[string]string, params map[string]string, step int64) error {
+	mtMetrics := &training.ModelTrainingMetrics{
+		Metrics: make([]training.MetricValue, 0, len(metrics)),
+		Params:  params,
+	}
+	for key, rawValue := range metrics {
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil {
+			return fmt.Errorf("value of metric %q is not a number: %s", key, rawValue)
+		}
+		mtMetrics.Metrics = append(mtMetrics.Metrics, training.MetricValue{Key: key, Value: value, Step: step})
+	}
+
+	if err := mt.trainClient.SaveModelTrainingMetrics(mt.modelTrainingID, mtMetrics); err != nil {
+		return err
+	}
+
+	mt.log.Info("Training metrics were saved", "metrics", len(mtMetrics.Metrics), "params", len(params))
+	return nil
+}
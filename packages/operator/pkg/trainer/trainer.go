@@ -124,7 +124,8 @@ func (mt *ModelTrainer) Setup() (err error) {
 }
 
 type trainingDescription struct {
-	Output map[string]string `yaml:"output"`
+	Output  map[string]string  `yaml:"output"`
+	Metrics map[string]float64 `yaml:"metrics"`
 }
 
 // This function saves a training result. To do this, it performs the following steps:
@@ -215,6 +216,7 @@ func (mt *ModelTrainer) SaveResult() error {
 			RunID:        trainingDesc.Output["run_id"],
 			ArtifactName: outputZipName,
 			CommitID:     commit.CommitID,
+			Metrics:      trainingDesc.Metrics,
 		},
 	); err != nil {
 		mt.log.Error(err, "Cannot save the training result")
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2021 EPAM Systems
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	jwksRSAKeyType = "RSA"
+	// Minimal interval between two JWKS downloads. Protects the identity provider
+	// from flooding by tokens with unknown key ids
+	jwksMinRefreshInterval = time.Minute
+)
+
+var (
+	ErrUnknownJWK = errors.New("JWT is signed by unknown key")
+)
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// JWKSKeyGetter downloads RSA public keys from JWKS URL and caches them.
+// Keys are downloaded again if a token is signed by an unknown key
+type JWKSKeyGetter struct {
+	url        string
+	httpClient *http.Client
+	// Minimal interval between two JWKS downloads
+	minRefreshInterval time.Duration
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	lastRefresh time.Time
+}
+
+func NewJWKSKeyGetter(url string) *JWKSKeyGetter {
+	return &JWKSKeyGetter{
+		url:                url,
+		httpClient:         &http.Client{Timeout: 10 * time.Second},
+		minRefreshInterval: jwksMinRefreshInterval,
+		keys:               map[string]*rsa.PublicKey{},
+	}
+}
+
+// Keyfunc can be passed to jwt.Parse to verify a token signature
+func (g *JWKSKeyGetter) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	if key, ok := g.getKey(kid); ok {
+		return key, nil
+	}
+
+	if err := g.refresh(); err != nil {
+		return nil, err
+	}
+
+	if key, ok := g.getKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrUnknownJWK
+}
+
+func (g *JWKSKeyGetter) getKey(kid string) (*rsa.PublicKey, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	key, ok := g.keys[kid]
+	return key, ok
+}
+
+func (g *JWKSKeyGetter) refresh() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if time.Since(g.lastRefresh) < g.minRefreshInterval {
+		return nil
+	}
+
+	resp, err := g.httpClient.Get(g.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to download JWKS from %s: status code %d", g.url, resp.StatusCode)
+	}
+
+	var keySet jsonWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keySet); err != nil {
+		return err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Kty != jwksRSAKeyType {
+			continue
+		}
+
+		key, err := parseRSAPublicKey(jwk)
+		if err != nil {
+			return err
+		}
+		keys[jwk.Kid] = key
+	}
+
+	g.keys = keys
+	g.lastRefresh = time.Now()
+
+	return nil
+}
+
+func parseRSAPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("malformed modulus of %s key: %s", jwk.Kid, err.Error())
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("malformed exponent of %s key: %s", jwk.Kid, err.Error())
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2021 EPAM Systems
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type jwksServer struct {
+	mu       sync.Mutex
+	keys     map[string]*rsa.PrivateKey
+	requests int
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	keySet := jsonWebKeySet{}
+	for kid, key := range s.keys {
+		keySet.Keys = append(keySet.Keys, jsonWebKey{
+			Kid: kid,
+			Kty: jwksRSAKeyType,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	_ = json.NewEncoder(w).Encode(keySet)
+}
+
+func (s *jwksServer) setKeys(keys map[string]*rsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = keys
+}
+
+type JWKSTestSuite struct {
+	suite.Suite
+	jwks   *jwksServer
+	server *httptest.Server
+	oldKey *rsa.PrivateKey
+	newKey *rsa.PrivateKey
+}
+
+func TestJWKSTestSuite(t *testing.T) {
+	suite.Run(t, new(JWKSTestSuite))
+}
+
+func (s *JWKSTestSuite) SetupSuite() {
+	var err error
+	s.oldKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.newKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+}
+
+func (s *JWKSTestSuite) SetupTest() {
+	s.jwks = &jwksServer{keys: map[string]*rsa.PrivateKey{"old": s.oldKey}}
+	s.server = httptest.NewServer(s.jwks)
+}
+
+func (s *JWKSTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *JWKSTestSuite) signToken(kid string, key *rsa.PrivateKey) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "user"})
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *JWKSTestSuite) parse(getter *JWKSKeyGetter, token string) error {
+	_, err := jwt.Parse(token, getter.Keyfunc)
+	return err
+}
+
+func (s *JWKSTestSuite) TestKnownKey() {
+	getter := NewJWKSKeyGetter(s.server.URL)
+
+	s.Assert().NoError(s.parse(getter, s.signToken("old", s.oldKey)))
+	s.Assert().NoError(s.parse(getter, s.signToken("old", s.oldKey)))
+	// Keys are cached
+	s.Assert().Equal(1, s.jwks.requests)
+}
+
+func (s *JWKSTestSuite) TestKeyRotation() {
+	getter := NewJWKSKeyGetter(s.server.URL)
+	getter.minRefreshInterval = 0
+
+	s.Require().NoError(s.parse(getter, s.signToken("old", s.oldKey)))
+
+	s.jwks.setKeys(map[string]*rsa.PrivateKey{"new": s.newKey})
+
+	// Token signed by the new key triggers JWKS download
+	s.Assert().NoError(s.parse(getter, s.signToken("new", s.newKey)))
+	s.Assert().Equal(2, s.jwks.requests)
+
+	// The old key is not trusted after rotation
+	err := s.parse(getter, s.signToken("old", s.oldKey))
+	s.Assert().Error(err)
+	s.Assert().Contains(err.Error(), ErrUnknownJWK.Error())
+}
+
+func (s *JWKSTestSuite) TestUnknownKid() {
+	getter := NewJWKSKeyGetter(s.server.URL)
+
+	err := s.parse(getter, s.signToken("unknown", s.newKey))
+	s.Assert().Error(err)
+	s.Assert().Contains(err.Error(), ErrUnknownJWK.Error())
+
+	// JWKS is not downloaded again until the minimal refresh interval passes
+	err = s.parse(getter, s.signToken("unknown", s.newKey))
+	s.Assert().Error(err)
+	s.Assert().Equal(1, s.jwks.requests)
+}
+
+func (s *JWKSTestSuite) TestForgedSignature() {
+	getter := NewJWKSKeyGetter(s.server.URL)
+
+	// Token has a known key id but it is signed by another key
+	s.Assert().Error(s.parse(getter, s.signToken("old", s.newKey)))
+}
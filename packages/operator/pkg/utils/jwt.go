@@ -22,14 +22,40 @@ import (
 	"github.com/dgrijalva/jwt-go"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/apis/user"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
+	"strings"
 )
 
 // Errors
 var (
 	ErrMalformedJWT    = errors.New("malformed JWT")
 	ErrClaimExtraction = errors.New("claim extraction failed")
+	ErrInvalidJWT      = errors.New("invalid JWT")
+	ErrInvalidIssuer   = errors.New("JWT is issued by unexpected issuer")
 )
 
+// ValidateToken verifies a token signature using keyFunc and checks the standard claims.
+// If issuer is not empty then the "iss" claim must be equal to it
+func ValidateToken(token string, keyFunc jwt.Keyfunc, issuer string) error {
+	parsedToken, err := jwt.Parse(token, keyFunc)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrInvalidJWT.Error(), err.Error())
+	}
+	if !parsedToken.Valid {
+		return ErrInvalidJWT
+	}
+
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return ErrClaimExtraction
+	}
+
+	if len(issuer) != 0 && !claims.VerifyIssuer(issuer, true) {
+		return ErrInvalidIssuer
+	}
+
+	return nil
+}
+
 func ExtractUserInfoFromToken(token string, config config.Claims) (*user.UserInfo, error) {
 	// Parse a token, but don't verify it
 	// Ignore the error, but make sure token isn't nil in case of there were parsing errors
@@ -72,3 +98,48 @@ func extractClaim(claims jwt.MapClaims, claimName string) (string, error) {
 
 	return claimValueStr, nil
 }
+
+// ExtractRolesFromToken returns values of a string list claim. Nested claims are addressed
+// by a dot-separated path, for example "realm_access.roles"
+func ExtractRolesFromToken(token string, rolesClaim string) ([]string, error) {
+	// Parse a token, but don't verify it
+	// Ignore the error, but make sure token isn't nil in case of there were parsing errors
+	parsedToken, _ := jwt.Parse(token, nil)
+	if parsedToken == nil {
+		return nil, ErrMalformedJWT
+	}
+
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrClaimExtraction
+	}
+
+	var claimValue interface{} = map[string]interface{}(claims)
+	for _, claimName := range strings.Split(rolesClaim, ".") {
+		nestedClaims, ok := claimValue.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s claim is not an object", rolesClaim)
+		}
+
+		claimValue, ok = nestedClaims[claimName]
+		if !ok {
+			return nil, fmt.Errorf("%s claim is missing", rolesClaim)
+		}
+	}
+
+	rawRoles, ok := claimValue.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s claim is not the list type", rolesClaim)
+	}
+
+	roles := make([]string, 0, len(rawRoles))
+	for _, rawRole := range rawRoles {
+		role, ok := rawRole.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s claim contains not string values", rolesClaim)
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
@@ -17,6 +17,7 @@
 package utils_test
 
 import (
+	"github.com/dgrijalva/jwt-go"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/config"
 	"github.com/odahu/odahu-flow/packages/operator/pkg/utils"
 	. "github.com/onsi/gomega"
@@ -118,3 +119,44 @@ func (s *JWTTestSuite) TestExtractUserInfoFromTokenClaimsMissing() {
 	_, err := utils.ExtractUserInfoFromToken(token, config.NewDefaultUserConfig().Claims)
 	s.g.Expect(err).Should(Equal(utils.ErrMalformedJWT))
 }
+
+func (s *JWTTestSuite) TestExtractRolesFromToken() {
+	// {
+	//  "name": "John Doe",
+	//  "realm_access": {"roles": ["odahu_admin", "offline_access"]}
+	// }
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"name": "John Doe",
+		"realm_access": map[string]interface{}{
+			"roles": []interface{}{"odahu_admin", "offline_access"},
+		},
+	}).SignedString([]byte("secret"))
+	s.g.Expect(err).Should(BeNil())
+
+	roles, err := utils.ExtractRolesFromToken(token, "realm_access.roles")
+	s.g.Expect(err).Should(BeNil())
+	s.g.Expect(roles).Should(Equal([]string{"odahu_admin", "offline_access"}))
+}
+
+func (s *JWTTestSuite) TestExtractRolesFromTokenMissingClaim() {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"name": "John Doe",
+	}).SignedString([]byte("secret"))
+	s.g.Expect(err).Should(BeNil())
+
+	_, err = utils.ExtractRolesFromToken(token, "realm_access.roles")
+	s.g.Expect(err).ShouldNot(BeNil())
+	s.g.Expect(err.Error()).Should(ContainSubstring("claim is missing"))
+}
+
+func (s *JWTTestSuite) TestValidateTokenWrongIssuer() {
+	secret := []byte("secret")
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://unknown.org",
+	}).SignedString(secret)
+	s.g.Expect(err).Should(BeNil())
+
+	keyFunc := func(*jwt.Token) (interface{}, error) { return secret, nil }
+	s.g.Expect(utils.ValidateToken(token, keyFunc, "https://issuer.org")).Should(Equal(utils.ErrInvalidIssuer))
+	s.g.Expect(utils.ValidateToken(token, keyFunc, "https://unknown.org")).Should(BeNil())
+}